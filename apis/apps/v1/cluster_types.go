@@ -198,6 +198,21 @@ type ClusterSpec struct {
 	//
 	// +optional
 	Backup *ClusterBackup `json:"backup,omitempty"`
+
+	// Specifies how the replicas of the Cluster are placed across the data-plane k8s clusters (contexts)
+	// when KubeBlocks runs in multi-cluster mode. It takes no effect in single-cluster mode.
+	//
+	// +optional
+	Placement *ClusterPlacement `json:"placement,omitempty"`
+
+	// Specifies the maintenance window of the Cluster.
+	//
+	// If specified, the disruptive operations, including restarts, upgrades, switchovers, reconfiguring and
+	// the rolling updates of instances, are queued until the window opens, and paused if the window closes mid-flight.
+	// An OpsRequest can bypass the window with `spec.bypassMaintenanceWindow`.
+	//
+	// +optional
+	MaintenanceWindow *MaintenanceWindow `json:"maintenanceWindow,omitempty"`
 }
 
 // ClusterStatus defines the observed state of the Cluster.
@@ -237,6 +252,11 @@ type ClusterStatus struct {
 	//
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Records where the instances of the Cluster are placed in multi-cluster mode.
+	//
+	// +optional
+	Placement *ClusterPlacementStatus `json:"placement,omitempty"`
 }
 
 // TerminationPolicyType defines termination policy types.
@@ -459,6 +479,14 @@ type ClusterComponentSpec struct {
 	// +optional
 	InstanceUpdateStrategy *InstanceUpdateStrategy `json:"instanceUpdateStrategy,omitempty"`
 
+	// Overrides the PodDisruptionBudget generated for the instances of the Component.
+	//
+	// If not specified, the PodDisruptionBudget is derived from the replica roles defined in the ComponentDefinition
+	// and adjusted automatically as the replicas change.
+	//
+	// +optional
+	PodDisruptionBudget *PodDisruptionBudgetPolicy `json:"podDisruptionBudget,omitempty"`
+
 	// Allows for the customization of configuration values for each instance within a Component.
 	// An instance represent a single replica (Pod and associated K8s resources like PVCs, Services, and ConfigMaps).
 	// While instances typically share a common configuration as defined in the ClusterComponentSpec,
@@ -527,6 +555,22 @@ type ClusterComponentSpec struct {
 	//
 	// +optional
 	Stop *bool `json:"stop,omitempty"`
+
+	// Switches the replicas of the Component into the read-only state.
+	// If set to true, the `readonly` lifecycle action will be invoked on each replica,
+	// and the `readwrite` action will be invoked to switch them back once it is set to false or unset.
+	//
+	// The result is reported by the `Readonly` condition in the status of the Component.
+	//
+	// +optional
+	Readonly *bool `json:"readonly,omitempty"`
+
+	// Specifies the policy to expand the volumes of the Component automatically according to their usage.
+	//
+	// The decisions are recorded in the `status.storageAutoscaling` of the Component.
+	//
+	// +optional
+	StorageAutoscaling *StorageAutoscalingPolicy `json:"storageAutoscaling,omitempty"`
 }
 
 type ClusterComponentService struct {
@@ -876,3 +920,75 @@ type ClusterComponentStatus struct {
 	// +optional
 	Message map[string]string `json:"message,omitempty"`
 }
+
+// PlacementPolicyType defines the policy used to choose data-plane contexts for a Cluster.
+//
+// +enum
+// +kubebuilder:validation:Enum={Spread,CapacityWeighted}
+type PlacementPolicyType string
+
+const (
+	// SpreadPlacementPolicy spreads the replicas across the candidate contexts randomly.
+	SpreadPlacementPolicy PlacementPolicyType = "Spread"
+
+	// CapacityWeightedPlacementPolicy prefers the candidate contexts with more free capacity,
+	// the probability of a context being chosen is proportional to its free capacity.
+	CapacityWeightedPlacementPolicy PlacementPolicyType = "CapacityWeighted"
+)
+
+// ClusterPlacement defines how the replicas of a Cluster are placed across the data-plane contexts.
+type ClusterPlacement struct {
+	// Specifies the policy used to choose the contexts from the candidates.
+	//
+	// +kubebuilder:default=Spread
+	// +optional
+	Policy PlacementPolicyType `json:"policy,omitempty"`
+
+	// Pins the Cluster to the contexts whose labels match the selector.
+	// The labels of contexts are configured by the `multi-cluster-context-labels` flag of the manager.
+	//
+	// If not specified, all contexts are candidates.
+	//
+	// +optional
+	ContextSelector *metav1.LabelSelector `json:"contextSelector,omitempty"`
+
+	// Disables the re-placement when a placed context becomes unavailable.
+	//
+	// By default, the replicas placed on an unavailable context are re-placed onto a healthy candidate context
+	// that hasn't been used by the Cluster yet.
+	//
+	// +optional
+	DisableReplacement bool `json:"disableReplacement,omitempty"`
+}
+
+// ClusterPlacementStatus records the placement of a Cluster.
+type ClusterPlacementStatus struct {
+	// The contexts the Cluster is placed on, in the order that the instance ordinals are assigned to.
+	//
+	// +optional
+	Contexts []string `json:"contexts,omitempty"`
+
+	// The contexts that are unavailable and have been replaced.
+	//
+	// +optional
+	ReplacedContexts []string `json:"replacedContexts,omitempty"`
+
+	// The context of each instance.
+	//
+	// +optional
+	Instances []InstancePlacement `json:"instances,omitempty"`
+}
+
+// InstancePlacement records the context an instance lives in.
+type InstancePlacement struct {
+	// The name of the instance.
+	Name string `json:"name"`
+
+	// The name of the component the instance belongs to.
+	//
+	// +optional
+	Component string `json:"component,omitempty"`
+
+	// The context the instance lives in.
+	Context string `json:"context"`
+}
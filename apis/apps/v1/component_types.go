@@ -21,7 +21,9 @@ package v1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
@@ -232,6 +234,14 @@ type ComponentSpec struct {
 	// +optional
 	InstanceUpdateStrategy *InstanceUpdateStrategy `json:"instanceUpdateStrategy,omitempty"`
 
+	// Overrides the PodDisruptionBudget generated for the instances of the Component.
+	//
+	// If not specified, the PodDisruptionBudget is derived from the replica roles defined in the ComponentDefinition
+	// and adjusted automatically as the replicas change.
+	//
+	// +optional
+	PodDisruptionBudget *PodDisruptionBudgetPolicy `json:"podDisruptionBudget,omitempty"`
+
 	// Specifies the scheduling policy for the Component.
 	//
 	// +optional
@@ -319,10 +329,30 @@ type ComponentSpec struct {
 	// +optional
 	Stop *bool `json:"stop,omitempty"`
 
+	// Switches the replicas of the Component into the read-only state.
+	// If set to true, the `readonly` lifecycle action will be invoked on each replica,
+	// and the `readwrite` action will be invoked to switch them back once it is set to false or unset.
+	//
+	// The result is reported by the `Readonly` condition in the status of the Component.
+	//
+	// +optional
+	Readonly *bool `json:"readonly,omitempty"`
+
 	// Specifies the sidecars to be injected into the Component.
 	//
 	// +optional
 	Sidecars []Sidecar `json:"sidecars,omitempty"`
+
+	// Specifies the maintenance window of the Component, it is inherited from the Cluster.
+	// The rolling updates of the instances are only performed within the window.
+	//
+	// +optional
+	MaintenanceWindow *MaintenanceWindow `json:"maintenanceWindow,omitempty"`
+
+	// Specifies the policy to expand the volumes of the Component automatically according to their usage.
+	//
+	// +optional
+	StorageAutoscaling *StorageAutoscalingPolicy `json:"storageAutoscaling,omitempty"`
 }
 
 // ComponentStatus represents the observed state of a Component within the Cluster.
@@ -362,6 +392,174 @@ type ComponentStatus struct {
 	//
 	// +optional
 	Message map[string]string `json:"message,omitempty"`
+
+	// Records the recent password rotations of the system accounts.
+	//
+	// +optional
+	PasswordRotations []PasswordRotationStatus `json:"passwordRotations,omitempty"`
+
+	// Records the latest storage autoscaling decisions of the volumes.
+	//
+	// +optional
+	StorageAutoscaling *StorageAutoscalingStatus `json:"storageAutoscaling,omitempty"`
+
+	// Records the replicas that have been switched to read-only by the readonly lifecycle action.
+	//
+	// +optional
+	ReadonlyReplicas []ReadonlyReplica `json:"readonlyReplicas,omitempty"`
+}
+
+// ReadonlyReplica identifies a replica that has been switched to read-only.
+// The UID of the Pod is recorded so that the replica is switched again after it's recreated.
+type ReadonlyReplica struct {
+	// The name of the Pod.
+	PodName string `json:"podName"`
+
+	// The UID of the Pod.
+	PodUID types.UID `json:"podUID"`
+}
+
+// PasswordRotationTrigger defines what triggers a password rotation.
+//
+// +enum
+// +kubebuilder:validation:Enum={Scheduled,OnDemand}
+type PasswordRotationTrigger string
+
+const (
+	// ScheduledPasswordRotation indicates the rotation is triggered by the rotation interval.
+	ScheduledPasswordRotation PasswordRotationTrigger = "Scheduled"
+
+	// OnDemandPasswordRotation indicates the rotation is requested by an OpsRequest.
+	OnDemandPasswordRotation PasswordRotationTrigger = "OnDemand"
+)
+
+// PasswordRotationPhase defines the phase of a password rotation.
+//
+// +enum
+// +kubebuilder:validation:Enum={Pending,Rotating,Completed,Failed}
+type PasswordRotationPhase string
+
+const (
+	// PendingPasswordRotationPhase indicates the new password is persisted in the account secret and waiting to be applied.
+	PendingPasswordRotationPhase PasswordRotationPhase = "Pending"
+
+	// RotatingPasswordRotationPhase indicates the new password is in use and the old one is still valid in the grace window.
+	RotatingPasswordRotationPhase PasswordRotationPhase = "Rotating"
+
+	// CompletedPasswordRotationPhase indicates the old password has been revoked.
+	CompletedPasswordRotationPhase PasswordRotationPhase = "Completed"
+
+	// FailedPasswordRotationPhase indicates the rotation is failed, and the old password is still in use.
+	FailedPasswordRotationPhase PasswordRotationPhase = "Failed"
+)
+
+// PasswordRotationStatus records a password rotation of a system account.
+type PasswordRotationStatus struct {
+	// The name of the system account.
+	Account string `json:"account"`
+
+	// What triggers the rotation.
+	Trigger PasswordRotationTrigger `json:"trigger"`
+
+	// The phase of the rotation.
+	Phase PasswordRotationPhase `json:"phase"`
+
+	// The time when the rotation started.
+	StartTime metav1.Time `json:"startTime"`
+
+	// The time after which the old password will be revoked.
+	//
+	// +optional
+	RevokeTime *metav1.Time `json:"revokeTime,omitempty"`
+
+	// The time when the rotation completed or failed.
+	//
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Provides additional information about the rotation.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// StorageAutoscalingDecision defines the decision made for a volume by the storage autoscaling.
+//
+// +enum
+// +kubebuilder:validation:Enum={NoAction,Expand,Cooldown,InProgress,MaxSizeReached,NotSupported,Unknown}
+type StorageAutoscalingDecision string
+
+const (
+	// NoActionStorageAutoscalingDecision indicates the usage of the volume is below the threshold.
+	NoActionStorageAutoscalingDecision StorageAutoscalingDecision = "NoAction"
+
+	// ExpandStorageAutoscalingDecision indicates a VolumeExpansion OpsRequest is created to expand the volume.
+	ExpandStorageAutoscalingDecision StorageAutoscalingDecision = "Expand"
+
+	// CooldownStorageAutoscalingDecision indicates the volume needs to be expanded, but the cooldown period has not elapsed yet.
+	CooldownStorageAutoscalingDecision StorageAutoscalingDecision = "Cooldown"
+
+	// InProgressStorageAutoscalingDecision indicates the previous VolumeExpansion OpsRequest is still running.
+	InProgressStorageAutoscalingDecision StorageAutoscalingDecision = "InProgress"
+
+	// MaxSizeReachedStorageAutoscalingDecision indicates the volume needs to be expanded, but it has reached the max size.
+	MaxSizeReachedStorageAutoscalingDecision StorageAutoscalingDecision = "MaxSizeReached"
+
+	// NotSupportedStorageAutoscalingDecision indicates the StorageClass of the volume does not allow volume expansion.
+	NotSupportedStorageAutoscalingDecision StorageAutoscalingDecision = "NotSupported"
+
+	// UnknownStorageAutoscalingDecision indicates the usage of the volume is unavailable.
+	UnknownStorageAutoscalingDecision StorageAutoscalingDecision = "Unknown"
+)
+
+// StorageAutoscalingStatus records the storage autoscaling decisions of a Component.
+type StorageAutoscalingStatus struct {
+	// The time when the volumes were last expanded by the autoscaling.
+	//
+	// +optional
+	LastExpansionTime *metav1.Time `json:"lastExpansionTime,omitempty"`
+
+	// The name of the last VolumeExpansion OpsRequest created by the autoscaling.
+	//
+	// +optional
+	LastOpsRequest string `json:"lastOpsRequest,omitempty"`
+
+	// The latest decision of each volume.
+	//
+	// +optional
+	Volumes []VolumeAutoscalingStatus `json:"volumes,omitempty"`
+}
+
+// VolumeAutoscalingStatus records the latest storage autoscaling decision of a volumeClaimTemplate.
+type VolumeAutoscalingStatus struct {
+	// The name of the volumeClaimTemplate.
+	Name string `json:"name"`
+
+	// The decision made for the volume.
+	Decision StorageAutoscalingDecision `json:"decision"`
+
+	// The highest usage percentage among the PVCs of the volume.
+	//
+	// +optional
+	UsagePercent *int32 `json:"usagePercent,omitempty"`
+
+	// The size of the volume when the decision was made.
+	//
+	// +optional
+	CurrentSize *resource.Quantity `json:"currentSize,omitempty"`
+
+	// The size which the volume is expanded to.
+	//
+	// +optional
+	TargetSize *resource.Quantity `json:"targetSize,omitempty"`
+
+	// The last time the decision changed.
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
+
+	// Provides additional information about the decision.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 type Sidecar struct {
@@ -1170,6 +1170,23 @@ type SystemAccountStatement struct {
 	//
 	// +optional
 	Update string `json:"update,omitempty"`
+
+	// The statement to rotate the password of an existing account, while keeping the current password valid,
+	// e.g. `ALTER USER ... IDENTIFIED BY ... RETAIN CURRENT PASSWORD` for MySQL.
+	// It is used to rotate the password without downtime, the `update` statement is used if it is not defined.
+	//
+	// This field is immutable once set.
+	//
+	// +optional
+	Rotate string `json:"rotate,omitempty"`
+
+	// The statement to revoke the old password of an account after the rotation,
+	// e.g. `ALTER USER ... DISCARD OLD PASSWORD` for MySQL.
+	//
+	// This field is immutable once set.
+	//
+	// +optional
+	Revoke string `json:"revoke,omitempty"`
 }
 
 type TLS struct {
@@ -1730,13 +1747,16 @@ type ComponentLifecycleActions struct {
 //     to access context information such as details about pods, components, the overall cluster state,
 //     or database connection credentials.
 //     These variables provide a dynamic and context-aware mechanism for script execution.
-//   - HTTPAction: Performs an HTTP request.
-//     HTTPAction is to be implemented in future version.
-//   - GRPCAction: In future version, Actions will support initiating gRPC calls.
+//   - HTTPAction: Performs an HTTP request against an endpoint exposed by the replica.
+//     The path, headers and body are rendered as templates with the same context variables as `exec`.
+//   - GRPCAction: Invokes a unary gRPC method exposed by the replica.
 //     This allows developers to implement Actions using plugins written in programming language like Go,
 //     providing greater flexibility and extensibility.
 //
-// An action is considered successful on returning 0, or HTTP 200 for status HTTP(s) Actions.
+// Exactly one of `exec`, `http` or `grpc` should be specified for an Action.
+//
+// An action is considered successful on returning 0, or the expected HTTP status codes (200 by default) for HTTP(s) Actions,
+// or the OK status code for gRPC Actions.
 // Any other return value or HTTP status codes indicate failure,
 // and the action may be retried based on the configured retry policy.
 //
@@ -1753,6 +1773,20 @@ type Action struct {
 	// +optional
 	Exec *ExecAction `json:"exec,omitempty"`
 
+	// Defines the HTTP request to perform.
+	//
+	// This field cannot be updated.
+	//
+	// +optional
+	HTTP *HTTPAction `json:"http,omitempty"`
+
+	// Defines the gRPC method to invoke.
+	//
+	// This field cannot be updated.
+	//
+	// +optional
+	GRPC *GRPCAction `json:"grpc,omitempty"`
+
 	// Specifies the maximum duration in seconds that the Action is allowed to run.
 	//
 	// If the Action does not complete within this time frame, it will be terminated.
@@ -1870,6 +1904,96 @@ type ExecAction struct {
 	Container string `json:"container,omitempty"`
 }
 
+// HTTPAction describes an Action that performs an HTTP request against an endpoint exposed by the replica.
+//
+// The request is sent by the kbagent running in the same Pod as the replica.
+// The `path`, the values of `httpHeaders` and the `body` are rendered as Go templates,
+// the predefined environment variables and the action-specific variables are available in the template,
+// e.g. `{{ .KB_SWITCHOVER_CANDIDATE_NAME }}`.
+type HTTPAction struct {
+	// Specifies the target port for the HTTP request. Number must be in the range 1 to 65535.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
+
+	// Indicates the server's domain name or IP address. Defaults to the loopback address ("127.0.0.1").
+	//
+	// +optional
+	Host string `json:"host,omitempty"`
+
+	// Designates the protocol used to make the request, such as HTTP or HTTPS.
+	// If not specified, HTTP is used by default.
+	//
+	// +kubebuilder:validation:Enum={HTTP,HTTPS}
+	// +optional
+	Scheme corev1.URIScheme `json:"scheme,omitempty"`
+
+	// Represents the type of HTTP request to be made, such as "GET," "POST," "PUT," etc.
+	// If not specified, "GET" is the default method.
+	//
+	// +optional
+	Method string `json:"method,omitempty"`
+
+	// Specifies the endpoint to be requested on the HTTP server.
+	//
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// Allows for the inclusion of custom headers in the request.
+	// HTTP permits the use of repeated headers.
+	//
+	// +optional
+	HTTPHeaders []corev1.HTTPHeader `json:"httpHeaders,omitempty"`
+
+	// Specifies the template of the request body.
+	//
+	// +optional
+	Body string `json:"body,omitempty"`
+
+	// Specifies the HTTP status codes that indicate a successful execution.
+	// If not specified, only 200 is considered as success.
+	//
+	// +optional
+	ExpectedStatusCodes []int32 `json:"expectedStatusCodes,omitempty"`
+}
+
+// GRPCAction describes an Action that invokes a unary gRPC method exposed by the replica.
+//
+// The call is made by the kbagent running in the same Pod as the replica, over an insecure connection.
+// The gRPC server must enable the server reflection service, which is used to resolve the method
+// and to encode the request and decode the response.
+// The response message is returned as the output of the Action in JSON format.
+type GRPCAction struct {
+	// Specifies the target port for the gRPC call. Number must be in the range 1 to 65535.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
+
+	// Indicates the server's domain name or IP address. Defaults to the loopback address ("127.0.0.1").
+	//
+	// +optional
+	Host string `json:"host,omitempty"`
+
+	// Specifies the fully-qualified name of the gRPC service, e.g. "grpc.health.v1.Health".
+	//
+	// +kubebuilder:validation:Required
+	Service string `json:"service"`
+
+	// Specifies the name of the method to invoke, e.g. "Check".
+	//
+	// +kubebuilder:validation:Required
+	Method string `json:"method"`
+
+	// Specifies the template of the request message in JSON format.
+	// It is rendered in the same way as the body of HTTPAction.
+	// If not specified, an empty message is sent.
+	//
+	// +optional
+	Request string `json:"request,omitempty"`
+}
+
 // TargetPodSelector defines how to select pod(s) to execute an Action.
 // +enum
 // +kubebuilder:validation:Enum={Any,All,Role,Ordinal}
@@ -120,3 +120,8 @@ func (t *InstanceTemplate) GetReplicas() int32 {
 func (t *InstanceTemplate) GetOrdinals() Ordinals {
 	return t.Ordinals
 }
+
+// Defined returns true if the action has a handler, i.e. exec, http or grpc, specified.
+func (r *Action) Defined() bool {
+	return r != nil && (r.Exec != nil || r.HTTP != nil || r.GRPC != nil)
+}
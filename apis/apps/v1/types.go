@@ -18,6 +18,8 @@ package v1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
@@ -395,6 +397,35 @@ type ComponentSystemAccount struct {
 	//
 	// +optional
 	SecretRef *ProvisionSecretRef `json:"secretRef,omitempty"`
+
+	// Specifies the policy to rotate the password of the account.
+	//
+	// The password is rotated by the `accountProvision` lifecycle action, and it is not supported for the account
+	// whose password is referred from a secret.
+	//
+	// +optional
+	RotationPolicy *PasswordRotationPolicy `json:"rotationPolicy,omitempty"`
+}
+
+// PasswordRotationPolicy defines the policy to rotate the password of a system account.
+//
+// The instances of the Component are restarted after the rotation to pick up the new password from the account secret,
+// other consumers of the secret outside the Component should reload the password within the grace period.
+type PasswordRotationPolicy struct {
+	// The interval in days to rotate the password periodically.
+	// If it is not set, the password is rotated on demand only.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	IntervalDays int32 `json:"intervalDays,omitempty"`
+
+	// The grace period in seconds during which the old password is still valid after the rotation.
+	// The old password will be revoked after the grace period, once all the instances of the Component are restarted.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=3600
+	// +optional
+	GracePeriodSeconds int32 `json:"gracePeriodSeconds,omitempty"`
 }
 
 // PasswordConfig helps provide to customize complexity of password generation pattern.
@@ -542,6 +573,93 @@ const (
 	PreferInPlacePodUpdatePolicyType PodUpdatePolicyType = "PreferInPlace"
 )
 
+// MaintenanceWindow defines a recurring time window in which the disruptive operations are allowed,
+// such as restarts, upgrades, switchovers and the rolling updates of instances.
+type MaintenanceWindow struct {
+	// Specifies when the window opens in Cron format, for example, "0 2 * * 6" opens the window at 02:00 every Saturday.
+	// The time zone can be specified with the "CRON_TZ=" prefix, for example, "CRON_TZ=Asia/Shanghai 0 2 * * 6".
+	// It defaults to UTC.
+	//
+	// +kubebuilder:validation:Required
+	Schedule string `json:"schedule"`
+
+	// Specifies how long the window stays open once it opens, for example, "4h".
+	//
+	// +kubebuilder:validation:Required
+	Duration metav1.Duration `json:"duration"`
+}
+
+// StorageAutoscalingPolicy defines how the volumes of a Component are expanded automatically according to their usage.
+//
+// A `VolumeExpansion` OpsRequest is created to expand the volumes once the usage of any PVC exceeds the threshold,
+// the volumes whose StorageClass does not allow volume expansion are left untouched.
+type StorageAutoscalingPolicy struct {
+	// Specifies the names of the volumeClaimTemplates to be autoscaled.
+	// All the volumeClaimTemplates of the Component are autoscaled if it is empty.
+	//
+	// +optional
+	VolumeClaimTemplates []string `json:"volumeClaimTemplates,omitempty"`
+
+	// Specifies the usage percentage of a volume above which the volume is expanded.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=99
+	// +kubebuilder:default=80
+	// +optional
+	ThresholdPercent int32 `json:"thresholdPercent,omitempty"`
+
+	// Specifies how much to expand the volume each time, either an absolute size, for example, "10Gi",
+	// or a percentage of the current size, for example, "20%".
+	// An integer is a size in GiB, for example, 10 is the same as "10Gi", and a size in string must have a unit.
+	//
+	// +kubebuilder:validation:XIntOrString
+	// +kubebuilder:validation:Pattern=`[^0-9]`
+	// +kubebuilder:default="20%"
+	// +optional
+	Increment intstr.IntOrString `json:"increment,omitempty"`
+
+	// Specifies the max size of the volume, the volume is never expanded beyond it.
+	//
+	// +kubebuilder:validation:Required
+	MaxSize resource.Quantity `json:"maxSize"`
+
+	// Specifies the minimum interval between two expansions, for example, "1h".
+	// It gives the storage time to finish the previous expansion and the usage time to settle.
+	//
+	// +kubebuilder:default="1h"
+	// +optional
+	CooldownPeriod metav1.Duration `json:"cooldownPeriod,omitempty"`
+
+	// Specifies where the usage of the volumes is read from.
+	// The usage is queried from the kbagent of the pods if it is not specified, the volumes are mounted into
+	// the kbagent container in read-only mode, so enabling it restarts the pods of the Component.
+	//
+	// +optional
+	UsageSource *StorageUsageSource `json:"usageSource,omitempty"`
+}
+
+// StorageUsageSource specifies where the usage of the volumes is read from.
+type StorageUsageSource struct {
+	// Specifies an endpoint that is compatible with the Prometheus HTTP API,
+	// which collects the `kubelet_volume_stats_used_bytes` and `kubelet_volume_stats_capacity_bytes` metrics.
+	//
+	// +optional
+	Prometheus *PrometheusStorageUsageSource `json:"prometheus,omitempty"`
+}
+
+type PrometheusStorageUsageSource struct {
+	// The address of the Prometheus server, e.g. http://prometheus.monitoring:9090.
+	//
+	// +kubebuilder:validation:Required
+	Address string `json:"address"`
+
+	// The timeout seconds of a query.
+	//
+	// +kubebuilder:default=10
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+}
+
 // InstanceUpdateStrategy defines fine-grained control over the spec update process of all instances.
 type InstanceUpdateStrategy struct {
 	// Indicates the type of the update strategy.
@@ -593,6 +711,48 @@ type RollingUpdate struct {
 	//
 	// +optional
 	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+
+	// The maximum number of extra instances that can be created above the desired replicas during the update.
+	// Value can be an absolute number (ex: 5) or a percentage of desired instances (ex: 10%).
+	// Absolute number is calculated from percentage by rounding up. Defaults to 0, which means no surge.
+	//
+	// If set, an instance that needs to be recreated will not be deleted directly. Instead, a surge instance
+	// with the new revision is created first and joins the member set, the old instance leaves and is recreated
+	// only after the surge instance is ready, and the surge instance is retired once the recreated instance
+	// has rejoined. So the redundancy never drops below the desired replicas, and MaxUnavailable is ignored.
+	//
+	// An instance is considered joined only after the MemberJoin action completes, which is called in the
+	// non-blocking mode and should not return until the new member has caught up with the data of the member set.
+	//
+	// +optional
+	MaxSurge *intstr.IntOrString `json:"maxSurge,omitempty"`
+}
+
+// PodDisruptionBudgetPolicy overrides the PodDisruptionBudget generated for the instances.
+//
+// By default, the PodDisruptionBudget is derived from the replica roles:
+// if any role participates in quorum, only pods with those roles are guarded and a majority of them must stay available;
+// otherwise, at most one instance can be voluntarily disrupted at a time.
+//
+// +kubebuilder:validation:XValidation:rule="!(has(self.minAvailable) && has(self.maxUnavailable))",message="minAvailable and maxUnavailable are mutually exclusive"
+type PodDisruptionBudgetPolicy struct {
+	// Disables the PodDisruptionBudget, the instances will not be guarded against voluntary disruptions.
+	//
+	// +kubebuilder:default=false
+	// +optional
+	Disabled bool `json:"disabled,omitempty"`
+
+	// The minimum number of instances that must be available after an eviction.
+	// Value can be an absolute number (ex: 5) or a percentage of desired instances (ex: 10%).
+	//
+	// +optional
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+
+	// The maximum number of instances that can be unavailable after an eviction.
+	// Value can be an absolute number (ex: 5) or a percentage of desired instances (ex: 10%).
+	//
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
 }
 
 type SchedulingPolicy struct {
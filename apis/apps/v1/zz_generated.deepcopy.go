@@ -40,6 +40,16 @@ func (in *Action) DeepCopyInto(out *Action) {
 		*out = new(ExecAction)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(HTTPAction)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GRPC != nil {
+		in, out := &in.GRPC, &out.GRPC
+		*out = new(GRPCAction)
+		**out = **in
+	}
 	if in.RetryPolicy != nil {
 		in, out := &in.RetryPolicy, &out.RetryPolicy
 		*out = new(RetryPolicy)
@@ -366,6 +376,11 @@ func (in *ClusterComponentSpec) DeepCopyInto(out *ClusterComponentSpec) {
 		*out = new(InstanceUpdateStrategy)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(PodDisruptionBudgetPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Instances != nil {
 		in, out := &in.Instances, &out.Instances
 		*out = make([]InstanceTemplate, len(*in))
@@ -388,6 +403,16 @@ func (in *ClusterComponentSpec) DeepCopyInto(out *ClusterComponentSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Readonly != nil {
+		in, out := &in.Readonly, &out.Readonly
+		*out = new(bool)
+		**out = **in
+	}
+	if in.StorageAutoscaling != nil {
+		in, out := &in.StorageAutoscaling, &out.StorageAutoscaling
+		*out = new(StorageAutoscalingPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterComponentSpec.
@@ -575,6 +600,56 @@ func (in *ClusterObjectReference) DeepCopy() *ClusterObjectReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPlacement) DeepCopyInto(out *ClusterPlacement) {
+	*out = *in
+	if in.ContextSelector != nil {
+		in, out := &in.ContextSelector, &out.ContextSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPlacement.
+func (in *ClusterPlacement) DeepCopy() *ClusterPlacement {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPlacement)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPlacementStatus) DeepCopyInto(out *ClusterPlacementStatus) {
+	*out = *in
+	if in.Contexts != nil {
+		in, out := &in.Contexts, &out.Contexts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ReplacedContexts != nil {
+		in, out := &in.ReplacedContexts, &out.ReplacedContexts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Instances != nil {
+		in, out := &in.Instances, &out.Instances
+		*out = make([]InstancePlacement, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPlacementStatus.
+func (in *ClusterPlacementStatus) DeepCopy() *ClusterPlacementStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPlacementStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterService) DeepCopyInto(out *ClusterService) {
 	*out = *in
@@ -658,6 +733,16 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 		*out = new(ClusterBackup)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Placement != nil {
+		in, out := &in.Placement, &out.Placement
+		*out = new(ClusterPlacement)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(MaintenanceWindow)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSpec.
@@ -694,6 +779,11 @@ func (in *ClusterStatus) DeepCopyInto(out *ClusterStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Placement != nil {
+		in, out := &in.Placement, &out.Placement
+		*out = new(ClusterPlacementStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStatus.
@@ -1541,6 +1631,11 @@ func (in *ComponentSpec) DeepCopyInto(out *ComponentSpec) {
 		*out = new(InstanceUpdateStrategy)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(PodDisruptionBudgetPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.SchedulingPolicy != nil {
 		in, out := &in.SchedulingPolicy, &out.SchedulingPolicy
 		*out = new(SchedulingPolicy)
@@ -1578,11 +1673,26 @@ func (in *ComponentSpec) DeepCopyInto(out *ComponentSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Readonly != nil {
+		in, out := &in.Readonly, &out.Readonly
+		*out = new(bool)
+		**out = **in
+	}
 	if in.Sidecars != nil {
 		in, out := &in.Sidecars, &out.Sidecars
 		*out = make([]Sidecar, len(*in))
 		copy(*out, *in)
 	}
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(MaintenanceWindow)
+		**out = **in
+	}
+	if in.StorageAutoscaling != nil {
+		in, out := &in.StorageAutoscaling, &out.StorageAutoscaling
+		*out = new(StorageAutoscalingPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentSpec.
@@ -1612,6 +1722,23 @@ func (in *ComponentStatus) DeepCopyInto(out *ComponentStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.PasswordRotations != nil {
+		in, out := &in.PasswordRotations, &out.PasswordRotations
+		*out = make([]PasswordRotationStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StorageAutoscaling != nil {
+		in, out := &in.StorageAutoscaling, &out.StorageAutoscaling
+		*out = new(StorageAutoscalingStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReadonlyReplicas != nil {
+		in, out := &in.ReadonlyReplicas, &out.ReadonlyReplicas
+		*out = make([]ReadonlyReplica, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentStatus.
@@ -1642,6 +1769,11 @@ func (in *ComponentSystemAccount) DeepCopyInto(out *ComponentSystemAccount) {
 		*out = new(ProvisionSecretRef)
 		**out = **in
 	}
+	if in.RotationPolicy != nil {
+		in, out := &in.RotationPolicy, &out.RotationPolicy
+		*out = new(PasswordRotationPolicy)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentSystemAccount.
@@ -2065,6 +2197,46 @@ func (in *Exporter) DeepCopy() *Exporter {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GRPCAction) DeepCopyInto(out *GRPCAction) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GRPCAction.
+func (in *GRPCAction) DeepCopy() *GRPCAction {
+	if in == nil {
+		return nil
+	}
+	out := new(GRPCAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPAction) DeepCopyInto(out *HTTPAction) {
+	*out = *in
+	if in.HTTPHeaders != nil {
+		in, out := &in.HTTPHeaders, &out.HTTPHeaders
+		*out = make([]corev1.HTTPHeader, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExpectedStatusCodes != nil {
+		in, out := &in.ExpectedStatusCodes, &out.ExpectedStatusCodes
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPAction.
+func (in *HTTPAction) DeepCopy() *HTTPAction {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HostNetwork) DeepCopyInto(out *HostNetwork) {
 	*out = *in
@@ -2144,6 +2316,21 @@ func (in *HostNetworkVars) DeepCopy() *HostNetworkVars {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstancePlacement) DeepCopyInto(out *InstancePlacement) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstancePlacement.
+func (in *InstancePlacement) DeepCopy() *InstancePlacement {
+	if in == nil {
+		return nil
+	}
+	out := new(InstancePlacement)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InstanceTemplate) DeepCopyInto(out *InstanceTemplate) {
 	*out = *in
@@ -2263,6 +2450,22 @@ func (in *LogConfig) DeepCopy() *LogConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MultipleClusterObjectCombinedOption) DeepCopyInto(out *MultipleClusterObjectCombinedOption) {
 	*out = *in
@@ -2388,6 +2591,45 @@ func (in *PasswordConfig) DeepCopy() *PasswordConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PasswordRotationPolicy) DeepCopyInto(out *PasswordRotationPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordRotationPolicy.
+func (in *PasswordRotationPolicy) DeepCopy() *PasswordRotationPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PasswordRotationPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PasswordRotationStatus) DeepCopyInto(out *PasswordRotationStatus) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.RevokeTime != nil {
+		in, out := &in.RevokeTime, &out.RevokeTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordRotationStatus.
+func (in *PasswordRotationStatus) DeepCopy() *PasswordRotationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PasswordRotationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PersistentVolumeClaimRetentionPolicy) DeepCopyInto(out *PersistentVolumeClaimRetentionPolicy) {
 	*out = *in
@@ -2438,6 +2680,31 @@ func (in *PersistentVolumeClaimTemplate) DeepCopy() *PersistentVolumeClaimTempla
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodDisruptionBudgetPolicy) DeepCopyInto(out *PodDisruptionBudgetPolicy) {
+	*out = *in
+	if in.MinAvailable != nil {
+		in, out := &in.MinAvailable, &out.MinAvailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodDisruptionBudgetPolicy.
+func (in *PodDisruptionBudgetPolicy) DeepCopy() *PodDisruptionBudgetPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PodDisruptionBudgetPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Probe) DeepCopyInto(out *Probe) {
 	*out = *in
@@ -2454,6 +2721,26 @@ func (in *Probe) DeepCopy() *Probe {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusStorageUsageSource) DeepCopyInto(out *PrometheusStorageUsageSource) {
+	*out = *in
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusStorageUsageSource.
+func (in *PrometheusStorageUsageSource) DeepCopy() *PrometheusStorageUsageSource {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusStorageUsageSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProvisionSecretRef) DeepCopyInto(out *ProvisionSecretRef) {
 	*out = *in
@@ -2484,6 +2771,21 @@ func (in *Range) DeepCopy() *Range {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReadonlyReplica) DeepCopyInto(out *ReadonlyReplica) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReadonlyReplica.
+func (in *ReadonlyReplica) DeepCopy() *ReadonlyReplica {
+	if in == nil {
+		return nil
+	}
+	out := new(ReadonlyReplica)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ReplicaRole) DeepCopyInto(out *ReplicaRole) {
 	*out = *in
@@ -2619,6 +2921,11 @@ func (in *RollingUpdate) DeepCopyInto(out *RollingUpdate) {
 		*out = new(intstr.IntOrString)
 		**out = **in
 	}
+	if in.MaxSurge != nil {
+		in, out := &in.MaxSurge, &out.MaxSurge
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollingUpdate.
@@ -3513,6 +3820,80 @@ func (in *SidecarDefinitionStatus) DeepCopy() *SidecarDefinitionStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageAutoscalingPolicy) DeepCopyInto(out *StorageAutoscalingPolicy) {
+	*out = *in
+	if in.VolumeClaimTemplates != nil {
+		in, out := &in.VolumeClaimTemplates, &out.VolumeClaimTemplates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.Increment = in.Increment
+	out.MaxSize = in.MaxSize.DeepCopy()
+	out.CooldownPeriod = in.CooldownPeriod
+	if in.UsageSource != nil {
+		in, out := &in.UsageSource, &out.UsageSource
+		*out = new(StorageUsageSource)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageAutoscalingPolicy.
+func (in *StorageAutoscalingPolicy) DeepCopy() *StorageAutoscalingPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageAutoscalingPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageAutoscalingStatus) DeepCopyInto(out *StorageAutoscalingStatus) {
+	*out = *in
+	if in.LastExpansionTime != nil {
+		in, out := &in.LastExpansionTime, &out.LastExpansionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]VolumeAutoscalingStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageAutoscalingStatus.
+func (in *StorageAutoscalingStatus) DeepCopy() *StorageAutoscalingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageAutoscalingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageUsageSource) DeepCopyInto(out *StorageUsageSource) {
+	*out = *in
+	if in.Prometheus != nil {
+		in, out := &in.Prometheus, &out.Prometheus
+		*out = new(PrometheusStorageUsageSource)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageUsageSource.
+func (in *StorageUsageSource) DeepCopy() *StorageUsageSource {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageUsageSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SystemAccount) DeepCopyInto(out *SystemAccount) {
 	*out = *in
@@ -3720,3 +4101,34 @@ func (in *VarSource) DeepCopy() *VarSource {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeAutoscalingStatus) DeepCopyInto(out *VolumeAutoscalingStatus) {
+	*out = *in
+	if in.UsagePercent != nil {
+		in, out := &in.UsagePercent, &out.UsagePercent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CurrentSize != nil {
+		in, out := &in.CurrentSize, &out.CurrentSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.TargetSize != nil {
+		in, out := &in.TargetSize, &out.TargetSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeAutoscalingStatus.
+func (in *VolumeAutoscalingStatus) DeepCopy() *VolumeAutoscalingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeAutoscalingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -20,6 +20,7 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package v1alpha1
 
 import (
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 
@@ -75,6 +76,34 @@ type RolloutSpec struct {
 	// +optional
 	Components []RolloutComponent `json:"components,omitempty"`
 
+	// Specifies the target shardings to be rolled out.
+	//
+	// +kubebuilder:validation:MaxItems=128
+	// +optional
+	Shardings []RolloutSharding `json:"shardings,omitempty"`
+
+	// Indicates whether the rollout is paused.
+	//
+	// The controller stops rolling out new instances while the rollout is paused,
+	// and continues from where it left off once it is resumed.
+	//
+	// +optional
+	Paused *bool `json:"paused,omitempty"`
+
+	// Indicates whether to abort the rollout.
+	//
+	// If set to true, the rollout is stopped and rolled back: the ServiceVersion, ComponentDefinition and replicas
+	// of the components are restored to the ones recorded in the status, and the new instances are removed.
+	// The rollback can not be undone once it is started.
+	//
+	// +optional
+	Abort *bool `json:"abort,omitempty"`
+
+	// Specifies whether to roll back the rollout automatically if any target component fails during the rollout.
+	//
+	// +optional
+	AutoRollback *bool `json:"autoRollback,omitempty"`
+
 	// TODO: auto-reclaim the successful rollouts.
 }
 
@@ -104,6 +133,11 @@ type RolloutStatus struct {
 	//
 	// +optional
 	Components []RolloutComponentStatus `json:"components,omitempty"`
+
+	// Records the status information of all shardings within the Rollout.
+	//
+	// +optional
+	Shardings []RolloutShardingStatus `json:"shardings,omitempty"`
 }
 
 type RolloutComponent struct {
@@ -140,6 +174,61 @@ type RolloutComponent struct {
 	//
 	// +optional
 	InstanceMeta *RolloutInstanceMeta `json:"instanceMeta,omitempty"`
+
+	// Specifies the wave the component belongs to.
+	//
+	// The targets (components and shardings) are rolled out wave by wave in ascending order,
+	// a wave is started only after all the targets in the previous waves have been rolled out successfully.
+	// The targets within the same wave are rolled out concurrently.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Wave int32 `json:"wave,omitempty"`
+}
+
+type RolloutSharding struct {
+	// Specifies the name of the sharding.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=15
+	// +kubebuilder:validation:Pattern:=`^[a-z]([a-z0-9\-]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// Specifies the target ServiceVersion of the sharding.
+	//
+	// +kubebuilder:validation:MaxLength=32
+	// +optional
+	ServiceVersion *string `json:"serviceVersion,omitempty"`
+
+	// Specifies the target ComponentDefinition of the sharding.
+	//
+	// +kubebuilder:validation:MaxLength=64
+	// +optional
+	CompDef *string `json:"compDef,omitempty"`
+
+	// Specifies the rollout strategy for the sharding.
+	//
+	// The shards are rolled out batch by batch, and each shard is updated with the strategy.
+	// Only the inplace strategy is supported for now.
+	//
+	// +kubebuilder:validation:Required
+	Strategy RolloutStrategy `json:"strategy"`
+
+	// The maximum number of shards that can be rolled out at the same time.
+	//
+	// Value can be an absolute number (e.g. 2) or a percentage of the total shards (e.g. 50%).
+	// Absolute number is calculated from percentage by rounding down, but it is at least 1.
+	//
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:XIntOrString
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+
+	// Specifies the wave the sharding belongs to.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Wave int32 `json:"wave,omitempty"`
 }
 
 type RolloutStrategy struct {
@@ -183,6 +272,13 @@ type RolloutStrategyReplace struct {
 	// +optional
 	ScaleDownDelaySeconds *int32 `json:"scaleDownDelaySeconds,omitempty"`
 
+	// Specifies the metric analysis to be performed after a new instance becomes ready.
+	//
+	// If specified, the old instance will be scaled down only when the analysis is successful.
+	//
+	// +optional
+	Analysis *RolloutAnalysis `json:"analysis,omitempty"`
+
 	// TODO: policy to scale-down the old instances and retain the PVCs.
 }
 
@@ -242,9 +338,105 @@ type RolloutPromoteCondition struct {
 	// +optional
 	Post *appsv1.Action `json:"post,omitempty"`
 
+	// The metric analysis before promoting the new instances.
+	//
+	// If specified, the new instances will be promoted only when the analysis is successful.
+	//
+	// +optional
+	Analysis *RolloutAnalysis `json:"analysis,omitempty"`
+
 	// TODO: variables can be used in the conditions.
 }
 
+// RolloutAnalysis defines the analysis that evaluates metrics to decide whether the rollout can proceed.
+type RolloutAnalysis struct {
+	// Specifies the metrics provider to query.
+	//
+	// +kubebuilder:validation:Required
+	Provider RolloutAnalysisProvider `json:"provider"`
+
+	// Specifies the metrics to be evaluated.
+	//
+	// The measurement is successful only when all the metrics meet their thresholds.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +listType=map
+	// +listMapKey=name
+	Metrics []RolloutAnalysisMetric `json:"metrics"`
+
+	// The number of seconds to wait before taking the first measurement.
+	//
+	// +optional
+	InitialDelaySeconds *int32 `json:"initialDelaySeconds,omitempty"`
+
+	// The number of seconds between two measurements.
+	//
+	// +kubebuilder:default=30
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	IntervalSeconds *int32 `json:"intervalSeconds,omitempty"`
+
+	// The number of measurements to be taken.
+	//
+	// +kubebuilder:default=3
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Count *int32 `json:"count,omitempty"`
+
+	// The maximum number of failed measurements allowed before the analysis is considered failed.
+	//
+	// When the analysis fails, the rollout will be rolled back if the autoRollback is enabled,
+	// otherwise the rollout will be blocked.
+	//
+	// +kubebuilder:default=0
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	FailureLimit *int32 `json:"failureLimit,omitempty"`
+}
+
+type RolloutAnalysisProvider struct {
+	// Specifies a metrics endpoint that is compatible with the Prometheus HTTP API.
+	//
+	// +optional
+	Prometheus *PrometheusAnalysisProvider `json:"prometheus,omitempty"`
+}
+
+type PrometheusAnalysisProvider struct {
+	// The address of the Prometheus server, e.g. http://prometheus.monitoring:9090.
+	//
+	// +kubebuilder:validation:Required
+	Address string `json:"address"`
+
+	// The timeout seconds of a query.
+	//
+	// +kubebuilder:default=10
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+}
+
+type RolloutAnalysisMetric struct {
+	// The name of the metric.
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// The query to be evaluated, it should return a scalar or a single-element vector.
+	//
+	// +kubebuilder:validation:Required
+	Query string `json:"query"`
+
+	// The minimum value the query result should be, inclusive.
+	//
+	// +optional
+	Min *resource.Quantity `json:"min,omitempty"`
+
+	// The maximum value the query result should be, inclusive.
+	//
+	// +optional
+	Max *resource.Quantity `json:"max,omitempty"`
+}
+
 type RolloutInstanceMeta struct {
 	//// Meta added to the old instances.
 	////
@@ -268,14 +460,29 @@ type InstanceMeta struct {
 // RolloutState defines the state of the Rollout within the .status.state field.
 //
 // +enum
-// +kubebuilder:validation:Enum={Pending,Rolling,Succeed,Error}
+// +kubebuilder:validation:Enum={Pending,Rolling,Paused,RollingBack,RolledBack,Succeed,Error}
 type RolloutState string
 
 const (
-	PendingRolloutState RolloutState = "Pending"
-	RollingRolloutState RolloutState = "Rolling"
-	SucceedRolloutState RolloutState = "Succeed"
-	ErrorRolloutState   RolloutState = "Error"
+	PendingRolloutState     RolloutState = "Pending"
+	RollingRolloutState     RolloutState = "Rolling"
+	PausedRolloutState      RolloutState = "Paused"
+	RollingBackRolloutState RolloutState = "RollingBack"
+	RolledBackRolloutState  RolloutState = "RolledBack"
+	SucceedRolloutState     RolloutState = "Succeed"
+	ErrorRolloutState       RolloutState = "Error"
+)
+
+const (
+	// ConditionTypeRolloutPaused indicates whether the rollout is paused.
+	ConditionTypeRolloutPaused = "Paused"
+
+	// ConditionTypeRolloutAnalysis indicates the result of the latest analysis run.
+	ConditionTypeRolloutAnalysis = "Analysis"
+
+	// ConditionTypeRolloutRolledBack indicates the progress of the rollback,
+	// it is false while rolling back and true once the rollback is completed.
+	ConditionTypeRolloutRolledBack = "RolledBack"
 )
 
 type RolloutComponentStatus struct {
@@ -299,6 +506,11 @@ type RolloutComponentStatus struct {
 	// +kubebuilder:validation:Required
 	Replicas int32 `json:"replicas"`
 
+	// The replicas of the instance templates the component has before the rollout.
+	//
+	// +optional
+	InstanceReplicas map[string]int32 `json:"instanceReplicas,omitempty"`
+
 	// The new replicas the component has been created successfully.
 	//
 	// +optional
@@ -328,4 +540,110 @@ type RolloutComponentStatus struct {
 	//
 	// +optional
 	LastScaleDownTimestamp metav1.Time `json:"lastScaleDownTimestamp,omitempty"`
+
+	// Records the analysis runs of the component.
+	//
+	// +optional
+	AnalysisRuns []RolloutAnalysisRun `json:"analysisRuns,omitempty"`
+
+	// The rollout state of the component.
+	//
+	// +optional
+	State RolloutState `json:"state,omitempty"`
+}
+
+type RolloutShardingStatus struct {
+	// The name of the sharding.
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// The ServiceVersion of the sharding before the rollout.
+	//
+	// +kubebuilder:validation:Required
+	ServiceVersion string `json:"serviceVersion"`
+
+	// The ComponentDefinition of the sharding before the rollout.
+	//
+	// +kubebuilder:validation:Required
+	CompDef string `json:"compDef"`
+
+	// The number of shards the sharding has before the rollout.
+	//
+	// +kubebuilder:validation:Required
+	Shards int32 `json:"shards"`
+
+	// The shards that have been rolled out successfully.
+	//
+	// +optional
+	RolledOutShards []string `json:"rolledOutShards,omitempty"`
+
+	// The shards that are being rolled out.
+	//
+	// +optional
+	RollingShards []string `json:"rollingShards,omitempty"`
+
+	// The rollout state of the sharding.
+	//
+	// +optional
+	State RolloutState `json:"state,omitempty"`
+}
+
+// RolloutAnalysisPhase defines the phase of an analysis run.
+//
+// +enum
+// +kubebuilder:validation:Enum={Running,Successful,Failed}
+type RolloutAnalysisPhase string
+
+const (
+	RunningRolloutAnalysisPhase    RolloutAnalysisPhase = "Running"
+	SuccessfulRolloutAnalysisPhase RolloutAnalysisPhase = "Successful"
+	FailedRolloutAnalysisPhase     RolloutAnalysisPhase = "Failed"
+)
+
+type RolloutAnalysisRun struct {
+	// The name of the analysis run, it identifies the rollout step the analysis is performed for.
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// The phase of the analysis run.
+	//
+	// +optional
+	Phase RolloutAnalysisPhase `json:"phase,omitempty"`
+
+	// The number of successful measurements.
+	//
+	// +optional
+	Successful int32 `json:"successful"`
+
+	// The number of failed measurements.
+	//
+	// +optional
+	Failed int32 `json:"failed"`
+
+	// The time the analysis run started.
+	//
+	// +optional
+	StartTimestamp metav1.Time `json:"startTimestamp,omitempty"`
+
+	// The time the last measurement was taken.
+	//
+	// +optional
+	LastMeasurementTimestamp metav1.Time `json:"lastMeasurementTimestamp,omitempty"`
+
+	// The time the analysis run completed.
+	//
+	// +optional
+	CompletionTimestamp metav1.Time `json:"completionTimestamp,omitempty"`
+
+	// The values of the metrics in the last measurement.
+	//
+	// +optional
+	Values map[string]string `json:"values,omitempty"`
+
+	// Provides additional information about the last measurement.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
 }
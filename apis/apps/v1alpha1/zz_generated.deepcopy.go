@@ -3347,6 +3347,26 @@ func (in *Probe) DeepCopy() *Probe {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusAnalysisProvider) DeepCopyInto(out *PrometheusAnalysisProvider) {
+	*out = *in
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusAnalysisProvider.
+func (in *PrometheusAnalysisProvider) DeepCopy() *PrometheusAnalysisProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusAnalysisProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProtectedVolume) DeepCopyInto(out *ProtectedVolume) {
 	*out = *in
@@ -3631,6 +3651,119 @@ func (in *Rollout) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutAnalysis) DeepCopyInto(out *RolloutAnalysis) {
+	*out = *in
+	in.Provider.DeepCopyInto(&out.Provider)
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = make([]RolloutAnalysisMetric, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.InitialDelaySeconds != nil {
+		in, out := &in.InitialDelaySeconds, &out.InitialDelaySeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.IntervalSeconds != nil {
+		in, out := &in.IntervalSeconds, &out.IntervalSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Count != nil {
+		in, out := &in.Count, &out.Count
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FailureLimit != nil {
+		in, out := &in.FailureLimit, &out.FailureLimit
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutAnalysis.
+func (in *RolloutAnalysis) DeepCopy() *RolloutAnalysis {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutAnalysis)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutAnalysisMetric) DeepCopyInto(out *RolloutAnalysisMetric) {
+	*out = *in
+	if in.Min != nil {
+		in, out := &in.Min, &out.Min
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Max != nil {
+		in, out := &in.Max, &out.Max
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutAnalysisMetric.
+func (in *RolloutAnalysisMetric) DeepCopy() *RolloutAnalysisMetric {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutAnalysisMetric)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutAnalysisProvider) DeepCopyInto(out *RolloutAnalysisProvider) {
+	*out = *in
+	if in.Prometheus != nil {
+		in, out := &in.Prometheus, &out.Prometheus
+		*out = new(PrometheusAnalysisProvider)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutAnalysisProvider.
+func (in *RolloutAnalysisProvider) DeepCopy() *RolloutAnalysisProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutAnalysisProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutAnalysisRun) DeepCopyInto(out *RolloutAnalysisRun) {
+	*out = *in
+	in.StartTimestamp.DeepCopyInto(&out.StartTimestamp)
+	in.LastMeasurementTimestamp.DeepCopyInto(&out.LastMeasurementTimestamp)
+	in.CompletionTimestamp.DeepCopyInto(&out.CompletionTimestamp)
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutAnalysisRun.
+func (in *RolloutAnalysisRun) DeepCopy() *RolloutAnalysisRun {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutAnalysisRun)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RolloutComponent) DeepCopyInto(out *RolloutComponent) {
 	*out = *in
@@ -3670,6 +3803,13 @@ func (in *RolloutComponent) DeepCopy() *RolloutComponent {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RolloutComponentStatus) DeepCopyInto(out *RolloutComponentStatus) {
 	*out = *in
+	if in.InstanceReplicas != nil {
+		in, out := &in.InstanceReplicas, &out.InstanceReplicas
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.ScaleDownInstances != nil {
 		in, out := &in.ScaleDownInstances, &out.ScaleDownInstances
 		*out = make([]string, len(*in))
@@ -3677,6 +3817,13 @@ func (in *RolloutComponentStatus) DeepCopyInto(out *RolloutComponentStatus) {
 	}
 	in.LastScaleUpTimestamp.DeepCopyInto(&out.LastScaleUpTimestamp)
 	in.LastScaleDownTimestamp.DeepCopyInto(&out.LastScaleDownTimestamp)
+	if in.AnalysisRuns != nil {
+		in, out := &in.AnalysisRuns, &out.AnalysisRuns
+		*out = make([]RolloutAnalysisRun, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutComponentStatus.
@@ -3754,6 +3901,11 @@ func (in *RolloutPromoteCondition) DeepCopyInto(out *RolloutPromoteCondition) {
 		*out = new(apisappsv1.Action)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Analysis != nil {
+		in, out := &in.Analysis, &out.Analysis
+		*out = new(RolloutAnalysis)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutPromoteCondition.
@@ -3801,6 +3953,62 @@ func (in *RolloutPromotion) DeepCopy() *RolloutPromotion {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutSharding) DeepCopyInto(out *RolloutSharding) {
+	*out = *in
+	if in.ServiceVersion != nil {
+		in, out := &in.ServiceVersion, &out.ServiceVersion
+		*out = new(string)
+		**out = **in
+	}
+	if in.CompDef != nil {
+		in, out := &in.CompDef, &out.CompDef
+		*out = new(string)
+		**out = **in
+	}
+	in.Strategy.DeepCopyInto(&out.Strategy)
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutSharding.
+func (in *RolloutSharding) DeepCopy() *RolloutSharding {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutSharding)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutShardingStatus) DeepCopyInto(out *RolloutShardingStatus) {
+	*out = *in
+	if in.RolledOutShards != nil {
+		in, out := &in.RolledOutShards, &out.RolledOutShards
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RollingShards != nil {
+		in, out := &in.RollingShards, &out.RollingShards
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutShardingStatus.
+func (in *RolloutShardingStatus) DeepCopy() *RolloutShardingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutShardingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RolloutSpec) DeepCopyInto(out *RolloutSpec) {
 	*out = *in
@@ -3811,6 +4019,28 @@ func (in *RolloutSpec) DeepCopyInto(out *RolloutSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Shardings != nil {
+		in, out := &in.Shardings, &out.Shardings
+		*out = make([]RolloutSharding, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Paused != nil {
+		in, out := &in.Paused, &out.Paused
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Abort != nil {
+		in, out := &in.Abort, &out.Abort
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AutoRollback != nil {
+		in, out := &in.AutoRollback, &out.AutoRollback
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutSpec.
@@ -3840,6 +4070,13 @@ func (in *RolloutStatus) DeepCopyInto(out *RolloutStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Shardings != nil {
+		in, out := &in.Shardings, &out.Shardings
+		*out = make([]RolloutShardingStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutStatus.
@@ -3945,6 +4182,11 @@ func (in *RolloutStrategyReplace) DeepCopyInto(out *RolloutStrategyReplace) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.Analysis != nil {
+		in, out := &in.Analysis, &out.Analysis
+		*out = new(RolloutAnalysis)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutStrategyReplace.
@@ -184,6 +184,11 @@ type BackupStatus struct {
 	// +optional
 	EncryptionConfig *EncryptionConfig `json:"encryptionConfig,omitempty"`
 
+	// Records the wrapped data key for this backup if the envelope encryption is enabled.
+	//
+	// +optional
+	EncryptionKey *BackupEncryptionKey `json:"encryptionKey,omitempty"`
+
 	// Records the actions status for this backup.
 	//
 	// +optional
@@ -210,8 +215,163 @@ type BackupStatus struct {
 	//
 	// +optional
 	Extras []map[string]string `json:"extras,omitempty"`
+
+	// Records the checksum of the backup data, in the format of `<algorithm>:<digest>`.
+	// The SHA-256 checksums of the backup files are computed while they are uploaded, and
+	// stored in the checksum file next to the backup data. This is the digest of the
+	// checksum file. The files are verified against the checksums while they are downloaded
+	// for restoring. It is not recorded for backups with multiple targets, whose files are
+	// still verified against the checksum file of each target.
+	//
+	// +optional
+	Checksum string `json:"checksum,omitempty"`
+
+	// Records the result of the latest verification restore of the backup.
+	//
+	// +optional
+	Verification *BackupVerificationStatus `json:"verification,omitempty"`
+
+	// Records the status of the copies of the backup in the secondary backup repositories,
+	// which are specified by `backupPolicy.spec.replicationTargets`.
+	//
+	// +listType=map
+	// +listMapKey=backupRepoName
+	// +optional
+	Replicas []BackupReplicaStatus `json:"replicas,omitempty"`
 }
 
+// BackupReplicaStatus records the status of a copy of the backup in a secondary backup repository.
+type BackupReplicaStatus struct {
+	// The name of the BackupRepo that stores the copy.
+	//
+	// +kubebuilder:validation:Required
+	BackupRepoName string `json:"backupRepoName"`
+
+	// The current phase of the copy.
+	//
+	// +optional
+	Phase BackupReplicaPhase `json:"phase,omitempty"`
+
+	// The path of the copy in the backup repository, it is the same as the path of the backup.
+	//
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// Records the time the copy was started.
+	//
+	// +optional
+	StartTimestamp *metav1.Time `json:"startTimestamp,omitempty"`
+
+	// Records the time the copy was completed.
+	//
+	// +optional
+	CompletionTimestamp *metav1.Time `json:"completionTimestamp,omitempty"`
+
+	// Records the time the data was last copied, continuous backups are copied periodically.
+	//
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// Records the time range of the data that has been copied.
+	//
+	// +optional
+	TimeRange *BackupTimeRange `json:"timeRange,omitempty"`
+
+	// Indicates when the copy will be deleted from the backup repository.
+	//
+	// +optional
+	Expiration *metav1.Time `json:"expiration,omitempty"`
+
+	// Represents the reason for the copy failure.
+	//
+	// +optional
+	FailureReason string `json:"failureReason,omitempty"`
+}
+
+// IsAvailable returns true if the copy can be used to restore the backup.
+func (r *BackupReplicaStatus) IsAvailable() bool {
+	return r.Phase == BackupReplicaPhaseCompleted ||
+		(r.Phase == BackupReplicaPhaseRunning && r.LastSyncTime != nil)
+}
+
+// BackupReplicaPhase describes the phase of a copy of the backup.
+// +enum
+// +kubebuilder:validation:Enum={Pending,Running,Completed,Failed,Deleting,Deleted}
+type BackupReplicaPhase string
+
+const (
+	// BackupReplicaPhasePending means the copy is waiting for its dependencies, such as the
+	// copy of the parent backup.
+	BackupReplicaPhasePending BackupReplicaPhase = "Pending"
+
+	// BackupReplicaPhaseRunning means the data is being copied. For continuous backups,
+	// the copy stays running while the backup is running.
+	BackupReplicaPhaseRunning BackupReplicaPhase = "Running"
+
+	// BackupReplicaPhaseCompleted means all the data has been copied.
+	BackupReplicaPhaseCompleted BackupReplicaPhase = "Completed"
+
+	// BackupReplicaPhaseFailed means the copy failed, the copy job is retained for
+	// troubleshooting, and the copy is retried after the job is deleted.
+	// It's also used for the backups that can not be copied, which are never retried.
+	BackupReplicaPhaseFailed BackupReplicaPhase = "Failed"
+
+	// BackupReplicaPhaseDeleting means the copy is expired and being deleted.
+	BackupReplicaPhaseDeleting BackupReplicaPhase = "Deleting"
+
+	// BackupReplicaPhaseDeleted means the copy is expired and has been deleted.
+	BackupReplicaPhaseDeleted BackupReplicaPhase = "Deleted"
+)
+
+// GetReplica returns the status of the copy in the backup repository, or nil if not found.
+func (r *BackupStatus) GetReplica(backupRepoName string) *BackupReplicaStatus {
+	for i := range r.Replicas {
+		if r.Replicas[i].BackupRepoName == backupRepoName {
+			return &r.Replicas[i]
+		}
+	}
+	return nil
+}
+
+// BackupVerificationStatus records the result of a verification restore of the backup.
+type BackupVerificationStatus struct {
+	// The current phase of the verification.
+	//
+	// +optional
+	Phase BackupVerificationPhase `json:"phase,omitempty"`
+
+	// Records the name of the throwaway cluster into which the backup is restored.
+	//
+	// +optional
+	ClusterName string `json:"clusterName,omitempty"`
+
+	// Records the time the verification was started.
+	//
+	// +optional
+	StartTimestamp *metav1.Time `json:"startTimestamp,omitempty"`
+
+	// Records the time the verification was completed.
+	//
+	// +optional
+	CompletionTimestamp *metav1.Time `json:"completionTimestamp,omitempty"`
+
+	// Provides a human-readable message about the verification.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// BackupVerificationPhase describes the phase of a backup verification.
+// +enum
+// +kubebuilder:validation:Enum={Running,Passed,Failed}
+type BackupVerificationPhase string
+
+const (
+	BackupVerificationRunning BackupVerificationPhase = "Running"
+	BackupVerificationPassed  BackupVerificationPhase = "Passed"
+	BackupVerificationFailed  BackupVerificationPhase = "Failed"
+)
+
 // BackupTimeRange records the time range of backed up data, for PITR, this is the
 // time range of recoverable data.
 type BackupTimeRange struct {
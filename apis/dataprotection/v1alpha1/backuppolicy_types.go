@@ -84,6 +84,40 @@ type BackupPolicySpec struct {
 	//
 	// +optional
 	RetentionPolicy BackupPolicyRetentionPolicy `json:"retentionPolicy,omitempty"`
+
+	// Specifies the count-based retention for the completed backups of this policy.
+	// It is applied per backup method, and can be overridden by `backupSchedule.spec.schedules[*].retention`.
+	//
+	// +optional
+	Retention *BackupRetention `json:"retention,omitempty"`
+
+	// Specifies the secondary backup repositories that the backups are copied to asynchronously,
+	// for disaster recovery. Full and incremental backups are copied after they are completed,
+	// and continuous backups are copied periodically while they are running.
+	// Backups that take volume snapshots or are stored in a Kopia repository are not copied,
+	// and their copies are marked as failed.
+	//
+	// +listType=map
+	// +listMapKey=backupRepoName
+	// +optional
+	ReplicationTargets []BackupReplicationTarget `json:"replicationTargets,omitempty"`
+}
+
+// BackupReplicationTarget defines a secondary backup repository to copy the backups to.
+type BackupReplicationTarget struct {
+	// Specifies the name of the BackupRepo to copy the backups to.
+	//
+	// +kubebuilder:validation:Required
+	BackupRepoName string `json:"backupRepoName"`
+
+	// Determines the duration for which the copies are retained in the backup repository,
+	// counting from the time the copy is completed. The copies are deleted after this period,
+	// or together with the backup if it is deleted earlier.
+	//
+	// If not set, the copies are retained as long as the backup.
+	//
+	// +optional
+	RetentionPeriod RetentionPeriod `json:"retentionPeriod,omitempty"`
 }
 
 type BackupTarget struct {
@@ -343,6 +377,59 @@ const (
 	BackupPolicyRetentionPolicyNone BackupPolicyRetentionPolicy = ""
 )
 
+// BackupRetentionType defines the type of the count-based backup retention.
+// +enum
+// +kubebuilder:validation:Enum={KeepLast,GFS}
+type BackupRetentionType string
+
+const (
+	// BackupRetentionTypeKeepLast keeps the latest N completed backups.
+	BackupRetentionTypeKeepLast BackupRetentionType = "KeepLast"
+	// BackupRetentionTypeGFS keeps the latest completed backup of each of the recent days, weeks and months,
+	// also known as grandfather-father-son rotation.
+	BackupRetentionTypeGFS BackupRetentionType = "GFS"
+)
+
+// BackupRetention defines the count-based retention of the completed backups.
+// A full backup that a retained incremental or continuous backup depends on is always kept.
+//
+// +kubebuilder:validation:XValidation:rule="self.type != 'KeepLast' || has(self.keepLast)",message="keepLast is required when type is KeepLast"
+// +kubebuilder:validation:XValidation:rule="self.type != 'GFS' || has(self.keepDaily) || has(self.keepWeekly) || has(self.keepMonthly)",message="at least one of keepDaily, keepWeekly and keepMonthly is required when type is GFS"
+type BackupRetention struct {
+	// Specifies the type of the retention.
+	//
+	// - `KeepLast`: keeps the latest `keepLast` backups.
+	// - `GFS`: keeps the latest backup of each of the latest `keepDaily` days, `keepWeekly` ISO weeks
+	//   and `keepMonthly` months. A backup is kept if any of the rules selects it.
+	//
+	// +kubebuilder:validation:Required
+	Type BackupRetentionType `json:"type"`
+
+	// Specifies the number of the latest backups to keep, used by the `KeepLast` type.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	KeepLast *int32 `json:"keepLast,omitempty"`
+
+	// Specifies the number of the latest days for which the latest backup is kept, used by the `GFS` type.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	KeepDaily *int32 `json:"keepDaily,omitempty"`
+
+	// Specifies the number of the latest weeks for which the latest backup is kept, used by the `GFS` type.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	KeepWeekly *int32 `json:"keepWeekly,omitempty"`
+
+	// Specifies the number of the latest months for which the latest backup is kept, used by the `GFS` type.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	KeepMonthly *int32 `json:"keepMonthly,omitempty"`
+}
+
 // +genclient
 // +k8s:openapi-gen=true
 // +kubebuilder:object:root=true
@@ -76,6 +76,29 @@ type BackupRepoSpec struct {
 	// +kubebuilder:validation:Pattern=`^([a-zA-Z0-9-_]+/?)*$`
 	// +optional
 	PathPrefix string `json:"pathPrefix,omitempty"`
+
+	// Specifies how to rediscover backups stored in the repository.
+	// When enabled, the controller scans the repository for backup manifests and recreates
+	// the `Backup` objects that do not exist in the current Kubernetes cluster, which makes
+	// it possible to restore from a repository in a fresh cluster (e.g. for disaster recovery).
+	//
+	// +optional
+	Sync *BackupRepoSync `json:"sync,omitempty"`
+}
+
+// BackupRepoSync defines how to sync backups from the backup repository.
+type BackupRepoSync struct {
+	// Specifies whether to sync backups from the repository.
+	//
+	// +kubebuilder:default=false
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Specifies the interval between two syncs.
+	// If it is not set, the repository is synced only once.
+	//
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
 }
 
 // BackupRepoStatus defines the observed state of `BackupRepo`.
@@ -121,6 +144,29 @@ type BackupRepoStatus struct {
 	//
 	// +optional
 	IsDefault bool `json:"isDefault,omitempty"`
+
+	// Records the status of the latest sync of backups from the repository.
+	//
+	// +optional
+	Sync *BackupRepoSyncStatus `json:"sync,omitempty"`
+}
+
+// BackupRepoSyncStatus records the status of the latest sync of backups.
+type BackupRepoSyncStatus struct {
+	// Records the time when the latest sync finished.
+	//
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// Records the number of backups imported by the latest sync.
+	//
+	// +optional
+	ImportedBackups int32 `json:"importedBackups,omitempty"`
+
+	// Provides a human-readable message about the latest sync.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 // +genclient
@@ -17,6 +17,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -41,6 +42,67 @@ type BackupScheduleSpec struct {
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:MinItems=1
 	Schedules []SchedulePolicy `json:"schedules"`
+
+	// Defines the policy to periodically verify that the latest backup can be restored.
+	// The latest completed backup is restored into a throwaway cluster, which contains
+	// only the backed up component, in the same way as the cluster is restored from the
+	// backup. Then the check action runs against the restored component. The result is
+	// recorded in the backup status, and the throwaway cluster is removed afterwards.
+	//
+	// +optional
+	Verification *BackupVerificationPolicy `json:"verification,omitempty"`
+}
+
+// BackupVerificationPolicy defines how to verify the backups periodically.
+type BackupVerificationPolicy struct {
+	// Specifies whether the verification is enabled.
+	//
+	// +kubebuilder:default=false
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Specifies the backup method, the latest completed backup of which is verified.
+	//
+	// +kubebuilder:validation:Required
+	BackupMethod string `json:"backupMethod"`
+
+	// Specifies the interval between two verifications.
+	//
+	// +kubebuilder:validation:Required
+	Interval metav1.Duration `json:"interval"`
+
+	// Specifies the maximum duration of a verification, including the restore and the check action.
+	// The verification is marked as failed if it is not finished within the timeout.
+	// Defaults to 1h.
+	//
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// Specifies the action to check the restored data. It runs as a job after the throwaway
+	// cluster is running, and the verification passes if the job completes successfully.
+	// The connection info of a pod of the restored component is provided by the env
+	// `DP_DB_HOST`, `DP_DB_PORT`, `DP_DB_USER` and `DP_DB_PASSWORD`.
+	//
+	// +kubebuilder:validation:Required
+	CheckAction VerificationCheckAction `json:"checkAction"`
+}
+
+// VerificationCheckAction defines the action to check the restored data.
+type VerificationCheckAction struct {
+	// Specifies the image of the check container.
+	//
+	// +kubebuilder:validation:Required
+	Image string `json:"image"`
+
+	// Defines the commands to check the restored data.
+	//
+	// +kubebuilder:validation:Required
+	Command []string `json:"command"`
+
+	// Specifies the environment variables of the check container.
+	//
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
 }
 
 type SchedulePolicy struct {
@@ -83,6 +145,13 @@ type SchedulePolicy struct {
 	// +kubebuilder:default="7d"
 	RetentionPeriod RetentionPeriod `json:"retentionPeriod,omitempty"`
 
+	// Specifies the count-based retention for the completed backups created by this schedule.
+	// If set, it takes precedence over `retentionPeriod` and `backupPolicy.spec.retention`,
+	// and the backups not selected by it will be removed regardless of their expiration.
+	//
+	// +optional
+	Retention *BackupRetention `json:"retention,omitempty"`
+
 	// Specifies a list of name-value pairs representing parameters and their corresponding values.
 	// Parameters match the schema specified in the `actionset.spec.parametersSchema`
 	//
@@ -118,6 +187,29 @@ type BackupScheduleStatus struct {
 	//
 	// +optional
 	Schedules map[string]ScheduleStatus `json:"schedules,omitempty"`
+
+	// Records the status of the latest backup verification.
+	//
+	// +optional
+	Verification *ScheduleVerificationStatus `json:"verification,omitempty"`
+}
+
+// ScheduleVerificationStatus records the status of the latest backup verification.
+type ScheduleVerificationStatus struct {
+	// Records the name of the latest verified backup.
+	//
+	// +optional
+	BackupName string `json:"backupName,omitempty"`
+
+	// Records the result of the latest verification.
+	//
+	// +optional
+	Phase BackupVerificationPhase `json:"phase,omitempty"`
+
+	// Records the time the latest verification was started.
+	//
+	// +optional
+	LastVerificationTime *metav1.Time `json:"lastVerificationTime,omitempty"`
 }
 
 // BackupSchedulePhase defines the phase of BackupSchedule
@@ -111,6 +111,15 @@ type BackupRef struct {
 
 	// Specifies the source target for restoration, identified by its name.
 	SourceTargetName string `json:"sourceTargetName,omitempty"`
+
+	// Specifies the name of the BackupRepo to restore the backup from, it can be the repo
+	// of the backup or a secondary repo that stores an available copy of the backup.
+	//
+	// If not set, the repo of the backup is used, unless it is not ready and an available
+	// copy exists in a ready secondary repo.
+	//
+	// +optional
+	BackupRepoName string `json:"backupRepoName,omitempty"`
 }
 
 type RestoreKubeResources struct {
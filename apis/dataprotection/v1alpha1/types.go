@@ -244,6 +244,8 @@ const (
 )
 
 // EncryptionConfig defines the parameters for encrypting backup data.
+//
+// +kubebuilder:validation:XValidation:rule="has(self.passPhraseSecretKeyRef) || has(self.keyManagement)",message="either passPhraseSecretKeyRef or keyManagement must be specified"
 type EncryptionConfig struct {
 	// Specifies the encryption algorithm. Currently supported algorithms are:
 	//
@@ -251,6 +253,9 @@ type EncryptionConfig struct {
 	// - AES-192-CFB
 	// - AES-256-CFB
 	//
+	// The AEAD algorithms (e.g. AES-256-GCM, ChaCha20-Poly1305) are not supported yet, since the backup data is
+	// encrypted by datasafed, which supports the CFB mode only.
+	//
 	// +kubebuilder:validation:Required
 	// +kubebuilder:default=AES-256-CFB
 	// +kubebuilder:validation:Enum={AES-128-CFB,AES-192-CFB,AES-256-CFB}
@@ -259,8 +264,75 @@ type EncryptionConfig struct {
 	// Selects the key of a secret in the current namespace, the value of the secret
 	// is used as the encryption key.
 	//
+	// It is ignored if `keyManagement` is specified.
+	//
+	// +optional
+	PassPhraseSecretKeyRef *corev1.SecretKeySelector `json:"passPhraseSecretKeyRef,omitempty"`
+
+	// Specifies the envelope encryption of the backup data. If specified, a random data key is
+	// generated for each backup to encrypt the backup data, and the data key is wrapped by
+	// the key-encryption key from the key provider. The wrapped data key is recorded in
+	// `backup.status.encryptionKey`.
+	//
+	// +optional
+	KeyManagement *EncryptionKeyManagement `json:"keyManagement,omitempty"`
+}
+
+const (
+	// DefaultEncryptionKeyProvider is the built-in key provider which reads the key-encryption keys from a secret.
+	DefaultEncryptionKeyProvider = "Secret"
+)
+
+// EncryptionKeyManagement defines the key-encryption key used to wrap the data keys of backups.
+//
+// +kubebuilder:validation:XValidation:rule="self.provider != 'Secret' || has(self.secretRef)",message="secretRef is required for the Secret key provider"
+type EncryptionKeyManagement struct {
+	// Specifies the name of the key provider which manages the key-encryption keys.
+	//
+	// - `Secret`: the built-in provider, which reads the key-encryption keys from the secret specified by `secretRef`,
+	//   the keys of the secret are the key IDs.
+	//
+	// Other providers, such as a KMIP server or a Vault transit engine, can be registered to the dataprotection controller.
+	//
+	// +kubebuilder:default=Secret
+	// +optional
+	Provider string `json:"provider,omitempty"`
+
+	// Specifies the ID of the key-encryption key used to wrap the data keys of new backups.
+	// To rotate the key-encryption key, add a new key to the provider and update this field,
+	// the old key should be kept for the existing backups.
+	//
 	// +kubebuilder:validation:Required
-	PassPhraseSecretKeyRef *corev1.SecretKeySelector `json:"passPhraseSecretKeyRef"`
+	KeyID string `json:"keyID"`
+
+	// Specifies the secret in the current namespace which stores the key-encryption keys
+	// for the `Secret` key provider.
+	//
+	// +optional
+	SecretRef *corev1.LocalObjectReference `json:"secretRef,omitempty"`
+
+	// Specifies the parameters of the key provider, such as the address of the key management service.
+	//
+	// +optional
+	Parameters map[string]string `json:"parameters,omitempty"`
+}
+
+// BackupEncryptionKey records the wrapped data key of a backup.
+type BackupEncryptionKey struct {
+	// Records the name of the key provider which wraps the data key.
+	//
+	// +optional
+	Provider string `json:"provider,omitempty"`
+
+	// Records the ID of the key-encryption key which wraps the data key.
+	//
+	// +optional
+	KeyID string `json:"keyID,omitempty"`
+
+	// Records the base64 encoded data key wrapped by the key-encryption key.
+	//
+	// +optional
+	WrappedDataKey string `json:"wrappedDataKey,omitempty"`
 }
 
 type ActionSetParametersSchema struct {
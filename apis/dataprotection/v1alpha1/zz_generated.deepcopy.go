@@ -326,6 +326,21 @@ func (in *BackupDataActionSpec) DeepCopy() *BackupDataActionSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupEncryptionKey) DeepCopyInto(out *BackupEncryptionKey) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupEncryptionKey.
+func (in *BackupEncryptionKey) DeepCopy() *BackupEncryptionKey {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupEncryptionKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackupList) DeepCopyInto(out *BackupList) {
 	*out = *in
@@ -545,6 +560,16 @@ func (in *BackupPolicySpec) DeepCopyInto(out *BackupPolicySpec) {
 		*out = new(EncryptionConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(BackupRetention)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReplicationTargets != nil {
+		in, out := &in.ReplicationTargets, &out.ReplicationTargets
+		*out = make([]BackupReplicationTarget, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupPolicySpec.
@@ -701,6 +726,57 @@ func (in *BackupRef) DeepCopy() *BackupRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupReplicaStatus) DeepCopyInto(out *BackupReplicaStatus) {
+	*out = *in
+	if in.StartTimestamp != nil {
+		in, out := &in.StartTimestamp, &out.StartTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTimestamp != nil {
+		in, out := &in.CompletionTimestamp, &out.CompletionTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.TimeRange != nil {
+		in, out := &in.TimeRange, &out.TimeRange
+		*out = new(BackupTimeRange)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Expiration != nil {
+		in, out := &in.Expiration, &out.Expiration
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupReplicaStatus.
+func (in *BackupReplicaStatus) DeepCopy() *BackupReplicaStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupReplicaStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupReplicationTarget) DeepCopyInto(out *BackupReplicationTarget) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupReplicationTarget.
+func (in *BackupReplicationTarget) DeepCopy() *BackupReplicationTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupReplicationTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackupRepo) DeepCopyInto(out *BackupRepo) {
 	*out = *in
@@ -776,6 +852,11 @@ func (in *BackupRepoSpec) DeepCopyInto(out *BackupRepoSpec) {
 		*out = new(v1.SecretReference)
 		**out = **in
 	}
+	if in.Sync != nil {
+		in, out := &in.Sync, &out.Sync
+		*out = new(BackupRepoSync)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupRepoSpec.
@@ -803,6 +884,11 @@ func (in *BackupRepoStatus) DeepCopyInto(out *BackupRepoStatus) {
 		*out = new(v1.SecretReference)
 		**out = **in
 	}
+	if in.Sync != nil {
+		in, out := &in.Sync, &out.Sync
+		*out = new(BackupRepoSyncStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupRepoStatus.
@@ -815,6 +901,80 @@ func (in *BackupRepoStatus) DeepCopy() *BackupRepoStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupRepoSync) DeepCopyInto(out *BackupRepoSync) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupRepoSync.
+func (in *BackupRepoSync) DeepCopy() *BackupRepoSync {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupRepoSync)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupRepoSyncStatus) DeepCopyInto(out *BackupRepoSyncStatus) {
+	*out = *in
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupRepoSyncStatus.
+func (in *BackupRepoSyncStatus) DeepCopy() *BackupRepoSyncStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupRepoSyncStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupRetention) DeepCopyInto(out *BackupRetention) {
+	*out = *in
+	if in.KeepLast != nil {
+		in, out := &in.KeepLast, &out.KeepLast
+		*out = new(int32)
+		**out = **in
+	}
+	if in.KeepDaily != nil {
+		in, out := &in.KeepDaily, &out.KeepDaily
+		*out = new(int32)
+		**out = **in
+	}
+	if in.KeepWeekly != nil {
+		in, out := &in.KeepWeekly, &out.KeepWeekly
+		*out = new(int32)
+		**out = **in
+	}
+	if in.KeepMonthly != nil {
+		in, out := &in.KeepMonthly, &out.KeepMonthly
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupRetention.
+func (in *BackupRetention) DeepCopy() *BackupRetention {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupRetention)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackupSchedule) DeepCopyInto(out *BackupSchedule) {
 	*out = *in
@@ -889,6 +1049,11 @@ func (in *BackupScheduleSpec) DeepCopyInto(out *BackupScheduleSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(BackupVerificationPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupScheduleSpec.
@@ -911,6 +1076,11 @@ func (in *BackupScheduleStatus) DeepCopyInto(out *BackupScheduleStatus) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(ScheduleVerificationStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupScheduleStatus.
@@ -990,6 +1160,11 @@ func (in *BackupStatus) DeepCopyInto(out *BackupStatus) {
 		*out = new(EncryptionConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.EncryptionKey != nil {
+		in, out := &in.EncryptionKey, &out.EncryptionKey
+		*out = new(BackupEncryptionKey)
+		**out = **in
+	}
 	if in.Actions != nil {
 		in, out := &in.Actions, &out.Actions
 		*out = make([]ActionStatus, len(*in))
@@ -1015,6 +1190,18 @@ func (in *BackupStatus) DeepCopyInto(out *BackupStatus) {
 			}
 		}
 	}
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(BackupVerificationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = make([]BackupReplicaStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupStatus.
@@ -1106,6 +1293,56 @@ func (in *BackupTimeRange) DeepCopy() *BackupTimeRange {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupVerificationPolicy) DeepCopyInto(out *BackupVerificationPolicy) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	out.Interval = in.Interval
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	in.CheckAction.DeepCopyInto(&out.CheckAction)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupVerificationPolicy.
+func (in *BackupVerificationPolicy) DeepCopy() *BackupVerificationPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupVerificationPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupVerificationStatus) DeepCopyInto(out *BackupVerificationStatus) {
+	*out = *in
+	if in.StartTimestamp != nil {
+		in, out := &in.StartTimestamp, &out.StartTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTimestamp != nil {
+		in, out := &in.CompletionTimestamp, &out.CompletionTimestamp
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupVerificationStatus.
+func (in *BackupVerificationStatus) DeepCopy() *BackupVerificationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupVerificationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BaseJobActionSpec) DeepCopyInto(out *BaseJobActionSpec) {
 	*out = *in
@@ -1164,6 +1401,11 @@ func (in *EncryptionConfig) DeepCopyInto(out *EncryptionConfig) {
 		*out = new(v1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.KeyManagement != nil {
+		in, out := &in.KeyManagement, &out.KeyManagement
+		*out = new(EncryptionKeyManagement)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EncryptionConfig.
@@ -1176,6 +1418,33 @@ func (in *EncryptionConfig) DeepCopy() *EncryptionConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EncryptionKeyManagement) DeepCopyInto(out *EncryptionKeyManagement) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EncryptionKeyManagement.
+func (in *EncryptionKeyManagement) DeepCopy() *EncryptionKeyManagement {
+	if in == nil {
+		return nil
+	}
+	out := new(EncryptionKeyManagement)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EnvVar) DeepCopyInto(out *EnvVar) {
 	*out = *in
@@ -1871,6 +2140,11 @@ func (in *SchedulePolicy) DeepCopyInto(out *SchedulePolicy) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(BackupRetention)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Parameters != nil {
 		in, out := &in.Parameters, &out.Parameters
 		*out = make([]ParameterPair, len(*in))
@@ -1911,6 +2185,25 @@ func (in *ScheduleStatus) DeepCopy() *ScheduleStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduleVerificationStatus) DeepCopyInto(out *ScheduleVerificationStatus) {
+	*out = *in
+	if in.LastVerificationTime != nil {
+		in, out := &in.LastVerificationTime, &out.LastVerificationTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduleVerificationStatus.
+func (in *ScheduleVerificationStatus) DeepCopy() *ScheduleVerificationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduleVerificationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SchedulingSpec) DeepCopyInto(out *SchedulingSpec) {
 	*out = *in
@@ -2162,6 +2455,33 @@ func (in *ValueFrom) DeepCopy() *ValueFrom {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VerificationCheckAction) DeepCopyInto(out *VerificationCheckAction) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VerificationCheckAction.
+func (in *VerificationCheckAction) DeepCopy() *VerificationCheckAction {
+	if in == nil {
+		return nil
+	}
+	out := new(VerificationCheckAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VersionMapping) DeepCopyInto(out *VersionMapping) {
 	*out = *in
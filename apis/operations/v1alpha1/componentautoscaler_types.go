@@ -0,0 +1,272 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ComponentAutoscalerSpec defines the desired state of ComponentAutoscaler.
+//
+// +kubebuilder:validation:XValidation:rule="!has(self.minReplicas) || self.minReplicas <= self.maxReplicas",message="minReplicas must not be greater than maxReplicas"
+type ComponentAutoscalerSpec struct {
+	// Specifies the name of the Cluster.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.clusterName"
+	ClusterName string `json:"clusterName"`
+
+	// Specifies the name of the Component in the Cluster to be scaled.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.componentName"
+	ComponentName string `json:"componentName"`
+
+	// Specifies the lower limit of the replicas.
+	// The replicas are also limited by the `replicasLimit` of the ComponentDefinition.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=1
+	// +optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// Specifies the upper limit of the replicas.
+	// The replicas are also limited by the `replicasLimit` of the ComponentDefinition.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Required
+	MaxReplicas int32 `json:"maxReplicas"`
+
+	// Specifies the metrics to calculate the desired replicas.
+	// The desired replicas are calculated for each metric, and the largest one is taken.
+	//
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:Required
+	Metrics []ComponentAutoscalerMetric `json:"metrics"`
+
+	// Specifies the scaling behavior in both up and down directions, which follows the HorizontalPodAutoscaler.
+	// By default, it scales up immediately, and scales down with a stabilization window of 300 seconds.
+	//
+	// +optional
+	Behavior *autoscalingv2.HorizontalPodAutoscalerBehavior `json:"behavior,omitempty"`
+
+	// Indicates whether to suspend the autoscaling, it does not affect the OpsRequest already created.
+	//
+	// +kubebuilder:default=false
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+}
+
+// ComponentAutoscalerMetric specifies a metric and its target value, exactly one source of the metric should be specified.
+//
+// +kubebuilder:validation:XValidation:rule="[has(self.resource), has(self.prometheus), has(self.probe)].filter(x, x).size() == 1",message="exactly one of resource, prometheus and probe should be specified"
+// +kubebuilder:validation:XValidation:rule="self.target.type != 'Utilization' || has(self.resource)",message="the Utilization target is only supported by the resource metric"
+type ComponentAutoscalerMetric struct {
+	// Specifies the resource usage of the pods, which is read from the resource metrics API, e.g. the metrics-server.
+	//
+	// +optional
+	Resource *ResourceMetricSource `json:"resource,omitempty"`
+
+	// Specifies a metric queried from an endpoint that is compatible with the Prometheus HTTP API.
+	//
+	// +optional
+	Prometheus *PrometheusMetricSource `json:"prometheus,omitempty"`
+
+	// Specifies a metric reported by a probe of kbagent, the output of the probe should be a number.
+	// The metric value is the average of the latest outputs of the replicas.
+	//
+	// +optional
+	Probe *ProbeMetricSource `json:"probe,omitempty"`
+
+	// Specifies the target value of the metric.
+	//
+	// - Utilization: the average utilization of the resource across the replicas, in percentage of the requests.
+	// - AverageValue: the metric value divided by the number of replicas.
+	// - Value: the metric value as a whole.
+	//
+	// +kubebuilder:validation:Required
+	Target autoscalingv2.MetricTarget `json:"target"`
+}
+
+type ResourceMetricSource struct {
+	// The name of the resource.
+	//
+	// +kubebuilder:validation:Enum={cpu,memory}
+	// +kubebuilder:validation:Required
+	Name corev1.ResourceName `json:"name"`
+}
+
+type PrometheusMetricSource struct {
+	// The address of the Prometheus server, e.g. http://prometheus.monitoring:9090.
+	//
+	// +kubebuilder:validation:Required
+	Address string `json:"address"`
+
+	// The PromQL query which should return a scalar or a single sample.
+	//
+	// +kubebuilder:validation:Required
+	Query string `json:"query"`
+
+	// The timeout seconds of a query.
+	//
+	// +kubebuilder:default=10
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+}
+
+type ProbeMetricSource struct {
+	// The name of the probe defined in the lifecycle actions of the ComponentDefinition, e.g. availableProbe.
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+}
+
+// ComponentAutoscalerStatus defines the observed state of ComponentAutoscaler.
+type ComponentAutoscalerStatus struct {
+	// Represents the most recent generation observed of this ComponentAutoscaler.
+	//
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Represents the phase of the ComponentAutoscaler.
+	// When it equals to "Available", the autoscaler is valid and in effect.
+	//
+	// +optional
+	Phase Phase `json:"phase,omitempty"`
+
+	// Provides additional information about the current phase.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// The current replicas of the Component.
+	//
+	// +optional
+	CurrentReplicas int32 `json:"currentReplicas,omitempty"`
+
+	// The desired replicas calculated from the metrics and the behavior.
+	//
+	// +optional
+	DesiredReplicas int32 `json:"desiredReplicas,omitempty"`
+
+	// The latest values of the metrics, in the same order as the `spec.metrics`.
+	//
+	// +optional
+	CurrentMetrics []ComponentAutoscalerMetricStatus `json:"currentMetrics,omitempty"`
+
+	// Records the last time the Component was scaled by this autoscaler.
+	//
+	// +optional
+	LastScaleTime *metav1.Time `json:"lastScaleTime,omitempty"`
+
+	// The name of the last HorizontalScaling OpsRequest created by this autoscaler.
+	//
+	// +optional
+	LastOpsRequest string `json:"lastOpsRequest,omitempty"`
+
+	// The phase of the last HorizontalScaling OpsRequest created by this autoscaler.
+	// If it's failed, the autoscaling is backed off for a while before creating the next OpsRequest.
+	//
+	// +optional
+	LastOpsRequestPhase OpsPhase `json:"lastOpsRequestPhase,omitempty"`
+
+	// Records the recent recommendations of the replicas, which are used by the stabilization windows.
+	//
+	// +optional
+	Recommendations []ReplicasRecommendation `json:"recommendations,omitempty"`
+
+	// Records the recent scaling events, which are used by the scaling policies.
+	//
+	// +optional
+	ScaleEvents []ScaleEvent `json:"scaleEvents,omitempty"`
+}
+
+// ComponentAutoscalerMetricStatus records the latest value of a metric.
+type ComponentAutoscalerMetricStatus struct {
+	// The value of the metric, it is the utilization in percentage for the Utilization target.
+	//
+	// +optional
+	Value *resource.Quantity `json:"value,omitempty"`
+
+	// The replicas recommended by the metric.
+	//
+	// +optional
+	Recommendation *int32 `json:"recommendation,omitempty"`
+
+	// Provides the reason why the metric is unavailable.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ReplicasRecommendation records the replicas recommended at a time.
+type ReplicasRecommendation struct {
+	Timestamp metav1.Time `json:"timestamp"`
+
+	Replicas int32 `json:"replicas"`
+}
+
+// ScaleEvent records a scaling of the Component.
+type ScaleEvent struct {
+	Timestamp metav1.Time `json:"timestamp"`
+
+	// The replicas before scaling.
+	FromReplicas int32 `json:"fromReplicas"`
+
+	// The replicas after scaling.
+	ToReplicas int32 `json:"toReplicas"`
+}
+
+// +genclient
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:categories={kubeblocks},shortName=cas
+// +kubebuilder:printcolumn:name="CLUSTER",type="string",JSONPath=".spec.clusterName",description="Operand cluster."
+// +kubebuilder:printcolumn:name="COMPONENT",type="string",JSONPath=".spec.componentName"
+// +kubebuilder:printcolumn:name="MIN",type="integer",JSONPath=".spec.minReplicas"
+// +kubebuilder:printcolumn:name="MAX",type="integer",JSONPath=".spec.maxReplicas"
+// +kubebuilder:printcolumn:name="REPLICAS",type="integer",JSONPath=".status.currentReplicas"
+// +kubebuilder:printcolumn:name="DESIRED",type="integer",JSONPath=".status.desiredReplicas"
+// +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ComponentAutoscaler is the Schema for the componentautoscalers API, it scales the replicas of a Component
+// according to the metrics by creating HorizontalScaling OpsRequests.
+type ComponentAutoscaler struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ComponentAutoscalerSpec   `json:"spec,omitempty"`
+	Status ComponentAutoscalerStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ComponentAutoscalerList contains a list of ComponentAutoscaler.
+type ComponentAutoscalerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ComponentAutoscaler `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ComponentAutoscaler{}, &ComponentAutoscalerList{})
+}
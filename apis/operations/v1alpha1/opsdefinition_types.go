@@ -241,6 +241,15 @@ type ParametersSchema struct {
 //     suitable for immediate, short-lived operations.
 //   - resourceModifier: Modifies a K8s object using JSON patches, useful for updating the spec of some resource.
 //
+// The output of an action is exposed to the subsequent actions as the environment variable
+// "KB_OPS_OUTPUT_<ACTION_NAME>" and as `.outputs` in the `when` expression, and recorded in the OpsRequest status:
+//
+//   - exec: the standard output of the command.
+//   - workload: the termination message of the first container.
+//
+// The values of the secrets referenced by the env of the container are redacted from the output,
+// and the output is truncated to the last 1KB.
+//
 // +kubebuilder:validation:XValidation:rule="has(self.workload) || has(self.exec) || has(self.resourceModifier)", message="at least one action exists for workload, exec and resourceModifier."
 type OpsAction struct {
 	// Specifies the name of the OpsAction.
@@ -285,6 +294,42 @@ type OpsAction struct {
 	//
 	// +optional
 	ResourceModifier *OpsResourceModifierAction `json:"resourceModifier,omitempty"`
+
+	// Specifies the names of the actions that must be completed before this action starts.
+	//
+	// If none of the actions in the OpsDefinition specifies `dependsOn`, the actions are executed sequentially
+	// in the order they are defined. Otherwise, the actions form a directed acyclic graph (DAG),
+	// and actions whose dependencies are all completed are executed in parallel.
+	//
+	// An action will not be executed if any of its dependencies failed with the "Fail" failure policy.
+	//
+	// +optional
+	DependsOn []string `json:"dependsOn,omitempty"`
+
+	// Specifies a condition that determines whether the action should be executed.
+	// The value is a Go template rendered with the sprig functions, which must render to a boolean.
+	// The action is skipped if it renders to false, and fails if it does not render to a boolean.
+	//
+	// The following objects can be referenced in the template:
+	//
+	// - `.cluster`: the Cluster object.
+	// - `.component`: the ClusterComponentSpec of the target component.
+	// - `.parameters`: the parameters of the OpsRequest.
+	// - `.outputs`: the outputs of the completed actions, keyed by the action name.
+	//
+	// For example: `{{ eq (index .outputs "check-role") "primary" }}`.
+	//
+	// +optional
+	When string `json:"when,omitempty"`
+
+	// Specifies the names of the actions to run as compensation if this action fails.
+	//
+	// The referenced actions are executed only when this action fails and are skipped otherwise.
+	// They are still executed after a failure with the "Fail" failure policy, which allows rolling back
+	// the changes made by the preceding actions.
+	//
+	// +optional
+	Compensations []string `json:"compensations,omitempty"`
 }
 
 // FailurePolicyType specifies the type of failure policy.
@@ -19,6 +19,7 @@ package v1alpha1
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -47,6 +48,8 @@ const (
 	ConditionTypeBackup             = "Backup"
 	ConditionTypeInstanceRebuilding = "InstancesRebuilding"
 	ConditionTypeCustomOperation    = "CustomOperation"
+	ConditionTypePasswordRotating   = "PasswordRotating"
+	ConditionTypeMaintenanceWindow  = "MaintenanceWindow"
 
 	// condition and event reasons
 	ReasonClusterPhaseMismatch  = "ClusterPhaseMismatch"
@@ -58,6 +61,11 @@ const (
 	ReasonOpsCancelFailed       = "CancelFailed"
 	ReasonOpsCancelSucceed      = "CancelSucceed"
 	ReasonOpsCancelByController = "CancelByController"
+
+	ReasonWaitForMaintenanceWindow  = "WaitForMaintenanceWindow"
+	ReasonPausedByMaintenanceWindow = "PausedByMaintenanceWindow"
+	ReasonInMaintenanceWindow       = "InMaintenanceWindow"
+	ReasonMaintenanceWindowBypassed = "MaintenanceWindowBypassed"
 )
 
 func (r *OpsRequest) SetStatusCondition(condition metav1.Condition) {
@@ -76,6 +84,47 @@ func NewWaitForProcessingCondition(ops *OpsRequest) *metav1.Condition {
 	}
 }
 
+// NewWaitForMaintenanceWindowCondition the OpsRequest is queued until the maintenance window opens.
+func NewWaitForMaintenanceWindowCondition(ops *OpsRequest, openTime time.Time) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               ConditionTypeMaintenanceWindow,
+		Status:             metav1.ConditionFalse,
+		Reason:             ReasonWaitForMaintenanceWindow,
+		LastTransitionTime: metav1.Now(),
+		Message: fmt.Sprintf("the OpsRequest: %s is waiting for the maintenance window of Cluster: %s, which opens at %s",
+			ops.Name, ops.Spec.GetClusterName(), openTime.UTC().Format(time.RFC3339)),
+	}
+}
+
+// NewPausedByMaintenanceWindowCondition the running OpsRequest is paused as the maintenance window closes.
+func NewPausedByMaintenanceWindowCondition(ops *OpsRequest, openTime time.Time) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               ConditionTypeMaintenanceWindow,
+		Status:             metav1.ConditionFalse,
+		Reason:             ReasonPausedByMaintenanceWindow,
+		LastTransitionTime: metav1.Now(),
+		Message: fmt.Sprintf("the OpsRequest: %s is paused as the maintenance window of Cluster: %s is closed, it will resume at %s",
+			ops.Name, ops.Spec.GetClusterName(), openTime.UTC().Format(time.RFC3339)),
+	}
+}
+
+// NewInMaintenanceWindowCondition the OpsRequest is allowed to run by the maintenance window.
+func NewInMaintenanceWindowCondition(ops *OpsRequest) *metav1.Condition {
+	condition := &metav1.Condition{
+		Type:               ConditionTypeMaintenanceWindow,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonInMaintenanceWindow,
+		LastTransitionTime: metav1.Now(),
+		Message:            fmt.Sprintf("the maintenance window of Cluster: %s is open", ops.Spec.GetClusterName()),
+	}
+	if ops.Spec.BypassMaintenanceWindow {
+		condition.Reason = ReasonMaintenanceWindowBypassed
+		condition.Message = fmt.Sprintf("the OpsRequest: %s bypasses the maintenance window of Cluster: %s",
+			ops.Name, ops.Spec.GetClusterName())
+	}
+	return condition
+}
+
 // NewCancelingCondition the controller is canceling the OpsRequest
 func NewCancelingCondition(ops *OpsRequest) *metav1.Condition {
 	return &metav1.Condition{
@@ -355,6 +404,17 @@ func NewBackupCondition(ops *OpsRequest) *metav1.Condition {
 	}
 }
 
+// NewPasswordRotatingCondition creates a condition that the OpsRequest starts to rotate the passwords of system accounts.
+func NewPasswordRotatingCondition(ops *OpsRequest) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               ConditionTypePasswordRotating,
+		Status:             metav1.ConditionTrue,
+		Reason:             "PasswordRotationStarted",
+		LastTransitionTime: metav1.Now(),
+		Message:            fmt.Sprintf("Start to rotate the passwords of system accounts in Cluster: %s", ops.Spec.GetClusterName()),
+	}
+}
+
 // NewRestoreCondition creates a condition that the OpsRequest restore the cluster.
 func NewRestoreCondition(ops *OpsRequest) *metav1.Condition {
 	return &metav1.Condition{
@@ -27,8 +27,6 @@ import (
 )
 
 // OpsRequestSpec defines the desired state of OpsRequest
-//
-// +kubebuilder:validation:XValidation:rule="has(self.cancel) && self.cancel ? (self.type in ['VerticalScaling', 'HorizontalScaling']) : true",message="forbidden to cancel the opsRequest which type not in ['VerticalScaling','HorizontalScaling']"
 type OpsRequestSpec struct {
 	// Specifies the name of the Cluster resource that this operation is targeting.
 	//
@@ -38,7 +36,20 @@ type OpsRequestSpec struct {
 	// Indicates whether the current operation should be canceled and terminated gracefully if it's in the
 	// "Pending", "Creating", or "Running" state.
 	//
-	// This field applies only to "VerticalScaling" and "HorizontalScaling" opsRequests.
+	// Once canceled, no further changes are made to the Pods of the target Components, and the changes made to the
+	// Cluster are reverted to `status.lastConfiguration` where possible:
+	//
+	// - "VerticalScaling", "HorizontalScaling", "Upgrade", "Stop" and "Start":
+	//   the Cluster spec is reverted, and the Pods that have been changed are rolled back.
+	// - "Expose": the Cluster services changed are restored to the ones recorded in `status.lastConfiguration`.
+	// - "Reconfiguring": the previous values of the updated parameters are re-applied.
+	// - "Custom" and "Switchover": pending actions are skipped, and the running ones are waited for completion.
+	// - "Backup": the Backup in progress is deleted.
+	// - "Restart", "VolumeExpansion", "Restore" and "RebuildInstance": canceling is supported only in the "Pending" state,
+	//   as the restarted Pods, the expanded PVCs and the restored data can not be rolled back.
+	//
+	// The instances that have been changed before the cancellation are recorded in
+	// `status.components[*].touchedInstances`.
 	//
 	// Note: Setting `cancel` to true is irreversible; further modifications to this field are ineffective.
 	//
@@ -64,6 +75,18 @@ type OpsRequestSpec struct {
 	// +optional
 	EnqueueOnForce bool `json:"enqueueOnForce,omitempty"`
 
+	// Indicates whether the opsRequest should bypass the maintenance window of the Cluster.
+	//
+	// The disruptive opsRequests, including 'Restart', 'Upgrade', 'Switchover', 'VerticalScaling' and the 'Reconfiguring'
+	// of the parameters that can't be reloaded dynamically, are queued until the maintenance window of the Cluster opens by default.
+	// If set to true, the opsRequest is executed immediately, and the rolling updates of the instances of the components
+	// it operates on are allowed outside the window until the opsRequest completes,
+	// or times out (an hour later if `timeoutSeconds` is not specified).
+	//
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.bypassMaintenanceWindow"
+	// +optional
+	BypassMaintenanceWindow bool `json:"bypassMaintenanceWindow,omitempty"`
+
 	// Specifies the type of this operation. Supported types include "Start", "Stop", "Restart", "Switchover",
 	// "VerticalScaling", "HorizontalScaling", "VolumeExpansion", "Reconfiguring", "Upgrade", "Backup", "Restore",
 	// "Expose", "RebuildInstance", "Custom".
@@ -226,6 +249,16 @@ type SpecificOpsRequest struct {
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.rebuildFrom"
 	RebuildFrom []RebuildInstance `json:"rebuildFrom,omitempty"  patchStrategy:"merge,retainKeys" patchMergeKey:"componentName"`
 
+	// Lists RotatePassword objects, each specifying a Component and the system accounts to rotate the passwords.
+	//
+	// +optional
+	// +patchMergeKey=componentName
+	// +patchStrategy=merge,retainKeys
+	// +listType=map
+	// +listMapKey=componentName
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.rotatePassword"
+	RotatePasswordList []RotatePassword `json:"rotatePassword,omitempty"  patchStrategy:"merge,retainKeys" patchMergeKey:"componentName"`
+
 	// Specifies a custom operation defined by OpsDefinition.
 	//
 	// +optional
@@ -239,6 +272,18 @@ type ComponentOps struct {
 	ComponentName string `json:"componentName"`
 }
 
+type RotatePassword struct {
+	// Specifies the name of the Component or the sharding.
+	ComponentOps `json:",inline"`
+
+	// Specifies the names of the system accounts to rotate the passwords.
+	// The rotation is not supported for the accounts whose passwords are referred from secrets.
+	//
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:Required
+	Accounts []string `json:"accounts"`
+}
+
 type RebuildInstance struct {
 	// Specifies the name of the Component.
 	ComponentOps `json:",inline"`
@@ -1019,6 +1064,11 @@ type ActionTask struct {
 	// The count of retry attempts made for this task.
 	// +optional
 	Retries int32 `json:"retries,omitempty"`
+
+	// The output of the task, such as the standard output of an 'exec' action
+	// or the termination message of a 'workload' action.
+	// +optional
+	Output string `json:"output,omitempty"`
 }
 
 // LastComponentConfiguration can be used to track and compare the desired state of the Component over time.
@@ -1059,6 +1109,21 @@ type LastComponentConfiguration struct {
 	// Records the name of the ComponentDefinition prior to any changes.
 	// +optional
 	ComponentDefinitionName string `json:"componentDefinitionName,omitempty"`
+
+	// Records the annotations of the Component prior to any changes, only the annotations
+	// that will be changed by the OpsRequest are recorded.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Records the `stop` of the Component prior to any changes.
+	// +optional
+	Stop *bool `json:"stop,omitempty"`
+
+	// Records the values of the parameters prior to any changes, only the parameters
+	// that will be updated by the OpsRequest are recorded.
+	// A nil value indicates the parameter is not set before.
+	// +optional
+	Parameters []ParameterPair `json:"parameters,omitempty"`
 }
 
 type LastConfiguration struct {
@@ -1066,6 +1131,11 @@ type LastConfiguration struct {
 	// Records the configuration of each Component prior to any changes.
 	// +optional
 	Components map[string]LastComponentConfiguration `json:"components,omitempty"`
+
+	// Records the services of the Cluster prior to any changes, only the services
+	// that will be changed by the OpsRequest are recorded.
+	// +optional
+	Services []appsv1.ClusterService `json:"services,omitempty"`
 }
 
 type OpsRequestComponentStatus struct {
@@ -1085,6 +1155,14 @@ type OpsRequestComponentStatus struct {
 	// +optional
 	ProgressDetails []ProgressStatusDetail `json:"progressDetails,omitempty"`
 
+	// Records the names of the instances that have been changed by the OpsRequest.
+	//
+	// It is populated when the OpsRequest is completed. If the OpsRequest is canceled,
+	// only the instances changed before the cancellation are recorded.
+	//
+	// +optional
+	TouchedInstances []string `json:"touchedInstances,omitempty"`
+
 	// Provides an explanation for the Component being in its current state.
 	// +kubebuilder:validation:MaxLength=1024
 	// +optional
@@ -145,6 +145,8 @@ func (r *OpsRequest) ValidateOps(ctx context.Context,
 		return r.validateExpose(ctx, cluster)
 	case RebuildInstanceType:
 		return r.validateRebuildInstance(cluster)
+	case RotatePasswordType:
+		return r.validateRotatePassword(cluster)
 	}
 	return nil
 }
@@ -191,6 +193,22 @@ func (r *OpsRequest) validateRebuildInstance(cluster *appsv1.Cluster) error {
 	return r.checkComponentExistence(cluster, compOpsList)
 }
 
+// validateRotatePassword validates spec.rotatePassword
+func (r *OpsRequest) validateRotatePassword(cluster *appsv1.Cluster) error {
+	rotatePasswordList := r.Spec.RotatePasswordList
+	if len(rotatePasswordList) == 0 {
+		return notEmptyError("spec.rotatePassword")
+	}
+	var compOpsList []ComponentOps
+	for _, v := range rotatePasswordList {
+		if len(v.Accounts) == 0 {
+			return notEmptyError("spec.rotatePassword.accounts")
+		}
+		compOpsList = append(compOpsList, v.ComponentOps)
+	}
+	return r.checkComponentExistence(cluster, compOpsList)
+}
+
 // validateUpgrade validates spec.restart
 func (r *OpsRequest) validateRestart(cluster *appsv1.Cluster) error {
 	restartList := r.Spec.RestartList
@@ -0,0 +1,193 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OpsRequestScheduleSpec defines the desired state of OpsRequestSchedule.
+type OpsRequestScheduleSpec struct {
+	// Specifies the schedule in the Cron format, the OpsRequest is created from the template at each schedule time.
+	// A time zone can be specified by the prefix "CRON_TZ=<zone>", e.g. "CRON_TZ=Asia/Shanghai 0 2 * * *",
+	// and UTC is used by default.
+	//
+	// +kubebuilder:validation:Required
+	Schedule string `json:"schedule"`
+
+	// Specifies how to treat the concurrent OpsRequests created by this schedule.
+	//
+	// - Allow: allows the OpsRequests to run concurrently, they're still queued by the Cluster as usual.
+	// - Forbid: skips the new OpsRequest if the previous one has not completed yet.
+	// - Replace: cancels the previous OpsRequest and creates the new one. If the previous one can not be cancelled,
+	//   for example, a running Restart, the new OpsRequest is skipped as Forbid does.
+	//
+	// +kubebuilder:default=Forbid
+	// +optional
+	ConcurrencyPolicy ConcurrencyPolicy `json:"concurrencyPolicy,omitempty"`
+
+	// Indicates whether to suspend the subsequent schedules, it does not affect the OpsRequests already created.
+	//
+	// +kubebuilder:default=false
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
+	// Specifies the deadline in seconds for starting the OpsRequest if it misses the schedule time for any reason,
+	// e.g. the controller is down. The missed schedules are skipped if exceeded.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	StartingDeadlineSeconds *int64 `json:"startingDeadlineSeconds,omitempty"`
+
+	// Specifies the number of the succeed OpsRequests to retain.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=3
+	// +optional
+	SuccessfulHistoryLimit *int32 `json:"successfulHistoryLimit,omitempty"`
+
+	// Specifies the number of the failed OpsRequests to retain, including the cancelled and aborted ones.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=1
+	// +optional
+	FailedHistoryLimit *int32 `json:"failedHistoryLimit,omitempty"`
+
+	// Specifies the template of the OpsRequests to be created.
+	//
+	// +kubebuilder:validation:Required
+	OpsRequestTemplate OpsRequestTemplate `json:"opsRequestTemplate"`
+}
+
+// ConcurrencyPolicy describes how the OpsRequests created by an OpsRequestSchedule run concurrently.
+//
+// +enum
+// +kubebuilder:validation:Enum={Allow,Forbid,Replace}
+type ConcurrencyPolicy string
+
+const (
+	AllowConcurrent   ConcurrencyPolicy = "Allow"
+	ForbidConcurrent  ConcurrencyPolicy = "Forbid"
+	ReplaceConcurrent ConcurrencyPolicy = "Replace"
+)
+
+// OpsRequestTemplate describes the OpsRequest to be created by an OpsRequestSchedule.
+type OpsRequestTemplate struct {
+	// Specifies the labels and annotations of the OpsRequest.
+	//
+	// +optional
+	Metadata OpsRequestTemplateMeta `json:"metadata,omitempty"`
+
+	// Specifies the spec of the OpsRequest.
+	// The field is not validated by the schema as the immutable fields of the OpsRequest can be updated in the template,
+	// it's validated when the OpsRequest is created instead.
+	//
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	Spec OpsRequestSpec `json:"spec"`
+}
+
+// OpsRequestTemplateMeta describes the metadata of the OpsRequest to be created.
+type OpsRequestTemplateMeta struct {
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// OpsRequestScheduleStatus defines the observed state of OpsRequestSchedule.
+type OpsRequestScheduleStatus struct {
+	// Represents the most recent generation observed of this OpsRequestSchedule.
+	//
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Represents the phase of the OpsRequestSchedule.
+	// When it equals to "Available", the schedule is valid and in effect.
+	//
+	// +optional
+	Phase Phase `json:"phase,omitempty"`
+
+	// Provides additional information about the current phase.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Records the references of the OpsRequests created by this schedule and not completed yet.
+	//
+	// +optional
+	Active []corev1.ObjectReference `json:"active,omitempty"`
+
+	// Records the last time the OpsRequest was created by this schedule.
+	//
+	// +optional
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+
+	// Records the last schedule time skipped as the previous OpsRequest was still active,
+	// with the "Forbid" concurrency policy.
+	//
+	// +optional
+	LastSkippedScheduleTime *metav1.Time `json:"lastSkippedScheduleTime,omitempty"`
+
+	// Records the last time the OpsRequest created by this schedule succeeded.
+	//
+	// +optional
+	LastSuccessfulTime *metav1.Time `json:"lastSuccessfulTime,omitempty"`
+
+	// Records the next time to create the OpsRequest.
+	//
+	// +optional
+	NextScheduleTime *metav1.Time `json:"nextScheduleTime,omitempty"`
+}
+
+// +genclient
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:categories={kubeblocks},shortName=opssch
+// +kubebuilder:printcolumn:name="TYPE",type="string",JSONPath=".spec.opsRequestTemplate.spec.type",description="Operation request type."
+// +kubebuilder:printcolumn:name="CLUSTER",type="string",JSONPath=".spec.opsRequestTemplate.spec.clusterName",description="Operand cluster."
+// +kubebuilder:printcolumn:name="SCHEDULE",type="string",JSONPath=".spec.schedule"
+// +kubebuilder:printcolumn:name="SUSPEND",type="boolean",JSONPath=".spec.suspend"
+// +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="LAST-SCHEDULE",type="date",JSONPath=".status.lastScheduleTime"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// OpsRequestSchedule is the Schema for the opsrequestschedules API, it creates OpsRequests from the template periodically.
+type OpsRequestSchedule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OpsRequestScheduleSpec   `json:"spec,omitempty"`
+	Status OpsRequestScheduleStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// OpsRequestScheduleList contains a list of OpsRequestSchedule.
+type OpsRequestScheduleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OpsRequestSchedule `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OpsRequestSchedule{}, &OpsRequestScheduleList{})
+}
@@ -72,7 +72,7 @@ const (
 
 // OpsType defines operation types.
 // +enum
-// +kubebuilder:validation:Enum={Upgrade,VerticalScaling,VolumeExpansion,HorizontalScaling,Restart,Reconfiguring,Start,Stop,Expose,Switchover,Backup,Restore,RebuildInstance,Custom}
+// +kubebuilder:validation:Enum={Upgrade,VerticalScaling,VolumeExpansion,HorizontalScaling,Restart,Reconfiguring,Start,Stop,Expose,Switchover,Backup,Restore,RebuildInstance,RotatePassword,Custom}
 type OpsType string
 
 const (
@@ -89,12 +89,13 @@ const (
 	BackupType            OpsType = "Backup"
 	RestoreType           OpsType = "Restore"
 	RebuildInstanceType   OpsType = "RebuildInstance" // RebuildInstance rebuilding an instance is very useful when a node is offline or an instance is unrecoverable.
+	RotatePasswordType    OpsType = "RotatePassword"  // RotatePassword rotates the passwords of system accounts on demand.
 	CustomType            OpsType = "Custom"          // use opsDefinition
 )
 
 // ProgressStatus defines the status of the opsRequest progress.
 // +enum
-// +kubebuilder:validation:Enum={Processing,Pending,Failed,Succeed}
+// +kubebuilder:validation:Enum={Processing,Pending,Failed,Succeed,Skipped}
 type ProgressStatus string
 
 const (
@@ -102,6 +103,7 @@ const (
 	ProcessingProgressStatus ProgressStatus = "Processing"
 	FailedProgressStatus     ProgressStatus = "Failed"
 	SucceedProgressStatus    ProgressStatus = "Succeed"
+	SkippedProgressStatus    ProgressStatus = "Skipped"
 )
 
 // ActionTaskStatus defines the status of the task.
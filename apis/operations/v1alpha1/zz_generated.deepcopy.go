@@ -26,6 +26,7 @@ package v1alpha1
 import (
 	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
 	dataprotectionv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	"k8s.io/api/autoscaling/v2"
 	"k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
@@ -98,6 +99,193 @@ func (in *CompletionProbe) DeepCopy() *CompletionProbe {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentAutoscaler) DeepCopyInto(out *ComponentAutoscaler) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentAutoscaler.
+func (in *ComponentAutoscaler) DeepCopy() *ComponentAutoscaler {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentAutoscaler)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ComponentAutoscaler) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentAutoscalerList) DeepCopyInto(out *ComponentAutoscalerList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ComponentAutoscaler, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentAutoscalerList.
+func (in *ComponentAutoscalerList) DeepCopy() *ComponentAutoscalerList {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentAutoscalerList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ComponentAutoscalerList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentAutoscalerMetric) DeepCopyInto(out *ComponentAutoscalerMetric) {
+	*out = *in
+	if in.Resource != nil {
+		in, out := &in.Resource, &out.Resource
+		*out = new(ResourceMetricSource)
+		**out = **in
+	}
+	if in.Prometheus != nil {
+		in, out := &in.Prometheus, &out.Prometheus
+		*out = new(PrometheusMetricSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Probe != nil {
+		in, out := &in.Probe, &out.Probe
+		*out = new(ProbeMetricSource)
+		**out = **in
+	}
+	in.Target.DeepCopyInto(&out.Target)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentAutoscalerMetric.
+func (in *ComponentAutoscalerMetric) DeepCopy() *ComponentAutoscalerMetric {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentAutoscalerMetric)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentAutoscalerMetricStatus) DeepCopyInto(out *ComponentAutoscalerMetricStatus) {
+	*out = *in
+	if in.Value != nil {
+		in, out := &in.Value, &out.Value
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Recommendation != nil {
+		in, out := &in.Recommendation, &out.Recommendation
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentAutoscalerMetricStatus.
+func (in *ComponentAutoscalerMetricStatus) DeepCopy() *ComponentAutoscalerMetricStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentAutoscalerMetricStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentAutoscalerSpec) DeepCopyInto(out *ComponentAutoscalerSpec) {
+	*out = *in
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = make([]ComponentAutoscalerMetric, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Behavior != nil {
+		in, out := &in.Behavior, &out.Behavior
+		*out = new(v2.HorizontalPodAutoscalerBehavior)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentAutoscalerSpec.
+func (in *ComponentAutoscalerSpec) DeepCopy() *ComponentAutoscalerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentAutoscalerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentAutoscalerStatus) DeepCopyInto(out *ComponentAutoscalerStatus) {
+	*out = *in
+	if in.CurrentMetrics != nil {
+		in, out := &in.CurrentMetrics, &out.CurrentMetrics
+		*out = make([]ComponentAutoscalerMetricStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastScaleTime != nil {
+		in, out := &in.LastScaleTime, &out.LastScaleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Recommendations != nil {
+		in, out := &in.Recommendations, &out.Recommendations
+		*out = make([]ReplicasRecommendation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ScaleEvents != nil {
+		in, out := &in.ScaleEvents, &out.ScaleEvents
+		*out = make([]ScaleEvent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentAutoscalerStatus.
+func (in *ComponentAutoscalerStatus) DeepCopy() *ComponentAutoscalerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentAutoscalerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ComponentInfo) DeepCopyInto(out *ComponentInfo) {
 	*out = *in
@@ -370,6 +558,25 @@ func (in *LastComponentConfiguration) DeepCopyInto(out *LastComponentConfigurati
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Stop != nil {
+		in, out := &in.Stop, &out.Stop
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make([]ParameterPair, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LastComponentConfiguration.
@@ -392,6 +599,13 @@ func (in *LastConfiguration) DeepCopyInto(out *LastConfiguration) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.Services != nil {
+		in, out := &in.Services, &out.Services
+		*out = make([]appsv1.ClusterService, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LastConfiguration.
@@ -442,6 +656,16 @@ func (in *OpsAction) DeepCopyInto(out *OpsAction) {
 		*out = new(OpsResourceModifierAction)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Compensations != nil {
+		in, out := &in.Compensations, &out.Compensations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsAction.
@@ -697,6 +921,11 @@ func (in *OpsRequestComponentStatus) DeepCopyInto(out *OpsRequestComponentStatus
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.TouchedInstances != nil {
+		in, out := &in.TouchedInstances, &out.TouchedInstances
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsRequestComponentStatus.
@@ -741,6 +970,132 @@ func (in *OpsRequestList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsRequestSchedule) DeepCopyInto(out *OpsRequestSchedule) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsRequestSchedule.
+func (in *OpsRequestSchedule) DeepCopy() *OpsRequestSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsRequestSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OpsRequestSchedule) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsRequestScheduleList) DeepCopyInto(out *OpsRequestScheduleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OpsRequestSchedule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsRequestScheduleList.
+func (in *OpsRequestScheduleList) DeepCopy() *OpsRequestScheduleList {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsRequestScheduleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OpsRequestScheduleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsRequestScheduleSpec) DeepCopyInto(out *OpsRequestScheduleSpec) {
+	*out = *in
+	if in.StartingDeadlineSeconds != nil {
+		in, out := &in.StartingDeadlineSeconds, &out.StartingDeadlineSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.SuccessfulHistoryLimit != nil {
+		in, out := &in.SuccessfulHistoryLimit, &out.SuccessfulHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FailedHistoryLimit != nil {
+		in, out := &in.FailedHistoryLimit, &out.FailedHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	in.OpsRequestTemplate.DeepCopyInto(&out.OpsRequestTemplate)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsRequestScheduleSpec.
+func (in *OpsRequestScheduleSpec) DeepCopy() *OpsRequestScheduleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsRequestScheduleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsRequestScheduleStatus) DeepCopyInto(out *OpsRequestScheduleStatus) {
+	*out = *in
+	if in.Active != nil {
+		in, out := &in.Active, &out.Active
+		*out = make([]v1.ObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastSkippedScheduleTime != nil {
+		in, out := &in.LastSkippedScheduleTime, &out.LastSkippedScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastSuccessfulTime != nil {
+		in, out := &in.LastSuccessfulTime, &out.LastSuccessfulTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextScheduleTime != nil {
+		in, out := &in.NextScheduleTime, &out.NextScheduleTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsRequestScheduleStatus.
+func (in *OpsRequestScheduleStatus) DeepCopy() *OpsRequestScheduleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsRequestScheduleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpsRequestSpec) DeepCopyInto(out *OpsRequestSpec) {
 	*out = *in
@@ -813,6 +1168,52 @@ func (in *OpsRequestStatus) DeepCopy() *OpsRequestStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsRequestTemplate) DeepCopyInto(out *OpsRequestTemplate) {
+	*out = *in
+	in.Metadata.DeepCopyInto(&out.Metadata)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsRequestTemplate.
+func (in *OpsRequestTemplate) DeepCopy() *OpsRequestTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsRequestTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsRequestTemplateMeta) DeepCopyInto(out *OpsRequestTemplateMeta) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsRequestTemplateMeta.
+func (in *OpsRequestTemplateMeta) DeepCopy() *OpsRequestTemplateMeta {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsRequestTemplateMeta)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpsRequestVolumeClaimTemplate) DeepCopyInto(out *OpsRequestVolumeClaimTemplate) {
 	*out = *in
@@ -1122,6 +1523,21 @@ func (in *PreCondition) DeepCopy() *PreCondition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProbeMetricSource) DeepCopyInto(out *ProbeMetricSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbeMetricSource.
+func (in *ProbeMetricSource) DeepCopy() *ProbeMetricSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ProbeMetricSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProgressStatusDetail) DeepCopyInto(out *ProgressStatusDetail) {
 	*out = *in
@@ -1144,6 +1560,26 @@ func (in *ProgressStatusDetail) DeepCopy() *ProgressStatusDetail {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusMetricSource) DeepCopyInto(out *PrometheusMetricSource) {
+	*out = *in
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusMetricSource.
+func (in *PrometheusMetricSource) DeepCopy() *PrometheusMetricSource {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusMetricSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RebuildInstance) DeepCopyInto(out *RebuildInstance) {
 	*out = *in
@@ -1235,6 +1671,37 @@ func (in *ReplicaChanger) DeepCopy() *ReplicaChanger {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicasRecommendation) DeepCopyInto(out *ReplicasRecommendation) {
+	*out = *in
+	in.Timestamp.DeepCopyInto(&out.Timestamp)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicasRecommendation.
+func (in *ReplicasRecommendation) DeepCopy() *ReplicasRecommendation {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicasRecommendation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceMetricSource) DeepCopyInto(out *ResourceMetricSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceMetricSource.
+func (in *ResourceMetricSource) DeepCopy() *ResourceMetricSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceMetricSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Restore) DeepCopyInto(out *Restore) {
 	*out = *in
@@ -1262,6 +1729,27 @@ func (in *Restore) DeepCopy() *Restore {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RotatePassword) DeepCopyInto(out *RotatePassword) {
+	*out = *in
+	out.ComponentOps = in.ComponentOps
+	if in.Accounts != nil {
+		in, out := &in.Accounts, &out.Accounts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RotatePassword.
+func (in *RotatePassword) DeepCopy() *RotatePassword {
+	if in == nil {
+		return nil
+	}
+	out := new(RotatePassword)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Rule) DeepCopyInto(out *Rule) {
 	*out = *in
@@ -1277,6 +1765,22 @@ func (in *Rule) DeepCopy() *Rule {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaleEvent) DeepCopyInto(out *ScaleEvent) {
+	*out = *in
+	in.Timestamp.DeepCopyInto(&out.Timestamp)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleEvent.
+func (in *ScaleEvent) DeepCopy() *ScaleEvent {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaleEvent)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScaleIn) DeepCopyInto(out *ScaleIn) {
 	*out = *in
@@ -1406,6 +1910,13 @@ func (in *SpecificOpsRequest) DeepCopyInto(out *SpecificOpsRequest) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.RotatePasswordList != nil {
+		in, out := &in.RotatePasswordList, &out.RotatePasswordList
+		*out = make([]RotatePassword, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.CustomOps != nil {
 		in, out := &in.CustomOps, &out.CustomOps
 		*out = new(CustomOps)
@@ -53,6 +53,13 @@ type ReconciliationTraceSpec struct {
 	//
 	// +optional
 	Locale *string `json:"locale,omitempty"`
+
+	// HistoryQuery queries the persisted changes of the TargetObject happened in a time range,
+	// including the ones truncated from the CurrentState or recorded before the Controller restarted.
+	// The result is described in the ReconciliationTraceStatus.
+	//
+	// +optional
+	HistoryQuery *HistoryQuery `json:"historyQuery,omitempty"`
 }
 
 // ReconciliationTraceStatus defines the observed state of ReconciliationTrace
@@ -75,6 +82,47 @@ type ReconciliationTraceStatus struct {
 	//
 	// +optional
 	DesiredState *ReconciliationCycleState `json:"desiredState,omitempty"`
+
+	// HistoryQueryResult specifies the result of the HistoryQuery.
+	//
+	// +optional
+	HistoryQueryResult *HistoryQueryResult `json:"historyQueryResult,omitempty"`
+}
+
+// HistoryQuery defines a time range to query the changes history.
+type HistoryQuery struct {
+	// StartTime specifies the start of the time range, inclusive.
+	//
+	// +kubebuilder:validation:Required
+	StartTime metav1.Time `json:"startTime"`
+
+	// EndTime specifies the end of the time range, inclusive.
+	// Default is now.
+	//
+	// +optional
+	EndTime *metav1.Time `json:"endTime,omitempty"`
+}
+
+// HistoryQueryResult defines the result of a HistoryQuery.
+type HistoryQueryResult struct {
+	// StartTime is the start of the queried time range.
+	//
+	StartTime metav1.Time `json:"startTime"`
+
+	// EndTime is the end of the queried time range.
+	//
+	EndTime metav1.Time `json:"endTime"`
+
+	// Changes are the changes happened in the time range, sorted by revision.
+	//
+	// +optional
+	Changes []ObjectChange `json:"changes,omitempty"`
+
+	// Truncated tells whether there are more changes than the ones listed in Changes,
+	// narrow down the time range to see them.
+	//
+	// +optional
+	Truncated bool `json:"truncated,omitempty"`
 }
 
 // ObjectReference defines a reference to an object.
@@ -92,13 +140,27 @@ type ObjectReference struct {
 	Name string `json:"name,omitempty"`
 }
 
+// +kubebuilder:validation:XValidation:rule="has(self.desiredSpec) != has(self.opsRequest)",message="exactly one of desiredSpec and opsRequest should be set"
 type DryRun struct {
 	// DesiredSpec specifies the desired spec of the TargetObject.
 	// The desired spec will be merged into the current spec by a strategic merge patch way to build the final spec,
 	// and the reconciliation plan will be calculated by comparing the current spec to the final spec.
 	// DesiredSpec should be a valid YAML string.
+	// Exactly one of DesiredSpec and OpsRequest should be set.
+	//
+	// +optional
+	DesiredSpec string `json:"desiredSpec,omitempty"`
+
+	// OpsRequest specifies an OpsRequest manifest to be applied to the TargetObject.
+	// The OpsRequest is not created, instead, its action is performed in simulation to mutate the TargetObject,
+	// and the reconciliation plan will be calculated by comparing the current spec to the mutated spec.
+	// The namespace and the spec.clusterName of the OpsRequest are overridden by the TargetObject.
+	// Switchover, RebuildInstance and Custom OpsRequests are not supported, as their changes are made
+	// while the OpsRequest is running rather than by its action.
+	// OpsRequest should be a valid YAML string.
 	//
-	DesiredSpec string `json:"desiredSpec"`
+	// +optional
+	OpsRequest string `json:"opsRequest,omitempty"`
 }
 
 // StateEvaluationExpression defines an object state evaluation expression.
@@ -138,7 +200,7 @@ type DryRunResult struct {
 	// +optional
 	Message string `json:"message,omitempty"`
 
-	// DesiredSpecRevision specifies the revision of the DesiredSpec.
+	// DesiredSpecRevision specifies the revision of the DesiredSpec or the OpsRequest.
 	//
 	DesiredSpecRevision string `json:"desiredSpecRevision"`
 
@@ -182,7 +244,7 @@ type DryRunResult struct {
 	//
 	SpecDiff string `json:"specDiff"`
 
-	// Plan describes the detail reconciliation process if the DesiredSpec is applied.
+	// Plan describes the detail reconciliation process if the DesiredSpec or the OpsRequest is applied.
 	//
 	Plan ReconciliationCycleState `json:"plan"`
 }
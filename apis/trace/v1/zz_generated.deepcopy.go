@@ -88,6 +88,50 @@ func (in *EventAttributes) DeepCopy() *EventAttributes {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HistoryQuery) DeepCopyInto(out *HistoryQuery) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.EndTime != nil {
+		in, out := &in.EndTime, &out.EndTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HistoryQuery.
+func (in *HistoryQuery) DeepCopy() *HistoryQuery {
+	if in == nil {
+		return nil
+	}
+	out := new(HistoryQuery)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HistoryQueryResult) DeepCopyInto(out *HistoryQueryResult) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	in.EndTime.DeepCopyInto(&out.EndTime)
+	if in.Changes != nil {
+		in, out := &in.Changes, &out.Changes
+		*out = make([]ObjectChange, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HistoryQueryResult.
+func (in *HistoryQueryResult) DeepCopy() *HistoryQueryResult {
+	if in == nil {
+		return nil
+	}
+	out := new(HistoryQueryResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ObjectChange) DeepCopyInto(out *ObjectChange) {
 	*out = *in
@@ -358,6 +402,11 @@ func (in *ReconciliationTraceSpec) DeepCopyInto(out *ReconciliationTraceSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.HistoryQuery != nil {
+		in, out := &in.HistoryQuery, &out.HistoryQuery
+		*out = new(HistoryQuery)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReconciliationTraceSpec.
@@ -389,6 +438,11 @@ func (in *ReconciliationTraceStatus) DeepCopyInto(out *ReconciliationTraceStatus
 		*out = new(ReconciliationCycleState)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.HistoryQueryResult != nil {
+		in, out := &in.HistoryQueryResult, &out.HistoryQueryResult
+		*out = new(HistoryQueryResult)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReconciliationTraceStatus.
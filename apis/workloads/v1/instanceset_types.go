@@ -212,6 +212,13 @@ type InstanceSetSpec struct {
 	// +optional
 	InstanceUpdateStrategy *InstanceUpdateStrategy `json:"instanceUpdateStrategy,omitempty"`
 
+	// Overrides the PodDisruptionBudget generated for the instances.
+	//
+	// If not specified, the PodDisruptionBudget is derived from the Roles and the Replicas.
+	//
+	// +optional
+	PodDisruptionBudget *PodDisruptionBudgetPolicy `json:"podDisruptionBudget,omitempty"`
+
 	// Members(Pods) update strategy.
 	//
 	// - serial: update Members one by one that guarantee minimum component unavailable time.
@@ -252,6 +259,12 @@ type InstanceSetSpec struct {
 	// +kubebuilder:default=false
 	// +optional
 	DisableDefaultHeadlessService bool `json:"disableDefaultHeadlessService,omitempty"`
+
+	// Specifies the maintenance window in which the instances can be updated.
+	// The updates that restart or recreate the instances are deferred until the window opens.
+	//
+	// +optional
+	MaintenanceWindow *kbappsv1.MaintenanceWindow `json:"maintenanceWindow,omitempty"`
 }
 
 // InstanceSetStatus defines the observed state of InstanceSet
@@ -462,6 +475,11 @@ type InstanceUpdateStrategy = kbappsv1.InstanceUpdateStrategy
 // +kubebuilder:object:generate=false
 type RollingUpdate = kbappsv1.RollingUpdate
 
+// PodDisruptionBudgetPolicy overrides the PodDisruptionBudget generated for the instances.
+//
+// +kubebuilder:object:generate=false
+type PodDisruptionBudgetPolicy = kbappsv1.PodDisruptionBudgetPolicy
+
 // MemberUpdateStrategy defines Cluster Component update strategy.
 // +enum
 type MemberUpdateStrategy string
@@ -482,7 +500,15 @@ type MembershipReconfiguration struct {
 	// +optional
 	Switchover *kbappsv1.Action `json:"switchover,omitempty"`
 
-	// TODO: member join/leave
+	// Defines the procedure to add a new replica to the member set.
+	//
+	// +optional
+	MemberJoin *kbappsv1.Action `json:"memberJoin,omitempty"`
+
+	// Defines the procedure to remove a replica from the member set.
+	//
+	// +optional
+	MemberLeave *kbappsv1.Action `json:"memberLeave,omitempty"`
 }
 
 type ConfigTemplate struct {
@@ -595,7 +621,7 @@ const (
 	InstanceFailure ConditionType = "InstanceFailure"
 
 	// InstanceUpdateRestricted represents a ConditionType that indicates updates to an InstanceSet are blocked(when the
-	// PodUpdatePolicy is set to StrictInPlace but the pods cannot be updated in-place).
+	// PodUpdatePolicy is set to StrictInPlace but the pods cannot be updated in-place, or it is out of the maintenance window).
 	InstanceUpdateRestricted ConditionType = "InstanceUpdateRestricted"
 )
 
@@ -617,6 +643,10 @@ const (
 
 	// ReasonInstanceUpdateRestricted is a reason for condition InstanceUpdateRestricted.
 	ReasonInstanceUpdateRestricted = "InstanceUpdateRestricted"
+
+	// ReasonOutOfMaintenanceWindow is a reason for condition InstanceUpdateRestricted,
+	// it indicates that the updates are deferred until the maintenance window opens.
+	ReasonOutOfMaintenanceWindow = "OutOfMaintenanceWindow"
 )
 
 // IsInstancesReady gives Instance level 'ready' state when all instances are available
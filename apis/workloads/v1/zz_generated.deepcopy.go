@@ -181,6 +181,11 @@ func (in *InstanceSetSpec) DeepCopyInto(out *InstanceSetSpec) {
 		*out = new(appsv1.InstanceUpdateStrategy)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(appsv1.PodDisruptionBudgetPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.MemberUpdateStrategy != nil {
 		in, out := &in.MemberUpdateStrategy, &out.MemberUpdateStrategy
 		*out = new(MemberUpdateStrategy)
@@ -210,6 +215,11 @@ func (in *InstanceSetSpec) DeepCopyInto(out *InstanceSetSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(appsv1.MaintenanceWindow)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceSetSpec.
@@ -418,6 +428,16 @@ func (in *MembershipReconfiguration) DeepCopyInto(out *MembershipReconfiguration
 		*out = new(appsv1.Action)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.MemberJoin != nil {
+		in, out := &in.MemberJoin, &out.MemberJoin
+		*out = new(appsv1.Action)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MemberLeave != nil {
+		in, out := &in.MemberLeave, &out.MemberLeave
+		*out = new(appsv1.Action)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MembershipReconfiguration.
@@ -256,6 +256,8 @@ func main() {
 		os.Exit(1)
 	}
 
+	metrics.RegisterDataProtectionMetrics()
+
 	cli, err := discoverycli.NewDiscoveryClientForConfig(mgr.GetConfig())
 	if err != nil {
 		setupLog.Error(err, "unable to create discovery client")
@@ -271,7 +273,7 @@ func main() {
 
 	// multi-cluster manager for all data-plane k8s
 	multiClusterMgr, err := multicluster.Setup(mgr.GetScheme(), mgr.GetConfig(), mgr.GetClient(),
-		multiClusterKubeConfig, multiClusterContexts, multiClusterContextsDisabled)
+		multiClusterKubeConfig, multiClusterContexts, multiClusterContextsDisabled, "")
 	if err != nil {
 		setupLog.Error(err, "unable to setup multi-cluster manager")
 		os.Exit(1)
@@ -301,6 +303,15 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err = (&dpcontrollers.BackupReplicationReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("backup-replication-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "BackupReplication")
+		os.Exit(1)
+	}
+
 	if err = (&dpcontrollers.RestoreReconciler{
 		Client:   mgr.GetClient(),
 		Scheme:   mgr.GetScheme(),
@@ -20,6 +20,7 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"flag"
 	"fmt"
@@ -76,6 +77,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/controller/multicluster"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 	"github.com/apecloud/kubeblocks/pkg/metrics"
+	"github.com/apecloud/kubeblocks/pkg/tracing"
 	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
@@ -102,6 +104,7 @@ const (
 	multiClusterKubeConfigFlagKey       flagName = "multi-cluster-kubeconfig"
 	multiClusterContextsFlagKey         flagName = "multi-cluster-contexts"
 	multiClusterContextsDisabledFlagKey flagName = "multi-cluster-contexts-disabled"
+	multiClusterContextLabelsFlagKey    flagName = "multi-cluster-context-labels"
 
 	userAgentFlagKey flagName = "user-agent"
 )
@@ -155,6 +158,11 @@ func init() {
 	viper.SetDefault(constant.FeatureGateIgnoreConfigTemplateDefaultMode, false)
 	viper.SetDefault(constant.FeatureGateInPlacePodVerticalScaling, false)
 	viper.SetDefault(constant.I18nResourcesName, "kubeblocks-i18n-resources")
+	viper.SetDefault(constant.CfgKeyTraceStorageRetention, "168h")
+	viper.SetDefault(constant.CfgKeyTraceStorageMaxChanges, 10000)
+	viper.SetDefault(constant.CfgKeyTraceStorageMaxRevisions, 1000)
+	viper.SetDefault(constant.CfgKeyTracingOTLPInsecure, true)
+	viper.SetDefault(constant.CfgKeyTracingSampleRatio, 1.0)
 	viper.SetDefault(constant.APIVersionSupported, "")
 }
 
@@ -197,6 +205,7 @@ func setupFlags() {
 	flag.String(multiClusterKubeConfigFlagKey.String(), "", "Paths to the kubeconfig for multi-cluster accessing.")
 	flag.String(multiClusterContextsFlagKey.String(), "", "Kube contexts the manager will talk to.")
 	flag.String(multiClusterContextsDisabledFlagKey.String(), "", "Kube contexts that mark as disabled.")
+	flag.String(multiClusterContextLabelsFlagKey.String(), "", "Labels of kube contexts, in the format of 'context1:key1=value1;key2=value2,context2:key1=value3'.")
 
 	flag.String(constant.ManagedNamespacesFlag, "",
 		"The namespaces that the operator will manage, multiple namespaces are separated by commas.")
@@ -298,6 +307,7 @@ func main() {
 		multiClusterKubeConfig       string
 		multiClusterContexts         string
 		multiClusterContextsDisabled string
+		multiClusterContextLabels    string
 		userAgent                    string
 		err                          error
 	)
@@ -339,6 +349,7 @@ func main() {
 	multiClusterKubeConfig = viper.GetString(multiClusterKubeConfigFlagKey.viperName())
 	multiClusterContexts = viper.GetString(multiClusterContextsFlagKey.viperName())
 	multiClusterContextsDisabled = viper.GetString(multiClusterContextsDisabledFlagKey.viperName())
+	multiClusterContextLabels = viper.GetString(multiClusterContextLabelsFlagKey.viperName())
 
 	userAgent = viper.GetString(userAgentFlagKey.viperName())
 
@@ -385,9 +396,11 @@ func main() {
 		os.Exit(1)
 	}
 
+	metrics.RegisterManagerMetrics(metrics.NewClusterCollector(mgr.GetClient()))
+
 	// multi-cluster manager for all data-plane k8s
 	multiClusterMgr, err := multicluster.Setup(mgr.GetScheme(), mgr.GetConfig(), mgr.GetClient(),
-		multiClusterKubeConfig, multiClusterContexts, multiClusterContextsDisabled)
+		multiClusterKubeConfig, multiClusterContexts, multiClusterContextsDisabled, multiClusterContextLabels)
 	if err != nil {
 		setupLog.Error(err, "unable to setup multi-cluster manager")
 		os.Exit(1)
@@ -486,6 +499,18 @@ func main() {
 			os.Exit(1)
 		}
 
+		if addr := viper.GetString(constant.CfgKeyKBAgentEventReceiverAddr); addr != "" {
+			if err = mgr.Add(&k8scorecontrollers.EventReceiver{
+				Client:   client,
+				Recorder: mgr.GetEventRecorderFor("event-receiver"),
+				Address:  addr,
+				CertDir:  viper.GetString("cert_dir"),
+			}); err != nil {
+				setupLog.Error(err, "unable to create kbagent event receiver")
+				os.Exit(1)
+			}
+		}
+
 		if err = (&rollout.RolloutReconciler{
 			Client:   mgr.GetClient(),
 			Scheme:   mgr.GetScheme(),
@@ -525,6 +550,33 @@ func main() {
 			setupLog.Error(err, "unable to create controller", "controller", "OpsRequest")
 			os.Exit(1)
 		}
+
+		if err = (&opscontrollers.OpsRequestScheduleReconciler{
+			Client:   mgr.GetClient(),
+			Scheme:   mgr.GetScheme(),
+			Recorder: mgr.GetEventRecorderFor("ops-request-schedule-controller"),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "OpsRequestSchedule")
+			os.Exit(1)
+		}
+
+		if err = (&opscontrollers.StorageAutoscalerReconciler{
+			Client:   mgr.GetClient(),
+			Scheme:   mgr.GetScheme(),
+			Recorder: mgr.GetEventRecorderFor("storage-autoscaler-controller"),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "StorageAutoscaler")
+			os.Exit(1)
+		}
+
+		if err = (&opscontrollers.ComponentAutoscalerReconciler{
+			Client:   mgr.GetClient(),
+			Scheme:   mgr.GetScheme(),
+			Recorder: mgr.GetEventRecorderFor("component-autoscaler-controller"),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ComponentAutoscaler")
+			os.Exit(1)
+		}
 	}
 
 	if viper.GetBool(extensionsFlagKey.viperName()) {
@@ -692,8 +744,32 @@ func main() {
 			os.Exit(1)
 		}
 	}
-	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+	shutdownTracing := setupTracing()
+	err = mgr.Start(ctrl.SetupSignalHandler())
+	shutdownTracing()
+	if err != nil {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
 }
+
+func setupTracing() func() {
+	endpoint := viper.GetString(constant.CfgKeyTracingOTLPEndpoint)
+	if len(endpoint) == 0 {
+		return func() {}
+	}
+	exporter, err := tracing.NewOTLPExporter(context.Background(), endpoint, viper.GetBool(constant.CfgKeyTracingOTLPInsecure))
+	if err != nil {
+		setupLog.Error(err, "unable to create OTLP trace exporter")
+		os.Exit(1)
+	}
+	setupLog.Info("exporting traces", "endpoint", endpoint)
+	shutdown := tracing.Setup(exporter, viper.GetFloat64(constant.CfgKeyTracingSampleRatio))
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdown(ctx); err != nil {
+			setupLog.Error(err, "failed to flush traces")
+		}
+	}
+}
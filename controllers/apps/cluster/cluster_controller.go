@@ -38,6 +38,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/multicluster"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/tracing"
 	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
@@ -181,5 +182,5 @@ func (r *ClusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Owns(&appsv1.Component{}).
 		Owns(&corev1.Service{}). // cluster services
 		Owns(&corev1.Secret{}).  // sharding account secret
-		Complete(r)
+		Complete(tracing.WrapReconciler(appsv1.ClusterKind, r))
 }
@@ -40,6 +40,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/tracing"
 )
 
 // clusterTransformContext a graph.TransformContext implementation for Cluster reconciliation
@@ -84,6 +85,7 @@ type clusterPlan struct {
 }
 
 var _ graph.TransformContext = &clusterTransformContext{}
+var _ graph.ContextSetter = &clusterTransformContext{}
 var _ graph.PlanBuilder = &clusterPlanBuilder{}
 var _ graph.Plan = &clusterPlan{}
 
@@ -93,6 +95,10 @@ func (c *clusterTransformContext) GetContext() context.Context {
 	return c.Context
 }
 
+func (c *clusterTransformContext) SetContext(ctx context.Context) {
+	c.Context = ctx
+}
+
 func (c *clusterTransformContext) GetClient() client.Reader {
 	return c.Client
 }
@@ -167,6 +173,7 @@ func (c *clusterPlanBuilder) Init() error {
 	if err := c.cli.Get(c.transCtx.Context, c.req.NamespacedName, cluster); err != nil {
 		return err
 	}
+	tracing.SetObjectAttributes(c.transCtx.Context, cluster)
 	c.AddTransformer(&clusterInitTransformer{cluster: cluster})
 	return nil
 }
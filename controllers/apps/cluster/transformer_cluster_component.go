@@ -283,7 +283,10 @@ func copyAndMergeComponent(oldCompObj, newCompObj *appsv1.Component) *appsv1.Com
 	compObjCopy.Spec.RuntimeClassName = compProto.Spec.RuntimeClassName
 	compObjCopy.Spec.DisableExporter = compProto.Spec.DisableExporter
 	compObjCopy.Spec.Stop = compProto.Spec.Stop
+	compObjCopy.Spec.Readonly = compProto.Spec.Readonly
 	compObjCopy.Spec.Sidecars = compProto.Spec.Sidecars
+	compObjCopy.Spec.MaintenanceWindow = compProto.Spec.MaintenanceWindow
+	compObjCopy.Spec.StorageAutoscaling = compProto.Spec.StorageAutoscaling
 	compObjCopy.Spec.Resources = compProto.Spec.Resources
 
 	metadataChanged := !reflect.DeepEqual(oldCompObj.Annotations, compObjCopy.Annotations) ||
@@ -24,6 +24,11 @@ import (
 	"slices"
 	"strings"
 
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
 	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
 	appsutil "github.com/apecloud/kubeblocks/controllers/apps/util"
 	"github.com/apecloud/kubeblocks/pkg/constant"
@@ -48,21 +53,30 @@ func (t *clusterPlacementTransformer) Transform(ctx graph.TransformContext, dag
 		return nil // do nothing
 	}
 
-	if t.assigned(transCtx) {
-		transCtx.Context = appsutil.IntoContext(transCtx.Context, appsutil.Placement(transCtx.OrigCluster))
-		return nil
-	}
-
-	p := t.assign(transCtx)
-
 	cluster := transCtx.Cluster
-	if cluster.Annotations == nil {
-		cluster.Annotations = make(map[string]string)
+	if t.assigned(transCtx) {
+		p, replaced, err := t.replace(transCtx)
+		if err != nil {
+			return err
+		}
+		if len(replaced) > 0 {
+			cluster.Annotations[constant.KBAppMultiClusterPlacementKey] = strings.Join(p, ",")
+			transCtx.EventRecorder.Eventf(cluster, corev1.EventTypeWarning, "Replacement",
+				"contexts %s are unavailable, re-place the replicas onto %s", strings.Join(replaced, ","), strings.Join(p, ","))
+		}
+	} else {
+		p, err := t.assign(transCtx)
+		if err != nil {
+			return err
+		}
+		if cluster.Annotations == nil {
+			cluster.Annotations = make(map[string]string)
+		}
+		cluster.Annotations[constant.KBAppMultiClusterPlacementKey] = strings.Join(p, ",")
 	}
-	cluster.Annotations[constant.KBAppMultiClusterPlacementKey] = strings.Join(p, ",")
 	transCtx.Context = appsutil.IntoContext(transCtx.Context, appsutil.Placement(cluster))
 
-	return nil
+	return t.buildPlacementStatus(transCtx)
 }
 
 func (t *clusterPlacementTransformer) assigned(transCtx *clusterTransformContext) bool {
@@ -75,20 +89,159 @@ func (t *clusterPlacementTransformer) assigned(transCtx *clusterTransformContext
 	return ok && len(strings.TrimSpace(p)) > 0
 }
 
-func (t *clusterPlacementTransformer) assign(transCtx *clusterTransformContext) []string {
+func (t *clusterPlacementTransformer) assign(transCtx *clusterTransformContext) ([]string, error) {
 	replicas := t.maxReplicas(transCtx)
-	contexts := t.multiClusterMgr.GetContexts()
+	contexts, err := t.candidates(transCtx)
+	if err != nil {
+		return nil, err
+	}
 	if replicas >= len(contexts) {
-		return contexts
+		return contexts, nil
+	}
+	return t.choose(transCtx, contexts, replicas)
+}
+
+// replace re-places the unavailable contexts with the healthy candidates which haven't been used yet,
+// the position of contexts is kept to keep the ordinals of available instances unchanged, and the instances
+// placed on a replaced context are re-created on the context at the same position by multicluster.Assign.
+func (t *clusterPlacementTransformer) replace(transCtx *clusterTransformContext) ([]string, []string, error) {
+	placement := strings.Split(appsutil.Placement(transCtx.OrigCluster), ",")
+	if spec := transCtx.Cluster.Spec.Placement; spec != nil && spec.DisableReplacement {
+		return placement, nil, nil
 	}
 
+	unavailable := slices.DeleteFunc(slices.Clone(placement), t.multiClusterMgr.IsContextAvailable)
+	if len(unavailable) == 0 {
+		return placement, nil, nil
+	}
+
+	candidates, err := t.candidates(transCtx)
+	if err != nil {
+		return nil, nil, err
+	}
+	candidates = slices.DeleteFunc(candidates, func(c string) bool {
+		return slices.Contains(placement, c)
+	})
+	if len(candidates) == 0 {
+		return placement, nil, nil // no healthy context to re-place
+	}
+	chosen, err := t.choose(transCtx, candidates, min(len(unavailable), len(candidates)))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	replaced := make([]string, 0)
+	for i, c := range placement {
+		if len(chosen) == 0 {
+			break
+		}
+		if !t.multiClusterMgr.IsContextAvailable(c) {
+			placement[i] = chosen[0]
+			chosen = chosen[1:]
+			replaced = append(replaced, c)
+		}
+	}
+	return placement, replaced, nil
+}
+
+// candidates returns the available contexts which match the context selector.
+func (t *clusterPlacementTransformer) candidates(transCtx *clusterTransformContext) ([]string, error) {
+	var selector labels.Selector
+	if spec := transCtx.Cluster.Spec.Placement; spec != nil && spec.ContextSelector != nil {
+		var err error
+		selector, err = metav1.LabelSelectorAsSelector(spec.ContextSelector)
+		if err != nil {
+			return nil, err
+		}
+	}
+	contexts := make([]string, 0)
+	for _, c := range t.multiClusterMgr.GetContexts() {
+		if !t.multiClusterMgr.IsContextAvailable(c) {
+			continue
+		}
+		if selector != nil && !selector.Matches(labels.Set(t.multiClusterMgr.GetContextLabels(c))) {
+			continue
+		}
+		contexts = append(contexts, c)
+	}
 	slices.Sort(contexts)
+	return contexts, nil
+}
+
+// choose chooses n contexts from the candidates according to the placement policy.
+func (t *clusterPlacementTransformer) choose(transCtx *clusterTransformContext, contexts []string, n int) ([]string, error) {
+	policy := appsv1.SpreadPlacementPolicy
+	if spec := transCtx.Cluster.Spec.Placement; spec != nil && len(spec.Policy) > 0 {
+		policy = spec.Policy
+	}
+	if policy == appsv1.CapacityWeightedPlacementPolicy {
+		weights, err := t.capacityWeights(transCtx, contexts)
+		if err != nil {
+			return nil, err
+		}
+		return weightedChoose(contexts, weights, n), nil
+	}
+
 	for k := 0; k < len(contexts); k++ {
 		rand.Shuffle(len(contexts), func(i, j int) {
 			contexts[i], contexts[j] = contexts[j], contexts[i]
 		})
 	}
-	return contexts[:replicas]
+	return contexts[:n], nil
+}
+
+// capacityWeights calculates the weights of contexts, which is the sum of the shares of free cpu and memory.
+func (t *clusterPlacementTransformer) capacityWeights(transCtx *clusterTransformContext, contexts []string) ([]float64, error) {
+	capacities := make([]corev1.ResourceList, 0, len(contexts))
+	totals := map[corev1.ResourceName]float64{}
+	for _, c := range contexts {
+		capacity, err := t.multiClusterMgr.GetContextCapacity(transCtx.Context, c)
+		if err != nil {
+			return nil, err
+		}
+		capacities = append(capacities, capacity)
+		for name, q := range capacity {
+			totals[name] += q.AsApproximateFloat64()
+		}
+	}
+	weights := make([]float64, len(contexts))
+	for i, capacity := range capacities {
+		for name, q := range capacity {
+			if totals[name] > 0 {
+				weights[i] += q.AsApproximateFloat64() / totals[name]
+			}
+		}
+	}
+	return weights, nil
+}
+
+// weightedChoose chooses n items randomly without replacement, the probability of an item being chosen
+// is proportional to its weight. The items with zero weight are chosen only if there are not enough others.
+func weightedChoose(items []string, weights []float64, n int) []string {
+	items, weights = slices.Clone(items), slices.Clone(weights)
+	chosen := make([]string, 0, n)
+	for len(chosen) < n && len(items) > 0 {
+		total := 0.0
+		for _, w := range weights {
+			total += w
+		}
+		idx := 0
+		if total > 0 {
+			r := rand.Float64() * total
+			for idx = 0; idx < len(weights)-1; idx++ {
+				if r < weights[idx] {
+					break
+				}
+				r -= weights[idx]
+			}
+		} else {
+			idx = rand.Intn(len(items))
+		}
+		chosen = append(chosen, items[idx])
+		items = slices.Delete(items, idx, idx+1)
+		weights = slices.Delete(weights, idx, idx+1)
+	}
+	return chosen
 }
 
 func (t *clusterPlacementTransformer) maxReplicas(transCtx *clusterTransformContext) int {
@@ -98,3 +251,37 @@ func (t *clusterPlacementTransformer) maxReplicas(transCtx *clusterTransformCont
 	})
 	return replicas
 }
+
+// buildPlacementStatus records the placed contexts and where each instance lives.
+func (t *clusterPlacementTransformer) buildPlacementStatus(transCtx *clusterTransformContext) error {
+	cluster := transCtx.Cluster
+	status := &appsv1.ClusterPlacementStatus{
+		Contexts: strings.Split(appsutil.Placement(cluster), ","),
+	}
+	if cluster.Status.Placement != nil {
+		status.ReplacedContexts = cluster.Status.Placement.ReplacedContexts
+	}
+	for _, c := range strings.Split(appsutil.Placement(transCtx.OrigCluster), ",") {
+		if len(c) > 0 && !slices.Contains(status.Contexts, c) && !slices.Contains(status.ReplacedContexts, c) {
+			status.ReplacedContexts = append(status.ReplacedContexts, c)
+		}
+	}
+
+	pods := &corev1.PodList{}
+	if err := transCtx.Client.List(transCtx.Context, pods, client.InNamespace(cluster.Namespace),
+		client.MatchingLabels{constant.AppInstanceLabelKey: cluster.Name}, appsutil.InDataContext4C()); err != nil {
+		return err
+	}
+	for _, pod := range pods.Items {
+		status.Instances = append(status.Instances, appsv1.InstancePlacement{
+			Name:      pod.Name,
+			Component: pod.Labels[constant.KBAppComponentLabelKey],
+			Context:   appsutil.Placement(&pod),
+		})
+	}
+	slices.SortFunc(status.Instances, func(a, b appsv1.InstancePlacement) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	cluster.Status.Placement = status
+	return nil
+}
@@ -0,0 +1,255 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/multicluster"
+)
+
+type mockMultiClusterManager struct {
+	contexts    []string
+	unavailable []string
+	labels      map[string]map[string]string
+	capacities  map[string]corev1.ResourceList
+}
+
+var _ multicluster.Manager = &mockMultiClusterManager{}
+
+func (m *mockMultiClusterManager) GetClient() client.Client {
+	return nil
+}
+
+func (m *mockMultiClusterManager) GetContexts() []string {
+	return slices.Clone(m.contexts)
+}
+
+func (m *mockMultiClusterManager) GetContextLabels(context string) map[string]string {
+	return m.labels[context]
+}
+
+func (m *mockMultiClusterManager) IsContextAvailable(context string) bool {
+	return slices.Contains(m.contexts, context) && !slices.Contains(m.unavailable, context)
+}
+
+func (m *mockMultiClusterManager) GetContextCapacity(_ context.Context, context string) (corev1.ResourceList, error) {
+	if !m.IsContextAvailable(context) {
+		return nil, fmt.Errorf("context %s is unavailable", context)
+	}
+	return m.capacities[context], nil
+}
+
+func (m *mockMultiClusterManager) Bind(ctrl.Manager) error {
+	return nil
+}
+
+func (m *mockMultiClusterManager) Own(*builder.Builder, client.Object, client.Object) multicluster.Manager {
+	return m
+}
+
+func (m *mockMultiClusterManager) Watch(*builder.Builder, client.Object, handler.EventHandler) multicluster.Manager {
+	return m
+}
+
+func newPlacementTransformContext(placement string, spec *appsv1.ClusterPlacement) *clusterTransformContext {
+	cluster := &appsv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test-cluster",
+		},
+		Spec: appsv1.ClusterSpec{
+			Placement: spec,
+		},
+	}
+	if len(placement) > 0 {
+		cluster.Annotations = map[string]string{constant.KBAppMultiClusterPlacementKey: placement}
+	}
+	return &clusterTransformContext{
+		Context:     context.Background(),
+		Cluster:     cluster,
+		OrigCluster: cluster.DeepCopy(),
+	}
+}
+
+func TestWeightedChoose(t *testing.T) {
+	items := []string{"a", "b", "c", "d"}
+
+	for i := 0; i < 100; i++ {
+		chosen := weightedChoose(items, []float64{0, 1, 0, 0}, 1)
+		if !slices.Equal(chosen, []string{"b"}) {
+			t.Fatalf("expected [b], got %v", chosen)
+		}
+	}
+
+	for i := 0; i < 100; i++ {
+		chosen := weightedChoose(items, []float64{0, 1, 2, 0}, 2)
+		slices.Sort(chosen)
+		if !slices.Equal(chosen, []string{"b", "c"}) {
+			t.Fatalf("expected the zero-weight items not to be chosen, got %v", chosen)
+		}
+	}
+
+	for _, weights := range [][]float64{{1, 2, 3, 4}, {0, 0, 0, 0}, {0, 1, 0, 0}} {
+		chosen := weightedChoose(items, weights, 3)
+		if len(chosen) != 3 || len(chosen) != len(slices.Compact(slices.Sorted(slices.Values(chosen)))) {
+			t.Errorf("expected 3 distinct items, got %v", chosen)
+		}
+	}
+
+	chosen := weightedChoose(items, []float64{1, 1, 1, 1}, 5)
+	slices.Sort(chosen)
+	if !slices.Equal(chosen, items) {
+		t.Errorf("expected all items, got %v", chosen)
+	}
+
+	if !slices.Equal(items, []string{"a", "b", "c", "d"}) {
+		t.Errorf("expected the items unchanged, got %v", items)
+	}
+}
+
+func TestClusterPlacementChoose(t *testing.T) {
+	mgr := &mockMultiClusterManager{
+		contexts: []string{"c1", "c2", "c3"},
+		capacities: map[string]corev1.ResourceList{
+			"c1": {corev1.ResourceCPU: resource.MustParse("0"), corev1.ResourceMemory: resource.MustParse("0")},
+			"c2": {corev1.ResourceCPU: resource.MustParse("4"), corev1.ResourceMemory: resource.MustParse("8Gi")},
+			"c3": {corev1.ResourceCPU: resource.MustParse("0"), corev1.ResourceMemory: resource.MustParse("0")},
+		},
+	}
+	transformer := &clusterPlacementTransformer{multiClusterMgr: mgr}
+
+	t.Run("spread", func(t *testing.T) {
+		transCtx := newPlacementTransformContext("", nil)
+		chosen, err := transformer.choose(transCtx, mgr.GetContexts(), 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(chosen) != 2 || chosen[0] == chosen[1] {
+			t.Errorf("expected 2 distinct contexts, got %v", chosen)
+		}
+		for _, c := range chosen {
+			if !slices.Contains(mgr.contexts, c) {
+				t.Errorf("unexpected context %s", c)
+			}
+		}
+	})
+
+	t.Run("capacity weighted", func(t *testing.T) {
+		transCtx := newPlacementTransformContext("", &appsv1.ClusterPlacement{Policy: appsv1.CapacityWeightedPlacementPolicy})
+		for i := 0; i < 20; i++ {
+			chosen, err := transformer.choose(transCtx, mgr.GetContexts(), 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(chosen, []string{"c2"}) {
+				t.Fatalf("expected the context with free capacity to be chosen, got %v", chosen)
+			}
+		}
+	})
+
+	t.Run("capacity error", func(t *testing.T) {
+		transCtx := newPlacementTransformContext("", &appsv1.ClusterPlacement{Policy: appsv1.CapacityWeightedPlacementPolicy})
+		if _, err := transformer.choose(transCtx, []string{"c1", "c4"}, 1); err == nil {
+			t.Error("expected an error for the unknown context")
+		}
+	})
+}
+
+func TestClusterPlacementReplace(t *testing.T) {
+	mgr := &mockMultiClusterManager{
+		contexts:    []string{"c1", "c2", "c3", "c4", "c5"},
+		unavailable: []string{"c2"},
+		labels: map[string]map[string]string{
+			"c4": {"region": "east"},
+			"c5": {"region": "west"},
+		},
+	}
+	transformer := &clusterPlacementTransformer{multiClusterMgr: mgr}
+
+	cases := []struct {
+		name      string
+		placement string
+		spec      *appsv1.ClusterPlacement
+		expected  []string // the acceptable placements
+		replaced  []string
+	}{
+		{
+			name:      "re-place by default",
+			placement: "c1,c2,c3",
+			expected:  []string{"c1,c4,c3", "c1,c5,c3"},
+			replaced:  []string{"c2"},
+		},
+		{
+			name:      "disabled",
+			placement: "c1,c2,c3",
+			spec:      &appsv1.ClusterPlacement{DisableReplacement: true},
+			expected:  []string{"c1,c2,c3"},
+		},
+		{
+			name:      "all available",
+			placement: "c1,c3,c4",
+			expected:  []string{"c1,c3,c4"},
+		},
+		{
+			name:      "context selector",
+			placement: "c2,c1,c3",
+			spec: &appsv1.ClusterPlacement{
+				ContextSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"region": "west"}},
+			},
+			expected: []string{"c5,c1,c3"},
+			replaced: []string{"c2"},
+		},
+		{
+			name:      "no candidates",
+			placement: "c1,c2,c3,c4,c5",
+			expected:  []string{"c1,c2,c3,c4,c5"},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			transCtx := newPlacementTransformContext(c.placement, c.spec)
+			placement, replaced, err := transformer.replace(transCtx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Contains(c.expected, strings.Join(placement, ",")) {
+				t.Errorf("expected placement in %v, got %v", c.expected, placement)
+			}
+			if !slices.Equal(replaced, c.replaced) && len(replaced)+len(c.replaced) > 0 {
+				t.Errorf("expected replaced %v, got %v", c.replaced, replaced)
+			}
+		})
+	}
+}
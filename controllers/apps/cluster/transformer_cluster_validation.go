@@ -62,6 +62,10 @@ func (t *clusterValidationTransformer) Transform(ctx graph.TransformContext, dag
 		return intctrlutil.NewRequeueError(appsutil.RequeueDuration, err.Error())
 	}
 
+	if err = intctrlutil.ValidateMaintenanceWindow(cluster.Spec.MaintenanceWindow); err != nil {
+		return intctrlutil.NewRequeueError(appsutil.RequeueDuration, err.Error())
+	}
+
 	if withClusterTopology(cluster) {
 		// check again with cluster definition loaded,
 		// and update topology to cluster spec in case the default topology changed.
@@ -43,6 +43,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/multicluster"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/tracing"
 	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
@@ -171,6 +172,8 @@ func (r *ComponentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 			&componentRBACTransformer{},
 			// handle component postProvision lifecycle action
 			&componentPostProvisionTransformer{},
+			// handle the readonly and readwrite lifecycle actions
+			&componentReadonlyTransformer{},
 			// update component status
 			&componentStatusTransformer{Client: r.Client},
 			// notify dependent components the possible spec changes
@@ -220,7 +223,7 @@ func (r *ComponentReconciler) setupWithManager(mgr ctrl.Manager) error {
 			Owns(&corev1.ServiceAccount{})
 	}
 
-	return b.Complete(r)
+	return b.Complete(tracing.WrapReconciler(appsv1.ComponentKind, r))
 }
 
 func (r *ComponentReconciler) setupWithMultiClusterManager(mgr ctrl.Manager, multiClusterMgr multicluster.Manager) error {
@@ -240,7 +243,7 @@ func (r *ComponentReconciler) setupWithMultiClusterManager(mgr ctrl.Manager, mul
 		Watch(b, &corev1.ServiceAccount{}, eventHandler).
 		Watch(b, &rbacv1.RoleBinding{}, eventHandler)
 
-	return b.Complete(r)
+	return b.Complete(tracing.WrapReconciler(appsv1.ComponentKind, r))
 }
 
 func (r *ComponentReconciler) filterComponentResources(ctx context.Context, obj client.Object) []reconcile.Request {
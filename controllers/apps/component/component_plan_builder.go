@@ -37,6 +37,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/tracing"
 )
 
 // componentTransformContext a graph.TransformContext implementation for Component reconciliation
@@ -57,6 +58,10 @@ func (c *componentTransformContext) GetContext() context.Context {
 	return c.Context
 }
 
+func (c *componentTransformContext) SetContext(ctx context.Context) {
+	c.Context = ctx
+}
+
 func (c *componentTransformContext) GetClient() client.Reader {
 	return c.Client
 }
@@ -85,6 +90,7 @@ type componentPlan struct {
 }
 
 var _ graph.TransformContext = &componentTransformContext{}
+var _ graph.ContextSetter = &componentTransformContext{}
 var _ graph.PlanBuilder = &componentPlanBuilder{}
 var _ graph.Plan = &componentPlan{}
 
@@ -94,6 +100,7 @@ func (c *componentPlanBuilder) Init() error {
 		return err
 	}
 
+	tracing.SetObjectAttributes(c.transCtx.Context, comp)
 	c.transCtx.Component = comp
 	c.transCtx.ComponentOrig = comp.DeepCopy()
 	c.transformers = append(c.transformers, &componentInitTransformer{})
@@ -286,8 +286,9 @@ func verifySystemAccountPassword(secret *corev1.Secret, hashedPassword []byte) b
 
 type synthesizedSystemAccount struct {
 	appsv1.SystemAccount
-	Disabled  *bool
-	SecretRef *appsv1.ProvisionSecretRef
+	Disabled       *bool
+	SecretRef      *appsv1.ProvisionSecretRef
+	RotationPolicy *appsv1.PasswordRotationPolicy
 }
 
 func synthesizeSystemAccounts(compDefAccounts []appsv1.SystemAccount,
@@ -305,6 +306,7 @@ func synthesizeSystemAccounts(compDefAccounts []appsv1.SystemAccount,
 		}
 		account.Disabled = compAccount.Disabled
 		account.SecretRef = compAccount.SecretRef
+		account.RotationPolicy = compAccount.RotationPolicy
 		return account
 	}
 
@@ -24,6 +24,7 @@ import (
 	"reflect"
 	"slices"
 	"strings"
+	"time"
 
 	"golang.org/x/exp/maps"
 	corev1 "k8s.io/api/core/v1"
@@ -37,6 +38,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/controller/component"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 	"github.com/apecloud/kubeblocks/pkg/controller/lifecycle"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 )
 
 const (
@@ -124,6 +126,21 @@ func (t *componentAccountProvisionTransformer) Transform(ctx graph.TransformCont
 
 	t.provisionCondDone(transCtx, condCopy, &cond, err3)
 
+	var requeueAfter time.Duration
+	for _, name := range sets.List(updateSet) {
+		after, err := t.rotateAccount(transCtx, dag, lfa, accounts[name], secrets[name])
+		if err != nil {
+			if err3 == nil {
+				err3 = err
+			}
+		} else if after > 0 && (requeueAfter == 0 || after < requeueAfter) {
+			requeueAfter = after
+		}
+	}
+	if err3 == nil && requeueAfter > 0 {
+		return intctrlutil.NewDelayedRequeueError(requeueAfter, "wait for the password rotation of system accounts")
+	}
+
 	return err3
 }
 
@@ -0,0 +1,359 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	appsutil "github.com/apecloud/kubeblocks/controllers/apps/util"
+	"github.com/apecloud/kubeblocks/pkg/common"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/lifecycle"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+const (
+	maxPasswordRotationHistory    = 3
+	passwordRotationRetryInterval = 10 * time.Minute
+	passwordRotationCheckInterval = 30 * time.Second
+	defaultPasswordGracePeriod    = time.Hour
+	passwordRotationApplyInterval = time.Second
+
+	// accountPendingPasswordKey is the key of the account secret holding the new password of the ongoing rotation,
+	// it is persisted before the password is applied, so that the retries of the rotate statement use the same password.
+	accountPendingPasswordKey = "pendingPassword"
+)
+
+// rotateAccount rotates the password of the account without downtime:
+//  1. generates the new password and persists it in the account secret as the pending password.
+//  2. applies the pending password through the rotate statement while keeping the old one valid, and updates the account secret.
+//  3. restarts the instances of the component to pick up the new password from the secret, see passwordRotationRestartTime.
+//  4. revokes the old password through the revoke statement after the grace period, once all the instances are restarted.
+//
+// It returns the duration after which the rotation should be checked again.
+func (t *componentAccountProvisionTransformer) rotateAccount(transCtx *componentTransformContext, dag *graph.DAG,
+	lfa lifecycle.Lifecycle, account synthesizedSystemAccount, secret *corev1.Secret) (time.Duration, error) {
+	_, requested := passwordRotationRequests(transCtx.Component)[account.Name]
+	if secret == nil || (account.RotationPolicy == nil && !requested) {
+		return 0, nil
+	}
+
+	now := time.Now()
+	last := latestPasswordRotation(transCtx.Component, account.Name)
+	if last != nil {
+		switch last.Phase {
+		case appsv1.PendingPasswordRotationPhase:
+			return t.applyPassword(transCtx, dag, lfa, account, secret, last, now)
+		case appsv1.RotatingPasswordRotationPhase:
+			return t.revokePassword(transCtx, dag, lfa, account, secret, last, now)
+		}
+	}
+
+	trigger, after := t.rotationTrigger(transCtx.Component, account, secret, last, now)
+	if len(trigger) == 0 {
+		return after, nil
+	}
+	if account.SecretRef != nil {
+		// the password is referred from the secret, which is managed by the user
+		appendPasswordRotation(transCtx.Component, appsv1.PasswordRotationStatus{
+			Account:        account.Name,
+			Trigger:        trigger,
+			Phase:          appsv1.FailedPasswordRotationPhase,
+			StartTime:      metav1.NewTime(now),
+			CompletionTime: &metav1.Time{Time: now},
+			Message:        "the rotation is not supported for the account whose password is referred from a secret",
+		})
+		return 0, nil
+	}
+	return t.rotatePassword(transCtx, dag, lfa, account, secret, trigger, now)
+}
+
+func (t *componentAccountProvisionTransformer) rotationTrigger(comp *appsv1.Component, account synthesizedSystemAccount,
+	secret *corev1.Secret, last *appsv1.PasswordRotationStatus, now time.Time) (appsv1.PasswordRotationTrigger, time.Duration) {
+	if requestTime, ok := passwordRotationRequests(comp)[account.Name]; ok {
+		if last == nil || last.StartTime.Time.Before(requestTime) {
+			return appsv1.OnDemandPasswordRotation, 0
+		}
+	}
+
+	if account.RotationPolicy == nil || account.RotationPolicy.IntervalDays <= 0 {
+		return "", 0
+	}
+	if last != nil && last.Phase == appsv1.FailedPasswordRotationPhase {
+		if elapsed := now.Sub(last.StartTime.Time); elapsed < passwordRotationRetryInterval {
+			return "", passwordRotationRetryInterval - elapsed
+		}
+	}
+	base := secret.CreationTimestamp.Time
+	if succeed := latestSucceedPasswordRotation(comp, account.Name); succeed != nil {
+		base = succeed.StartTime.Time
+	}
+	next := base.Add(time.Duration(account.RotationPolicy.IntervalDays) * 24 * time.Hour)
+	if now.Before(next) {
+		return "", next.Sub(now)
+	}
+	return appsv1.ScheduledPasswordRotation, 0
+}
+
+// rotatePassword starts a rotation, the new password is persisted in the account secret before it is applied.
+func (t *componentAccountProvisionTransformer) rotatePassword(transCtx *componentTransformContext, dag *graph.DAG,
+	lfa lifecycle.Lifecycle, account synthesizedSystemAccount, secret *corev1.Secret,
+	trigger appsv1.PasswordRotationTrigger, now time.Time) (time.Duration, error) {
+	appendPasswordRotation(transCtx.Component, appsv1.PasswordRotationStatus{
+		Account:   account.Name,
+		Trigger:   trigger,
+		Phase:     appsv1.PendingPasswordRotationPhase,
+		StartTime: metav1.NewTime(now),
+	})
+	rotation := latestPasswordRotation(transCtx.Component, account.Name)
+	if len(rotateStatement(account)) == 0 {
+		return t.failRotation(transCtx, account, rotation, now,
+			fmt.Errorf("has no rotate or update statement defined for system account: %s", account.Name))
+	}
+
+	// the pending password left by a failed rotation may have been applied partially, reuse it
+	password := secret.Data[accountPendingPasswordKey]
+	if len(password) == 0 || slices.Equal(password, secret.Data[constant.AccountPasswdForSecret]) {
+		generated, err := common.GeneratePasswordByConfig(account.PasswordGenerationPolicy)
+		if err != nil {
+			return t.failRotation(transCtx, account, rotation, now, err)
+		}
+		password = []byte(generated)
+	}
+	t.updateAccountSecret(transCtx, dag, secret, map[string][]byte{accountPendingPasswordKey: password})
+	return passwordRotationApplyInterval, nil
+}
+
+// applyPassword applies the pending password persisted in the account secret through the rotate statement,
+// it is safe to be retried since the same pending password is used until the rotation moves on.
+func (t *componentAccountProvisionTransformer) applyPassword(transCtx *componentTransformContext, dag *graph.DAG,
+	lfa lifecycle.Lifecycle, account synthesizedSystemAccount, secret *corev1.Secret,
+	rotation *appsv1.PasswordRotationStatus, now time.Time) (time.Duration, error) {
+	password := secret.Data[accountPendingPasswordKey]
+	if len(password) == 0 {
+		// the pending password has not been persisted yet
+		if now.Sub(rotation.StartTime.Time) < passwordRotationCheckInterval {
+			return passwordRotationApplyInterval, nil
+		}
+		return t.failRotation(transCtx, account, rotation, now, fmt.Errorf("the pending password is not found in the account secret"))
+	}
+
+	// the pending password has been applied already if it is saved as the password, but the rotation status was not updated
+	if !slices.Equal(password, secret.Data[constant.AccountPasswdForSecret]) {
+		statement := rotateStatement(account)
+		if len(statement) == 0 {
+			return t.failRotation(transCtx, account, rotation, now,
+				fmt.Errorf("has no rotate or update statement defined for system account: %s", account.Name))
+		}
+		username := string(secret.Data[constant.AccountNameForSecret])
+		err := lfa.AccountProvision(transCtx.Context, transCtx.Client, nil, statement, username, string(password))
+		if err = lifecycle.IgnoreNotDefined(err); err != nil {
+			return t.failRotation(transCtx, account, rotation, now, err)
+		}
+		// the new password is valid now, update the account secret, the vars referring to it will be resolved again
+		t.updateAccountSecret(transCtx, dag, secret, map[string][]byte{constant.AccountPasswdForSecret: password})
+	}
+
+	gracePeriod := defaultPasswordGracePeriod
+	if account.RotationPolicy != nil {
+		gracePeriod = time.Duration(account.RotationPolicy.GracePeriodSeconds) * time.Second
+	}
+	rotation.Phase = appsv1.RotatingPasswordRotationPhase
+	rotation.RevokeTime = &metav1.Time{Time: now.Add(gracePeriod)}
+	rotation.Message = ""
+	transCtx.EventRecorder.Eventf(transCtx.Component, corev1.EventTypeNormal, "PasswordRotated",
+		"the password of system account %s is rotated, the old one will be revoked after %s", account.Name, gracePeriod.String())
+	return gracePeriod, nil
+}
+
+// failRotation marks the rotation as failed, the pending password is kept in the account secret to be reused by the retry.
+func (t *componentAccountProvisionTransformer) failRotation(transCtx *componentTransformContext,
+	account synthesizedSystemAccount, rotation *appsv1.PasswordRotationStatus, now time.Time, err error) (time.Duration, error) {
+	rotation.Phase = appsv1.FailedPasswordRotationPhase
+	rotation.CompletionTime = &metav1.Time{Time: now}
+	rotation.Message = err.Error()
+	transCtx.EventRecorder.Eventf(transCtx.Component, corev1.EventTypeWarning, "PasswordRotationFailed",
+		"failed to rotate the password of system account %s: %s", account.Name, err.Error())
+	return passwordRotationRetryInterval, nil
+}
+
+func (t *componentAccountProvisionTransformer) revokePassword(transCtx *componentTransformContext, dag *graph.DAG,
+	lfa lifecycle.Lifecycle, account synthesizedSystemAccount, secret *corev1.Secret,
+	rotation *appsv1.PasswordRotationStatus, now time.Time) (time.Duration, error) {
+	if rotation.RevokeTime != nil && now.Before(rotation.RevokeTime.Time) {
+		return rotation.RevokeTime.Sub(now), nil
+	}
+	// the instances referring to the secret keep using the old password until they are restarted
+	restarted, err := t.isRestartedAfterRotation(transCtx, rotation)
+	if err != nil {
+		return 0, err
+	}
+	if !restarted {
+		rotation.Message = "wait for the instances to be restarted with the new password before revoking the old one"
+		return passwordRotationCheckInterval, nil
+	}
+	if account.Statement != nil && len(account.Statement.Revoke) > 0 {
+		if err := t.provision(transCtx, lfa, account.Statement.Revoke, secret); err != nil {
+			rotation.Message = fmt.Sprintf("failed to revoke the old password: %s", err.Error())
+			return 0, err
+		}
+	}
+	if _, ok := secret.Data[accountPendingPasswordKey]; ok {
+		t.updateAccountSecret(transCtx, dag, secret, map[string][]byte{accountPendingPasswordKey: nil})
+	}
+	rotation.Phase = appsv1.CompletedPasswordRotationPhase
+	rotation.CompletionTime = &metav1.Time{Time: now}
+	rotation.Message = ""
+	return 0, nil
+}
+
+// isRestartedAfterRotation checks whether all the instances of the component have been restarted after the rotation.
+func (t *componentAccountProvisionTransformer) isRestartedAfterRotation(transCtx *componentTransformContext,
+	rotation *appsv1.PasswordRotationStatus) (bool, error) {
+	synthesizedComp := transCtx.SynthesizeComponent
+	pods, err := component.ListOwnedPods(transCtx.Context, transCtx.Client,
+		synthesizedComp.Namespace, synthesizedComp.ClusterName, synthesizedComp.Name)
+	if err != nil {
+		return false, err
+	}
+	if len(pods) < int(synthesizedComp.Replicas) {
+		return false, nil
+	}
+	for _, pod := range pods {
+		if pod.CreationTimestamp.Before(&rotation.StartTime) || !intctrlutil.IsPodReady(pod) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// updateAccountSecret updates the data of the account secret, the key with nil value is removed.
+func (t *componentAccountProvisionTransformer) updateAccountSecret(transCtx *componentTransformContext,
+	dag *graph.DAG, secret *corev1.Secret, data map[string][]byte) {
+	update := func(obj *corev1.Secret) {
+		for key, value := range data {
+			if value == nil {
+				delete(obj.Data, key)
+			} else {
+				obj.Data[key] = value
+			}
+		}
+	}
+	graphCli, _ := transCtx.Client.(model.GraphClient)
+	// the secret may have been updated by the account transformer
+	if v := graphCli.FindMatchedVertex(dag, secret); v != nil {
+		if obj, ok := v.(*model.ObjectVertex).Obj.(*corev1.Secret); ok {
+			update(obj)
+			return
+		}
+	}
+	secretCopy := secret.DeepCopy()
+	update(secretCopy)
+	graphCli.Update(dag, secret, secretCopy, appsutil.InUniversalContext4G())
+}
+
+func rotateStatement(account synthesizedSystemAccount) string {
+	if account.Statement == nil {
+		return ""
+	}
+	if len(account.Statement.Rotate) > 0 {
+		return account.Statement.Rotate
+	}
+	return account.Statement.Update
+}
+
+// passwordRotationRequests returns the on-demand rotation requests of the component.
+func passwordRotationRequests(comp *appsv1.Component) map[string]time.Time {
+	requests := map[string]time.Time{}
+	if comp.Annotations == nil || len(comp.Annotations[constant.SystemAccountRotationAnnotationKey]) == 0 {
+		return requests
+	}
+	values := map[string]string{}
+	if err := json.Unmarshal([]byte(comp.Annotations[constant.SystemAccountRotationAnnotationKey]), &values); err != nil {
+		return requests
+	}
+	for account, value := range values {
+		if requestTime, err := time.Parse(time.RFC3339, value); err == nil {
+			requests[account] = requestTime
+		}
+	}
+	return requests
+}
+
+// passwordRotationRestartTime returns the start time of the latest rotation which has updated the password,
+// the instances of the component are restarted to pick up the new password if they started before it.
+func passwordRotationRestartTime(comp *appsv1.Component) *time.Time {
+	var restartTime *time.Time
+	for i, rotation := range comp.Status.PasswordRotations {
+		if rotation.Phase == appsv1.FailedPasswordRotationPhase || rotation.Phase == appsv1.PendingPasswordRotationPhase {
+			continue
+		}
+		if restartTime == nil || restartTime.Before(rotation.StartTime.Time) {
+			restartTime = &comp.Status.PasswordRotations[i].StartTime.Time
+		}
+	}
+	return restartTime
+}
+
+func latestPasswordRotation(comp *appsv1.Component, account string) *appsv1.PasswordRotationStatus {
+	rotations := comp.Status.PasswordRotations
+	for i := len(rotations) - 1; i >= 0; i-- {
+		if rotations[i].Account == account {
+			return &rotations[i]
+		}
+	}
+	return nil
+}
+
+func latestSucceedPasswordRotation(comp *appsv1.Component, account string) *appsv1.PasswordRotationStatus {
+	rotations := comp.Status.PasswordRotations
+	for i := len(rotations) - 1; i >= 0; i-- {
+		if rotations[i].Account == account && rotations[i].Phase != appsv1.FailedPasswordRotationPhase {
+			return &rotations[i]
+		}
+	}
+	return nil
+}
+
+// appendPasswordRotation appends a rotation to the history, and keeps the latest ones of each account only.
+func appendPasswordRotation(comp *appsv1.Component, rotation appsv1.PasswordRotationStatus) {
+	rotations := append(comp.Status.PasswordRotations, rotation)
+	count := 0
+	for i := len(rotations) - 1; i >= 0; i-- {
+		if rotations[i].Account != rotation.Account {
+			continue
+		}
+		count++
+		if count > maxPasswordRotationHistory {
+			rotations = slices.Delete(rotations, i, i+1)
+		}
+	}
+	comp.Status.PasswordRotations = rotations
+}
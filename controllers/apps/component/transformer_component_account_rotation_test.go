@@ -0,0 +1,353 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1"
+	appsutil "github.com/apecloud/kubeblocks/controllers/apps/util"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/lifecycle"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+)
+
+var _ = Describe("account rotation transformer test", func() {
+	var (
+		t      *componentAccountProvisionTransformer
+		now    time.Time
+		comp   *appsv1.Component
+		secret *corev1.Secret
+	)
+
+	newAccount := func(policy *appsv1.PasswordRotationPolicy) synthesizedSystemAccount {
+		return synthesizedSystemAccount{
+			SystemAccount:  appsv1.SystemAccount{Name: "root"},
+			RotationPolicy: policy,
+		}
+	}
+
+	BeforeEach(func() {
+		t = &componentAccountProvisionTransformer{}
+		now = time.Now()
+		comp = &appsv1.Component{}
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				CreationTimestamp: metav1.NewTime(now.Add(-48 * time.Hour)),
+			},
+		}
+	})
+
+	Context("rotation trigger", func() {
+		It("scheduled", func() {
+			account := newAccount(&appsv1.PasswordRotationPolicy{IntervalDays: 1})
+			trigger, _ := t.rotationTrigger(comp, account, secret, nil, now)
+			Expect(trigger).Should(Equal(appsv1.ScheduledPasswordRotation))
+
+			account = newAccount(&appsv1.PasswordRotationPolicy{IntervalDays: 3})
+			trigger, after := t.rotationTrigger(comp, account, secret, nil, now)
+			Expect(trigger).Should(BeEmpty())
+			Expect(after).Should(BeNumerically("~", 24*time.Hour, time.Second))
+		})
+
+		It("on-demand", func() {
+			requests, _ := json.Marshal(map[string]string{"root": now.UTC().Format(time.RFC3339)})
+			comp.Annotations = map[string]string{constant.SystemAccountRotationAnnotationKey: string(requests)}
+
+			trigger, _ := t.rotationTrigger(comp, newAccount(nil), secret, nil, now)
+			Expect(trigger).Should(Equal(appsv1.OnDemandPasswordRotation))
+
+			last := &appsv1.PasswordRotationStatus{
+				Account:   "root",
+				Phase:     appsv1.CompletedPasswordRotationPhase,
+				StartTime: metav1.NewTime(now.Add(time.Second)),
+			}
+			trigger, _ = t.rotationTrigger(comp, newAccount(nil), secret, last, now)
+			Expect(trigger).Should(BeEmpty())
+		})
+
+		It("retry after failure", func() {
+			account := newAccount(&appsv1.PasswordRotationPolicy{IntervalDays: 1})
+			last := &appsv1.PasswordRotationStatus{
+				Account:   "root",
+				Phase:     appsv1.FailedPasswordRotationPhase,
+				StartTime: metav1.NewTime(now.Add(-time.Minute)),
+			}
+			trigger, after := t.rotationTrigger(comp, account, secret, last, now)
+			Expect(trigger).Should(BeEmpty())
+			Expect(after).Should(Equal(passwordRotationRetryInterval - time.Minute))
+		})
+	})
+
+	Context("rotation history", func() {
+		It("keeps the latest ones", func() {
+			for i := 0; i < maxPasswordRotationHistory+2; i++ {
+				appendPasswordRotation(comp, appsv1.PasswordRotationStatus{
+					Account:   "root",
+					Phase:     appsv1.CompletedPasswordRotationPhase,
+					StartTime: metav1.NewTime(now.Add(time.Duration(i) * time.Hour)),
+				})
+			}
+			appendPasswordRotation(comp, appsv1.PasswordRotationStatus{Account: "admin"})
+			Expect(comp.Status.PasswordRotations).Should(HaveLen(maxPasswordRotationHistory + 1))
+
+			latest := latestPasswordRotation(comp, "root")
+			Expect(latest).ShouldNot(BeNil())
+			Expect(latest.StartTime.Time).Should(BeTemporally("~", now.Add(time.Duration(maxPasswordRotationHistory+1)*time.Hour), time.Second))
+		})
+	})
+})
+
+type mockAccountLifecycle struct {
+	lifecycle.Lifecycle
+	statements []string
+	passwords  []string
+	err        error
+}
+
+func (l *mockAccountLifecycle) AccountProvision(_ context.Context, _ client.Reader, _ *lifecycle.Options, statement, _, password string) error {
+	l.statements = append(l.statements, statement)
+	l.passwords = append(l.passwords, password)
+	return l.err
+}
+
+func newAccountRotationTestContext(pods ...client.Object) (*componentTransformContext, *graph.DAG, *corev1.Secret) {
+	comp := &appsv1.Component{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "mycluster-mysql"},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "mycluster-mysql-account-root"},
+		Data: map[string][]byte{
+			constant.AccountNameForSecret:   []byte("root"),
+			constant.AccountPasswdForSecret: []byte("old-password"),
+		},
+	}
+	transCtx := &componentTransformContext{
+		Context:       context.Background(),
+		Client:        model.NewGraphClient(&appsutil.MockReader{Objects: pods}),
+		EventRecorder: record.NewFakeRecorder(10),
+		Logger:        logr.Discard(),
+		Component:     comp,
+		ComponentOrig: comp.DeepCopy(),
+		SynthesizeComponent: &component.SynthesizedComponent{
+			Namespace:   "default",
+			ClusterName: "mycluster",
+			Name:        "mysql",
+			Replicas:    int32(len(pods)),
+		},
+	}
+	return transCtx, newAccountRotationTestDAG(transCtx), secret
+}
+
+func newAccountRotationTestDAG(transCtx *componentTransformContext) *graph.DAG {
+	dag := graph.NewDAG()
+	transCtx.Client.(model.GraphClient).Root(dag, transCtx.Component, transCtx.Component, model.ActionStatusPtr())
+	return dag
+}
+
+func newAccountRotationTestPod(name string, creationTime time.Time) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         "default",
+			Name:              name,
+			Labels:            constant.GetCompLabels("mycluster", "mysql"),
+			CreationTimestamp: metav1.NewTime(creationTime),
+		},
+		Status: corev1.PodStatus{
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		},
+	}
+}
+
+func updatedAccountSecret(transCtx *componentTransformContext, dag *graph.DAG) *corev1.Secret {
+	var updated *corev1.Secret
+	for _, obj := range transCtx.Client.(model.GraphClient).FindAll(dag, &corev1.Secret{}) {
+		updated = obj.(*corev1.Secret)
+	}
+	return updated
+}
+
+func TestRotatePassword(t *testing.T) {
+	transCtx, dag, secret := newAccountRotationTestContext()
+	lfa := &mockAccountLifecycle{}
+	account := synthesizedSystemAccount{
+		SystemAccount: appsv1.SystemAccount{
+			Name:                     "root",
+			Statement:                &appsv1.SystemAccountStatement{Rotate: "rotate", Revoke: "revoke"},
+			PasswordGenerationPolicy: appsv1.PasswordConfig{Length: 10},
+		},
+		RotationPolicy: &appsv1.PasswordRotationPolicy{IntervalDays: 1, GracePeriodSeconds: 60},
+	}
+	now := time.Now()
+	tf := &componentAccountProvisionTransformer{}
+
+	// the new password is persisted before it is applied
+	after, err := tf.rotatePassword(transCtx, dag, lfa, account, secret, appsv1.ScheduledPasswordRotation, now)
+	if err != nil || after != passwordRotationApplyInterval {
+		t.Fatalf("expect to apply the password after it is persisted, got %v %v", after, err)
+	}
+	if len(lfa.statements) != 0 {
+		t.Fatalf("expect the rotate statement not to be executed, got %v", lfa.statements)
+	}
+	rotation := latestPasswordRotation(transCtx.Component, "root")
+	if rotation == nil || rotation.Phase != appsv1.PendingPasswordRotationPhase {
+		t.Fatalf("expect the rotation to be pending, got %v", rotation)
+	}
+	persisted := updatedAccountSecret(transCtx, dag)
+	pending := string(persisted.Data[accountPendingPasswordKey])
+	if len(pending) == 0 || string(persisted.Data[constant.AccountPasswdForSecret]) != "old-password" {
+		t.Fatalf("expect the pending password to be persisted, got %v", persisted.Data)
+	}
+	if passwordRotationRestartTime(transCtx.Component) != nil {
+		t.Error("expect the instances not to be restarted before the password is applied")
+	}
+
+	// the failed action is retried with the same password
+	lfa.err = fmt.Errorf("mock error")
+	dag = newAccountRotationTestDAG(transCtx)
+	if _, err = tf.applyPassword(transCtx, dag, lfa, account, persisted, rotation, now); err != nil {
+		t.Fatal(err)
+	}
+	if rotation.Phase != appsv1.FailedPasswordRotationPhase || updatedAccountSecret(transCtx, dag) != nil {
+		t.Fatalf("expect the rotation to fail without updating the secret, got %v", rotation.Phase)
+	}
+	lfa.err = nil
+	transCtx.Component.Status.PasswordRotations = nil
+	if _, err = tf.rotatePassword(transCtx, dag, lfa, account, persisted, appsv1.ScheduledPasswordRotation, now); err != nil {
+		t.Fatal(err)
+	}
+	persisted = updatedAccountSecret(transCtx, dag)
+	if string(persisted.Data[accountPendingPasswordKey]) != pending {
+		t.Fatalf("expect the pending password to be reused, got %s", persisted.Data[accountPendingPasswordKey])
+	}
+
+	rotation = latestPasswordRotation(transCtx.Component, "root")
+	dag = newAccountRotationTestDAG(transCtx)
+	after, err = tf.applyPassword(transCtx, dag, lfa, account, persisted, rotation, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if after != time.Minute {
+		t.Errorf("expect to check the rotation after the grace period, got %v", after)
+	}
+	if len(lfa.passwords) != 2 || lfa.passwords[0] != pending || lfa.passwords[1] != pending {
+		t.Fatalf("expect the pending password to be applied, got %v", lfa.passwords)
+	}
+	if rotation.Phase != appsv1.RotatingPasswordRotationPhase || rotation.RevokeTime == nil {
+		t.Fatalf("expect the rotation to wait for revoking, got %v", rotation)
+	}
+	updated := updatedAccountSecret(transCtx, dag)
+	if updated == nil || string(updated.Data[constant.AccountPasswdForSecret]) != pending {
+		t.Fatal("expect the account secret to be updated with the new password")
+	}
+
+	// the password has been saved, but the rotation status was not updated
+	lfa.statements = nil
+	rotation.Phase = appsv1.PendingPasswordRotationPhase
+	if _, err = tf.applyPassword(transCtx, newAccountRotationTestDAG(transCtx), lfa, account, updated, rotation, now); err != nil {
+		t.Fatal(err)
+	}
+	if len(lfa.statements) != 0 || rotation.Phase != appsv1.RotatingPasswordRotationPhase {
+		t.Errorf("expect the applied password not to be applied again, got %v %v", lfa.statements, rotation.Phase)
+	}
+
+	its := &workloads.InstanceSet{}
+	(&componentWorkloadTransformer{}).buildPasswordRotationRestartAnnotation(transCtx.Component, its)
+	if its.Spec.Template.Annotations[constant.RestartAnnotationKey] != rotation.StartTime.UTC().Format(time.RFC3339) {
+		t.Errorf("expect the instances to be restarted after the rotation, got %v", its.Spec.Template.Annotations)
+	}
+	its.Spec.Template.Annotations[constant.RestartAnnotationKey] = now.Add(time.Hour).UTC().Format(time.RFC3339)
+	(&componentWorkloadTransformer{}).buildPasswordRotationRestartAnnotation(transCtx.Component, its)
+	if its.Spec.Template.Annotations[constant.RestartAnnotationKey] != now.Add(time.Hour).UTC().Format(time.RFC3339) {
+		t.Error("expect the later restart to be kept")
+	}
+}
+
+func TestRevokePassword(t *testing.T) {
+	now := time.Now()
+	rotation := &appsv1.PasswordRotationStatus{
+		Account:    "root",
+		Phase:      appsv1.RotatingPasswordRotationPhase,
+		StartTime:  metav1.NewTime(now.Add(-2 * time.Hour).Truncate(time.Second)),
+		RevokeTime: &metav1.Time{Time: now.Add(-time.Hour)},
+	}
+	account := synthesizedSystemAccount{
+		SystemAccount: appsv1.SystemAccount{
+			Name:      "root",
+			Statement: &appsv1.SystemAccountStatement{Rotate: "rotate", Revoke: "revoke"},
+		},
+	}
+	tf := &componentAccountProvisionTransformer{}
+
+	// the grace period has not elapsed
+	transCtx, _, secret := newAccountRotationTestContext(newAccountRotationTestPod("mysql-0", now))
+	lfa := &mockAccountLifecycle{}
+	pending := rotation.DeepCopy()
+	pending.RevokeTime = &metav1.Time{Time: now.Add(time.Minute)}
+	if after, err := tf.revokePassword(transCtx, newAccountRotationTestDAG(transCtx), lfa, account, secret, pending, now); err != nil || after != time.Minute {
+		t.Errorf("expect to wait for the grace period, got %v %v", after, err)
+	}
+
+	// the instances have not been restarted
+	transCtx, _, secret = newAccountRotationTestContext(
+		newAccountRotationTestPod("mysql-0", now.Add(-3*time.Hour)), newAccountRotationTestPod("mysql-1", now))
+	waiting := rotation.DeepCopy()
+	after, err := tf.revokePassword(transCtx, newAccountRotationTestDAG(transCtx), lfa, account, secret, waiting, now)
+	if err != nil || after != passwordRotationCheckInterval || waiting.Phase != appsv1.RotatingPasswordRotationPhase {
+		t.Errorf("expect to wait for the instances to be restarted, got %v %v %v", after, err, waiting.Phase)
+	}
+	if len(lfa.statements) != 0 {
+		t.Fatalf("expect the old password not to be revoked, got %v", lfa.statements)
+	}
+
+	// all the instances have been restarted
+	transCtx, dag, secret := newAccountRotationTestContext(
+		newAccountRotationTestPod("mysql-0", now.Add(-time.Hour)), newAccountRotationTestPod("mysql-1", now))
+	secret.Data[accountPendingPasswordKey] = secret.Data[constant.AccountPasswdForSecret]
+	completed := rotation.DeepCopy()
+	if _, err = tf.revokePassword(transCtx, dag, lfa, account, secret, completed, now); err != nil {
+		t.Fatal(err)
+	}
+	if updated := updatedAccountSecret(transCtx, dag); updated == nil || updated.Data[accountPendingPasswordKey] != nil {
+		t.Error("expect the pending password to be removed from the account secret")
+	}
+	if completed.Phase != appsv1.CompletedPasswordRotationPhase || completed.CompletionTime == nil {
+		t.Errorf("expect the rotation to be completed, got %v", completed.Phase)
+	}
+	if len(lfa.statements) != 1 || lfa.statements[0] != "revoke" {
+		t.Errorf("expect the revoke statement to be executed, got %v", lfa.statements)
+	}
+}
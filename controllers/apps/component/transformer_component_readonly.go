@@ -0,0 +1,188 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/lifecycle"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+const (
+	readonlyConditionType              = "Readonly"
+	readonlyConditionReasonInProgress  = "InProgress"
+	readonlyConditionReasonReadonly    = "AllReadonly"
+	readonlyConditionReasonReadwrite   = "AllReadwrite"
+	readonlyConditionReasonNotDefined  = "ActionNotDefined"
+	readonlyConditionReasonActionError = "ActionFailed"
+
+	readonlyRequeueAfter = 5 * time.Second
+)
+
+// componentReadonlyTransformer switches the replicas of the component between the read-only and read-write states.
+//
+// The replicas that have been switched to read-only are recorded in the status of the component,
+// so that the recreated replicas can be switched again.
+type componentReadonlyTransformer struct{}
+
+var _ graph.Transformer = &componentReadonlyTransformer{}
+
+func (t *componentReadonlyTransformer) Transform(ctx graph.TransformContext, dag *graph.DAG) error {
+	transCtx, _ := ctx.(*componentTransformContext)
+	if isCompDeleting(transCtx.ComponentOrig) {
+		return nil
+	}
+
+	synthesizedComp := transCtx.SynthesizeComponent
+	if synthesizedComp == nil || isCompStopped(synthesizedComp) {
+		return nil
+	}
+
+	comp := transCtx.Component
+	cond := meta.FindStatusCondition(comp.Status.Conditions, readonlyConditionType)
+	readonly := synthesizedComp.Readonly != nil && *synthesizedComp.Readonly
+	if !readonly && (cond == nil || cond.Reason == readonlyConditionReasonReadwrite) {
+		return nil // has never been switched to read-only, or all replicas have been switched back
+	}
+
+	if cond == nil {
+		cond = &metav1.Condition{
+			Type:   readonlyConditionType,
+			Status: metav1.ConditionFalse,
+			Reason: readonlyConditionReasonInProgress,
+		}
+	}
+	newCond := cond.DeepCopy()
+	newCond.ObservedGeneration = comp.Generation
+
+	err := t.reconcile(transCtx, newCond, readonly)
+	if err != nil && errors.Is(err, lifecycle.ErrActionNotDefined) {
+		newCond.Status = metav1.ConditionFalse
+		newCond.Reason = readonlyConditionReasonNotDefined
+		err = nil // it is meaningless to retry
+	}
+	meta.SetStatusCondition(&comp.Status.Conditions, *newCond)
+	return err
+}
+
+func (t *componentReadonlyTransformer) reconcile(transCtx *componentTransformContext, cond *metav1.Condition, readonly bool) error {
+	synthesizedComp := transCtx.SynthesizeComponent
+	pods, err := component.ListOwnedPods(transCtx.Context, transCtx.Client,
+		synthesizedComp.Namespace, synthesizedComp.ClusterName, synthesizedComp.Name)
+	if err != nil {
+		return err
+	}
+
+	// remove the replicas which have been deleted or recreated
+	comp := transCtx.Component
+	replicas := slices.DeleteFunc(slices.Clone(comp.Status.ReadonlyReplicas), func(r appsv1.ReadonlyReplica) bool {
+		return !slices.ContainsFunc(pods, func(pod *corev1.Pod) bool {
+			return r == readonlyReplica(pod)
+		})
+	})
+
+	var errs []string
+	for _, pod := range pods {
+		replica := readonlyReplica(pod)
+		switched := slices.Contains(replicas, replica)
+		if readonly == switched {
+			continue
+		}
+		if !intctrlutil.IsPodAvailable(pod, synthesizedComp.MinReadySeconds) {
+			errs = append(errs, fmt.Sprintf("%s is not available", pod.Name))
+			continue
+		}
+		if err = t.switchReplica(transCtx, pod, pods, readonly); err != nil {
+			if errors.Is(err, lifecycle.ErrActionNotDefined) {
+				return err
+			}
+			errs = append(errs, fmt.Sprintf("%s: %s", pod.Name, err.Error()))
+			continue
+		}
+		if readonly {
+			replicas = append(replicas, replica)
+		} else {
+			replicas = slices.DeleteFunc(replicas, func(r appsv1.ReadonlyReplica) bool { return r == replica })
+		}
+	}
+	slices.SortFunc(replicas, func(a, b appsv1.ReadonlyReplica) int {
+		return strings.Compare(a.PodName, b.PodName)
+	})
+	comp.Status.ReadonlyReplicas = replicas
+	if len(replicas) == 0 {
+		comp.Status.ReadonlyReplicas = nil
+	}
+
+	switch {
+	case len(errs) > 0:
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = readonlyConditionReasonActionError
+		cond.Message = fmt.Sprintf("switch replicas to %s failed: %s", t.state(readonly), strings.Join(errs, "; "))
+		return intctrlutil.NewDelayedRequeueError(readonlyRequeueAfter, cond.Message)
+	case readonly:
+		cond.Status = metav1.ConditionTrue
+		cond.Reason = readonlyConditionReasonReadonly
+	default:
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = readonlyConditionReasonReadwrite
+	}
+	cond.Message = ""
+	return nil
+}
+
+func (t *componentReadonlyTransformer) switchReplica(transCtx *componentTransformContext,
+	pod *corev1.Pod, pods []*corev1.Pod, readonly bool) error {
+	synthesizedComp := transCtx.SynthesizeComponent
+	lfa, err := lifecycle.New(synthesizedComp.Namespace, synthesizedComp.ClusterName, synthesizedComp.Name,
+		synthesizedComp.LifecycleActions, synthesizedComp.TemplateVars, pod, pods...)
+	if err != nil {
+		return err
+	}
+	if readonly {
+		return lfa.Readonly(transCtx.Context, transCtx.Client, nil)
+	}
+	return lfa.Readwrite(transCtx.Context, transCtx.Client, nil)
+}
+
+func (t *componentReadonlyTransformer) state(readonly bool) string {
+	if readonly {
+		return "read-only"
+	}
+	return "read-write"
+}
+
+func readonlyReplica(pod *corev1.Pod) appsv1.ReadonlyReplica {
+	return appsv1.ReadonlyReplica{
+		PodName: pod.Name,
+		PodUID:  pod.UID,
+	}
+}
@@ -0,0 +1,232 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/golang/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	appsutil "github.com/apecloud/kubeblocks/controllers/apps/util"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	kbacli "github.com/apecloud/kubeblocks/pkg/kbagent/client"
+	kbagentproto "github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+)
+
+var _ = Describe("readonly transformer test", func() {
+	const (
+		clusterName = "test-cluster"
+		compName    = "comp"
+	)
+
+	var (
+		reader   *appsutil.MockReader
+		dag      *graph.DAG
+		transCtx *componentTransformContext
+		actions  map[string][]string
+	)
+
+	newDAG := func(graphCli model.GraphClient, comp *appsv1.Component) *graph.DAG {
+		d := graph.NewDAG()
+		graphCli.Root(d, comp, comp, model.ActionStatusPtr())
+		return d
+	}
+
+	newPod := func(ordinal int) *corev1.Pod {
+		name := fmt.Sprintf("%s-%d", constant.GenerateWorkloadNamePattern(clusterName, compName), ordinal)
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testCtx.DefaultNamespace,
+				Name:      name,
+				UID:       types.UID(name),
+				Labels: map[string]string{
+					constant.AppManagedByLabelKey:   constant.AppName,
+					constant.AppInstanceLabelKey:    clusterName,
+					constant.KBAppComponentLabelKey: compName,
+				},
+			},
+			Status: corev1.PodStatus{
+				Conditions: []corev1.PodCondition{
+					{
+						Type:               corev1.PodReady,
+						Status:             corev1.ConditionTrue,
+						LastTransitionTime: metav1.Now(),
+					},
+				},
+			},
+		}
+	}
+
+	readonlyCond := func() *metav1.Condition {
+		return meta.FindStatusCondition(transCtx.Component.Status.Conditions, readonlyConditionType)
+	}
+
+	BeforeEach(func() {
+		actions = map[string][]string{}
+		testapps.MockKBAgentClient(func(recorder *kbacli.MockClientMockRecorder) {
+			recorder.Action(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, req kbagentproto.ActionRequest) (kbagentproto.ActionResponse, error) {
+				actions[req.Action] = append(actions[req.Action], req.Action)
+				return kbagentproto.ActionResponse{}, nil
+			}).AnyTimes()
+		})
+
+		comp := &appsv1.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:  testCtx.DefaultNamespace,
+				Name:       constant.GenerateClusterComponentName(clusterName, compName),
+				Generation: 1,
+			},
+			Spec: appsv1.ComponentSpec{
+				Replicas: 2,
+				Readonly: ptr.To(true),
+			},
+			Status: appsv1.ComponentStatus{
+				Phase: appsv1.RunningComponentPhase,
+			},
+		}
+
+		reader = &appsutil.MockReader{
+			Objects: []client.Object{newPod(0), newPod(1)},
+		}
+
+		graphCli := model.NewGraphClient(reader)
+		dag = newDAG(graphCli, comp)
+
+		transCtx = &componentTransformContext{
+			Context:       ctx,
+			Client:        graphCli,
+			EventRecorder: nil,
+			Logger:        logger,
+			Component:     comp,
+			ComponentOrig: comp.DeepCopy(),
+			SynthesizeComponent: &component.SynthesizedComponent{
+				Namespace:   testCtx.DefaultNamespace,
+				ClusterName: clusterName,
+				Name:        compName,
+				Replicas:    2,
+				Readonly:    ptr.To(true),
+				LifecycleActions: &appsv1.ComponentLifecycleActions{
+					Readonly:  testapps.NewLifecycleAction("readonly"),
+					Readwrite: testapps.NewLifecycleAction("readwrite"),
+				},
+			},
+		}
+	})
+
+	Context("readonly", func() {
+		It("switch to read-only", func() {
+			transformer := &componentReadonlyTransformer{}
+			Expect(transformer.Transform(transCtx, dag)).Should(Succeed())
+			Expect(actions["readonly"]).Should(HaveLen(2))
+
+			cond := readonlyCond()
+			Expect(cond).ShouldNot(BeNil())
+			Expect(cond.Status).Should(Equal(metav1.ConditionTrue))
+			Expect(cond.Reason).Should(Equal(readonlyConditionReasonReadonly))
+			Expect(transCtx.Component.Status.ReadonlyReplicas).Should(HaveLen(2))
+
+			By("reconcile again")
+			Expect(transformer.Transform(transCtx, dag)).Should(Succeed())
+			Expect(actions["readonly"]).Should(HaveLen(2))
+		})
+
+		It("switch back to read-write", func() {
+			transformer := &componentReadonlyTransformer{}
+			Expect(transformer.Transform(transCtx, dag)).Should(Succeed())
+
+			transCtx.SynthesizeComponent.Readonly = nil
+			Expect(transformer.Transform(transCtx, dag)).Should(Succeed())
+			Expect(actions["readwrite"]).Should(HaveLen(2))
+
+			cond := readonlyCond()
+			Expect(cond).ShouldNot(BeNil())
+			Expect(cond.Status).Should(Equal(metav1.ConditionFalse))
+			Expect(cond.Reason).Should(Equal(readonlyConditionReasonReadwrite))
+			Expect(transCtx.Component.Status.ReadonlyReplicas).Should(BeEmpty())
+		})
+
+		It("recreated replica", func() {
+			transformer := &componentReadonlyTransformer{}
+			Expect(transformer.Transform(transCtx, dag)).Should(Succeed())
+			Expect(actions["readonly"]).Should(HaveLen(2))
+
+			pod := reader.Objects[1].(*corev1.Pod)
+			pod.UID = types.UID("recreated")
+			Expect(transformer.Transform(transCtx, dag)).Should(Succeed())
+			Expect(actions["readonly"]).Should(HaveLen(3))
+			Expect(transCtx.Component.Status.ReadonlyReplicas).Should(ContainElement(appsv1.ReadonlyReplica{
+				PodName: pod.Name,
+				PodUID:  types.UID("recreated"),
+			}))
+		})
+
+		It("replica not available", func() {
+			pod := reader.Objects[1].(*corev1.Pod)
+			pod.Status.Conditions = nil
+
+			transformer := &componentReadonlyTransformer{}
+			err := transformer.Transform(transCtx, dag)
+			Expect(err).ShouldNot(BeNil())
+			Expect(err.Error()).Should(ContainSubstring("is not available"))
+			Expect(actions["readonly"]).Should(HaveLen(1))
+
+			cond := readonlyCond()
+			Expect(cond).ShouldNot(BeNil())
+			Expect(cond.Status).Should(Equal(metav1.ConditionFalse))
+			Expect(cond.Reason).Should(Equal(readonlyConditionReasonActionError))
+		})
+
+		It("not defined", func() {
+			transCtx.SynthesizeComponent.LifecycleActions.Readonly = nil
+
+			transformer := &componentReadonlyTransformer{}
+			Expect(transformer.Transform(transCtx, dag)).Should(Succeed())
+			Expect(actions).Should(BeEmpty())
+
+			cond := readonlyCond()
+			Expect(cond).ShouldNot(BeNil())
+			Expect(cond.Reason).Should(Equal(readonlyConditionReasonNotDefined))
+		})
+
+		It("never switched", func() {
+			transCtx.SynthesizeComponent.Readonly = nil
+
+			transformer := &componentReadonlyTransformer{}
+			Expect(transformer.Transform(transCtx, dag)).Should(Succeed())
+			Expect(actions).Should(BeEmpty())
+			Expect(readonlyCond()).Should(BeNil())
+		})
+	})
+})
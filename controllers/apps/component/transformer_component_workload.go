@@ -24,10 +24,13 @@ import (
 	"encoding/json"
 	"reflect"
 	"strings"
+	"time"
 
 	"golang.org/x/exp/maps"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -36,6 +39,7 @@ import (
 	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1"
 	appsutil "github.com/apecloud/kubeblocks/controllers/apps/util"
 	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/builder"
 	"github.com/apecloud/kubeblocks/pkg/controller/component"
 	"github.com/apecloud/kubeblocks/pkg/controller/factory"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
@@ -85,6 +89,9 @@ func (t *componentWorkloadTransformer) Transform(ctx graph.TransformContext, dag
 	}
 
 	graphCli, _ := transCtx.Client.(model.GraphClient)
+	if err = t.reconcileKBAgentEventCA(transCtx, graphCli, dag); err != nil {
+		return err
+	}
 	if runningITS == nil {
 		if protoITS != nil {
 			if err := setCompOwnershipNFinalizer(comp, protoITS); err != nil {
@@ -116,6 +123,7 @@ func (t *componentWorkloadTransformer) reconcileWorkload(ctx context.Context, cl
 	}
 
 	t.buildInstanceSetPlacementAnnotation(comp, protoITS)
+	t.buildPasswordRotationRestartAnnotation(comp, protoITS)
 
 	if err := t.reconcileReplicasStatus(ctx, cli, synthesizedComp, runningITS, protoITS); err != nil {
 		return err
@@ -124,6 +132,44 @@ func (t *componentWorkloadTransformer) reconcileWorkload(ctx context.Context, cl
 	return nil
 }
 
+// reconcileKBAgentEventCA creates or updates the config map which provides the CA certificate of the event channel
+// to kbagent. It is projected to the kbagent containers instead of the env, so renewing the CA doesn't restart the pods.
+func (t *componentWorkloadTransformer) reconcileKBAgentEventCA(transCtx *componentTransformContext,
+	graphCli model.GraphClient, dag *graph.DAG) error {
+	synthesizedComp := transCtx.SynthesizeComponent
+	data := component.KBAgentEventChannelCAData(synthesizedComp)
+	if data == nil {
+		return nil
+	}
+	cmKey := types.NamespacedName{
+		Namespace: synthesizedComp.Namespace,
+		Name:      constant.GenerateComponentKBAgentEventCAPattern(synthesizedComp.ClusterName, synthesizedComp.Name),
+	}
+	cm := &corev1.ConfigMap{}
+	if err := transCtx.Client.Get(transCtx.Context, cmKey, cm, appsutil.InDataContext4C()); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		obj := builder.NewConfigMapBuilder(cmKey.Namespace, cmKey.Name).
+			AddLabelsInMap(constant.GetCompLabels(synthesizedComp.ClusterName, synthesizedComp.Name)).
+			AddLabelsInMap(synthesizedComp.StaticLabels).
+			AddAnnotationsInMap(synthesizedComp.StaticAnnotations).
+			SetData(data).
+			GetObject()
+		if err = setCompOwnershipNFinalizer(transCtx.Component, obj); err != nil {
+			return err
+		}
+		graphCli.Create(dag, obj, appsutil.InDataContext4G())
+		return nil
+	}
+	if !reflect.DeepEqual(cm.Data, data) {
+		cmCopy := cm.DeepCopy()
+		cmCopy.Data = data
+		graphCli.Update(dag, cm, cmCopy, appsutil.InDataContext4G())
+	}
+	return nil
+}
+
 func (t *componentWorkloadTransformer) buildInstanceSetPlacementAnnotation(comp *appsv1.Component, its *workloads.InstanceSet) {
 	p := appsutil.Placement(comp)
 	if len(p) > 0 {
@@ -134,6 +180,24 @@ func (t *componentWorkloadTransformer) buildInstanceSetPlacementAnnotation(comp
 	}
 }
 
+// buildPasswordRotationRestartAnnotation restarts the instances after the password of any system account is rotated,
+// to make the instances referring to the account secrets pick up the new password.
+func (t *componentWorkloadTransformer) buildPasswordRotationRestartAnnotation(comp *appsv1.Component, its *workloads.InstanceSet) {
+	rotationTime := passwordRotationRestartTime(comp)
+	if rotationTime == nil {
+		return
+	}
+	if restart, ok := its.Spec.Template.Annotations[constant.RestartAnnotationKey]; ok {
+		if restartTime, err := time.Parse(time.RFC3339, restart); err == nil && !restartTime.Before(*rotationTime) {
+			return
+		}
+	}
+	if its.Spec.Template.Annotations == nil {
+		its.Spec.Template.Annotations = make(map[string]string)
+	}
+	its.Spec.Template.Annotations[constant.RestartAnnotationKey] = rotationTime.UTC().Format(time.RFC3339)
+}
+
 func (t *componentWorkloadTransformer) reconcileReplicasStatus(ctx context.Context, cli client.Reader,
 	synthesizedComp *component.SynthesizedComponent, runningITS, protoITS *workloads.InstanceSet) error {
 	var (
@@ -357,11 +421,13 @@ func copyAndMergeITS(oldITS, newITS *workloads.InstanceSet) *workloads.InstanceS
 	itsObjCopy.Spec.ParallelPodManagementConcurrency = itsProto.Spec.ParallelPodManagementConcurrency
 	itsObjCopy.Spec.PodUpdatePolicy = itsProto.Spec.PodUpdatePolicy
 	itsObjCopy.Spec.InstanceUpdateStrategy = itsProto.Spec.InstanceUpdateStrategy
+	itsObjCopy.Spec.PodDisruptionBudget = itsProto.Spec.PodDisruptionBudget
 	itsObjCopy.Spec.MemberUpdateStrategy = itsProto.Spec.MemberUpdateStrategy
 	itsObjCopy.Spec.Paused = itsProto.Spec.Paused
 	itsObjCopy.Spec.Configs = itsProto.Spec.Configs
 	itsObjCopy.Spec.Selector = itsProto.Spec.Selector
 	itsObjCopy.Spec.DisableDefaultHeadlessService = itsProto.Spec.DisableDefaultHeadlessService
+	itsObjCopy.Spec.MaintenanceWindow = itsProto.Spec.MaintenanceWindow
 
 	if itsObjCopy.Spec.InstanceUpdateStrategy != nil && itsObjCopy.Spec.InstanceUpdateStrategy.RollingUpdate != nil {
 		// use oldITS because itsObjCopy has been overwritten
@@ -448,7 +514,7 @@ func hasMemberJoinNDataActionDefined(lifecycleActions *appsv1.ComponentLifecycle
 	}
 	hasActionDefined := func(actions []*appsv1.Action) bool {
 		for _, action := range actions {
-			if action == nil || action.Exec == nil {
+			if !action.Defined() {
 				return false
 			}
 		}
@@ -477,6 +477,52 @@ func (r *ComponentDefinitionReconciler) validatePolicyRules(cli client.Client, r
 
 func (r *ComponentDefinitionReconciler) validateLifecycleActions(cli client.Client, reqCtx intctrlutil.RequestCtx,
 	cmpd *appsv1.ComponentDefinition) error {
+	actions := cmpd.Spec.LifecycleActions
+	if actions == nil {
+		return nil
+	}
+	validate := func(name string, action *appsv1.Action) error {
+		if action == nil {
+			return nil
+		}
+		handlers := 0
+		for _, defined := range []bool{action.Exec != nil, action.HTTP != nil, action.GRPC != nil} {
+			if defined {
+				handlers++
+			}
+		}
+		if handlers > 1 {
+			return fmt.Errorf("only one of exec, http and grpc can be specified for the lifecycle action %s", name)
+		}
+		return nil
+	}
+	for name, action := range map[string]*appsv1.Action{
+		"postProvision":    actions.PostProvision,
+		"preTerminate":     actions.PreTerminate,
+		"switchover":       actions.Switchover,
+		"memberJoin":       actions.MemberJoin,
+		"memberLeave":      actions.MemberLeave,
+		"readonly":         actions.Readonly,
+		"readwrite":        actions.Readwrite,
+		"dataDump":         actions.DataDump,
+		"dataLoad":         actions.DataLoad,
+		"reconfigure":      actions.Reconfigure,
+		"accountProvision": actions.AccountProvision,
+	} {
+		if err := validate(name, action); err != nil {
+			return err
+		}
+	}
+	for name, probe := range map[string]*appsv1.Probe{
+		"roleProbe":      actions.RoleProbe,
+		"availableProbe": actions.AvailableProbe,
+	} {
+		if probe != nil {
+			if err := validate(name, &probe.Action); err != nil {
+				return err
+			}
+		}
+	}
 	return nil
 }
 
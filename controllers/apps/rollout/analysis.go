@@ -0,0 +1,175 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package rollout
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/controller/analysis"
+	"github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+const (
+	reasonRolloutAnalysisSuccessful = "AnalysisSuccessful"
+	reasonRolloutAnalysisFailed     = "AnalysisFailed"
+)
+
+// analyze performs the analysis run for a step of the component, it returns nil only when the analysis is successful.
+func analyze(transCtx *rolloutTransformContext, rollout *appsv1alpha1.Rollout,
+	compName, runName string, spec *appsv1alpha1.RolloutAnalysis) error {
+	run := analysisRun(rollout, compName, runName)
+	if run == nil {
+		return fmt.Errorf("the status of component %s is not found", compName)
+	}
+	switch run.Phase {
+	case appsv1alpha1.SuccessfulRolloutAnalysisPhase:
+		return nil
+	case appsv1alpha1.FailedRolloutAnalysisPhase:
+		return controllerutil.NewDelayedRequeueError(infiniteDelayRequeueDuration,
+			fmt.Sprintf("the analysis %s of component %s is failed", runName, compName))
+	}
+
+	interval := time.Duration(ptr.Deref(spec.IntervalSeconds, 30)) * time.Second
+	next := run.StartTimestamp.Add(time.Duration(ptr.Deref(spec.InitialDelaySeconds, 0)) * time.Second)
+	if !run.LastMeasurementTimestamp.IsZero() {
+		next = run.LastMeasurementTimestamp.Add(interval)
+	}
+	if diff := time.Until(next); diff > 0 {
+		return controllerutil.NewDelayedRequeueError(diff, fmt.Sprintf("wait %s for the next measurement of analysis %s", diff.String(), runName))
+	}
+
+	measure(transCtx, run, spec)
+
+	switch {
+	case run.Failed > ptr.Deref(spec.FailureLimit, 0):
+		completeAnalysisRun(transCtx, rollout, compName, run, appsv1alpha1.FailedRolloutAnalysisPhase)
+		return controllerutil.NewDelayedRequeueError(infiniteDelayRequeueDuration,
+			fmt.Sprintf("the analysis %s of component %s is failed", runName, compName))
+	case run.Successful+run.Failed >= ptr.Deref(spec.Count, 3):
+		completeAnalysisRun(transCtx, rollout, compName, run, appsv1alpha1.SuccessfulRolloutAnalysisPhase)
+		return nil
+	default:
+		return controllerutil.NewDelayedRequeueError(interval, fmt.Sprintf("the analysis %s of component %s is running", runName, compName))
+	}
+}
+
+// analysisRun returns the analysis run with the given name in the component status, it will be created if not exists.
+func analysisRun(rollout *appsv1alpha1.Rollout, compName, runName string) *appsv1alpha1.RolloutAnalysisRun {
+	for i, status := range rollout.Status.Components {
+		if status.Name != compName {
+			continue
+		}
+		for j, run := range status.AnalysisRuns {
+			if run.Name == runName {
+				return &rollout.Status.Components[i].AnalysisRuns[j]
+			}
+		}
+		rollout.Status.Components[i].AnalysisRuns = append(rollout.Status.Components[i].AnalysisRuns, appsv1alpha1.RolloutAnalysisRun{
+			Name:           runName,
+			Phase:          appsv1alpha1.RunningRolloutAnalysisPhase,
+			StartTimestamp: metav1.Now(),
+		})
+		runs := rollout.Status.Components[i].AnalysisRuns
+		return &runs[len(runs)-1]
+	}
+	return nil
+}
+
+// measure takes a measurement of all the metrics, the measurement is successful only when all the metrics meet their thresholds.
+func measure(transCtx *rolloutTransformContext, run *appsv1alpha1.RolloutAnalysisRun, spec *appsv1alpha1.RolloutAnalysis) {
+	run.LastMeasurementTimestamp = metav1.Now()
+	run.Values = map[string]string{}
+
+	failed := func(message string) {
+		run.Failed++
+		run.Message = message
+	}
+
+	cli, err := analysis.NewClient(spec.Provider)
+	if err != nil {
+		failed(fmt.Sprintf("failed to create the metrics client: %s", err.Error()))
+		return
+	}
+	var messages []string
+	for _, metric := range spec.Metrics {
+		value, err := cli.Query(transCtx.Context, metric.Query)
+		if err != nil {
+			messages = append(messages, fmt.Sprintf("failed to query metric %s: %s", metric.Name, err.Error()))
+			continue
+		}
+		run.Values[metric.Name] = strconv.FormatFloat(value, 'f', -1, 64)
+		if !analysis.Evaluate(metric, value) {
+			messages = append(messages, fmt.Sprintf("the value of metric %s does not meet the thresholds", metric.Name))
+		}
+	}
+	if len(messages) > 0 {
+		failed(strings.Join(messages, "; "))
+		return
+	}
+	run.Successful++
+	run.Message = ""
+}
+
+func completeAnalysisRun(transCtx *rolloutTransformContext, rollout *appsv1alpha1.Rollout,
+	compName string, run *appsv1alpha1.RolloutAnalysisRun, phase appsv1alpha1.RolloutAnalysisPhase) {
+	run.Phase = phase
+	run.CompletionTimestamp = metav1.Now()
+
+	cond := metav1.Condition{
+		Type:               appsv1alpha1.ConditionTypeRolloutAnalysis,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: rollout.Generation,
+		Reason:             reasonRolloutAnalysisSuccessful,
+		Message:            fmt.Sprintf("the analysis %s of component %s is successful", run.Name, compName),
+	}
+	eventType := corev1.EventTypeNormal
+	if phase == appsv1alpha1.FailedRolloutAnalysisPhase {
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = reasonRolloutAnalysisFailed
+		cond.Message = fmt.Sprintf("the analysis %s of component %s is failed: %s", run.Name, compName, run.Message)
+		eventType = corev1.EventTypeWarning
+	}
+	meta.SetStatusCondition(&rollout.Status.Conditions, cond)
+	transCtx.EventRecorder.Event(rollout, eventType, cond.Reason, cond.Message)
+}
+
+// failedAnalysisRun returns the name of the failed analysis run of the component, if any.
+func failedAnalysisRun(rollout *appsv1alpha1.Rollout, compName string) (string, bool) {
+	for _, status := range rollout.Status.Components {
+		if status.Name != compName {
+			continue
+		}
+		for _, run := range status.AnalysisRuns {
+			if run.Phase == appsv1alpha1.FailedRolloutAnalysisPhase {
+				return run.Name, true
+			}
+		}
+	}
+	return "", false
+}
@@ -20,6 +20,7 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package rollout
 
 import (
+	"context"
 	"fmt"
 	"slices"
 	"strings"
@@ -28,16 +29,28 @@ import (
 	. "github.com/onsi/gomega"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/analysis"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/generics"
 	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
 )
 
+type fakeAnalysisClient struct {
+	value float64
+}
+
+func (c *fakeAnalysisClient) Query(ctx context.Context, query string) (float64, error) {
+	return c.value, nil
+}
+
 var _ = Describe("rollout controller", func() {
 	const (
 		compDefName     = "test-compdef"
@@ -504,6 +517,310 @@ var _ = Describe("rollout controller", func() {
 		})
 	})
 
+	Context("wave", func() {
+		const (
+			compName2 = "comp2"
+		)
+
+		var (
+			compKey2 client.ObjectKey
+		)
+
+		BeforeEach(func() {
+			By("creating a cluster object with two components")
+			clusterObj = testapps.NewClusterFactory(testCtx.DefaultNamespace, clusterName, "").
+				WithRandomName().
+				AddComponent(compName, compDefName).
+				SetServiceVersion(serviceVersion1).
+				SetReplicas(replicas).
+				AddComponent(compName2, compDefName).
+				SetServiceVersion(serviceVersion1).
+				SetReplicas(replicas).
+				Create(&testCtx).
+				GetObject()
+			clusterKey = client.ObjectKeyFromObject(clusterObj)
+
+			By("creating the component objects")
+			for _, name := range []string{compName, compName2} {
+				comp := testapps.NewComponentFactory(testCtx.DefaultNamespace, constant.GenerateClusterComponentName(clusterKey.Name, name), compDefName).
+					AddLabelsInMap(constant.GetCompLabelsWithDef(clusterKey.Name, name, compDefName)).
+					SetReplicas(replicas).
+					Create(&testCtx).
+					GetObject()
+				if name == compName {
+					compKey = client.ObjectKeyFromObject(comp)
+				} else {
+					compKey2 = client.ObjectKeyFromObject(comp)
+				}
+			}
+		})
+
+		mockRunning := func() {
+			By("mock cluster & components as running")
+			for _, key := range []client.ObjectKey{compKey, compKey2} {
+				Expect(testapps.GetAndChangeObjStatus(&testCtx, key, func(comp *appsv1.Component) {
+					comp.Status.ObservedGeneration = comp.Generation
+					comp.Status.Phase = appsv1.RunningComponentPhase
+				})()).Should(Succeed())
+			}
+			Expect(testapps.GetAndChangeObjStatus(&testCtx, clusterKey, func(cluster *appsv1.Cluster) {
+				cluster.Status.ObservedGeneration = cluster.Generation
+				cluster.Status.Components = map[string]appsv1.ClusterComponentStatus{
+					compName:  {Phase: appsv1.RunningComponentPhase},
+					compName2: {Phase: appsv1.RunningComponentPhase},
+				}
+			})()).Should(Succeed())
+		}
+
+		It("ordered waves", func() {
+			createRolloutObj(func(f *testapps.MockRolloutFactory) {
+				f.SetServiceVersion(serviceVersion2).
+					SetInplaceStrategy().
+					SetWave(1).
+					AddComponent(compName2).
+					SetServiceVersion(serviceVersion2).
+					SetInplaceStrategy().
+					SetWave(0)
+			})
+
+			mockRunning()
+
+			By("checking the component in the first wave been updated only")
+			Eventually(testapps.CheckObj(&testCtx, clusterKey, func(g Gomega, cluster *appsv1.Cluster) {
+				g.Expect(cluster.Spec.ComponentSpecs[0].ServiceVersion).Should(Equal(serviceVersion1))
+				g.Expect(cluster.Spec.ComponentSpecs[1].ServiceVersion).Should(Equal(serviceVersion2))
+			})).Should(Succeed())
+			Consistently(testapps.CheckObj(&testCtx, clusterKey, func(g Gomega, cluster *appsv1.Cluster) {
+				g.Expect(cluster.Spec.ComponentSpecs[0].ServiceVersion).Should(Equal(serviceVersion1))
+			})).Should(Succeed())
+
+			mockRunning()
+
+			By("checking the component in the first wave succeed")
+			Eventually(testapps.CheckObj(&testCtx, rolloutKey, func(g Gomega, rollout *appsv1alpha1.Rollout) {
+				g.Expect(rollout.Status.State).Should(Equal(appsv1alpha1.RollingRolloutState))
+				g.Expect(rollout.Status.Components).Should(HaveLen(2))
+				g.Expect(rollout.Status.Components[1].State).Should(Equal(appsv1alpha1.SucceedRolloutState))
+			})).Should(Succeed())
+
+			By("checking the component in the second wave been updated")
+			Eventually(testapps.CheckObj(&testCtx, clusterKey, func(g Gomega, cluster *appsv1.Cluster) {
+				g.Expect(cluster.Spec.ComponentSpecs[0].ServiceVersion).Should(Equal(serviceVersion2))
+			})).Should(Succeed())
+
+			mockRunning()
+
+			By("checking the rollout state as succeed")
+			Eventually(testapps.CheckObj(&testCtx, rolloutKey, func(g Gomega, rollout *appsv1alpha1.Rollout) {
+				g.Expect(rollout.Status.State).Should(Equal(appsv1alpha1.SucceedRolloutState))
+			})).Should(Succeed())
+		})
+	})
+
+	Context("pause & abort", func() {
+		BeforeEach(func() {
+			createClusterNCompObj()
+		})
+
+		It("paused", func() {
+			createRolloutObj(func(f *testapps.MockRolloutFactory) {
+				f.SetPaused(true).
+					SetServiceVersion(serviceVersion2).
+					SetStrategy(appsv1alpha1.RolloutStrategy{
+						Replace: &appsv1alpha1.RolloutStrategyReplace{},
+					}).
+					SetReplicas(replicas)
+			})
+
+			mockClusterNCompRunning()
+
+			By("checking the rollout state as paused")
+			Eventually(testapps.CheckObj(&testCtx, rolloutKey, func(g Gomega, rollout *appsv1alpha1.Rollout) {
+				g.Expect(rollout.Status.State).Should(Equal(appsv1alpha1.PausedRolloutState))
+				g.Expect(meta.IsStatusConditionTrue(rollout.Status.Conditions, appsv1alpha1.ConditionTypeRolloutPaused)).Should(BeTrue())
+			})).Should(Succeed())
+
+			By("checking the cluster spec not been updated")
+			Consistently(testapps.CheckObj(&testCtx, clusterKey, func(g Gomega, cluster *appsv1.Cluster) {
+				spec := cluster.Spec.ComponentSpecs[0]
+				g.Expect(spec.Replicas).Should(Equal(replicas))
+				g.Expect(spec.Instances).Should(BeEmpty())
+			})).Should(Succeed())
+
+			By("resuming the rollout")
+			Expect(testapps.GetAndChangeObj(&testCtx, rolloutKey, func(rollout *appsv1alpha1.Rollout) {
+				rollout.Spec.Paused = ptr.To(false)
+			})()).Should(Succeed())
+
+			By("checking the cluster spec been updated")
+			Eventually(testapps.CheckObj(&testCtx, clusterKey, func(g Gomega, cluster *appsv1.Cluster) {
+				spec := cluster.Spec.ComponentSpecs[0]
+				g.Expect(spec.Replicas).Should(Equal(replicas + 1))
+				g.Expect(spec.Instances).Should(HaveLen(1))
+			})).Should(Succeed())
+
+			By("checking the rollout state as rolling")
+			Eventually(testapps.CheckObj(&testCtx, rolloutKey, func(g Gomega, rollout *appsv1alpha1.Rollout) {
+				g.Expect(rollout.Status.State).Should(Equal(appsv1alpha1.RollingRolloutState))
+				g.Expect(meta.IsStatusConditionFalse(rollout.Status.Conditions, appsv1alpha1.ConditionTypeRolloutPaused)).Should(BeTrue())
+			})).Should(Succeed())
+		})
+
+		It("abort", func() {
+			createRolloutObj(func(f *testapps.MockRolloutFactory) {
+				f.SetServiceVersion(serviceVersion2).
+					SetStrategy(appsv1alpha1.RolloutStrategy{
+						Replace: &appsv1alpha1.RolloutStrategyReplace{},
+					}).
+					SetReplicas(replicas)
+			})
+
+			By("creating pods for the component")
+			mockCreatePods([]int32{0, 1, 2}, "")
+
+			mockClusterNCompRunning() // to up
+
+			By("checking the cluster spec after roll up")
+			Eventually(testapps.CheckObj(&testCtx, clusterKey, func(g Gomega, cluster *appsv1.Cluster) {
+				spec := cluster.Spec.ComponentSpecs[0]
+				g.Expect(spec.Replicas).Should(Equal(replicas + 1))
+				g.Expect(spec.Instances).Should(HaveLen(1))
+			})).Should(Succeed())
+
+			By("aborting the rollout")
+			Expect(testapps.GetAndChangeObj(&testCtx, rolloutKey, func(rollout *appsv1alpha1.Rollout) {
+				rollout.Spec.Abort = ptr.To(true)
+			})()).Should(Succeed())
+
+			By("checking the cluster spec been rolled back")
+			Eventually(testapps.CheckObj(&testCtx, clusterKey, func(g Gomega, cluster *appsv1.Cluster) {
+				spec := cluster.Spec.ComponentSpecs[0]
+				g.Expect(spec.Replicas).Should(Equal(replicas))
+				g.Expect(spec.ServiceVersion).Should(Equal(serviceVersion1))
+				g.Expect(spec.Instances).Should(BeEmpty())
+			})).Should(Succeed())
+
+			mockClusterNCompRunning()
+
+			By("checking the rollout state as rolled back")
+			Eventually(testapps.CheckObj(&testCtx, rolloutKey, func(g Gomega, rollout *appsv1alpha1.Rollout) {
+				g.Expect(rollout.Status.State).Should(Equal(appsv1alpha1.RolledBackRolloutState))
+				g.Expect(meta.IsStatusConditionTrue(rollout.Status.Conditions, appsv1alpha1.ConditionTypeRolloutRolledBack)).Should(BeTrue())
+			})).Should(Succeed())
+		})
+	})
+
+	Context("analysis", func() {
+		var (
+			metricValue float64
+		)
+
+		BeforeEach(func() {
+			analysis.SetClientFactory(func(appsv1alpha1.RolloutAnalysisProvider) (analysis.Client, error) {
+				return &fakeAnalysisClient{value: metricValue}, nil
+			})
+			createClusterNCompObj()
+		})
+
+		AfterEach(func() {
+			analysis.SetClientFactory(nil)
+		})
+
+		createRolloutWithAnalysis := func() {
+			createRolloutObj(func(f *testapps.MockRolloutFactory) {
+				f.SetServiceVersion(serviceVersion2).
+					SetStrategy(appsv1alpha1.RolloutStrategy{
+						Replace: &appsv1alpha1.RolloutStrategyReplace{
+							Analysis: &appsv1alpha1.RolloutAnalysis{
+								Provider: appsv1alpha1.RolloutAnalysisProvider{
+									Prometheus: &appsv1alpha1.PrometheusAnalysisProvider{Address: "http://prometheus:9090"},
+								},
+								Metrics: []appsv1alpha1.RolloutAnalysisMetric{
+									{
+										Name:  "success-rate",
+										Query: "success_rate",
+										Min:   ptr.To(resource.MustParse("0.95")),
+									},
+								},
+								IntervalSeconds: ptr.To[int32](1),
+								Count:           ptr.To[int32](1),
+								FailureLimit:    ptr.To[int32](0),
+							},
+						},
+					}).
+					SetReplicas(replicas)
+			})
+		}
+
+		It("successful", func() {
+			metricValue = 0.99
+			createRolloutWithAnalysis()
+
+			By("creating pods for the component")
+			pods := mockCreatePods([]int32{0, 1, 2}, "")
+
+			mockClusterNCompRunning() // to up
+
+			By("checking the cluster spec after roll up")
+			Eventually(testapps.CheckObj(&testCtx, clusterKey, func(g Gomega, cluster *appsv1.Cluster) {
+				g.Expect(cluster.Spec.ComponentSpecs[0].Replicas).Should(Equal(replicas + 1))
+			})).Should(Succeed())
+
+			By("creating the new pod")
+			mockCreatePods([]int32{10}, string(rolloutObj.UID[:8]))
+
+			mockClusterNCompRunning() // to down
+
+			By("checking the analysis run is successful")
+			Eventually(testapps.CheckObj(&testCtx, rolloutKey, func(g Gomega, rollout *appsv1alpha1.Rollout) {
+				g.Expect(rollout.Status.Components).Should(HaveLen(1))
+				g.Expect(rollout.Status.Components[0].AnalysisRuns).Should(HaveLen(1))
+				run := rollout.Status.Components[0].AnalysisRuns[0]
+				g.Expect(run.Name).Should(Equal("replace-1"))
+				g.Expect(run.Phase).Should(Equal(appsv1alpha1.SuccessfulRolloutAnalysisPhase))
+				g.Expect(run.Successful).Should(Equal(int32(1)))
+				g.Expect(run.Values).Should(HaveKeyWithValue("success-rate", "0.99"))
+			})).Should(Succeed())
+
+			By("checking the cluster spec after scale down")
+			Eventually(testapps.CheckObj(&testCtx, clusterKey, func(g Gomega, cluster *appsv1.Cluster) {
+				spec := cluster.Spec.ComponentSpecs[0]
+				g.Expect(spec.Replicas).Should(Equal(replicas))
+				g.Expect(spec.OfflineInstances).Should(HaveLen(1))
+				g.Expect(spec.OfflineInstances[0]).Should(Equal(pods[0].Name))
+			})).Should(Succeed())
+		})
+
+		It("failed", func() {
+			metricValue = 0.5
+			createRolloutWithAnalysis()
+
+			By("creating pods for the component")
+			mockCreatePods([]int32{0, 1, 2}, "")
+
+			mockClusterNCompRunning() // to up
+
+			By("creating the new pod")
+			mockCreatePods([]int32{10}, string(rolloutObj.UID[:8]))
+
+			mockClusterNCompRunning() // to down
+
+			By("checking the rollout state as error")
+			Eventually(testapps.CheckObj(&testCtx, rolloutKey, func(g Gomega, rollout *appsv1alpha1.Rollout) {
+				g.Expect(rollout.Status.State).Should(Equal(appsv1alpha1.ErrorRolloutState))
+				g.Expect(rollout.Status.Components[0].AnalysisRuns[0].Phase).Should(Equal(appsv1alpha1.FailedRolloutAnalysisPhase))
+				g.Expect(meta.IsStatusConditionFalse(rollout.Status.Conditions, appsv1alpha1.ConditionTypeRolloutAnalysis)).Should(BeTrue())
+			})).Should(Succeed())
+
+			By("checking the old instance is not scaled down")
+			Consistently(testapps.CheckObj(&testCtx, clusterKey, func(g Gomega, cluster *appsv1.Cluster) {
+				spec := cluster.Spec.ComponentSpecs[0]
+				g.Expect(spec.Replicas).Should(Equal(replicas + 1))
+				g.Expect(spec.OfflineInstances).Should(BeEmpty())
+			})).Should(Succeed())
+		})
+	})
+
 	// Context("create", func() {
 	//	It("auto promotion", func() {
 	//	})
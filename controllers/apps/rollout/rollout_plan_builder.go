@@ -66,12 +66,17 @@ type rolloutTransformContext struct {
 	ClusterComps     map[string]*appsv1.ClusterComponentSpec
 	ClusterShardings map[string]*appsv1.ClusterSharding
 	Components       map[string]*appsv1.Component
+	ShardingComps    map[string][]*appsv1.Component
 }
 
 func (c *rolloutTransformContext) GetContext() context.Context {
 	return c.Context
 }
 
+func (c *rolloutTransformContext) SetContext(ctx context.Context) {
+	c.Context = ctx
+}
+
 func (c *rolloutTransformContext) GetClient() client.Reader {
 	return c.Client
 }
@@ -100,6 +105,7 @@ type rolloutPlan struct {
 }
 
 var _ graph.TransformContext = &rolloutTransformContext{}
+var _ graph.ContextSetter = &rolloutTransformContext{}
 var _ graph.PlanBuilder = &rolloutPlanBuilder{}
 var _ graph.Plan = &rolloutPlan{}
 
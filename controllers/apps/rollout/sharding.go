@@ -0,0 +1,131 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package rollout
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+// rolloutShardTemplateName returns the name of the shard template that holds the rolled out shards.
+func rolloutShardTemplateName(rollout *appsv1alpha1.Rollout) string {
+	return string(rollout.UID[:8])
+}
+
+func rolloutShardTemplate(rollout *appsv1alpha1.Rollout, spec *appsv1.ClusterSharding) *appsv1.ShardTemplate {
+	name := rolloutShardTemplateName(rollout)
+	for i, tpl := range spec.ShardTemplates {
+		if tpl.Name == name {
+			return &spec.ShardTemplates[i]
+		}
+	}
+	return nil
+}
+
+func removeRolloutShardTemplate(rollout *appsv1alpha1.Rollout, spec *appsv1.ClusterSharding) {
+	name := rolloutShardTemplateName(rollout)
+	spec.ShardTemplates = slices.DeleteFunc(spec.ShardTemplates, func(tpl appsv1.ShardTemplate) bool {
+		return tpl.Name == name
+	})
+	if len(spec.ShardTemplates) == 0 {
+		spec.ShardTemplates = nil
+	}
+}
+
+func rolloutShardingStatus(rollout *appsv1alpha1.Rollout, shardingName string) *appsv1alpha1.RolloutShardingStatus {
+	for i, status := range rollout.Status.Shardings {
+		if status.Name == shardingName {
+			return &rollout.Status.Shardings[i]
+		}
+	}
+	return nil
+}
+
+// shardingTarget returns the target service version and component definition of the sharding.
+func shardingTarget(sharding appsv1alpha1.RolloutSharding, status *appsv1alpha1.RolloutShardingStatus) (string, string) {
+	return ptr.Deref(sharding.ServiceVersion, status.ServiceVersion), ptr.Deref(sharding.CompDef, status.CompDef)
+}
+
+func shardID(clusterName, shardingName string, comp *appsv1.Component) string {
+	id, _ := strings.CutPrefix(comp.Name, fmt.Sprintf("%s-%s-", clusterName, shardingName))
+	return id
+}
+
+// isShardRolledOut checks whether the shard has been moved to the rollout shard template and become running.
+func isShardRolledOut(rollout *appsv1alpha1.Rollout, comp *appsv1.Component) bool {
+	if comp.Labels == nil || comp.Labels[constant.KBAppShardTemplateLabelKey] != rolloutShardTemplateName(rollout) {
+		return false
+	}
+	return comp.Generation == comp.Status.ObservedGeneration && comp.Status.Phase == appsv1.RunningComponentPhase
+}
+
+func shardingMaxUnavailable(sharding appsv1alpha1.RolloutSharding, shards int32) (int32, error) {
+	maxUnavailable := int32(1)
+	if sharding.MaxUnavailable != nil {
+		value, err := intstr.GetScaledValueFromIntOrPercent(sharding.MaxUnavailable, int(shards), false)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get scaled value for maxUnavailable of sharding %s: %w", sharding.Name, err)
+		}
+		maxUnavailable = max(int32(value), 1)
+	}
+	return maxUnavailable, nil
+}
+
+// classifyShards splits the shards of the rollout shard template into the rolled out and rolling ones.
+func classifyShards(transCtx *rolloutTransformContext, shardingName string, tpl *appsv1.ShardTemplate) ([]string, []string) {
+	rolledOut, rolling := make([]string, 0), make([]string, 0)
+	if tpl == nil {
+		return rolledOut, rolling
+	}
+	clusterObserved := transCtx.ClusterOrig.Generation == transCtx.ClusterOrig.Status.ObservedGeneration
+	for _, comp := range transCtx.ShardingComps[shardingName] {
+		id := shardID(transCtx.Cluster.Name, shardingName, comp)
+		if !slices.Contains(tpl.ShardIDs, id) {
+			continue
+		}
+		if clusterObserved && isShardRolledOut(transCtx.Rollout, comp) {
+			rolledOut = append(rolledOut, id)
+		} else {
+			rolling = append(rolling, id)
+		}
+	}
+	return rolledOut, rolling
+}
+
+func rollbackSharding(rollout *appsv1alpha1.Rollout, spec *appsv1.ClusterSharding, status appsv1alpha1.RolloutShardingStatus) {
+	spec.Template.ServiceVersion = status.ServiceVersion
+	spec.Template.ComponentDef = status.CompDef
+	removeRolloutShardTemplate(rollout, spec)
+}
+
+// isRolloutShardingRolledBack checks whether the sharding spec has been restored to the one recorded in the status.
+func isRolloutShardingRolledBack(rollout *appsv1alpha1.Rollout, spec *appsv1.ClusterSharding, status appsv1alpha1.RolloutShardingStatus) bool {
+	return spec.Template.ServiceVersion == status.ServiceVersion &&
+		spec.Template.ComponentDef == status.CompDef &&
+		rolloutShardTemplate(rollout, spec) == nil
+}
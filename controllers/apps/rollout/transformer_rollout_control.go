@@ -0,0 +1,226 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package rollout
+
+import (
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+)
+
+const (
+	reasonRolloutPaused          = "Paused"
+	reasonRolloutResumed         = "Resumed"
+	reasonRolloutAborted         = "Aborted"
+	reasonRolloutComponentFailed = "ComponentFailed"
+	reasonRolloutRolledBack      = "RolledBack"
+)
+
+// rolloutControlTransformer handles the pause, abort and rollback controls of the rollout.
+type rolloutControlTransformer struct{}
+
+var _ graph.Transformer = &rolloutControlTransformer{}
+
+func (t *rolloutControlTransformer) Transform(ctx graph.TransformContext, dag *graph.DAG) error {
+	transCtx := ctx.(*rolloutTransformContext)
+	if model.IsObjectDeleting(transCtx.RolloutOrig) || isRolloutTerminated(transCtx.RolloutOrig) {
+		return nil
+	}
+
+	rollout := transCtx.Rollout
+	if !isRolloutRollingBack(rollout) {
+		if reason, message, ok := t.checkRollback(transCtx, rollout); ok {
+			t.startRollback(transCtx, rollout, reason, message)
+		}
+	}
+	if isRolloutRollingBack(rollout) {
+		return t.rollback(transCtx, rollout)
+	}
+	t.pause(transCtx, rollout)
+	return nil
+}
+
+func (t *rolloutControlTransformer) checkRollback(transCtx *rolloutTransformContext, rollout *appsv1alpha1.Rollout) (string, string, bool) {
+	if ptr.Deref(rollout.Spec.Abort, false) {
+		return reasonRolloutAborted, "the rollout is aborted", true
+	}
+	if ptr.Deref(rollout.Spec.AutoRollback, false) {
+		for _, sharding := range rollout.Spec.Shardings {
+			if checkShardingFailed(transCtx, sharding.Name) {
+				return reasonRolloutComponentFailed, fmt.Sprintf("the sharding %s is failed", sharding.Name), true
+			}
+		}
+		for _, comp := range rollout.Spec.Components {
+			if checkClusterNCompFailed(transCtx, comp.Name) {
+				return reasonRolloutComponentFailed, fmt.Sprintf("the component %s is failed", comp.Name), true
+			}
+			if runName, ok := failedAnalysisRun(rollout, comp.Name); ok {
+				return reasonRolloutAnalysisFailed, fmt.Sprintf("the analysis %s of component %s is failed", runName, comp.Name), true
+			}
+		}
+	}
+	return "", "", false
+}
+
+func (t *rolloutControlTransformer) startRollback(transCtx *rolloutTransformContext, rollout *appsv1alpha1.Rollout, reason, message string) {
+	rollout.Status.State = appsv1alpha1.RollingBackRolloutState
+	rollout.Status.Message = fmt.Sprintf("rolling back: %s", message)
+	meta.SetStatusCondition(&rollout.Status.Conditions, metav1.Condition{
+		Type:               appsv1alpha1.ConditionTypeRolloutRolledBack,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: rollout.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+	transCtx.EventRecorder.Event(rollout, corev1.EventTypeWarning, reason, fmt.Sprintf("start to roll back, %s", message))
+}
+
+// rollback restores the components and shardings to the ones recorded in the status, and removes the new instances.
+func (t *rolloutControlTransformer) rollback(transCtx *rolloutTransformContext, rollout *appsv1alpha1.Rollout) error {
+	for _, comp := range rollout.Spec.Components {
+		spec := transCtx.ClusterComps[comp.Name]
+		if spec == nil {
+			return fmt.Errorf("the component %s is not found in cluster", comp.Name)
+		}
+		for _, status := range rollout.Status.Components {
+			if status.Name == comp.Name {
+				rollbackComponent(rollout, spec, status)
+				break
+			}
+		}
+	}
+	for _, status := range rollout.Status.Shardings {
+		spec := transCtx.ClusterShardings[status.Name]
+		if spec == nil {
+			return fmt.Errorf("the sharding %s is not found in cluster", status.Name)
+		}
+		rollbackSharding(rollout, spec, status)
+	}
+	return nil
+}
+
+func (t *rolloutControlTransformer) pause(transCtx *rolloutTransformContext, rollout *appsv1alpha1.Rollout) {
+	paused := ptr.Deref(rollout.Spec.Paused, false)
+	if !paused && !meta.IsStatusConditionTrue(rollout.Status.Conditions, appsv1alpha1.ConditionTypeRolloutPaused) {
+		return
+	}
+	cond := metav1.Condition{
+		Type:               appsv1alpha1.ConditionTypeRolloutPaused,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: rollout.Generation,
+		Reason:             reasonRolloutPaused,
+		Message:            "the rollout is paused",
+	}
+	if !paused {
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = reasonRolloutResumed
+		cond.Message = "the rollout is resumed"
+	}
+	if meta.SetStatusCondition(&rollout.Status.Conditions, cond) {
+		transCtx.EventRecorder.Event(rollout, corev1.EventTypeNormal, cond.Reason, cond.Message)
+	}
+}
+
+func rollbackComponent(rollout *appsv1alpha1.Rollout, spec *appsv1.ClusterComponentSpec, status appsv1alpha1.RolloutComponentStatus) {
+	tplName := string(rollout.UID[:8])
+	spec.ServiceVersion = status.ServiceVersion
+	spec.ComponentDef = status.CompDef
+	spec.Replicas = status.Replicas
+	spec.Instances = slices.DeleteFunc(spec.Instances, func(tpl appsv1.InstanceTemplate) bool {
+		return tpl.Name == tplName
+	})
+	for i, tpl := range spec.Instances {
+		if replicas, ok := status.InstanceReplicas[tpl.Name]; ok {
+			spec.Instances[i].Replicas = ptr.To(replicas)
+		}
+	}
+	spec.OfflineInstances = slices.DeleteFunc(spec.OfflineInstances, func(instance string) bool {
+		return slices.Contains(status.ScaleDownInstances, instance)
+	})
+}
+
+// isRolloutComponentRolledBack checks whether the component spec has been restored to the one recorded in the status.
+func isRolloutComponentRolledBack(rollout *appsv1alpha1.Rollout, spec *appsv1.ClusterComponentSpec, status appsv1alpha1.RolloutComponentStatus) bool {
+	expected := spec.DeepCopy()
+	rollbackComponent(rollout, expected, status)
+	return spec.ServiceVersion == expected.ServiceVersion &&
+		spec.ComponentDef == expected.ComponentDef &&
+		spec.Replicas == expected.Replicas &&
+		slices.EqualFunc(spec.Instances, expected.Instances, func(a, b appsv1.InstanceTemplate) bool {
+			return a.Name == b.Name && ptr.Equal(a.Replicas, b.Replicas)
+		}) &&
+		slices.Equal(spec.OfflineInstances, expected.OfflineInstances)
+}
+
+func isRolloutTerminated(rollout *appsv1alpha1.Rollout) bool {
+	return isRolloutSucceed(rollout) || rollout.Status.State == appsv1alpha1.RolledBackRolloutState
+}
+
+func isRolloutRollingBack(rollout *appsv1alpha1.Rollout) bool {
+	return rollout.Status.State == appsv1alpha1.RollingBackRolloutState
+}
+
+// isRolloutHalted checks whether the rollout should stop rolling out new instances.
+func isRolloutHalted(rollout *appsv1alpha1.Rollout) bool {
+	return ptr.Deref(rollout.Spec.Paused, false) || isRolloutRollingBack(rollout) || isRolloutAnalysisFailed(rollout)
+}
+
+func isRolloutAnalysisFailed(rollout *appsv1alpha1.Rollout) bool {
+	for _, comp := range rollout.Spec.Components {
+		if _, ok := failedAnalysisRun(rollout, comp.Name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// isRolloutWaveReady checks whether all the targets in the previous waves have been rolled out successfully.
+func isRolloutWaveReady(rollout *appsv1alpha1.Rollout, wave int32) bool {
+	compState := func(compName string) appsv1alpha1.RolloutState {
+		for _, status := range rollout.Status.Components {
+			if status.Name == compName {
+				return status.State
+			}
+		}
+		return ""
+	}
+	for _, comp := range rollout.Spec.Components {
+		if comp.Wave < wave && compState(comp.Name) != appsv1alpha1.SucceedRolloutState {
+			return false
+		}
+	}
+	for _, sharding := range rollout.Spec.Shardings {
+		if sharding.Wave < wave {
+			if status := rolloutShardingStatus(rollout, sharding.Name); status == nil || status.State != appsv1alpha1.SucceedRolloutState {
+				return false
+			}
+		}
+	}
+	return true
+}
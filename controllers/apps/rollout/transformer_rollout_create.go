@@ -39,7 +39,7 @@ var _ graph.Transformer = &rolloutCreateTransformer{}
 
 func (t *rolloutCreateTransformer) Transform(ctx graph.TransformContext, dag *graph.DAG) error {
 	transCtx := ctx.(*rolloutTransformContext)
-	if model.IsObjectDeleting(transCtx.RolloutOrig) || isRolloutSucceed(transCtx.RolloutOrig) {
+	if model.IsObjectDeleting(transCtx.RolloutOrig) || isRolloutTerminated(transCtx.RolloutOrig) || isRolloutHalted(transCtx.Rollout) {
 		return nil
 	}
 	return t.rollout(transCtx)
@@ -56,7 +56,7 @@ func (t *rolloutCreateTransformer) rollout(transCtx *rolloutTransformContext) er
 func (t *rolloutCreateTransformer) components(transCtx *rolloutTransformContext) error {
 	rollout := transCtx.Rollout
 	for _, comp := range rollout.Spec.Components {
-		if comp.Strategy.Create != nil {
+		if comp.Strategy.Create != nil && isRolloutWaveReady(rollout, comp.Wave) {
 			if err := t.component(transCtx, rollout, comp); err != nil {
 				return err
 			}
@@ -165,6 +165,13 @@ func (t *rolloutCreateTransformer) promote(transCtx *rolloutTransformContext,
 		return nil
 	}
 
+	promotion := comp.Strategy.Create.Promotion
+	if promotion.Condition != nil && promotion.Condition.Analysis != nil {
+		if err := analyze(transCtx, transCtx.Rollout, comp.Name, "promote", promotion.Condition.Analysis); err != nil {
+			return err
+		}
+	}
+
 	// TODO: promote
 
 	return nil
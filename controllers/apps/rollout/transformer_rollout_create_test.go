@@ -0,0 +1,182 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package rollout
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/analysis"
+)
+
+func newCreatePromotionTestContext(t *testing.T) (*rolloutTransformContext, appsv1alpha1.RolloutComponent) {
+	const (
+		clusterName = "test-cluster"
+		compName    = "comp"
+	)
+	comp := appsv1alpha1.RolloutComponent{
+		Name:           compName,
+		ServiceVersion: ptr.To("1.0.2"),
+		Replicas:       ptr.To(intstr.FromInt32(3)),
+		Strategy: appsv1alpha1.RolloutStrategy{
+			Create: &appsv1alpha1.RolloutStrategyCreate{
+				Canary: ptr.To(true),
+				Promotion: &appsv1alpha1.RolloutPromotion{
+					Auto: ptr.To(true),
+					Condition: &appsv1alpha1.RolloutPromoteCondition{
+						Analysis: &appsv1alpha1.RolloutAnalysis{
+							Provider: appsv1alpha1.RolloutAnalysisProvider{
+								Prometheus: &appsv1alpha1.PrometheusAnalysisProvider{Address: "http://prometheus:9090"},
+							},
+							Metrics: []appsv1alpha1.RolloutAnalysisMetric{
+								{Name: "error-rate", Query: "error_rate", Max: ptr.To(resource.MustParse("10m"))},
+							},
+							Count: ptr.To[int32](1),
+						},
+					},
+				},
+			},
+		},
+	}
+	rollout := &appsv1alpha1.Rollout{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "rollout", UID: "abcdefgh-uid"},
+		Spec: appsv1alpha1.RolloutSpec{
+			ClusterName: clusterName,
+			Components:  []appsv1alpha1.RolloutComponent{comp},
+		},
+		Status: appsv1alpha1.RolloutStatus{
+			Components: []appsv1alpha1.RolloutComponentStatus{
+				{
+					Name:                 compName,
+					ServiceVersion:       "1.0.1",
+					Replicas:             3,
+					NewReplicas:          3,
+					LastScaleUpTimestamp: metav1.NewTime(time.Now().Add(-time.Minute)),
+				},
+			},
+		},
+	}
+	cluster := &appsv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: clusterName, Generation: 1},
+		Spec: appsv1.ClusterSpec{
+			ComponentSpecs: []appsv1.ClusterComponentSpec{
+				{
+					Name:                compName,
+					ServiceVersion:      "1.0.1",
+					Replicas:            6,
+					FlatInstanceOrdinal: true,
+					Instances: []appsv1.InstanceTemplate{
+						{Name: "abcdefgh", ServiceVersion: "1.0.2", Canary: ptr.To(true), Replicas: ptr.To[int32](3)},
+					},
+				},
+			},
+		},
+		Status: appsv1.ClusterStatus{
+			ObservedGeneration: 1,
+			Components: map[string]appsv1.ClusterComponentStatus{
+				compName: {Phase: appsv1.RunningComponentPhase},
+			},
+		},
+	}
+
+	var objs []client.Object
+	for i := 0; i < 6; i++ {
+		labels := constant.GetCompLabels(clusterName, compName)
+		if i >= 3 {
+			labels[constant.KBAppInstanceTemplateLabelKey] = "abcdefgh"
+		}
+		objs = append(objs, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: fmt.Sprintf("%s-%s-%d", clusterName, compName, i), Labels: labels},
+		})
+	}
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	transCtx := &rolloutTransformContext{
+		Context:       context.Background(),
+		Client:        fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build(),
+		EventRecorder: record.NewFakeRecorder(10),
+		Logger:        logr.Discard(),
+		Rollout:       rollout,
+		RolloutOrig:   rollout.DeepCopy(),
+		Cluster:       cluster,
+		ClusterOrig:   cluster.DeepCopy(),
+		ClusterComps:  map[string]*appsv1.ClusterComponentSpec{compName: &cluster.Spec.ComponentSpecs[0]},
+		Components: map[string]*appsv1.Component{
+			compName: {Status: appsv1.ComponentStatus{Phase: appsv1.RunningComponentPhase}},
+		},
+	}
+	return transCtx, comp
+}
+
+func TestCreatePromotionAnalysis(t *testing.T) {
+	defer analysis.SetClientFactory(nil)
+
+	t.Run("failed analysis blocks promotion", func(t *testing.T) {
+		analysis.SetClientFactory(func(appsv1alpha1.RolloutAnalysisProvider) (analysis.Client, error) {
+			return &fakeAnalysisClient{value: 0.05}, nil
+		})
+		transCtx, comp := newCreatePromotionTestContext(t)
+
+		if err := (&rolloutCreateTransformer{}).component(transCtx, transCtx.Rollout, comp); err == nil {
+			t.Fatal("expect the promotion to be blocked")
+		}
+		if _, ok := failedAnalysisRun(transCtx.Rollout, comp.Name); !ok {
+			t.Errorf("expect the analysis run to be failed: %+v", transCtx.Rollout.Status.Components[0].AnalysisRuns)
+		}
+		if !isRolloutHalted(transCtx.Rollout) {
+			t.Error("expect the rollout to be halted")
+		}
+	})
+
+	t.Run("successful analysis passes the gate", func(t *testing.T) {
+		analysis.SetClientFactory(func(appsv1alpha1.RolloutAnalysisProvider) (analysis.Client, error) {
+			return &fakeAnalysisClient{value: 0.001}, nil
+		})
+		transCtx, comp := newCreatePromotionTestContext(t)
+
+		if err := (&rolloutCreateTransformer{}).component(transCtx, transCtx.Rollout, comp); err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := failedAnalysisRun(transCtx.Rollout, comp.Name); ok {
+			t.Errorf("expect the analysis run to be successful: %+v", transCtx.Rollout.Status.Components[0].AnalysisRuns)
+		}
+		if isRolloutHalted(transCtx.Rollout) {
+			t.Error("expect the rollout not to be halted")
+		}
+	})
+}
@@ -21,9 +21,11 @@ package rollout
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
 
 	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
@@ -38,7 +40,7 @@ var _ graph.Transformer = &rolloutInplaceTransformer{}
 
 func (t *rolloutInplaceTransformer) Transform(ctx graph.TransformContext, dag *graph.DAG) error {
 	transCtx := ctx.(*rolloutTransformContext)
-	if model.IsObjectDeleting(transCtx.RolloutOrig) || isRolloutSucceed(transCtx.RolloutOrig) {
+	if model.IsObjectDeleting(transCtx.RolloutOrig) || isRolloutTerminated(transCtx.RolloutOrig) || isRolloutHalted(transCtx.Rollout) {
 		return nil
 	}
 	return t.rollout(transCtx)
@@ -48,14 +50,13 @@ func (t *rolloutInplaceTransformer) rollout(transCtx *rolloutTransformContext) e
 	if err := t.components(transCtx); err != nil {
 		return err
 	}
-	// TODO: sharding
-	return nil
+	return t.shardings(transCtx)
 }
 
 func (t *rolloutInplaceTransformer) components(transCtx *rolloutTransformContext) error {
 	rollout := transCtx.Rollout
 	for _, comp := range rollout.Spec.Components {
-		if comp.Strategy.Inplace != nil {
+		if comp.Strategy.Inplace != nil && isRolloutWaveReady(rollout, comp.Wave) {
 			if err := t.component(transCtx, comp); err != nil {
 				return err
 			}
@@ -97,6 +98,75 @@ func (t *rolloutInplaceTransformer) component(transCtx *rolloutTransformContext,
 	return nil
 }
 
+func (t *rolloutInplaceTransformer) shardings(transCtx *rolloutTransformContext) error {
+	rollout := transCtx.Rollout
+	for _, sharding := range rollout.Spec.Shardings {
+		if sharding.Strategy.Inplace != nil && isRolloutWaveReady(rollout, sharding.Wave) {
+			if err := t.sharding(transCtx, rollout, sharding); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// sharding moves the shards to the rollout shard template batch by batch, each shard is updated in-place.
+func (t *rolloutInplaceTransformer) sharding(transCtx *rolloutTransformContext,
+	rollout *appsv1alpha1.Rollout, sharding appsv1alpha1.RolloutSharding) error {
+	spec := transCtx.ClusterShardings[sharding.Name]
+	status := rolloutShardingStatus(rollout, sharding.Name)
+	if spec == nil || status == nil {
+		return fmt.Errorf("the sharding %s is not found in cluster", sharding.Name)
+	}
+
+	serviceVersion, compDef := shardingTarget(sharding, status)
+	tpl := rolloutShardTemplate(rollout, spec)
+	if tpl == nil && (spec.Template.ServiceVersion != status.ServiceVersion || spec.Template.ComponentDef != status.CompDef) {
+		return nil // has been torn down
+	}
+
+	candidates := make([]string, 0)
+	for _, comp := range transCtx.ShardingComps[sharding.Name] {
+		id := shardID(transCtx.Cluster.Name, sharding.Name, comp)
+		if tpl == nil || !slices.Contains(tpl.ShardIDs, id) {
+			candidates = append(candidates, id)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	if transCtx.ClusterOrig.Generation != transCtx.ClusterOrig.Status.ObservedGeneration {
+		return controllerutil.NewDelayedRequeueError(componentNotReadyRequeueDuration, fmt.Sprintf("the cluster %s is not ready", transCtx.Cluster.Name))
+	}
+	// all the shards should be ready before starting the rollout
+	if tpl == nil && !checkShardingRunning(transCtx, sharding.Name) {
+		return controllerutil.NewDelayedRequeueError(componentNotReadyRequeueDuration, fmt.Sprintf("the sharding %s is not ready", sharding.Name))
+	}
+
+	maxUnavailable, err := shardingMaxUnavailable(sharding, status.Shards)
+	if err != nil {
+		return err
+	}
+	_, rolling := classifyShards(transCtx, sharding.Name, tpl)
+	cnt := min(int(maxUnavailable)-len(rolling), len(candidates))
+	if cnt <= 0 {
+		return nil // wait for the rolling shards
+	}
+
+	if tpl == nil {
+		spec.ShardTemplates = append(spec.ShardTemplates, appsv1.ShardTemplate{
+			Name:           rolloutShardTemplateName(rollout),
+			ServiceVersion: ptr.To(serviceVersion),
+			CompDef:        ptr.To(compDef),
+		})
+		tpl = &spec.ShardTemplates[len(spec.ShardTemplates)-1]
+	}
+	tpl.ShardIDs = append(tpl.ShardIDs, candidates[:cnt]...)
+	tpl.Shards = ptr.To(int32(len(tpl.ShardIDs)))
+	return nil
+}
+
 // serviceVersionNCompDef obtains the original service version and component definition.
 func serviceVersionNCompDef(rollout *appsv1alpha1.Rollout, comp appsv1alpha1.RolloutComponent, spec *appsv1.ClusterComponentSpec) (string, string) {
 	serviceVer, compDef := spec.ServiceVersion, spec.ComponentDef
@@ -21,6 +21,8 @@ package rollout
 
 import (
 	"context"
+	"slices"
+	"strings"
 	"time"
 
 	"k8s.io/apimachinery/pkg/types"
@@ -31,6 +33,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	"github.com/apecloud/kubeblocks/pkg/controller/sharding"
 )
 
 const (
@@ -44,7 +47,7 @@ var _ graph.Transformer = &rolloutLoadTransformer{}
 
 func (t *rolloutLoadTransformer) Transform(ctx graph.TransformContext, dag *graph.DAG) error {
 	transCtx := ctx.(*rolloutTransformContext)
-	if model.IsObjectDeleting(transCtx.RolloutOrig) || isRolloutSucceed(transCtx.RolloutOrig) {
+	if model.IsObjectDeleting(transCtx.RolloutOrig) || isRolloutTerminated(transCtx.RolloutOrig) {
 		return nil
 	}
 
@@ -61,6 +64,10 @@ func (t *rolloutLoadTransformer) Transform(ctx graph.TransformContext, dag *grap
 	if err != nil {
 		return err
 	}
+	transCtx.ShardingComps, err = t.getShardingComponents(transCtx.Context, transCtx.Client, rollout, transCtx.Cluster)
+	if err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -117,6 +124,31 @@ func (t *rolloutLoadTransformer) getNCheckComponent(ctx context.Context, cli cli
 	return comp, nil
 }
 
+func (t *rolloutLoadTransformer) getShardingComponents(ctx context.Context, cli client.Reader,
+	rollout *appsv1alpha1.Rollout, cluster *appsv1.Cluster) (map[string][]*appsv1.Component, error) {
+	if len(rollout.Spec.Shardings) == 0 {
+		return nil, nil
+	}
+	shardingComps := make(map[string][]*appsv1.Component)
+	for _, spec := range rollout.Spec.Shardings {
+		comps, err := sharding.ListShardingComponents(ctx, cli, cluster, spec.Name)
+		if err != nil {
+			return nil, err
+		}
+		objs := make([]*appsv1.Component, 0)
+		for i := range comps {
+			if !model.IsObjectDeleting(&comps[i]) {
+				objs = append(objs, &comps[i])
+			}
+		}
+		slices.SortFunc(objs, func(a, b *appsv1.Component) int {
+			return strings.Compare(a.Name, b.Name)
+		})
+		shardingComps[spec.Name] = objs
+	}
+	return shardingComps, nil
+}
+
 func checkClusterNCompRunning(transCtx *rolloutTransformContext, compName string) bool {
 	cluster := transCtx.ClusterOrig
 	compStatus := cluster.Status.Components[compName]
@@ -129,3 +161,33 @@ func checkClusterNCompRunning(transCtx *rolloutTransformContext, compName string
 	}
 	return compObj.Generation == compObj.Status.ObservedGeneration && compObj.Status.Phase == appsv1.RunningComponentPhase
 }
+
+func checkClusterNCompFailed(transCtx *rolloutTransformContext, compName string) bool {
+	if transCtx.ClusterOrig.Status.Components[compName].Phase == appsv1.FailedComponentPhase {
+		return true
+	}
+	compObj, ok := transCtx.Components[compName]
+	return ok && compObj != nil && compObj.Status.Phase == appsv1.FailedComponentPhase
+}
+
+func checkShardingRunning(transCtx *rolloutTransformContext, shardingName string) bool {
+	cluster := transCtx.ClusterOrig
+	if cluster.Generation != cluster.Status.ObservedGeneration {
+		return false
+	}
+	for _, comp := range transCtx.ShardingComps[shardingName] {
+		if comp.Generation != comp.Status.ObservedGeneration || comp.Status.Phase != appsv1.RunningComponentPhase {
+			return false
+		}
+	}
+	return true
+}
+
+func checkShardingFailed(transCtx *rolloutTransformContext, shardingName string) bool {
+	for _, comp := range transCtx.ShardingComps[shardingName] {
+		if comp.Status.Phase == appsv1.FailedComponentPhase {
+			return true
+		}
+	}
+	return false
+}
@@ -46,7 +46,7 @@ var _ graph.Transformer = &rolloutReplaceTransformer{}
 
 func (t *rolloutReplaceTransformer) Transform(ctx graph.TransformContext, dag *graph.DAG) error {
 	transCtx := ctx.(*rolloutTransformContext)
-	if model.IsObjectDeleting(transCtx.RolloutOrig) || isRolloutSucceed(transCtx.RolloutOrig) {
+	if model.IsObjectDeleting(transCtx.RolloutOrig) || isRolloutTerminated(transCtx.RolloutOrig) || isRolloutHalted(transCtx.Rollout) {
 		return nil
 	}
 	return t.rollout(transCtx)
@@ -63,7 +63,7 @@ func (t *rolloutReplaceTransformer) rollout(transCtx *rolloutTransformContext) e
 func (t *rolloutReplaceTransformer) components(transCtx *rolloutTransformContext) error {
 	rollout := transCtx.Rollout
 	for _, comp := range rollout.Spec.Components {
-		if comp.Strategy.Replace != nil {
+		if comp.Strategy.Replace != nil && isRolloutWaveReady(rollout, comp.Wave) {
 			if err := t.component(transCtx, rollout, comp); err != nil {
 				return err
 			}
@@ -130,6 +130,12 @@ func (t *rolloutReplaceTransformer) down(transCtx *rolloutTransformContext,
 	if err := t.checkDelaySeconds(rollout, comp, *tpl.Replicas, true); err != nil {
 		return err
 	}
+	if comp.Strategy.Replace.Analysis != nil {
+		runName := fmt.Sprintf("replace-%d", *tpl.Replicas)
+		if err := analyze(transCtx, rollout, comp.Name, runName, comp.Strategy.Replace.Analysis); err != nil {
+			return err
+		}
+	}
 
 	instance, instTpl, err := t.pickInstanceToScaleDown(transCtx, spec, tpl)
 	if err != nil {
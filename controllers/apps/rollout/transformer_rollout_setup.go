@@ -38,7 +38,7 @@ var _ graph.Transformer = &rolloutSetupTransformer{}
 
 func (t *rolloutSetupTransformer) Transform(ctx graph.TransformContext, dag *graph.DAG) error {
 	transCtx := ctx.(*rolloutTransformContext)
-	if model.IsObjectDeleting(transCtx.RolloutOrig) || isRolloutSucceed(transCtx.RolloutOrig) {
+	if model.IsObjectDeleting(transCtx.RolloutOrig) || isRolloutTerminated(transCtx.RolloutOrig) {
 		return nil
 	}
 
@@ -47,11 +47,19 @@ func (t *rolloutSetupTransformer) Transform(ctx graph.TransformContext, dag *gra
 	)
 
 	// pre-check
+	if len(rollout.Spec.Components) == 0 && len(rollout.Spec.Shardings) == 0 {
+		return fmt.Errorf("neither components nor shardings are defined")
+	}
 	for _, comp := range rollout.Spec.Components {
 		if err := t.precheck(comp); err != nil {
 			return err
 		}
 	}
+	for _, sharding := range rollout.Spec.Shardings {
+		if err := t.precheckSharding(transCtx, sharding); err != nil {
+			return err
+		}
+	}
 
 	// check and add the rollout label to the cluster
 	if err := t.patchClusterLabel(transCtx, dag, rollout); err != nil {
@@ -88,6 +96,26 @@ func (t *rolloutSetupTransformer) precheck(comp appsv1alpha1.RolloutComponent) e
 	return nil
 }
 
+func (t *rolloutSetupTransformer) precheckSharding(transCtx *rolloutTransformContext, sharding appsv1alpha1.RolloutSharding) error {
+	if sharding.ServiceVersion == nil && sharding.CompDef == nil {
+		return fmt.Errorf("neither serviceVersion nor compDef is defined for sharding %s", sharding.Name)
+	}
+	if sharding.Strategy.Inplace == nil || sharding.Strategy.Replace != nil || sharding.Strategy.Create != nil {
+		return fmt.Errorf("only the inplace strategy is supported for sharding %s", sharding.Name)
+	}
+	spec := transCtx.ClusterShardings[sharding.Name]
+	if spec == nil {
+		return fmt.Errorf("the sharding %s is not found in cluster", sharding.Name)
+	}
+	tplName := rolloutShardTemplateName(transCtx.Rollout)
+	for _, tpl := range spec.ShardTemplates {
+		if tpl.Name != tplName {
+			return fmt.Errorf("not support to rollout the sharding %s with shard templates", sharding.Name)
+		}
+	}
+	return nil
+}
+
 func (t *rolloutSetupTransformer) patchClusterLabel(transCtx *rolloutTransformContext, dag *graph.DAG, rollout *appsv1alpha1.Rollout) error {
 	var (
 		graphCli = transCtx.Client.(model.GraphClient)
@@ -123,6 +151,11 @@ func (t *rolloutSetupTransformer) initRolloutStatus(transCtx *rolloutTransformCo
 			return err
 		}
 	}
+	for _, sharding := range rollout.Spec.Shardings {
+		if err := t.initShardingStatus(transCtx, rollout, sharding); err != nil {
+			return err
+		}
+	}
 	if !reflect.DeepEqual(transCtx.RolloutOrig.Status, rollout.Status) {
 		graphCli.Status(dag, transCtx.RolloutOrig, rollout)
 		return graph.ErrPrematureStop
@@ -141,11 +174,41 @@ func (t *rolloutSetupTransformer) initCompStatus(transCtx *rolloutTransformConte
 			return nil // has been initialized
 		}
 	}
+	var instanceReplicas map[string]int32
+	for _, tpl := range spec.Instances {
+		if tpl.Replicas != nil {
+			if instanceReplicas == nil {
+				instanceReplicas = make(map[string]int32)
+			}
+			instanceReplicas[tpl.Name] = *tpl.Replicas
+		}
+	}
 	rollout.Status.Components = append(rollout.Status.Components, appsv1alpha1.RolloutComponentStatus{
-		Name:           comp.Name,
-		ServiceVersion: spec.ServiceVersion,
-		CompDef:        spec.ComponentDef,
-		Replicas:       spec.Replicas,
+		Name:             comp.Name,
+		ServiceVersion:   spec.ServiceVersion,
+		CompDef:          spec.ComponentDef,
+		Replicas:         spec.Replicas,
+		InstanceReplicas: instanceReplicas,
+	})
+	return nil
+}
+
+func (t *rolloutSetupTransformer) initShardingStatus(transCtx *rolloutTransformContext,
+	rollout *appsv1alpha1.Rollout, sharding appsv1alpha1.RolloutSharding) error {
+	spec := transCtx.ClusterShardings[sharding.Name]
+	if spec == nil {
+		return fmt.Errorf("the sharding %s is not found in cluster", sharding.Name)
+	}
+	for _, status := range rollout.Status.Shardings {
+		if status.Name == sharding.Name {
+			return nil // has been initialized
+		}
+	}
+	rollout.Status.Shardings = append(rollout.Status.Shardings, appsv1alpha1.RolloutShardingStatus{
+		Name:           sharding.Name,
+		ServiceVersion: spec.Template.ServiceVersion,
+		CompDef:        spec.Template.ComponentDef,
+		Shards:         spec.Shards,
 	})
 	return nil
 }
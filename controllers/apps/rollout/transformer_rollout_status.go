@@ -20,8 +20,12 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package rollout
 
 import (
+	"fmt"
+
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
@@ -38,17 +42,43 @@ var _ graph.Transformer = &rolloutStatusTransformer{}
 
 func (t *rolloutStatusTransformer) Transform(ctx graph.TransformContext, dag *graph.DAG) error {
 	transCtx := ctx.(*rolloutTransformContext)
-	if model.IsObjectDeleting(transCtx.RolloutOrig) || isRolloutSucceed(transCtx.RolloutOrig) {
+	if model.IsObjectDeleting(transCtx.RolloutOrig) || isRolloutTerminated(transCtx.RolloutOrig) {
 		return nil
 	}
 
 	rollout := transCtx.Rollout
-	state, err := t.components(transCtx, rollout)
+	if isRolloutRollingBack(rollout) {
+		state, err := t.rollback(transCtx, rollout)
+		if err != nil {
+			return err
+		}
+		rollout.Status.ObservedGeneration = rollout.Generation
+		rollout.Status.State = state
+		return nil
+	}
+
+	compStates, err := t.components(transCtx, rollout)
 	if err != nil {
 		return err
 	}
-	// TODO: sharding
+	shardingStates, err := t.shardings(transCtx, rollout)
+	if err != nil {
+		return err
+	}
+	state := mergeRolloutStates(append(compStates, shardingStates...))
 
+	if ptr.Deref(rollout.Spec.Paused, false) && state != appsv1alpha1.SucceedRolloutState {
+		state = appsv1alpha1.PausedRolloutState
+	}
+	if state != appsv1alpha1.SucceedRolloutState {
+		for _, comp := range rollout.Spec.Components {
+			if runName, ok := failedAnalysisRun(rollout, comp.Name); ok {
+				state = appsv1alpha1.ErrorRolloutState
+				rollout.Status.Message = fmt.Sprintf("the analysis %s of component %s is failed", runName, comp.Name)
+				break
+			}
+		}
+	}
 	rollout.Status.ObservedGeneration = rollout.Generation
 	rollout.Status.State = state
 
@@ -57,16 +87,25 @@ func (t *rolloutStatusTransformer) Transform(ctx graph.TransformContext, dag *gr
 	return nil
 }
 
-func (t *rolloutStatusTransformer) components(transCtx *rolloutTransformContext, rollout *appsv1alpha1.Rollout) (appsv1alpha1.RolloutState, error) {
+func (t *rolloutStatusTransformer) components(transCtx *rolloutTransformContext, rollout *appsv1alpha1.Rollout) ([]appsv1alpha1.RolloutState, error) {
 	states := make([]appsv1alpha1.RolloutState, 0)
 	for _, comp := range rollout.Spec.Components {
 		state, err := t.component(transCtx, rollout, comp)
 		if err != nil {
-			return "", err
+			return nil, err
+		}
+		for i, status := range rollout.Status.Components {
+			if status.Name == comp.Name {
+				rollout.Status.Components[i].State = state
+				break
+			}
 		}
 		states = append(states, state)
 	}
+	return states, nil
+}
 
+func mergeRolloutStates(states []appsv1alpha1.RolloutState) appsv1alpha1.RolloutState {
 	var (
 		hasError   = false
 		hasRolling = false
@@ -93,17 +132,17 @@ func (t *rolloutStatusTransformer) components(transCtx *rolloutTransformContext,
 	}
 	switch {
 	case hasError:
-		return appsv1alpha1.ErrorRolloutState, nil
+		return appsv1alpha1.ErrorRolloutState
 	case hasRolling:
-		return appsv1alpha1.RollingRolloutState, nil
+		return appsv1alpha1.RollingRolloutState
 	case allSucceed:
-		return appsv1alpha1.SucceedRolloutState, nil
+		return appsv1alpha1.SucceedRolloutState
 	case hasSucceed:
-		return appsv1alpha1.RollingRolloutState, nil
+		return appsv1alpha1.RollingRolloutState
 	case hasPending:
-		return appsv1alpha1.PendingRolloutState, nil
+		return appsv1alpha1.PendingRolloutState
 	default:
-		return "", nil
+		return ""
 	}
 }
 
@@ -199,6 +238,92 @@ func (t *rolloutStatusTransformer) create(transCtx *rolloutTransformContext,
 	return "", createStrategyNotSupportedError
 }
 
+func (t *rolloutStatusTransformer) shardings(transCtx *rolloutTransformContext, rollout *appsv1alpha1.Rollout) ([]appsv1alpha1.RolloutState, error) {
+	states := make([]appsv1alpha1.RolloutState, 0)
+	for _, sharding := range rollout.Spec.Shardings {
+		status := rolloutShardingStatus(rollout, sharding.Name)
+		spec := t.shardingSpec(transCtx, sharding.Name)
+		if status == nil || spec == nil {
+			return nil, fmt.Errorf("the sharding %s is not found in cluster", sharding.Name)
+		}
+		status.State = t.sharding(transCtx, rollout, sharding, spec, status)
+		states = append(states, status.State)
+	}
+	return states, nil
+}
+
+func (t *rolloutStatusTransformer) sharding(transCtx *rolloutTransformContext, rollout *appsv1alpha1.Rollout,
+	sharding appsv1alpha1.RolloutSharding, spec *appsv1.ClusterSharding, status *appsv1alpha1.RolloutShardingStatus) appsv1alpha1.RolloutState {
+	tpl := rolloutShardTemplate(rollout, spec)
+	if tpl != nil {
+		status.RolledOutShards, status.RollingShards = classifyShards(transCtx, sharding.Name, tpl)
+		return appsv1alpha1.RollingRolloutState
+	}
+
+	if spec.Template.ServiceVersion == status.ServiceVersion && spec.Template.ComponentDef == status.CompDef {
+		return appsv1alpha1.PendingRolloutState
+	}
+	// the rollout shard template has been torn down
+	status.RolledOutShards, status.RollingShards = nil, nil
+	for _, comp := range transCtx.ShardingComps[sharding.Name] {
+		status.RolledOutShards = append(status.RolledOutShards, shardID(transCtx.Cluster.Name, sharding.Name, comp))
+	}
+	if checkShardingRunning(transCtx, sharding.Name) {
+		return appsv1alpha1.SucceedRolloutState
+	}
+	return appsv1alpha1.RollingRolloutState
+}
+
+func (t *rolloutStatusTransformer) shardingSpec(transCtx *rolloutTransformContext, shardingName string) *appsv1.ClusterSharding {
+	// use the original cluster spec
+	cluster := transCtx.ClusterOrig
+	for i, spec := range cluster.Spec.Shardings {
+		if spec.Name == shardingName {
+			return &cluster.Spec.Shardings[i]
+		}
+	}
+	return nil
+}
+
+// rollback checks whether all the components have been rolled back and become running.
+func (t *rolloutStatusTransformer) rollback(transCtx *rolloutTransformContext, rollout *appsv1alpha1.Rollout) (appsv1alpha1.RolloutState, error) {
+	for _, status := range rollout.Status.Components {
+		spec := t.compSpec(transCtx, status.Name)
+		if spec == nil || !isRolloutComponentRolledBack(rollout, spec, status) || !checkClusterNCompRunning(transCtx, status.Name) {
+			return appsv1alpha1.RollingBackRolloutState, nil
+		}
+		pods := &corev1.PodList{}
+		listOpts := []client.ListOption{
+			client.InNamespace(rollout.Namespace),
+			client.MatchingLabels(constant.GetCompLabels(rollout.Spec.ClusterName, status.Name)),
+		}
+		if err := transCtx.Client.List(transCtx.Context, pods, listOpts...); err != nil {
+			return "", err
+		}
+		if int32(len(pods.Items)) != spec.Replicas {
+			return appsv1alpha1.RollingBackRolloutState, nil // scaling down the new instances
+		}
+	}
+	for _, status := range rollout.Status.Shardings {
+		spec := t.shardingSpec(transCtx, status.Name)
+		if spec == nil || !isRolloutShardingRolledBack(rollout, spec, status) || !checkShardingRunning(transCtx, status.Name) {
+			return appsv1alpha1.RollingBackRolloutState, nil
+		}
+	}
+
+	rollout.Status.Message = ""
+	if meta.SetStatusCondition(&rollout.Status.Conditions, metav1.Condition{
+		Type:               appsv1alpha1.ConditionTypeRolloutRolledBack,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: rollout.Generation,
+		Reason:             reasonRolloutRolledBack,
+		Message:            "the rollout is rolled back",
+	}) {
+		transCtx.EventRecorder.Event(rollout, corev1.EventTypeNormal, reasonRolloutRolledBack, "the rollout is rolled back")
+	}
+	return appsv1alpha1.RolledBackRolloutState, nil
+}
+
 func (t *rolloutStatusTransformer) compSpec(transCtx *rolloutTransformContext, compName string) *appsv1.ClusterComponentSpec {
 	// use the original cluster spec
 	cluster := transCtx.ClusterOrig
@@ -22,6 +22,8 @@ package rollout
 import (
 	"slices"
 
+	"k8s.io/utils/ptr"
+
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
@@ -33,7 +35,7 @@ var _ graph.Transformer = &rolloutTearDownTransformer{}
 
 func (t *rolloutTearDownTransformer) Transform(ctx graph.TransformContext, dag *graph.DAG) error {
 	transCtx := ctx.(*rolloutTransformContext)
-	if model.IsObjectDeleting(transCtx.RolloutOrig) || isRolloutSucceed(transCtx.RolloutOrig) {
+	if model.IsObjectDeleting(transCtx.RolloutOrig) || isRolloutTerminated(transCtx.RolloutOrig) || isRolloutHalted(transCtx.Rollout) {
 		return nil
 	}
 	return t.tearDown(transCtx)
@@ -43,8 +45,7 @@ func (t *rolloutTearDownTransformer) tearDown(transCtx *rolloutTransformContext)
 	if err := t.components(transCtx); err != nil {
 		return err
 	}
-	// TODO: sharding
-	return nil
+	return t.shardings(transCtx)
 }
 
 func (t *rolloutTearDownTransformer) components(transCtx *rolloutTransformContext) error {
@@ -69,6 +70,37 @@ func (t *rolloutTearDownTransformer) components(transCtx *rolloutTransformContex
 	return nil
 }
 
+func (t *rolloutTearDownTransformer) shardings(transCtx *rolloutTransformContext) error {
+	rollout := transCtx.Rollout
+	for _, sharding := range rollout.Spec.Shardings {
+		if sharding.Strategy.Inplace != nil {
+			t.inplaceSharding(transCtx, rollout, sharding)
+		}
+	}
+	return nil
+}
+
+// inplaceSharding updates the sharding template and removes the rollout shard template after all the shards are rolled out,
+// the shards of the removed template are adopted by the default template.
+func (t *rolloutTearDownTransformer) inplaceSharding(transCtx *rolloutTransformContext,
+	rollout *appsv1alpha1.Rollout, sharding appsv1alpha1.RolloutSharding) {
+	spec := transCtx.ClusterShardings[sharding.Name]
+	if spec == nil {
+		return
+	}
+	tpl := rolloutShardTemplate(rollout, spec)
+	if tpl == nil {
+		return
+	}
+	rolledOut, rolling := classifyShards(transCtx, sharding.Name, tpl)
+	if len(rolling) > 0 || len(rolledOut) != len(transCtx.ShardingComps[sharding.Name]) {
+		return
+	}
+	spec.Template.ServiceVersion = ptr.Deref(tpl.ServiceVersion, "")
+	spec.Template.ComponentDef = ptr.Deref(tpl.CompDef, "")
+	removeRolloutShardTemplate(rollout, spec)
+}
+
 func (t *rolloutTearDownTransformer) inplace(transCtx *rolloutTransformContext,
 	rollout *appsv1alpha1.Rollout, comp appsv1alpha1.RolloutComponent) error {
 	return nil // do nothing
@@ -54,9 +54,11 @@ import (
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 	"github.com/apecloud/kubeblocks/pkg/dataprotection/action"
 	dpbackup "github.com/apecloud/kubeblocks/pkg/dataprotection/backup"
+	dpencryption "github.com/apecloud/kubeblocks/pkg/dataprotection/encryption"
 	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
 	dputils "github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
 	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils/boolptr"
+	"github.com/apecloud/kubeblocks/pkg/metrics"
 	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
@@ -123,6 +125,12 @@ func (r *BackupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 
 	switch backup.Status.Phase {
 	case "", dpv1alpha1.BackupPhaseNew:
+		if _, ok := backup.Annotations[dptypes.ImportedFromRepoAnnotationKey]; ok {
+			// the backup is imported from the backup repo, its status will be restored
+			// by the backup repo controller.
+			reqCtx.Log.V(1).Info("skip the imported backup which is waiting for its status")
+			return intctrlutil.Reconciled()
+		}
 		return r.handleNewPhase(reqCtx, backup)
 	case dpv1alpha1.BackupPhaseRunning:
 		return r.handleRunningPhase(reqCtx, backup)
@@ -138,6 +146,11 @@ func (r *BackupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 			}
 			return r.handleRunningPhase(reqCtx, backup)
 		}
+		// the backup jobs are kept for troubleshooting, but the data key is not needed anymore.
+		if err := dpencryption.DeleteDataKeySecrets(reqCtx.Ctx, r.Client, backup,
+			map[string]string{dptypes.BackupNameLabelKey: backup.Name}); err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
 		return intctrlutil.Reconciled()
 	default:
 		return intctrlutil.Reconciled()
@@ -518,11 +531,15 @@ func (r *BackupReconciler) patchBackupStatus(
 			request.Backup, request.BackupRepo.Spec.PathPrefix, request.BackupPolicy.Spec.PathPrefix)
 	}
 	if request.ParentBackup != nil {
-		// inherit encryption config from parent backup
+		// inherit encryption config and data key from parent backup
 		request.Status.EncryptionConfig = request.ParentBackup.Status.EncryptionConfig
+		request.Status.EncryptionKey = request.ParentBackup.Status.EncryptionKey
 	} else if request.BackupPolicy.Spec.EncryptionConfig != nil {
 		request.Status.EncryptionConfig = request.BackupPolicy.Spec.EncryptionConfig
 	}
+	if err := r.prepareBackupEncryptionKey(request); err != nil {
+		return err
+	}
 	// init action status
 	actions, err := request.BuildActions()
 	if err != nil {
@@ -585,6 +602,9 @@ func (r *BackupReconciler) handleRunningPhase(
 	if err = r.syncContinuousBackupEncryptionConfig(reqCtx, backup, request.BackupPolicy); err != nil {
 		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "sync continuous backup encryption config failed")
 	}
+	if err = r.prepareBackupEncryptionKey(request); err != nil {
+		return r.updateStatusIfFailed(reqCtx, backup, request.Backup, err)
+	}
 	var (
 		existFailedAction bool
 		waiting           bool
@@ -644,6 +664,12 @@ func (r *BackupReconciler) handleRunningPhase(
 		if err = r.Client.Status().Patch(reqCtx.Ctx, request.Backup, client.MergeFrom(backup)); err != nil {
 			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
 		}
+		// refresh the manifest of the continuous backup to record its progress.
+		if r.needWriteBackupManifest(request.Backup) {
+			if _, err = r.writeBackupManifest(reqCtx, request.Backup); err != nil {
+				return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+			}
+		}
 		return intctrlutil.Reconciled()
 	}
 	if existFailedAction {
@@ -666,9 +692,78 @@ func (r *BackupReconciler) handleRunningPhase(
 	if err = r.Client.Status().Patch(reqCtx.Ctx, request.Backup, client.MergeFrom(backup)); err != nil {
 		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
 	}
+	metrics.ObserveBackup(request.Backup)
 	return intctrlutil.Reconciled()
 }
 
+// needWriteBackupManifest checks whether the manifest of the backup needs to be (re)written. The manifest
+// of a completed backup is written once after the completion, and the manifest of a running continuous
+// backup is refreshed periodically to record its progress.
+func (r *BackupReconciler) needWriteBackupManifest(backup *dpv1alpha1.Backup) bool {
+	if backup.Status.BackupRepoName == "" || backup.Status.Path == "" {
+		return false
+	}
+	if _, ok := backup.Annotations[dptypes.ImportedFromRepoAnnotationKey]; ok {
+		return false
+	}
+	writtenAt, err := time.Parse(time.RFC3339, backup.Annotations[dptypes.ManifestTimestampAnnotationKey])
+	if err != nil {
+		// the manifest has never been written.
+		writtenAt = time.Time{}
+	}
+	switch backup.Status.Phase {
+	case dpv1alpha1.BackupPhaseCompleted:
+		return backup.Status.CompletionTimestamp != nil && writtenAt.Before(backup.Status.CompletionTimestamp.Time)
+	case dpv1alpha1.BackupPhaseRunning:
+		return backup.Labels[dptypes.BackupTypeLabelKey] == string(dpv1alpha1.BackupTypeContinuous) &&
+			r.clock.Since(writtenAt) >= continuousBackupManifestInterval
+	}
+	return false
+}
+
+// writeBackupManifest writes the manifest of the backup into the backup repo by a job, so that
+// the backup can be rediscovered from the backup repo, and records the time when the manifest
+// is written. It returns true if the job is still running. The failure of the job does not fail
+// the backup, as the backup data is intact.
+func (r *BackupReconciler) writeBackupManifest(reqCtx intctrlutil.RequestCtx, backup *dpv1alpha1.Backup) (bool, error) {
+	repo := &dpv1alpha1.BackupRepo{}
+	if err := r.Client.Get(reqCtx.Ctx, client.ObjectKey{Name: backup.Status.BackupRepoName}, repo); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	saName, err := EnsureWorkerServiceAccount(reqCtx, r.Client, backup.Namespace, nil)
+	if err != nil {
+		return false, err
+	}
+	writer := &dpbackup.ManifestWriter{
+		RequestCtx:           reqCtx,
+		Client:               r.Client,
+		Scheme:               r.Scheme,
+		WorkerServiceAccount: saName,
+	}
+	job, err := writer.Write(backup, repo)
+	if err != nil {
+		return false, err
+	}
+	finished, finishedType, msg := dputils.IsJobFinished(job)
+	if !finished {
+		return true, nil
+	}
+	if finishedType == batchv1.JobFailed {
+		r.Recorder.Event(backup, corev1.EventTypeWarning, "WriteManifestFailed",
+			fmt.Sprintf("failed to write the backup manifest, the backup can not be synced from the backup repo: %s", msg))
+	}
+	// remove the job to write the manifest again next time.
+	if err = intctrlutil.BackgroundDeleteObject(r.Client, reqCtx.Ctx, job); err != nil {
+		return false, err
+	}
+	patch := client.MergeFrom(backup.DeepCopy())
+	if backup.Annotations == nil {
+		backup.Annotations = map[string]string{}
+	}
+	backup.Annotations[dptypes.ManifestTimestampAnnotationKey] = r.clock.Now().UTC().Format(time.RFC3339)
+	return false, r.Client.Patch(reqCtx.Ctx, backup, patch)
+}
+
 func (r *BackupReconciler) syncContinuousBackupEncryptionConfig(reqCtx intctrlutil.RequestCtx, backup *dpv1alpha1.Backup, backupPolicy *dpv1alpha1.BackupPolicy) error {
 	if backup.Labels[dptypes.BackupTypeLabelKey] != string(dpv1alpha1.BackupTypeContinuous) {
 		return nil
@@ -680,6 +775,35 @@ func (r *BackupReconciler) syncContinuousBackupEncryptionConfig(reqCtx intctrlut
 	return nil
 }
 
+// prepareBackupEncryptionKey generates the wrapped data key for the backup if the envelope encryption
+// is enabled, and ensures the data key secret which is used by the backup jobs.
+func (r *BackupReconciler) prepareBackupEncryptionKey(request *dpbackup.Request) error {
+	if !dpencryption.IsEnvelopeEncryption(request.Status.EncryptionConfig) {
+		return nil
+	}
+	secretName := dpencryption.GetDataKeySecretName(request.Backup, request.Backup)
+	if request.Status.EncryptionKey == nil {
+		encryptionKey, err := dpencryption.NewBackupEncryptionKey(request.Ctx, r.Client,
+			request.Namespace, request.Status.EncryptionConfig.KeyManagement)
+		if err != nil {
+			return fmt.Errorf("failed to generate the data key: %w", err)
+		}
+		request.Status.EncryptionKey = encryptionKey
+		// remove the stale data key secret whose data key is not recorded in the backup status.
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: secretName, Namespace: request.Namespace}}
+		if err = r.Client.Delete(request.Ctx, secret); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	jobEncryptionConfig, err := dpencryption.EnsureDataKeySecret(request.Ctx, r.Client, r.Scheme, request.Backup,
+		request.Backup, secretName, dpbackup.BuildBackupWorkloadLabels(request.Backup))
+	if err != nil {
+		return fmt.Errorf("failed to prepare the data key secret: %w", err)
+	}
+	request.JobEncryptionConfig = jobEncryptionConfig
+	return nil
+}
+
 func (r *BackupReconciler) checkRestoreInProgress(reqCtx intctrlutil.RequestCtx, backup *dpv1alpha1.Backup) (restoreInProgress bool, err error) {
 	clusterName, ok := backup.Labels[constant.AppInstanceLabelKey]
 	if !ok {
@@ -750,7 +874,11 @@ func (r *BackupReconciler) checkIsCompletedDuringRunning(reqCtx intctrlutil.Requ
 		act.CompletionTimestamp = backup.Status.CompletionTimestamp
 	}
 
-	return true, r.Client.Status().Patch(reqCtx.Ctx, backup, patch)
+	if err := r.Client.Status().Patch(reqCtx.Ctx, backup, patch); err != nil {
+		return true, err
+	}
+	metrics.ObserveBackup(backup)
+	return true, nil
 }
 
 // handleCompletedPhase handles the backup object in completed phase.
@@ -758,6 +886,15 @@ func (r *BackupReconciler) checkIsCompletedDuringRunning(reqCtx intctrlutil.Requ
 func (r *BackupReconciler) handleCompletedPhase(
 	reqCtx intctrlutil.RequestCtx,
 	backup *dpv1alpha1.Backup) (ctrl.Result, error) {
+	// write the manifest with the final status before deleting the workloads, so that
+	// the backup can be rediscovered from the backup repo.
+	if r.needWriteBackupManifest(backup) {
+		if running, err := r.writeBackupManifest(reqCtx, backup); err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		} else if running {
+			return intctrlutil.Reconciled()
+		}
+	}
 	if err := r.deleteExternalResources(reqCtx, backup); err != nil {
 		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
 	}
@@ -784,6 +921,9 @@ func (r *BackupReconciler) updateStatusIfFailed(
 	if errUpdate := r.Client.Status().Patch(reqCtx.Ctx, backup, client.MergeFrom(original)); errUpdate != nil {
 		return intctrlutil.CheckedRequeueWithError(errUpdate, reqCtx.Log, "")
 	}
+	if original.Status.Phase != dpv1alpha1.BackupPhaseFailed {
+		metrics.ObserveBackup(backup)
+	}
 	return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
 }
 
@@ -835,7 +975,13 @@ func (r *BackupReconciler) deleteExternalResources(
 	}
 
 	// delete the external statefulSets.
-	return deleteRelatedObjectList(reqCtx, r.Client, &appsv1.StatefulSetList{}, namespaces, labels)
+	if err := deleteRelatedObjectList(reqCtx, r.Client, &appsv1.StatefulSetList{}, namespaces, labels); err != nil {
+		return err
+	}
+
+	// delete the data key secrets, the data key can be unwrapped again from the backup status when needed.
+	return dpencryption.DeleteDataKeySecrets(reqCtx.Ctx, r.Client, backup,
+		map[string]string{dptypes.BackupNameLabelKey: backup.Name})
 }
 
 // deleteRelatedBackups deletes the related backups.
@@ -53,6 +53,13 @@ import (
 	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
+// completeManifestJob mocks the job which writes the backup manifest to be completed.
+func completeManifestJob(backupKey client.ObjectKey) {
+	backup := &dpv1alpha1.Backup{}
+	Expect(testCtx.Cli.Get(testCtx.Ctx, backupKey, backup)).Should(Succeed())
+	testdp.PatchK8sJobStatus(&testCtx, dpbackup.BuildManifestJobKey(backup), batchv1.JobComplete)
+}
+
 var _ = Describe("Backup Controller test", func() {
 	cleanEnv := func() {
 		// must wait till resources deleted and no longer existed before the testcases start,
@@ -165,6 +172,7 @@ var _ = Describe("Backup Controller test", func() {
 				})).Should(Succeed())
 
 				testdp.PatchK8sJobStatus(&testCtx, getJobKey(), batchv1.JobComplete)
+				completeManifestJob(backupKey)
 
 				By("backup job should have completed")
 				Eventually(testapps.CheckObj(&testCtx, getJobKey(), func(g Gomega, fetched *batchv1.Job) {
@@ -262,6 +270,7 @@ var _ = Describe("Backup Controller test", func() {
 				})).Should(Succeed())
 
 				testdp.PatchK8sJobStatus(&testCtx, getJobKey(), batchv1.JobComplete)
+				completeManifestJob(backupKey)
 
 				By("check backup expiration is updated by completion time when backup is completed")
 				Eventually(testapps.CheckObj(&testCtx, backupKey, func(g Gomega, fetched *dpv1alpha1.Backup) {
@@ -342,6 +351,7 @@ var _ = Describe("Backup Controller test", func() {
 				By("mock jobs are completed and backup should be completed")
 				testdp.PatchK8sJobStatus(&testCtx, getJobKey(0), batchv1.JobComplete)
 				testdp.PatchK8sJobStatus(&testCtx, getJobKey(1), batchv1.JobComplete)
+				completeManifestJob(client.ObjectKeyFromObject(backup))
 				Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(backup), func(g Gomega, fetched *dpv1alpha1.Backup) {
 					g.Expect(fetched.Status.Phase).To(Equal(dpv1alpha1.BackupPhaseCompleted))
 					g.Expect(fetched.Status.CompletionTimestamp).ShouldNot(BeNil())
@@ -384,6 +394,7 @@ var _ = Describe("Backup Controller test", func() {
 			By("mock backup jobs to completed and backup should be completed")
 			testdp.PatchK8sJobStatus(&testCtx, getJobKey(targets[0].Name), batchv1.JobComplete)
 			testdp.PatchK8sJobStatus(&testCtx, getJobKey(targets[1].Name), batchv1.JobComplete)
+			completeManifestJob(client.ObjectKeyFromObject(backup))
 			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(backup), func(g Gomega, fetched *dpv1alpha1.Backup) {
 				g.Expect(fetched.Status.Phase).To(Equal(dpv1alpha1.BackupPhaseCompleted))
 			})).Should(Succeed())
@@ -456,6 +467,7 @@ var _ = Describe("Backup Controller test", func() {
 			}
 			By("mock backup jobs to completed and backup should be completed")
 			testdp.PatchK8sJobStatus(&testCtx, getJobKey(target.Name), batchv1.JobComplete)
+			completeManifestJob(client.ObjectKeyFromObject(backup))
 			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(backup), func(g Gomega, fetched *dpv1alpha1.Backup) {
 				g.Expect(fetched.Status.Phase).To(Equal(dpv1alpha1.BackupPhaseCompleted))
 			})).Should(Succeed())
@@ -570,6 +582,7 @@ var _ = Describe("Backup Controller test", func() {
 			}
 			waitBackupCompleted := func(backup *dpv1alpha1.Backup) {
 				testdp.PatchK8sJobStatus(&testCtx, getJobKey(backup, 0), batchv1.JobComplete)
+				completeManifestJob(client.ObjectKeyFromObject(backup))
 				Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(backup), func(g Gomega, fetched *dpv1alpha1.Backup) {
 					g.Expect(fetched.Status.Phase).To(Equal(dpv1alpha1.BackupPhaseCompleted))
 				})).Should(Succeed())
@@ -935,6 +948,7 @@ var _ = Describe("Backup Controller test", func() {
 
 			checkBackupCompleted := func(backup *dpv1alpha1.Backup) {
 				testdp.PatchK8sJobStatus(&testCtx, getJobKey(backup), batchv1.JobComplete)
+				completeManifestJob(client.ObjectKeyFromObject(backup))
 				Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(backup), func(g Gomega, fetched *dpv1alpha1.Backup) {
 					g.Expect(fetched.Status.Phase).To(Equal(dpv1alpha1.BackupPhaseCompleted))
 				})).Should(Succeed())
@@ -1509,6 +1523,7 @@ var _ = Describe("Backup Controller test", func() {
 				})).Should(Succeed())
 
 				testdp.PatchK8sJobStatus(&testCtx, getJobKey(), batchv1.JobComplete)
+				completeManifestJob(backupKey)
 
 				By("backup job should have completed")
 				Eventually(testapps.CheckObj(&testCtx, getJobKey(), func(g Gomega, fetched *batchv1.Job) {
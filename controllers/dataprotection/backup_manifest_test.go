@@ -0,0 +1,138 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package dataprotection
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	dpbackup "github.com/apecloud/kubeblocks/pkg/dataprotection/backup"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+)
+
+func TestBackupManifestWriting(t *testing.T) {
+	ctx := context.Background()
+	backup := newReplicationTestBackup("full")
+	backup.Status.CompletionTimestamp = &metav1.Time{Time: time.Now().Add(-time.Minute)}
+	scheme := newReplicationTestScheme()
+	r := &BackupReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(append(newReplicationTestObjects(""), backup)...).
+			WithStatusSubresource(&dpv1alpha1.Backup{}).Build(),
+		Scheme:   scheme,
+		Recorder: record.NewFakeRecorder(10),
+	}
+	reqCtx := intctrlutil.RequestCtx{Ctx: ctx, Log: logr.Discard()}
+	latest := func() *dpv1alpha1.Backup {
+		obj := &dpv1alpha1.Backup{}
+		if err := r.Client.Get(ctx, client.ObjectKeyFromObject(backup), obj); err != nil {
+			t.Fatal(err)
+		}
+		return obj
+	}
+
+	// the manifest of the completed backup is written before the workloads are deleted
+	if !r.needWriteBackupManifest(latest()) {
+		t.Fatal("expect the manifest of the completed backup to be written")
+	}
+	if _, err := r.handleCompletedPhase(reqCtx, latest()); err != nil {
+		t.Fatal(err)
+	}
+	jobKey := dpbackup.BuildManifestJobKey(backup)
+	completeJob(t, r.Client, jobKey)
+	if _, err := r.handleCompletedPhase(reqCtx, latest()); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Client.Get(ctx, jobKey, &batchv1.Job{}); err == nil {
+		t.Error("expect the finished manifest job to be deleted")
+	}
+	if r.needWriteBackupManifest(latest()) {
+		t.Error("expect the manifest not to be written again")
+	}
+
+	// the manifest of the running continuous backup is refreshed periodically
+	continuous := latest()
+	continuous.Labels[dptypes.BackupTypeLabelKey] = string(dpv1alpha1.BackupTypeContinuous)
+	continuous.Status.Phase = dpv1alpha1.BackupPhaseRunning
+	if r.needWriteBackupManifest(continuous) {
+		t.Error("expect the manifest of the continuous backup not to be refreshed within the interval")
+	}
+	continuous.Annotations[dptypes.ManifestTimestampAnnotationKey] =
+		time.Now().Add(-continuousBackupManifestInterval).UTC().Format(time.RFC3339)
+	if !r.needWriteBackupManifest(continuous) {
+		t.Error("expect the manifest of the continuous backup to be refreshed")
+	}
+	// the running backups of other types have no manifest
+	continuous.Labels[dptypes.BackupTypeLabelKey] = string(dpv1alpha1.BackupTypeFull)
+	if r.needWriteBackupManifest(continuous) {
+		t.Error("expect no manifest for the running full backup")
+	}
+
+	// the imported backups have their manifests already
+	imported := newReplicationTestBackup("imported")
+	imported.Annotations = map[string]string{dptypes.ImportedFromRepoAnnotationKey: replicationTestSourceRepo}
+	if r.needWriteBackupManifest(imported) {
+		t.Error("expect no manifest for the imported backup")
+	}
+}
+
+func TestReadSyncCatalog(t *testing.T) {
+	repo := &dpv1alpha1.BackupRepo{ObjectMeta: metav1.ObjectMeta{Name: "repo", UID: "12345678-repo"}}
+	r := &BackupRepoReconciler{}
+	chunk := func(suffix string, data string, binary bool) *corev1.ConfigMap {
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "kb-system",
+				Name:      r.syncResourceName(repo) + "-" + suffix,
+				Labels:    r.syncCatalogLabels(repo),
+			},
+		}
+		if binary {
+			cm.BinaryData = map[string][]byte{dpbackup.CatalogConfigMapKey: []byte(data)}
+		} else {
+			cm.Data = map[string]string{dpbackup.CatalogConfigMapKey: data}
+		}
+		return cm
+	}
+	other := chunk("aa", "other", false)
+	other.Labels = map[string]string{dataProtectionBackupRepoKey: "other"}
+	other.Name = "other-aa"
+	r.Client = fake.NewClientBuilder().WithScheme(newReplicationTestScheme()).
+		WithObjects(chunk("ac", "3\n", false), chunk("aa", "1", false), chunk("ab", "2\n", true), other).Build()
+
+	job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Namespace: "kb-system", Name: r.syncResourceName(repo)}}
+	catalog, err := r.readSyncCatalog(&reconcileContext{RequestCtx: intctrlutil.RequestCtx{Ctx: context.Background()}, repo: repo}, job)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(catalog) != "12\n3\n" {
+		t.Errorf("expect the chunks to be joined in order, got %q", string(catalog))
+	}
+}
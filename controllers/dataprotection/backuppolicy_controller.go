@@ -119,6 +119,14 @@ func (r *BackupPolicyReconciler) validateBackupPolicy(backupPolicy *dpv1alpha1.B
 			return err
 		}
 	}
+	for _, v := range backupPolicy.Spec.ReplicationTargets {
+		if backupPolicy.Spec.BackupRepoName != nil && v.BackupRepoName == *backupPolicy.Spec.BackupRepoName {
+			return fmt.Errorf(`the replication target "%s" can not be the backup repo of the backup policy`, v.BackupRepoName)
+		}
+		if _, err := v.RetentionPeriod.ToDuration(); err != nil {
+			return fmt.Errorf(`invalid retention period of the replication target "%s": %s`, v.BackupRepoName, err.Error())
+		}
+	}
 	return nil
 }
 
@@ -285,6 +285,7 @@ func (r *backupPolicyAndScheduleBuilder) buildBackupSchedule(
 			CronExpression:  s.CronExpression,
 			Enabled:         s.Enabled,
 			RetentionPeriod: s.RetentionPeriod,
+			Retention:       s.Retention,
 			Name:            name,
 			Parameters:      s.Parameters,
 		})
@@ -318,6 +319,7 @@ func (r *backupPolicyAndScheduleBuilder) syncBackupSchedule(backupSchedule *dpv1
 			CronExpression:  s.CronExpression,
 			Enabled:         s.Enabled,
 			RetentionPeriod: s.RetentionPeriod,
+			Retention:       s.Retention,
 			Name:            name,
 			Parameters:      s.Parameters,
 		})
@@ -0,0 +1,555 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package dataprotection
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	dpbackup "github.com/apecloud/kubeblocks/pkg/dataprotection/backup"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+	dputils "github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils/boolptr"
+)
+
+const (
+	// continuousReplicationInterval is the interval to copy the running continuous backups.
+	continuousReplicationInterval = 5 * time.Minute
+	// replicationCheckInterval is the interval to check the dependencies of the pending copies.
+	replicationCheckInterval = time.Minute
+)
+
+// BackupReplicationReconciler copies the backups to the secondary backup repos specified by
+// the backup policy, and deletes the copies when they are expired or the backup is deleted.
+type BackupReplicationReconciler struct {
+	client.Client
+	Scheme   *k8sruntime.Scheme
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=backups,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=backups/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=backups/finalizers,verbs=update
+// +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=backuppolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=backuprepos,verbs=get;list;watch
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// copy the backup to the secondary backup repos.
+func (r *BackupReplicationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqCtx := intctrlutil.RequestCtx{
+		Ctx:      ctx,
+		Req:      req,
+		Log:      log.FromContext(ctx).WithValues("backup", req.NamespacedName),
+		Recorder: r.Recorder,
+	}
+
+	backup := &dpv1alpha1.Backup{}
+	if err := r.Client.Get(reqCtx.Ctx, reqCtx.Req.NamespacedName, backup); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if val, ok := backup.Annotations[dptypes.SkipReconciliationAnnotationKey]; ok && strings.EqualFold(val, "true") {
+		return intctrlutil.Reconciled()
+	}
+
+	if !backup.GetDeletionTimestamp().IsZero() {
+		return r.handleDeletion(reqCtx, backup)
+	}
+
+	targets, err := r.getReplicationTargets(reqCtx, backup)
+	if err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if len(targets) == 0 && len(backup.Status.Replicas) == 0 {
+		return intctrlutil.Reconciled()
+	}
+
+	if len(targets) > 0 && !controllerutil.ContainsFinalizer(backup, dptypes.ReplicationFinalizerName) {
+		patch := client.MergeFrom(backup.DeepCopy())
+		controllerutil.AddFinalizer(backup, dptypes.ReplicationFinalizerName)
+		if err = r.Client.Patch(reqCtx.Ctx, backup, patch); err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+	}
+
+	saName, err := EnsureWorkerServiceAccount(reqCtx, r.Client, backup.Namespace, nil)
+	if err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	replicator := &dpbackup.Replicator{
+		RequestCtx:           reqCtx,
+		Client:               r.Client,
+		Scheme:               r.Scheme,
+		WorkerServiceAccount: saName,
+	}
+
+	var requeueAfter time.Duration
+	nextCheck := func(after time.Duration) {
+		if after > 0 && (requeueAfter == 0 || after < requeueAfter) {
+			requeueAfter = after
+		}
+	}
+	original := backup.DeepCopy()
+	for i := range targets {
+		after, err := r.reconcileReplica(reqCtx, replicator, backup, &targets[i])
+		if err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+		nextCheck(after)
+	}
+	for i := range backup.Status.Replicas {
+		after, err := r.reconcileExpiration(reqCtx, saName, backup, &backup.Status.Replicas[i])
+		if err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+		nextCheck(after)
+	}
+	if !reflect.DeepEqual(original.Status, backup.Status) {
+		if err = r.Client.Status().Patch(reqCtx.Ctx, backup, client.MergeFrom(original)); err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+	}
+	if requeueAfter > 0 {
+		return intctrlutil.RequeueAfter(requeueAfter, reqCtx.Log, "")
+	}
+	return intctrlutil.Reconciled()
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *BackupReplicationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return intctrlutil.NewControllerManagedBy(mgr).
+		Named("backupreplication").
+		For(&dpv1alpha1.Backup{}).
+		Owns(&batchv1.Job{}).
+		Watches(&dpv1alpha1.BackupPolicy{}, handler.EnqueueRequestsFromMapFunc(r.parseBackupPolicy)).
+		Complete(r)
+}
+
+func (r *BackupReplicationReconciler) parseBackupPolicy(ctx context.Context, object client.Object) []reconcile.Request {
+	backupPolicy := object.(*dpv1alpha1.BackupPolicy)
+	if len(backupPolicy.Spec.ReplicationTargets) == 0 {
+		return []reconcile.Request{}
+	}
+	backupList := &dpv1alpha1.BackupList{}
+	if err := r.Client.List(ctx, backupList, client.InNamespace(backupPolicy.Namespace),
+		client.MatchingLabels{dptypes.BackupPolicyLabelKey: backupPolicy.Name}); err != nil {
+		return []reconcile.Request{}
+	}
+	var requests []reconcile.Request
+	for _, backup := range backupList.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: backup.Namespace, Name: backup.Name},
+		})
+	}
+	return requests
+}
+
+// getReplicationTargets gets the replication targets of the backup from its backup policy.
+func (r *BackupReplicationReconciler) getReplicationTargets(reqCtx intctrlutil.RequestCtx,
+	backup *dpv1alpha1.Backup) ([]dpv1alpha1.BackupReplicationTarget, error) {
+	if _, ok := backup.Annotations[dptypes.ImportedFromRepoAnnotationKey]; ok {
+		// the imported backups are copies, do not copy them again.
+		return nil, nil
+	}
+	backupPolicy := &dpv1alpha1.BackupPolicy{}
+	if err := r.Client.Get(reqCtx.Ctx, client.ObjectKey{Namespace: backup.Namespace,
+		Name: backup.Spec.BackupPolicyName}, backupPolicy); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	var targets []dpv1alpha1.BackupReplicationTarget
+	for _, target := range backupPolicy.Spec.ReplicationTargets {
+		// never copy the backup to its own repo, the copy shares the same path.
+		if target.BackupRepoName != backup.Status.BackupRepoName {
+			targets = append(targets, target)
+		}
+	}
+	return targets, nil
+}
+
+// unreplicableReason returns the reason if the backup can never be copied, the copy
+// is done by datasafed, which does not support the volume snapshots and the Kopia repos.
+func unreplicableReason(backup *dpv1alpha1.Backup) string {
+	if backup.Status.KopiaRepoPath != "" {
+		return "the backup stored in a Kopia repository can not be copied"
+	}
+	if backup.Status.BackupMethod != nil && boolptr.IsSetToTrue(backup.Status.BackupMethod.SnapshotVolumes) {
+		return "the backup taking volume snapshots can not be copied"
+	}
+	return ""
+}
+
+// isReplicable checks if the backup can be copied now. The completed backups and the
+// running continuous backups can be copied.
+func isReplicable(backup *dpv1alpha1.Backup) bool {
+	if backup.Status.BackupRepoName == "" || backup.Status.Path == "" {
+		return false
+	}
+	switch backup.Status.Phase {
+	case dpv1alpha1.BackupPhaseCompleted:
+		return true
+	case dpv1alpha1.BackupPhaseRunning:
+		return isContinuousBackup(backup)
+	}
+	return false
+}
+
+func isContinuousBackup(backup *dpv1alpha1.Backup) bool {
+	return backup.Labels[dptypes.BackupTypeLabelKey] == string(dpv1alpha1.BackupTypeContinuous)
+}
+
+// reconcileReplica copies the backup to the replication target, and returns the duration
+// after which the copy should be checked again.
+func (r *BackupReplicationReconciler) reconcileReplica(reqCtx intctrlutil.RequestCtx,
+	replicator *dpbackup.Replicator,
+	backup *dpv1alpha1.Backup,
+	target *dpv1alpha1.BackupReplicationTarget) (time.Duration, error) {
+	if reason := unreplicableReason(backup); reason != "" {
+		if backup.Status.GetReplica(target.BackupRepoName) == nil {
+			// the path is left empty, as there are no files to delete.
+			backup.Status.Replicas = append(backup.Status.Replicas, dpv1alpha1.BackupReplicaStatus{
+				BackupRepoName: target.BackupRepoName,
+				Phase:          dpv1alpha1.BackupReplicaPhaseFailed,
+				FailureReason:  reason,
+			})
+			r.Recorder.Event(backup, corev1.EventTypeWarning, "ReplicateBackupFailed",
+				fmt.Sprintf("failed to copy the backup to backup repo %s: %s", target.BackupRepoName, reason))
+		}
+		return 0, nil
+	}
+	if !isReplicable(backup) {
+		return 0, nil
+	}
+	replica := backup.Status.GetReplica(target.BackupRepoName)
+	if replica == nil {
+		backup.Status.Replicas = append(backup.Status.Replicas, dpv1alpha1.BackupReplicaStatus{
+			BackupRepoName: target.BackupRepoName,
+			Phase:          dpv1alpha1.BackupReplicaPhasePending,
+			Path:           backup.Status.Path,
+		})
+		replica = &backup.Status.Replicas[len(backup.Status.Replicas)-1]
+	}
+	switch replica.Phase {
+	case dpv1alpha1.BackupReplicaPhaseCompleted,
+		dpv1alpha1.BackupReplicaPhaseDeleting,
+		dpv1alpha1.BackupReplicaPhaseDeleted:
+		return 0, nil
+	}
+
+	pending := func(reason string) (time.Duration, error) {
+		if replica.Phase != dpv1alpha1.BackupReplicaPhaseRunning {
+			replica.Phase = dpv1alpha1.BackupReplicaPhasePending
+		}
+		replica.FailureReason = reason
+		return replicationCheckInterval, nil
+	}
+
+	// the incremental backup depends on its parent backup, copy it after the parent backup is copied.
+	if backup.Status.ParentBackupName != "" {
+		parent := &dpv1alpha1.Backup{}
+		if err := r.Client.Get(reqCtx.Ctx, client.ObjectKey{Namespace: backup.Namespace,
+			Name: backup.Status.ParentBackupName}, parent); err != nil {
+			if apierrors.IsNotFound(err) {
+				return pending(fmt.Sprintf("the parent backup %s is not found", backup.Status.ParentBackupName))
+			}
+			return 0, err
+		}
+		if parentReplica := parent.Status.GetReplica(target.BackupRepoName); parentReplica == nil ||
+			parentReplica.Phase != dpv1alpha1.BackupReplicaPhaseCompleted {
+			return pending(fmt.Sprintf("waiting for the parent backup %s to be copied", parent.Name))
+		}
+	}
+
+	sourceRepo, reason, err := r.getReadyBackupRepo(reqCtx, backup.Status.BackupRepoName)
+	if err != nil {
+		return 0, err
+	}
+	if reason != "" {
+		return pending(reason)
+	}
+	targetRepo, reason, err := r.getReadyBackupRepo(reqCtx, target.BackupRepoName)
+	if err != nil {
+		return 0, err
+	}
+	if reason != "" {
+		return pending(reason)
+	}
+
+	jobKey := dpbackup.BuildReplicationJobKey(backup, target.BackupRepoName)
+	job := &batchv1.Job{}
+	exists, err := intctrlutil.CheckResourceExists(reqCtx.Ctx, r.Client, jobKey, job)
+	if err != nil {
+		return 0, err
+	}
+	if exists {
+		if !job.DeletionTimestamp.IsZero() {
+			// wait for the previous job to be deleted
+			return replicationCheckInterval, nil
+		}
+		_, finishedType, msg := dputils.IsJobFinished(job)
+		switch finishedType {
+		case batchv1.JobComplete:
+			return r.handleReplicationCompleted(reqCtx, backup, replica, target, job)
+		case batchv1.JobFailed:
+			if replica.Phase != dpv1alpha1.BackupReplicaPhaseFailed {
+				r.Recorder.Event(backup, corev1.EventTypeWarning, "ReplicateBackupFailed",
+					fmt.Sprintf("failed to copy the backup to backup repo %s: %s", target.BackupRepoName, msg))
+			}
+			replica.Phase = dpv1alpha1.BackupReplicaPhaseFailed
+			replica.FailureReason = fmt.Sprintf(`replication job "%s" failed, you can delete it to retry, %s`, job.Name, msg)
+		}
+		return 0, nil
+	}
+
+	// the running continuous backups are copied periodically.
+	if replica.LastSyncTime != nil {
+		if after := continuousReplicationInterval - time.Since(replica.LastSyncTime.Time); after > 0 {
+			return after, nil
+		}
+	}
+	if _, err = replicator.Replicate(backup, sourceRepo, targetRepo); err != nil {
+		return 0, err
+	}
+	replica.Phase = dpv1alpha1.BackupReplicaPhaseRunning
+	replica.FailureReason = ""
+	if replica.StartTimestamp == nil {
+		replica.StartTimestamp = &metav1.Time{Time: time.Now()}
+	}
+	return 0, nil
+}
+
+// handleReplicationCompleted records the copied data after the replication job is completed,
+// and deletes the job to allow the running continuous backups to be copied again.
+func (r *BackupReplicationReconciler) handleReplicationCompleted(reqCtx intctrlutil.RequestCtx,
+	backup *dpv1alpha1.Backup,
+	replica *dpv1alpha1.BackupReplicaStatus,
+	target *dpv1alpha1.BackupReplicationTarget,
+	job *batchv1.Job) (time.Duration, error) {
+	now := metav1.Now()
+	replica.LastSyncTime = &now
+	replica.FailureReason = ""
+	replica.Phase = dpv1alpha1.BackupReplicaPhaseRunning
+	copiedEnd := dpbackup.GetReplicatedTimeRangeEnd(job)
+	if timeRange := backup.Status.TimeRange; timeRange != nil && copiedEnd != nil {
+		replica.TimeRange = &dpv1alpha1.BackupTimeRange{
+			TimeZone: timeRange.TimeZone,
+			Start:    timeRange.Start,
+			End:      copiedEnd,
+		}
+	}
+
+	// the copy is completed if all the data of the completed backup has been copied.
+	var after time.Duration
+	if backup.Status.Phase == dpv1alpha1.BackupPhaseCompleted && (!isContinuousBackup(backup) ||
+		backup.Status.TimeRange == nil || backup.Status.TimeRange.End == nil ||
+		(copiedEnd != nil && !copiedEnd.Before(backup.Status.TimeRange.End))) {
+		replica.Phase = dpv1alpha1.BackupReplicaPhaseCompleted
+		replica.CompletionTimestamp = &now
+		retention, err := target.RetentionPeriod.ToDuration()
+		if err != nil {
+			return 0, err
+		}
+		if retention > 0 {
+			replica.Expiration = &metav1.Time{Time: now.Add(retention)}
+			after = retention
+		}
+		r.Recorder.Event(backup, corev1.EventTypeNormal, "ReplicatedBackup",
+			fmt.Sprintf("the backup is copied to backup repo %s", target.BackupRepoName))
+	} else {
+		after = continuousReplicationInterval
+	}
+	return after, intctrlutil.BackgroundDeleteObject(r.Client, reqCtx.Ctx, job)
+}
+
+// getReadyBackupRepo gets the backup repo, and returns the reason if it is not ready.
+func (r *BackupReplicationReconciler) getReadyBackupRepo(reqCtx intctrlutil.RequestCtx,
+	name string) (*dpv1alpha1.BackupRepo, string, error) {
+	repo := &dpv1alpha1.BackupRepo{}
+	if err := r.Client.Get(reqCtx.Ctx, client.ObjectKey{Name: name}, repo); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Sprintf("backup repo %s is not found", name), nil
+		}
+		return nil, "", err
+	}
+	if repo.Status.Phase != dpv1alpha1.BackupRepoReady {
+		return nil, fmt.Sprintf("backup repo %s is not ready", name), nil
+	}
+	return repo, "", nil
+}
+
+// reconcileExpiration deletes the expired copy, and returns the duration after which the
+// copy will be expired.
+func (r *BackupReplicationReconciler) reconcileExpiration(reqCtx intctrlutil.RequestCtx,
+	saName string,
+	backup *dpv1alpha1.Backup,
+	replica *dpv1alpha1.BackupReplicaStatus) (time.Duration, error) {
+	if replica.Expiration == nil || replica.Phase == dpv1alpha1.BackupReplicaPhaseDeleted {
+		return 0, nil
+	}
+	if after := time.Until(replica.Expiration.Time); after > 0 {
+		return after, nil
+	}
+	// the copy is retained while the copies of its child backups in the same repo are retained.
+	depended, err := r.isReplicaDepended(reqCtx, backup, replica.BackupRepoName)
+	if err != nil || depended {
+		return replicationCheckInterval, err
+	}
+	replica.Phase = dpv1alpha1.BackupReplicaPhaseDeleting
+	deleter := &dpbackup.Deleter{
+		RequestCtx:           reqCtx,
+		Client:               r.Client,
+		Scheme:               r.Scheme,
+		WorkerServiceAccount: saName,
+	}
+	status, err := deleter.DeleteReplicaFiles(backup, replica)
+	switch status {
+	case dpbackup.DeletionStatusSucceeded:
+		replica.Phase = dpv1alpha1.BackupReplicaPhaseDeleted
+		return 0, nil
+	case dpbackup.DeletionStatusFailed:
+		if replica.FailureReason != err.Error() {
+			r.Recorder.Event(backup, corev1.EventTypeWarning, "DeleteReplicaFilesFailed", err.Error())
+		}
+		replica.FailureReason = err.Error()
+		return 0, nil
+	}
+	return 0, err
+}
+
+func (r *BackupReplicationReconciler) isReplicaDepended(reqCtx intctrlutil.RequestCtx,
+	backup *dpv1alpha1.Backup, backupRepoName string) (bool, error) {
+	backupList := &dpv1alpha1.BackupList{}
+	if err := r.Client.List(reqCtx.Ctx, backupList, client.InNamespace(backup.Namespace),
+		client.MatchingLabels{dptypes.BackupPolicyLabelKey: backup.Spec.BackupPolicyName}); err != nil {
+		return false, err
+	}
+	for _, child := range backupList.Items {
+		if child.Status.ParentBackupName != backup.Name || !child.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if replica := child.Status.GetReplica(backupRepoName); replica != nil &&
+			replica.Phase != dpv1alpha1.BackupReplicaPhaseDeleted {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// handleDeletion deletes the copies of the backup before the backup is deleted.
+func (r *BackupReplicationReconciler) handleDeletion(reqCtx intctrlutil.RequestCtx,
+	backup *dpv1alpha1.Backup) (ctrl.Result, error) {
+	if !controllerutil.ContainsFinalizer(backup, dptypes.ReplicationFinalizerName) {
+		return intctrlutil.Reconciled()
+	}
+	removeFinalizer := func() (ctrl.Result, error) {
+		patch := client.MergeFrom(backup.DeepCopy())
+		controllerutil.RemoveFinalizer(backup, dptypes.ReplicationFinalizerName)
+		if err := r.Client.Patch(reqCtx.Ctx, backup, patch); err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+		return intctrlutil.Reconciled()
+	}
+	if backup.Spec.DeletionPolicy == dpv1alpha1.BackupDeletionPolicyRetain {
+		return removeFinalizer()
+	}
+
+	// stop the replication jobs before deleting the copies.
+	labels := map[string]string{dptypes.BackupReplicationLabelKey: backup.Name}
+	jobList := &batchv1.JobList{}
+	if err := r.Client.List(reqCtx.Ctx, jobList, client.InNamespace(backup.Namespace), client.MatchingLabels(labels)); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	for i := range jobList.Items {
+		if err := intctrlutil.BackgroundDeleteObject(r.Client, reqCtx.Ctx, &jobList.Items[i]); err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+	}
+	podList := &corev1.PodList{}
+	if err := r.Client.List(reqCtx.Ctx, podList, client.InNamespace(backup.Namespace), client.MatchingLabels(labels)); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if len(jobList.Items) > 0 || len(podList.Items) > 0 {
+		return intctrlutil.RequeueAfter(reconcileInterval, reqCtx.Log, "wait for the replication jobs to be deleted")
+	}
+
+	saName, err := EnsureWorkerServiceAccount(reqCtx, r.Client, backup.Namespace, nil)
+	if err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	deleter := &dpbackup.Deleter{
+		RequestCtx:           reqCtx,
+		Client:               r.Client,
+		Scheme:               r.Scheme,
+		WorkerServiceAccount: saName,
+	}
+	original := backup.DeepCopy()
+	deleted := true
+	for i := range backup.Status.Replicas {
+		replica := &backup.Status.Replicas[i]
+		if replica.Phase == dpv1alpha1.BackupReplicaPhaseDeleted {
+			continue
+		}
+		status, err := deleter.DeleteReplicaFiles(backup, replica)
+		switch status {
+		case dpbackup.DeletionStatusSucceeded:
+			replica.Phase = dpv1alpha1.BackupReplicaPhaseDeleted
+			continue
+		case dpbackup.DeletionStatusFailed:
+			if replica.FailureReason != err.Error() {
+				r.Recorder.Event(backup, corev1.EventTypeWarning, "DeleteReplicaFilesFailed", err.Error())
+			}
+			replica.FailureReason = err.Error()
+		case dpbackup.DeletionStatusDeleting:
+			replica.Phase = dpv1alpha1.BackupReplicaPhaseDeleting
+		default:
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+		deleted = false
+	}
+	if !reflect.DeepEqual(original.Status, backup.Status) {
+		if err = r.Client.Status().Patch(reqCtx.Ctx, backup, client.MergeFrom(original)); err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+	}
+	if !deleted {
+		// wait for the deletion jobs, which are owned by the backup.
+		return intctrlutil.Reconciled()
+	}
+	return removeFinalizer()
+}
@@ -0,0 +1,306 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package dataprotection
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	dpbackup "github.com/apecloud/kubeblocks/pkg/dataprotection/backup"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+const (
+	replicationTestNamespace  = "default"
+	replicationTestPolicy     = "policy"
+	replicationTestSourceRepo = "primary-repo"
+	replicationTestTargetRepo = "secondary-repo"
+)
+
+func newReplicationTestScheme() *k8sruntime.Scheme {
+	scheme := k8sruntime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = dpv1alpha1.AddToScheme(scheme)
+	return scheme
+}
+
+func newReplicationTestObjects(retention dpv1alpha1.RetentionPeriod) []client.Object {
+	viper.Set(dptypes.CfgKeyWorkerServiceAccountName, "kubeblocks-dataprotection-worker")
+	viper.Set(dptypes.CfgKeyWorkerClusterRoleName, "kubeblocks-dataprotection-worker-role")
+	newRepo := func(name string) *dpv1alpha1.BackupRepo {
+		return &dpv1alpha1.BackupRepo{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       dpv1alpha1.BackupRepoSpec{AccessMethod: dpv1alpha1.AccessMethodTool},
+			Status: dpv1alpha1.BackupRepoStatus{
+				Phase:                dpv1alpha1.BackupRepoReady,
+				ToolConfigSecretName: name + "-config",
+			},
+		}
+	}
+	policy := &dpv1alpha1.BackupPolicy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: replicationTestNamespace, Name: replicationTestPolicy},
+		Spec: dpv1alpha1.BackupPolicySpec{
+			ReplicationTargets: []dpv1alpha1.BackupReplicationTarget{
+				{BackupRepoName: replicationTestTargetRepo, RetentionPeriod: retention},
+			},
+		},
+	}
+	return []client.Object{newRepo(replicationTestSourceRepo), newRepo(replicationTestTargetRepo), policy}
+}
+
+func newReplicationTestBackup(name string) *dpv1alpha1.Backup {
+	return &dpv1alpha1.Backup{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: replicationTestNamespace,
+			Name:      name,
+			UID:       types.UID("12345678-" + name),
+			Labels:    map[string]string{dptypes.BackupPolicyLabelKey: replicationTestPolicy},
+		},
+		Spec: dpv1alpha1.BackupSpec{
+			BackupPolicyName: replicationTestPolicy,
+			DeletionPolicy:   dpv1alpha1.BackupDeletionPolicyDelete,
+		},
+		Status: dpv1alpha1.BackupStatus{
+			Phase:          dpv1alpha1.BackupPhaseCompleted,
+			BackupRepoName: replicationTestSourceRepo,
+			Path:           "/default/" + name,
+		},
+	}
+}
+
+func newReplicationTestReconciler(funcs *interceptor.Funcs, objs ...client.Object) *BackupReplicationReconciler {
+	scheme := newReplicationTestScheme()
+	builder := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).
+		WithStatusSubresource(&dpv1alpha1.Backup{})
+	if funcs != nil {
+		builder = builder.WithInterceptorFuncs(*funcs)
+	}
+	return &BackupReplicationReconciler{
+		Client:   builder.Build(),
+		Scheme:   scheme,
+		Recorder: record.NewFakeRecorder(10),
+	}
+}
+
+func reconcileReplication(t *testing.T, r *BackupReplicationReconciler, backup *dpv1alpha1.Backup) (ctrl.Result, *dpv1alpha1.Backup) {
+	key := client.ObjectKeyFromObject(backup)
+	result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+	if err != nil {
+		t.Fatal(err)
+	}
+	latest := &dpv1alpha1.Backup{}
+	if err = r.Client.Get(context.Background(), key, latest); err != nil {
+		t.Fatal(err)
+	}
+	return result, latest
+}
+
+func completeJob(t *testing.T, cli client.Client, key client.ObjectKey) {
+	job := &batchv1.Job{}
+	if err := cli.Get(context.Background(), key, job); err != nil {
+		t.Fatal(err)
+	}
+	job.Status.Conditions = append(job.Status.Conditions, batchv1.JobCondition{
+		Type:   batchv1.JobComplete,
+		Status: corev1.ConditionTrue,
+	})
+	if err := cli.Status().Update(context.Background(), job); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBackupReplicationReconcile(t *testing.T) {
+	backup := newReplicationTestBackup("full")
+	r := newReplicationTestReconciler(nil, append(newReplicationTestObjects("7d"), backup)...)
+
+	_, latest := reconcileReplication(t, r, backup)
+	if !controllerutil.ContainsFinalizer(latest, dptypes.ReplicationFinalizerName) {
+		t.Error("expect the replication finalizer to be added")
+	}
+	replica := latest.Status.GetReplica(replicationTestTargetRepo)
+	if replica == nil || replica.Phase != dpv1alpha1.BackupReplicaPhaseRunning || replica.Path != backup.Status.Path {
+		t.Fatalf("expect the copy to be running, got %v", replica)
+	}
+	jobKey := dpbackup.BuildReplicationJobKey(latest, replicationTestTargetRepo)
+	completeJob(t, r.Client, jobKey)
+
+	result, latest := reconcileReplication(t, r, latest)
+	replica = latest.Status.GetReplica(replicationTestTargetRepo)
+	if replica.Phase != dpv1alpha1.BackupReplicaPhaseCompleted || replica.Expiration == nil {
+		t.Fatalf("expect the copy to be completed with expiration, got %v", replica)
+	}
+	if result.RequeueAfter <= 0 || result.RequeueAfter > 7*24*time.Hour {
+		t.Errorf("expect to check the expiration of the copy, got %v", result.RequeueAfter)
+	}
+	if err := r.Client.Get(context.Background(), jobKey, &batchv1.Job{}); err == nil {
+		t.Error("expect the completed replication job to be deleted")
+	}
+}
+
+func TestBackupReplicationParentOrdering(t *testing.T) {
+	parent := newReplicationTestBackup("full")
+	child := newReplicationTestBackup("incremental")
+	child.Status.ParentBackupName = parent.Name
+	r := newReplicationTestReconciler(nil, append(newReplicationTestObjects(""), parent, child)...)
+
+	result, latest := reconcileReplication(t, r, child)
+	replica := latest.Status.GetReplica(replicationTestTargetRepo)
+	if replica == nil || replica.Phase != dpv1alpha1.BackupReplicaPhasePending ||
+		!strings.Contains(replica.FailureReason, parent.Name) || result.RequeueAfter != replicationCheckInterval {
+		t.Fatalf("expect the copy to wait for the parent, got %v", replica)
+	}
+
+	_, latestParent := reconcileReplication(t, r, parent)
+	completeJob(t, r.Client, dpbackup.BuildReplicationJobKey(latestParent, replicationTestTargetRepo))
+	if _, latestParent = reconcileReplication(t, r, latestParent); latestParent.Status.GetReplica(replicationTestTargetRepo).Phase !=
+		dpv1alpha1.BackupReplicaPhaseCompleted {
+		t.Fatal("expect the copy of the parent to be completed")
+	}
+
+	_, latest = reconcileReplication(t, r, latest)
+	if replica = latest.Status.GetReplica(replicationTestTargetRepo); replica.Phase != dpv1alpha1.BackupReplicaPhaseRunning {
+		t.Errorf("expect the copy to start after the parent is copied, got %v", replica)
+	}
+}
+
+func TestBackupReplicationRepoError(t *testing.T) {
+	backup := newReplicationTestBackup("full")
+	funcs := &interceptor.Funcs{
+		Get: func(ctx context.Context, cli client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			if _, ok := obj.(*dpv1alpha1.BackupRepo); ok {
+				return errors.New("mock API error")
+			}
+			return cli.Get(ctx, key, obj, opts...)
+		},
+	}
+	r := newReplicationTestReconciler(funcs, append(newReplicationTestObjects(""), backup)...)
+	_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(backup)})
+	if err == nil || !strings.Contains(err.Error(), "mock API error") {
+		t.Errorf("expect the API error to be returned rather than pending the copy, got %v", err)
+	}
+}
+
+func TestBackupReplicationUnsupported(t *testing.T) {
+	backup := newReplicationTestBackup("kopia")
+	backup.Status.KopiaRepoPath = "/kopia"
+	r := newReplicationTestReconciler(nil, append(newReplicationTestObjects(""), backup)...)
+
+	_, latest := reconcileReplication(t, r, backup)
+	replica := latest.Status.GetReplica(replicationTestTargetRepo)
+	if replica == nil || replica.Phase != dpv1alpha1.BackupReplicaPhaseFailed || replica.Path != "" {
+		t.Fatalf("expect the copy to be failed, got %v", replica)
+	}
+	recorder := r.Recorder.(*record.FakeRecorder)
+	if len(recorder.Events) != 1 || !strings.Contains(<-recorder.Events, "ReplicateBackupFailed") {
+		t.Error("expect an event about the backup can not be copied")
+	}
+	jobList := &batchv1.JobList{}
+	if err := r.Client.List(context.Background(), jobList); err != nil || len(jobList.Items) != 0 {
+		t.Errorf("expect no replication job to be created, got %d", len(jobList.Items))
+	}
+}
+
+func TestBackupReplicationExpiration(t *testing.T) {
+	expired := []dpv1alpha1.BackupReplicaStatus{{
+		BackupRepoName: replicationTestTargetRepo,
+		Phase:          dpv1alpha1.BackupReplicaPhaseCompleted,
+		Path:           "/default/full",
+		Expiration:     &metav1.Time{Time: time.Now().Add(-time.Minute)},
+	}}
+	parent := newReplicationTestBackup("full")
+	parent.Status.Replicas = expired
+	child := newReplicationTestBackup("incremental")
+	child.Status.ParentBackupName = parent.Name
+	child.Status.Replicas = []dpv1alpha1.BackupReplicaStatus{{
+		BackupRepoName: replicationTestTargetRepo,
+		Phase:          dpv1alpha1.BackupReplicaPhaseCompleted,
+		Path:           "/default/incremental",
+	}}
+	r := newReplicationTestReconciler(nil, append(newReplicationTestObjects("1d"), parent, child)...)
+
+	// the copy is retained while the copy of its child is retained
+	_, latest := reconcileReplication(t, r, parent)
+	if replica := latest.Status.GetReplica(replicationTestTargetRepo); replica.Phase != dpv1alpha1.BackupReplicaPhaseCompleted {
+		t.Fatalf("expect the depended copy to be retained, got %v", replica.Phase)
+	}
+
+	if err := r.Client.Delete(context.Background(), child); err != nil {
+		t.Fatal(err)
+	}
+	_, latest = reconcileReplication(t, r, latest)
+	if replica := latest.Status.GetReplica(replicationTestTargetRepo); replica.Phase != dpv1alpha1.BackupReplicaPhaseDeleting {
+		t.Fatalf("expect the expired copy to be deleted, got %v", replica.Phase)
+	}
+	completeJob(t, r.Client, dpbackup.BuildDeleteReplicaFilesJobKey(latest, replicationTestTargetRepo))
+	_, latest = reconcileReplication(t, r, latest)
+	if replica := latest.Status.GetReplica(replicationTestTargetRepo); replica.Phase != dpv1alpha1.BackupReplicaPhaseDeleted {
+		t.Errorf("expect the expired copy to be deleted, got %v", replica.Phase)
+	}
+}
+
+func TestBackupReplicationHandleDeletion(t *testing.T) {
+	backup := newReplicationTestBackup("full")
+	backup.Finalizers = []string{dptypes.ReplicationFinalizerName}
+	backup.Status.Replicas = []dpv1alpha1.BackupReplicaStatus{{
+		BackupRepoName: replicationTestTargetRepo,
+		Phase:          dpv1alpha1.BackupReplicaPhaseCompleted,
+		Path:           backup.Status.Path,
+	}}
+	r := newReplicationTestReconciler(nil, append(newReplicationTestObjects(""), backup)...)
+	if err := r.Client.Delete(context.Background(), backup); err != nil {
+		t.Fatal(err)
+	}
+
+	_, latest := reconcileReplication(t, r, backup)
+	if replica := latest.Status.GetReplica(replicationTestTargetRepo); replica.Phase != dpv1alpha1.BackupReplicaPhaseDeleting {
+		t.Fatalf("expect the copy to be deleting, got %v", replica.Phase)
+	}
+	if !controllerutil.ContainsFinalizer(latest, dptypes.ReplicationFinalizerName) {
+		t.Fatal("expect the finalizer to be retained until the copies are deleted")
+	}
+
+	completeJob(t, r.Client, dpbackup.BuildDeleteReplicaFilesJobKey(latest, replicationTestTargetRepo))
+	key := client.ObjectKeyFromObject(backup)
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatal(err)
+	}
+	// the backup is removed once the finalizer is removed
+	if err := r.Client.Get(context.Background(), key, &dpv1alpha1.Backup{}); err == nil {
+		t.Error("expect the finalizer to be removed after the copies are deleted")
+	}
+}
@@ -64,6 +64,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/multicluster"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	dpbackup "github.com/apecloud/kubeblocks/pkg/dataprotection/backup"
 	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
 	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
 	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils/boolptr"
@@ -77,6 +78,8 @@ const (
 	defaultCheckInterval   = 1 * time.Minute
 
 	preCheckContainerName = "pre-check"
+	syncContainerName     = "sync"
+	syncCatalogMountPath  = "/dp-catalog"
 )
 
 var (
@@ -144,8 +147,9 @@ type BackupRepoReconciler struct {
 // create or watch StorageProviders
 // +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=storageproviders,verbs=create;get;list;watch
 
-// watch or update Backups
-// +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=backups,verbs=get;list;watch;update;patch
+// watch or update Backups, and import Backups from the repo
+// +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=backups,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=backups/status,verbs=get;update;patch
 
 // watch or update Restores
 // +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=restores,verbs=get;list;watch;update;patch
@@ -162,6 +166,9 @@ type BackupRepoReconciler struct {
 // create or delete Jobs
 // +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
 
+// read and delete the backup catalogs collected by the sync jobs
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;delete;deletecollection
+
 // manage service accounts for worker
 // +kubebuilder:rbac:groups=core,resources=serviceaccounts,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings,verbs=get;list;watch;create;update;patch;delete
@@ -287,6 +294,14 @@ func (r *BackupRepoReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 			return checkedRequeueWithError(err, reqCtx.Log,
 				"check associated restores failed")
 		}
+
+		// sync backups from the repo if needed
+		res, err := r.syncBackupsFromRepo(reconCtx)
+		if err != nil {
+			return checkedRequeueWithError(err, reqCtx.Log,
+				"failed to sync backups from the repo")
+		}
+		return res, nil
 	}
 
 	return ctrl.Result{}, nil
@@ -1081,6 +1096,238 @@ func (r *BackupRepoReconciler) prepareBackupRepoInNamespace(reconCtx *reconcileC
 	return nil
 }
 
+// syncBackupsFromRepo runs a job to collect the backup manifests in the repo, and imports
+// the backups which do not exist in the cluster.
+func (r *BackupRepoReconciler) syncBackupsFromRepo(reconCtx *reconcileContext) (ctrl.Result, error) {
+	repo := reconCtx.repo
+	if repo.Spec.Sync == nil || !repo.Spec.Sync.Enabled {
+		return ctrl.Result{}, nil
+	}
+	if after, due := nextSyncAfter(repo); !due {
+		return ctrl.Result{RequeueAfter: after}, nil
+	}
+
+	namespace := viper.GetString(constant.CfgKeyCtrlrMgrNS)
+	if err := r.prepareBackupRepoInNamespace(reconCtx, namespace); err != nil {
+		return ctrl.Result{}, err
+	}
+	saName, err := EnsureWorkerServiceAccount(reconCtx.RequestCtx, r.Client, namespace, r.MultiClusterMgr)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	job, err := r.runSyncJob(reconCtx, namespace, saName)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	finished, jobStatus, failureReason := utils.IsJobFinished(job)
+	if !finished {
+		return ctrl.Result{}, intctrlutil.NewRequeueError(defaultCheckInterval, "wait sync job to finish")
+	}
+
+	syncStatus := &dpv1alpha1.BackupRepoSyncStatus{
+		LastSyncTime: &metav1.Time{Time: wallClock.Now()},
+	}
+	if jobStatus == batchv1.JobFailed {
+		syncStatus.Message = fmt.Sprintf("Sync job failed: %s", failureReason)
+		r.Recorder.Event(repo, corev1.EventTypeWarning, "SyncBackupsFailed", syncStatus.Message)
+	} else {
+		catalog, err := r.readSyncCatalog(reconCtx, job)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		manifests, errs := dpbackup.ParseCatalog(catalog)
+		imported, importErrs := r.importBackups(reconCtx, manifests)
+		errs = append(errs, importErrs...)
+		syncStatus.ImportedBackups = imported
+		syncStatus.Message = fmt.Sprintf("Found %d backup manifests, imported %d backups.", len(manifests), imported)
+		if len(errs) > 0 {
+			syncStatus.Message += fmt.Sprintf(" %d errors occurred, the first one: %s", len(errs), errs[0].Error())
+		}
+		r.Recorder.Event(repo, corev1.EventTypeNormal, "SyncedBackups", syncStatus.Message)
+	}
+
+	// remove the job and the catalog, and record the sync status
+	if err = intctrlutil.BackgroundDeleteObject(r.Client, reconCtx.Ctx, job, multicluster.InControlContext()); err != nil {
+		return ctrl.Result{}, err
+	}
+	if err = r.Client.DeleteAllOf(reconCtx.Ctx, &corev1.ConfigMap{}, client.InNamespace(job.Namespace),
+		client.MatchingLabels(r.syncCatalogLabels(reconCtx.repo)), multicluster.InControlContext()); err != nil {
+		return ctrl.Result{}, err
+	}
+	patch := client.MergeFrom(repo.DeepCopy())
+	repo.Status.Sync = syncStatus
+	if err = r.Client.Status().Patch(reconCtx.Ctx, repo, patch, multicluster.InControlContext()); err != nil {
+		return ctrl.Result{}, err
+	}
+	after, _ := nextSyncAfter(repo)
+	return ctrl.Result{RequeueAfter: after}, nil
+}
+
+// nextSyncAfter returns the duration until the next sync, and whether the sync is due now.
+func nextSyncAfter(repo *dpv1alpha1.BackupRepo) (time.Duration, bool) {
+	if repo.Status.Sync == nil || repo.Status.Sync.LastSyncTime == nil {
+		return 0, true
+	}
+	if repo.Spec.Sync.Interval == nil || repo.Spec.Sync.Interval.Duration <= 0 {
+		// sync only once
+		return 0, false
+	}
+	after := repo.Spec.Sync.Interval.Duration - wallClock.Since(repo.Status.Sync.LastSyncTime.Time)
+	if after <= 0 {
+		return 0, true
+	}
+	return after, false
+}
+
+func (r *BackupRepoReconciler) syncResourceName(repo *dpv1alpha1.BackupRepo) string {
+	return cutName(fmt.Sprintf("sync-%s-%s", repo.UID[:8], repo.Name))
+}
+
+// syncCatalogLabels returns the labels of the ConfigMaps which carry the backup catalog collected by the sync job.
+func (r *BackupRepoReconciler) syncCatalogLabels(repo *dpv1alpha1.BackupRepo) map[string]string {
+	return map[string]string{
+		dataProtectionBackupRepoKey:    repo.Name,
+		dataProtectionBackupCatalogKey: r.syncResourceName(repo),
+	}
+}
+
+// readSyncCatalog reads the backup catalog collected by the sync job, which is split into
+// chunks and stored in ConfigMaps, as it may exceed the size limit of a single object.
+func (r *BackupRepoReconciler) readSyncCatalog(reconCtx *reconcileContext, job *batchv1.Job) ([]byte, error) {
+	cmList := &corev1.ConfigMapList{}
+	if err := r.Client.List(reconCtx.Ctx, cmList, client.InNamespace(job.Namespace),
+		client.MatchingLabels(r.syncCatalogLabels(reconCtx.repo)), multicluster.InControlContext()); err != nil {
+		return nil, err
+	}
+	// the chunks are named in order
+	slices.SortFunc(cmList.Items, func(a, b corev1.ConfigMap) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	var catalog []byte
+	for _, cm := range cmList.Items {
+		if data, ok := cm.Data[dpbackup.CatalogConfigMapKey]; ok {
+			catalog = append(catalog, data...)
+		} else {
+			catalog = append(catalog, cm.BinaryData[dpbackup.CatalogConfigMapKey]...)
+		}
+	}
+	return catalog, nil
+}
+
+func (r *BackupRepoReconciler) runSyncJob(reconCtx *reconcileContext, namespace string, saName string) (*batchv1.Job, error) {
+	job := &batchv1.Job{}
+	job.Name = r.syncResourceName(reconCtx.repo)
+	job.Namespace = namespace
+	_, err := createObjectIfNotExist(reconCtx.Ctx, r.Client, job, func() error {
+		runAsUser := int64(0)
+		job.Spec = batchv1.JobSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					Containers: []corev1.Container{{
+						Name:            syncContainerName,
+						Image:           viper.GetString(constant.KBToolsImage),
+						ImagePullPolicy: corev1.PullPolicy(viper.GetString(constant.KBImagePullPolicy)),
+						Command: []string{
+							"sh", "-c",
+							dpbackup.BuildSyncManifestsScript(filepath.Join("/", reconCtx.repo.Spec.PathPrefix),
+								syncCatalogMountPath, namespace, job.Name, r.syncCatalogLabels(reconCtx.repo)),
+						},
+						SecurityContext: &corev1.SecurityContext{
+							AllowPrivilegeEscalation: boolptr.False(),
+							RunAsUser:                &runAsUser,
+						},
+						VolumeMounts: []corev1.VolumeMount{{
+							Name:      "dp-catalog",
+							MountPath: syncCatalogMountPath,
+						}},
+					}},
+					Volumes: []corev1.Volume{{
+						Name: "dp-catalog",
+						VolumeSource: corev1.VolumeSource{
+							EmptyDir: &corev1.EmptyDirVolumeSource{},
+						},
+					}},
+					ServiceAccountName: saName,
+				},
+			},
+			BackoffLimit: pointer.Int32(2),
+		}
+		job.Labels = map[string]string{
+			dataProtectionBackupRepoKey: reconCtx.repo.Name,
+		}
+		if err := utils.AddTolerations(&job.Spec.Template.Spec); err != nil {
+			return err
+		}
+		for i := range job.Spec.Template.Spec.Containers {
+			intctrlutil.InjectZeroResourcesLimitsIfEmpty(&job.Spec.Template.Spec.Containers[i])
+		}
+		utils.InjectDatasafed(&job.Spec.Template.Spec, reconCtx.repo, dpbackup.RepoVolumeMountPath, nil, "")
+		return controllerutil.SetControllerReference(reconCtx.repo, job, r.Scheme)
+	}, multicluster.InControlContext())
+	if err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// importBackups creates the backups from the manifests, and returns the number of
+// imported backups. The backups which already exist, are expired, or whose namespaces
+// do not exist are skipped.
+func (r *BackupRepoReconciler) importBackups(reconCtx *reconcileContext, manifests []*dpbackup.Manifest) (int32, []error) {
+	var (
+		imported int32
+		errs     []error
+	)
+	for _, manifest := range manifests {
+		backup := manifest.ToBackup(reconCtx.repo.Name)
+		backup.Labels[dataProtectionBackupRepoKey] = reconCtx.repo.Name
+		delete(backup.Labels, dataProtectionWaitRepoPreparationKey)
+		if backup.Status.Expiration != nil && backup.Status.Expiration.Time.Before(wallClock.Now()) {
+			reconCtx.Log.V(1).Info("skip the expired backup", "backup", client.ObjectKeyFromObject(backup))
+			continue
+		}
+		ok, err := r.importBackup(reconCtx, backup)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to import backup %s: %w", client.ObjectKeyFromObject(backup), err))
+			continue
+		}
+		if ok {
+			imported++
+		}
+	}
+	return imported, errs
+}
+
+func (r *BackupRepoReconciler) importBackup(reconCtx *reconcileContext, backup *dpv1alpha1.Backup) (bool, error) {
+	status := backup.Status
+	existing := &dpv1alpha1.Backup{}
+	err := r.Client.Get(reconCtx.Ctx, client.ObjectKeyFromObject(backup), existing, multicluster.InControlContext())
+	switch {
+	case err == nil:
+		// restore the status if the backup was imported by this repo but its status
+		// failed to be updated.
+		if existing.Status.Phase != "" ||
+			existing.Annotations[dptypes.ImportedFromRepoAnnotationKey] != reconCtx.repo.Name {
+			return false, nil
+		}
+		backup = existing
+	case apierrors.IsNotFound(err):
+		// the creation fails if the namespace of the backup does not exist.
+		if err = r.Client.Create(reconCtx.Ctx, backup, multicluster.InControlContext()); err != nil {
+			return false, err
+		}
+	default:
+		return false, err
+	}
+	backup.Status = status
+	if err = r.Client.Status().Update(reconCtx.Ctx, backup, multicluster.InControlContext()); err != nil {
+		return false, err
+	}
+	reconCtx.Log.Info("imported backup from the repo", "backup", client.ObjectKeyFromObject(backup))
+	return true, nil
+}
+
 func (r *BackupRepoReconciler) listAssociatedRestores(
 	ctx context.Context, repo *dpv1alpha1.BackupRepo, extraSelector map[string]string) ([]*dpv1alpha1.Restore, error) {
 	// list restores associated with the repo
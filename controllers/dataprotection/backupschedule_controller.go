@@ -22,6 +22,7 @@ package dataprotection
 import (
 	"context"
 	"reflect"
+	"time"
 
 	batchv1 "k8s.io/api/batch/v1"
 	batchv1beta1 "k8s.io/api/batch/v1beta1"
@@ -35,11 +36,14 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
 	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 	dpbackup "github.com/apecloud/kubeblocks/pkg/dataprotection/backup"
+	dprestore "github.com/apecloud/kubeblocks/pkg/dataprotection/restore"
 	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
 	dputils "github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils/boolptr"
 )
 
 // BackupScheduleReconciler reconciles a BackupSchedule object
@@ -56,6 +60,11 @@ type BackupScheduleReconciler struct {
 // +kubebuilder:rbac:groups=batch,resources=cronjobs,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=batch,resources=cronjobs/status,verbs=get
 // +kubebuilder:rbac:groups=batch,resources=cronjobs/finalizers,verbs=update;patch
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=clusters,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=restores,verbs=get;list;watch
+// +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=backups/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the backupschedule closer to the desired state.
@@ -91,7 +100,18 @@ func (r *BackupScheduleReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		return r.patchStatusFailed(reqCtx, backupSchedule, "HandleBackupScheduleFailed", err)
 	}
 
-	return r.patchStatusAvailable(reqCtx, original, backupSchedule)
+	// the verification failure should not fail the backup schedule.
+	verifyAfter, err := r.verifyBackup(reqCtx, backupSchedule)
+	if err != nil {
+		r.Recorder.Event(backupSchedule, corev1.EventTypeWarning, "VerifyBackupFailed", err.Error())
+		verifyAfter = reconcileInterval
+	}
+
+	result, err := r.patchStatusAvailable(reqCtx, original, backupSchedule)
+	if err != nil || verifyAfter <= 0 {
+		return result, err
+	}
+	return intctrlutil.RequeueAfter(verifyAfter, reqCtx.Log, "")
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -106,6 +126,7 @@ func (r *BackupScheduleReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		b.Owns(&batchv1beta1.CronJob{})
 	}
 	b.Watches(&dpv1alpha1.Backup{}, handler.EnqueueRequestsFromMapFunc(r.parseBackup))
+	b.Watches(&appsv1.Cluster{}, handler.EnqueueRequestsFromMapFunc(r.parseVerificationCluster))
 	return b.Complete(r)
 }
 
@@ -176,6 +197,28 @@ func (r *BackupScheduleReconciler) patchStatusFailed(reqCtx intctrlutil.RequestC
 	return intctrlutil.RequeueWithError(err, reqCtx.Log, "")
 }
 
+// verifyBackup verifies the latest backup of the backup schedule if the verification is enabled,
+// and returns the duration after which the verification should be checked again.
+func (r *BackupScheduleReconciler) verifyBackup(
+	reqCtx intctrlutil.RequestCtx,
+	backupSchedule *dpv1alpha1.BackupSchedule) (time.Duration, error) {
+	if backupSchedule.Spec.Verification == nil || !boolptr.IsSetToTrue(backupSchedule.Spec.Verification.Enabled) {
+		return 0, nil
+	}
+	saName, err := EnsureWorkerServiceAccount(reqCtx, r.Client, backupSchedule.Namespace, nil)
+	if err != nil {
+		return 0, err
+	}
+	verifier := &dprestore.Verifier{
+		RequestCtx:           reqCtx,
+		Client:               r.Client,
+		Scheme:               r.Scheme,
+		BackupSchedule:       backupSchedule,
+		WorkerServiceAccount: saName,
+	}
+	return verifier.Verify()
+}
+
 // handleSchedule handles backup schedules for different backup method.
 func (r *BackupScheduleReconciler) handleSchedule(
 	reqCtx intctrlutil.RequestCtx,
@@ -233,3 +276,14 @@ func (r *BackupScheduleReconciler) parseBackup(ctx context.Context, object clien
 	}
 	return []reconcile.Request{}
 }
+
+func (r *BackupScheduleReconciler) parseVerificationCluster(ctx context.Context, object client.Object) []reconcile.Request {
+	labels := object.GetLabels()
+	if labels[dptypes.BackupVerificationLabelKey] == "" || labels[dptypes.BackupScheduleLabelKey] == "" {
+		return []reconcile.Request{}
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{
+		Namespace: object.GetNamespace(),
+		Name:      labels[dptypes.BackupScheduleLabelKey],
+	}}}
+}
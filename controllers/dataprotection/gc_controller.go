@@ -27,6 +27,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/clock"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -103,19 +104,47 @@ func (r *GCReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Re
 	reqCtx.Log = reqCtx.Log.WithValues("expiration", backup.Status.Expiration)
 
 	now := r.clock.Now()
-	if backup.Status.Expiration == nil || backup.Status.Expiration.After(now) {
-		reqCtx.Log.V(1).Info("backup is not expired yet, skipping")
-		return intctrlutil.Reconciled()
+	retention, err := r.getBackupRetention(reqCtx.Ctx, backup)
+	if err != nil {
+		reqCtx.Log.Error(err, "failed to get backup retention")
+		return intctrlutil.RequeueWithError(err, reqCtx.Log, "")
 	}
+	reason := "backup has expired"
+	if retention != nil && backup.Status.Phase == dpv1alpha1.BackupPhaseCompleted {
+		// the count-based retention takes precedence over the expiration for completed backups.
+		retained, err := r.isRetainedByRetention(reqCtx.Ctx, backup, retention)
+		if err != nil {
+			reqCtx.Log.Error(err, "failed to check backup retention")
+			return intctrlutil.RequeueWithError(err, reqCtx.Log, "")
+		}
+		if retained {
+			reqCtx.Log.V(1).Info("backup is retained by the retention, skipping", "retention", retention.Type)
+			return intctrlutil.Reconciled()
+		}
+		reason = fmt.Sprintf("backup is not retained by the %s retention", retention.Type)
+	} else {
+		if backup.Status.Expiration == nil || backup.Status.Expiration.After(now) {
+			reqCtx.Log.V(1).Info("backup is not expired yet, skipping")
+			return intctrlutil.Reconciled()
+		}
 
-	if deletable, err := r.isBackupDeletable(reqCtx, backup); !deletable {
-		return intctrlutil.Reconciled()
-	} else if err != nil {
-		reqCtx.Log.Error(err, "failed to check backup deletability")
+		if deletable, err := r.isBackupDeletable(reqCtx, backup); !deletable {
+			return intctrlutil.Reconciled()
+		} else if err != nil {
+			reqCtx.Log.Error(err, "failed to check backup deletability")
+			return intctrlutil.RequeueWithError(err, reqCtx.Log, "")
+		}
+	}
+
+	if depended, err := r.isDependedByRetainedBackups(reqCtx.Ctx, backup, now); err != nil {
+		reqCtx.Log.Error(err, "failed to check backup dependencies")
 		return intctrlutil.RequeueWithError(err, reqCtx.Log, "")
+	} else if depended {
+		reqCtx.Log.V(1).Info("backup is depended on by the retained incremental or continuous backups, skipping")
+		return intctrlutil.Reconciled()
 	}
 
-	reqCtx.Log.Info("backup has expired, delete it", "backup", req.String())
+	reqCtx.Log.Info(fmt.Sprintf("%s, delete it", reason), "backup", req.String())
 	if err := intctrlutil.BackgroundDeleteObject(r.Client, reqCtx.Ctx, backup); err != nil {
 		reqCtx.Log.Error(err, "failed to delete backup")
 		r.Recorder.Event(backup, corev1.EventTypeWarning, "RemoveExpiredBackupsFailed", err.Error())
@@ -244,3 +273,117 @@ func (r *GCReconciler) getRelatedBackups(ctx context.Context, backup *dpv1alpha1
 	}
 	return filtered, nil
 }
+
+// getBackupRetention returns the count-based retention of the backup. The retention of the backup schedule
+// which creates the backup takes precedence over the retention of the backup policy.
+func (r *GCReconciler) getBackupRetention(ctx context.Context, backup *dpv1alpha1.Backup) (*dpv1alpha1.BackupRetention, error) {
+	if scheduleName := backup.Labels[dptypes.BackupScheduleLabelKey]; len(scheduleName) != 0 {
+		backupSchedule := &dpv1alpha1.BackupSchedule{}
+		err := r.Get(ctx, client.ObjectKey{Name: scheduleName, Namespace: backup.Namespace}, backupSchedule)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		if err == nil {
+			for _, s := range backupSchedule.Spec.Schedules {
+				if s.BackupMethod == backup.Spec.BackupMethod && s.Retention != nil {
+					return s.Retention, nil
+				}
+			}
+		}
+	}
+	backupPolicy := &dpv1alpha1.BackupPolicy{}
+	err := r.Get(ctx, client.ObjectKey{Name: backup.Spec.BackupPolicyName, Namespace: backup.Namespace}, backupPolicy)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return backupPolicy.Spec.Retention, nil
+}
+
+// isRetainedByRetention returns true if the backup is selected by the retention among
+// the backups of the same backup policy and backup method.
+func (r *GCReconciler) isRetainedByRetention(ctx context.Context, backup *dpv1alpha1.Backup, retention *dpv1alpha1.BackupRetention) (bool, error) {
+	policyBackups, err := r.listPolicyBackups(ctx, backup)
+	if err != nil {
+		return false, err
+	}
+	retained := dputils.SelectRetainedBackups(retention, filterBackupsByMethod(policyBackups, backup.Spec.BackupMethod))
+	return retained.Has(backup.Name), nil
+}
+
+// isDependedByRetainedBackups returns true if the backup is the parent or base backup of a retained incremental
+// or continuous backup. A backup is retained if it is selected by its retention, or it has not expired yet
+// when no retention is specified.
+func (r *GCReconciler) isDependedByRetainedBackups(ctx context.Context, backup *dpv1alpha1.Backup, now time.Time) (bool, error) {
+	backupType := backup.Labels[dptypes.BackupTypeLabelKey]
+	if backupType != string(dpv1alpha1.BackupTypeFull) && backupType != string(dpv1alpha1.BackupTypeIncremental) {
+		return false, nil
+	}
+	policyBackups, err := r.listPolicyBackups(ctx, backup)
+	if err != nil {
+		return false, err
+	}
+	retainedByMethod := map[string]sets.Set[string]{}
+	isRetained := func(b *dpv1alpha1.Backup) (bool, error) {
+		retention, err := r.getBackupRetention(ctx, b)
+		if err != nil {
+			return false, err
+		}
+		if retention == nil || b.Status.Phase != dpv1alpha1.BackupPhaseCompleted {
+			return b.Status.Expiration == nil || b.Status.Expiration.After(now), nil
+		}
+		key := b.Labels[dptypes.BackupScheduleLabelKey] + "/" + b.Spec.BackupMethod
+		if _, ok := retainedByMethod[key]; !ok {
+			retainedByMethod[key] = dputils.SelectRetainedBackups(retention, filterBackupsByMethod(policyBackups, b.Spec.BackupMethod))
+		}
+		return retainedByMethod[key].Has(b.Name), nil
+	}
+
+	var retainedBackups []*dpv1alpha1.Backup
+	for _, b := range policyBackups {
+		dependentType := b.Labels[dptypes.BackupTypeLabelKey]
+		if b.Name == backup.Name || !b.DeletionTimestamp.IsZero() ||
+			(dependentType != string(dpv1alpha1.BackupTypeIncremental) && dependentType != string(dpv1alpha1.BackupTypeContinuous)) {
+			continue
+		}
+		retained, err := isRetained(b)
+		if err != nil {
+			return false, err
+		}
+		if retained {
+			retainedBackups = append(retainedBackups, b)
+		}
+	}
+	return dputils.GetDependedBackups(retainedBackups, policyBackups).Has(backup.Name), nil
+}
+
+// listPolicyBackups returns the backups created by the same backup policy and cluster of the given backup.
+func (r *GCReconciler) listPolicyBackups(ctx context.Context, backup *dpv1alpha1.Backup) ([]*dpv1alpha1.Backup, error) {
+	matchingLabels := client.MatchingLabels{
+		dptypes.BackupPolicyLabelKey: backup.Spec.BackupPolicyName,
+	}
+	if clusterUID := backup.Labels[dptypes.ClusterUIDLabelKey]; len(clusterUID) != 0 {
+		matchingLabels[dptypes.ClusterUIDLabelKey] = clusterUID
+	}
+	backupList := &dpv1alpha1.BackupList{}
+	if err := r.List(ctx, backupList, client.InNamespace(backup.Namespace), matchingLabels); err != nil {
+		return nil, err
+	}
+	backups := make([]*dpv1alpha1.Backup, 0, len(backupList.Items))
+	for i := range backupList.Items {
+		backups = append(backups, &backupList.Items[i])
+	}
+	return backups, nil
+}
+
+func filterBackupsByMethod(backups []*dpv1alpha1.Backup, backupMethod string) []*dpv1alpha1.Backup {
+	var filtered []*dpv1alpha1.Backup
+	for i := range backups {
+		if backups[i].Spec.BackupMethod == backupMethod {
+			filtered = append(filtered, backups[i])
+		}
+	}
+	return filtered
+}
@@ -171,6 +171,42 @@ var _ = Describe("Data Protection Garbage Collection Controller", func() {
 			Eventually(testapps.CheckObjExists(&testCtx, expiredKey, &dpv1alpha1.Backup{}, false)).Should(Succeed())
 		})
 
+		It("delete backups not retained by the keep-last retention", func() {
+			setBackupCompletedAt := func(backup *dpv1alpha1.Backup, completionTime time.Time) {
+				backup.Status.Phase = dpv1alpha1.BackupPhaseCompleted
+				backup.Status.Expiration = &metav1.Time{Time: fakeClock.Now().Add(time.Hour * 24)}
+				backup.Status.StartTimestamp = &metav1.Time{Time: completionTime.Add(-time.Minute)}
+				backup.Status.CompletionTimestamp = &metav1.Time{Time: completionTime}
+				testdp.PatchBackupStatus(&testCtx, client.ObjectKeyFromObject(backup), backup.Status)
+			}
+
+			By("setting the backup policy retention to keep the last backup")
+			Expect(testapps.ChangeObj(&testCtx, backupPolicy, func(policy *dpv1alpha1.BackupPolicy) {
+				keepLast := int32(1)
+				policy.Spec.Retention = &dpv1alpha1.BackupRetention{
+					Type:     dpv1alpha1.BackupRetentionTypeKeepLast,
+					KeepLast: &keepLast,
+				}
+			})).Should(Succeed())
+
+			By("creating an older backup and a newer backup, both are unexpired")
+			olderBackup := createBackup(backupNamePrefix+"older", testdp.BackupMethodName)
+			olderKey := client.ObjectKeyFromObject(olderBackup)
+			testdp.PatchK8sJobStatus(&testCtx, getJobKey(olderBackup), batchv1.JobComplete)
+			checkBackupCompleted(olderKey)
+			setBackupCompletedAt(olderBackup, fakeClock.Now().Add(-time.Hour*2))
+
+			newerBackup := createBackup(backupNamePrefix+"newer", testdp.BackupMethodName)
+			newerKey := client.ObjectKeyFromObject(newerBackup)
+			testdp.PatchK8sJobStatus(&testCtx, getJobKey(newerBackup), batchv1.JobComplete)
+			checkBackupCompleted(newerKey)
+			setBackupCompletedAt(newerBackup, fakeClock.Now().Add(-time.Hour))
+
+			By("the older backup should be deleted although it is unexpired")
+			Eventually(testapps.CheckObjExists(&testCtx, olderKey, &dpv1alpha1.Backup{}, false)).Should(Succeed())
+			Eventually(testapps.CheckObjExists(&testCtx, newerKey, &dpv1alpha1.Backup{}, true)).Should(Succeed())
+		})
+
 		It("should not delete the latest backup", func() {
 			shouldNotDelete := func(key client.ObjectKey) {
 				Eventually(testapps.CheckObjExists(&testCtx, key, &dpv1alpha1.Backup{}, true)).Should(Succeed())
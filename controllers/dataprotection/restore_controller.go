@@ -44,10 +44,12 @@ import (
 	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	dpencryption "github.com/apecloud/kubeblocks/pkg/dataprotection/encryption"
 	dperrors "github.com/apecloud/kubeblocks/pkg/dataprotection/errors"
 	dprestore "github.com/apecloud/kubeblocks/pkg/dataprotection/restore"
 	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
 	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
+	"github.com/apecloud/kubeblocks/pkg/metrics"
 	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
@@ -96,6 +98,11 @@ func (r *RestoreReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		if err = r.deleteExternalResources(reqCtx, restore); err != nil {
 			return intctrlutil.RequeueWithError(err, reqCtx.Log, "")
 		}
+	case dpv1alpha1.RestorePhaseFailed:
+		// the restore jobs are kept for troubleshooting, but the data keys are not needed anymore.
+		if err = dpencryption.DeleteDataKeySecrets(reqCtx.Ctx, r.Client, restore, dprestore.BuildRestoreLabels(restore.Name)); err != nil {
+			return intctrlutil.RequeueWithError(err, reqCtx.Log, "")
+		}
 	}
 	return intctrlutil.Reconciled()
 }
@@ -151,7 +158,12 @@ func (r *RestoreReconciler) deleteExternalResources(reqCtx intctrlutil.RequestCt
 		viper.GetString(constant.CfgKeyCtrlrMgrNS): {},
 	}
 
-	return deleteRelatedObjectList(reqCtx, r.Client, &batchv1.JobList{}, namespaces, labels)
+	if err := deleteRelatedObjectList(reqCtx, r.Client, &batchv1.JobList{}, namespaces, labels); err != nil {
+		return err
+	}
+
+	// delete the data key secrets unwrapped for the restore jobs.
+	return dpencryption.DeleteDataKeySecrets(reqCtx.Ctx, r.Client, restore, labels)
 }
 
 func CheckBackupRepoForRestore(reqCtx intctrlutil.RequestCtx, cli client.Client, restore *dpv1alpha1.Restore) (string, error) {
@@ -164,13 +176,17 @@ func CheckBackupRepoForRestore(reqCtx intctrlutil.RequestCtx, cli client.Client,
 		}
 		return "", err
 	}
-	if backup.Status.BackupRepoName == "" {
+	// the backup may be restored from its copy in a secondary backup repo.
+	repoName, err := dprestore.SelectBackupRepoName(reqCtx, cli, restore, backup)
+	if err != nil {
+		return "", err
+	}
+	if repoName == "" {
 		// The backup doesn't use backup repo.
 		return "", nil
 	}
 
 	restoreNamespace := restore.Namespace
-	repoName := backup.Status.BackupRepoName
 	repo := &dpv1alpha1.BackupRepo{}
 	if err := cli.Get(reqCtx.Ctx, client.ObjectKey{Name: repoName}, repo); err != nil {
 		if apierrors.IsNotFound(err) {
@@ -284,6 +300,7 @@ func (r *RestoreReconciler) newAction(reqCtx intctrlutil.RequestCtx, restore *dp
 	if err := r.Client.Status().Patch(reqCtx.Ctx, restore, patch); err != nil {
 		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
 	}
+	metrics.ObserveRestore(restore)
 	return intctrlutil.Reconciled()
 }
 
@@ -314,6 +331,9 @@ func (r *RestoreReconciler) handleRunningPhase(reqCtx intctrlutil.RequestCtx, re
 	// patch restore status if changes occur
 	if !reflect.DeepEqual(restoreMgr.OriginalRestore.Status, restoreMgr.Restore.Status) {
 		err = r.Client.Status().Patch(reqCtx.Ctx, restoreMgr.Restore, client.MergeFrom(restoreMgr.OriginalRestore))
+		if err == nil {
+			metrics.ObserveRestore(restoreMgr.Restore)
+		}
 	}
 	if err != nil {
 		r.Recorder.Event(restore, corev1.EventTypeWarning, corev1.EventTypeWarning, err.Error())
@@ -35,6 +35,7 @@ const (
 	dataProtectionBackupRepoKey          = "dataprotection.kubeblocks.io/backup-repo-name"
 	dataProtectionWaitRepoPreparationKey = "dataprotection.kubeblocks.io/wait-repo-preparation"
 	dataProtectionIsToolConfigKey        = "dataprotection.kubeblocks.io/is-tool-config"
+	dataProtectionBackupCatalogKey       = "dataprotection.kubeblocks.io/backup-catalog"
 
 	// annotation keys
 	dataProtectionBackupRepoDigestAnnotationKey     = "dataprotection.kubeblocks.io/backup-repo-digest"
@@ -95,3 +96,6 @@ const (
 )
 
 var reconcileInterval = time.Second
+
+// continuousBackupManifestInterval is the interval to refresh the manifest of a running continuous backup.
+const continuousBackupManifestInterval = 30 * time.Minute
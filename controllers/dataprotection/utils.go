@@ -47,6 +47,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/controller/multicluster"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 	dpbackup "github.com/apecloud/kubeblocks/pkg/dataprotection/backup"
+	dpencryption "github.com/apecloud/kubeblocks/pkg/dataprotection/encryption"
 	dperrors "github.com/apecloud/kubeblocks/pkg/dataprotection/errors"
 	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
 	dputils "github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
@@ -302,7 +303,7 @@ func getDefaultBackupRepo(ctx context.Context, cli client.Client) (*dpv1alpha1.B
 }
 
 type objectList interface {
-	*appsv1.StatefulSetList | *batchv1.JobList
+	*appsv1.StatefulSetList | *batchv1.JobList | *corev1.SecretList
 	client.ObjectList
 }
 
@@ -766,6 +767,15 @@ func checkEncryptionConfig(ctx context.Context, config *dpv1alpha1.EncryptionCon
 	if config == nil {
 		return nil
 	}
+	if km := config.KeyManagement; km != nil {
+		if len(km.KeyID) == 0 {
+			return fmt.Errorf("encryptionConfig.keyManagement.keyID is empty")
+		}
+		if _, err := dpencryption.NewKeyProvider(ctx, cli, namespace, km); err != nil {
+			return fmt.Errorf("failed to check encryption key provider: %w", err)
+		}
+		return nil
+	}
 	secretKeyRef := config.PassPhraseSecretKeyRef
 	if secretKeyRef == nil {
 		return fmt.Errorf("encryptionConfig.passPhraseSecretKeyRef if empty")
@@ -46,6 +46,25 @@ type eventHandler interface {
 	Handle(cli client.Client, reqCtx intctrlutil.RequestCtx, recorder record.EventRecorder, event *corev1.Event) error
 }
 
+func eventHandlers() []eventHandler {
+	return []eventHandler{
+		&instanceset.PodRoleEventHandler{},
+		&component.AvailableEventHandler{},
+		&component.KBAgentTaskEventHandler{},
+		&component.ProbeOutputEventHandler{},
+	}
+}
+
+// handleEvent dispatches the event to all the handlers.
+func handleEvent(cli client.Client, reqCtx intctrlutil.RequestCtx, recorder record.EventRecorder, event *corev1.Event) error {
+	for _, handler := range eventHandlers() {
+		if err := handler.Handle(cli, reqCtx, recorder, event); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
 // EventReconciler reconciles an Event object
 type EventReconciler struct {
 	client.Client
@@ -79,15 +98,8 @@ func (r *EventReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 		return intctrlutil.Reconciled()
 	}
 
-	handlers := []eventHandler{
-		&instanceset.PodRoleEventHandler{},
-		&component.AvailableEventHandler{},
-		&component.KBAgentTaskEventHandler{},
-	}
-	for _, handler := range handlers {
-		if err := handler.Handle(r.Client, reqCtx, r.Recorder, event); err != nil && !apierrors.IsNotFound(err) {
-			return intctrlutil.RequeueWithError(err, reqCtx.Log, "handleEventError")
-		}
+	if err := handleEvent(r.Client, reqCtx, r.Recorder, event); err != nil {
+		return intctrlutil.RequeueWithError(err, reqCtx.Log, "handleEventError")
 	}
 
 	if err := r.eventHandled(ctx, event); err != nil {
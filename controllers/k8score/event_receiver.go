@@ -0,0 +1,312 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package k8score
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+const (
+	eventReceiverMaxBodySize    = 4 << 20
+	eventReceiverTokenCacheTTL  = time.Minute
+	eventReceiverShutdownPeriod = 5 * time.Second
+
+	serviceAccountUsernamePrefix = "system:serviceaccount:"
+	podNameExtraKey              = "authentication.kubernetes.io/pod-name"
+	podUIDExtraKey               = "authentication.kubernetes.io/pod-uid"
+)
+
+// EventReceiver receives the probe and task events pushed by kbagent through the event channel,
+// and dispatches them to the same handlers as the EventReconciler.
+//
+// The receiver is served over TLS with the webhook serving certificate. kbagent authenticates itself with
+// a projected service account token of a dedicated audience, which is verified by the TokenReview API.
+// The token must be bound to the pod, and the service account must be the one of the component that the pod belongs to,
+// the events are only accepted for the pod itself.
+// kbagent falls back to K8s Events if the receiver is unavailable.
+type EventReceiver struct {
+	Client   client.Client
+	Recorder record.EventRecorder
+	Address  string
+	// CertDir is the directory that contains the serving certificate tls.crt and the key tls.key.
+	CertDir string
+
+	mu     sync.Mutex
+	tokens map[string]*eventSourceIdentity
+}
+
+type eventSourceIdentity struct {
+	namespace string
+	podName   string
+	podUID    string
+	expiredAt time.Time
+}
+
+var _ manager.Runnable = &EventReceiver{}
+
+// +kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
+
+// Start starts the HTTP server of the receiver and blocks until the context is done.
+func (r *EventReceiver) Start(ctx context.Context) error {
+	watcher, err := certwatcher.New(filepath.Join(r.CertDir, "tls.crt"), filepath.Join(r.CertDir, "tls.key"))
+	if err != nil {
+		return err
+	}
+	go func() {
+		if err := watcher.Start(ctx); err != nil {
+			ctrl.Log.WithName("kbagent-event-receiver").Error(err, "failed to watch the serving certificate")
+		}
+	}()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(proto.EventChannelPath, r.handle)
+	server := &http.Server{
+		Addr:              r.Address,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+		TLSConfig: &tls.Config{
+			MinVersion:     tls.VersionTLS12,
+			GetCertificate: watcher.GetCertificate,
+		},
+	}
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.ListenAndServeTLS("", "")
+	}()
+	select {
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), eventReceiverShutdownPeriod)
+		defer cancel()
+		return server.Shutdown(shutdownCtx)
+	case err := <-errCh:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return err
+	}
+}
+
+func (r *EventReceiver) handle(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		r.reply(w, http.StatusMethodNotAllowed, &proto.EventBatchResponse{Error: "method not allowed"})
+		return
+	}
+	identity, err := r.authenticate(req.Context(), req.Header.Get("Authorization"))
+	if err != nil {
+		r.reply(w, http.StatusUnauthorized, &proto.EventBatchResponse{Error: err.Error()})
+		return
+	}
+	batch := &proto.EventBatch{}
+	if err = json.NewDecoder(io.LimitReader(req.Body, eventReceiverMaxBodySize)).Decode(batch); err != nil {
+		r.reply(w, http.StatusBadRequest, &proto.EventBatchResponse{Error: err.Error()})
+		return
+	}
+	for _, event := range batch.Events {
+		if !identity.allowed(event) {
+			r.reply(w, http.StatusForbidden, &proto.EventBatchResponse{
+				Error: fmt.Sprintf("not allowed to report events for pod %s/%s", event.Namespace, event.PodName)})
+			return
+		}
+	}
+
+	rsp := &proto.EventBatchResponse{}
+	for i, event := range batch.Events {
+		reqCtx := intctrlutil.RequestCtx{
+			Ctx: req.Context(),
+			Log: ctrl.Log.WithName("kbagent-event-receiver").WithValues("pod", types.NamespacedName{
+				Namespace: event.Namespace, Name: event.PodName}, "reason", event.Reason),
+		}
+		if err = handleEvent(r.Client, reqCtx, r.Recorder, buildK8sEvent(event)); err != nil {
+			reqCtx.Log.Error(err, "failed to handle the event pushed by kbagent")
+			rsp.Failed = append(rsp.Failed, i)
+		}
+	}
+	r.reply(w, http.StatusOK, rsp)
+}
+
+func (r *EventReceiver) reply(w http.ResponseWriter, code int, rsp *proto.EventBatchResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(rsp)
+}
+
+// authenticate verifies the bearer token by the TokenReview API, the result is cached for a while.
+func (r *EventReceiver) authenticate(ctx context.Context, authorization string) (*eventSourceIdentity, error) {
+	token, ok := strings.CutPrefix(authorization, "Bearer ")
+	if !ok || len(token) == 0 {
+		return nil, fmt.Errorf("bearer token is required")
+	}
+	key := fmt.Sprintf("%x", sha256.Sum256([]byte(token)))
+	now := time.Now()
+
+	r.mu.Lock()
+	identity, ok := r.tokens[key]
+	r.mu.Unlock()
+	if ok && now.Before(identity.expiredAt) {
+		return identity, nil
+	}
+
+	review := &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{
+			Token:     token,
+			Audiences: []string{proto.EventChannelTokenAudience},
+		},
+	}
+	if err := r.Client.Create(ctx, review); err != nil {
+		return nil, err
+	}
+	if !review.Status.Authenticated {
+		return nil, fmt.Errorf("token is not authenticated: %s", review.Status.Error)
+	}
+	if !slices.Contains(review.Status.Audiences, proto.EventChannelTokenAudience) {
+		return nil, fmt.Errorf("the audience %s is required", proto.EventChannelTokenAudience)
+	}
+	username, ok := strings.CutPrefix(review.Status.User.Username, serviceAccountUsernamePrefix)
+	if !ok {
+		return nil, fmt.Errorf("the user %s is not a service account", review.Status.User.Username)
+	}
+	namespace, serviceAccount, _ := strings.Cut(username, ":")
+	identity = &eventSourceIdentity{
+		namespace: namespace,
+		expiredAt: now.Add(eventReceiverTokenCacheTTL),
+	}
+	// the bound service account tokens carry the name and UID of the pod, the tokens not bound to a pod are rejected.
+	if values := review.Status.User.Extra[podNameExtraKey]; len(values) > 0 {
+		identity.podName = values[0]
+	}
+	if values := review.Status.User.Extra[podUIDExtraKey]; len(values) > 0 {
+		identity.podUID = values[0]
+	}
+	if len(identity.podName) == 0 || len(identity.podUID) == 0 {
+		return nil, fmt.Errorf("the token is not bound to a pod")
+	}
+	if err := r.verifyServiceAccount(ctx, identity, serviceAccount); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.tokens == nil {
+		r.tokens = map[string]*eventSourceIdentity{}
+	}
+	for k, v := range r.tokens {
+		if now.After(v.expiredAt) {
+			delete(r.tokens, k)
+		}
+	}
+	r.tokens[key] = identity
+	return identity, nil
+}
+
+// verifyServiceAccount checks that the pod runs with the service account, and it is the one created by KubeBlocks
+// or specified for the component that the pod belongs to.
+func (r *EventReceiver) verifyServiceAccount(ctx context.Context, identity *eventSourceIdentity, serviceAccount string) error {
+	pod := &corev1.Pod{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Namespace: identity.namespace, Name: identity.podName}, pod); err != nil {
+		return err
+	}
+	if string(pod.UID) != identity.podUID || pod.Spec.ServiceAccountName != serviceAccount {
+		return fmt.Errorf("the token is not bound to the pod %s/%s", identity.namespace, identity.podName)
+	}
+	clusterName, compName := pod.Labels[constant.AppInstanceLabelKey], pod.Labels[constant.KBAppComponentLabelKey]
+	if len(clusterName) == 0 || len(compName) == 0 {
+		return fmt.Errorf("the pod %s/%s is not a pod of component", identity.namespace, identity.podName)
+	}
+	comp, err := component.GetComponentByName(ctx, r.Client, identity.namespace, component.FullName(clusterName, compName))
+	if err != nil {
+		return err
+	}
+	expected := comp.Spec.ServiceAccountName
+	if len(expected) == 0 {
+		expected = constant.GenerateDefaultServiceAccountName(comp.Spec.CompDef)
+	}
+	if serviceAccount != expected {
+		return fmt.Errorf("the service account %s is not the one of the component %s", serviceAccount, comp.Name)
+	}
+	return nil
+}
+
+func (i *eventSourceIdentity) allowed(event proto.Event) bool {
+	return event.Namespace == i.namespace && event.PodName == i.podName && event.PodUID == i.podUID
+}
+
+// buildK8sEvent builds the K8s Event in the same way as kbagent does, so that the event handlers can handle it.
+// the event is not persisted, it is annotated to let the handlers skip updating it.
+func buildK8sEvent(event proto.Event) *corev1.Event {
+	hash := fnv.New32a()
+	hash.Write([]byte(fmt.Sprintf("%s.%s.%s", event.PodUID, event.Reason, event.Message)))
+	timestamp := metav1.NewTime(event.Timestamp)
+	return &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        fmt.Sprintf("%s.%x", event.PodName, hash.Sum32()),
+			Namespace:   event.Namespace,
+			Annotations: map[string]string{proto.EventChannelAnnotationKey: "true"},
+		},
+		InvolvedObject: corev1.ObjectReference{
+			Kind:      "Pod",
+			Namespace: event.Namespace,
+			Name:      event.PodName,
+			UID:       types.UID(event.PodUID),
+			FieldPath: proto.ProbeEventFieldPath,
+		},
+		Reason:  event.Reason,
+		Message: event.Message,
+		Source: corev1.EventSource{
+			Component: proto.ProbeEventSourceComponent,
+			Host:      event.NodeName,
+		},
+		FirstTimestamp:      timestamp,
+		LastTimestamp:       timestamp,
+		EventTime:           metav1.NewMicroTime(event.Timestamp),
+		ReportingController: proto.ProbeEventReportingController,
+		ReportingInstance:   event.PodName,
+		Action:              event.Reason,
+		Type:                corev1.EventTypeNormal,
+		Count:               1,
+	}
+}
@@ -0,0 +1,165 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package k8score
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+func TestEventReceiverHandle(t *testing.T) {
+	reviews := 0
+	bindings := map[string][2]string{
+		"valid":       {"system:serviceaccount:default:kb-mysql", "pod-0"},
+		"unbound":     {"system:serviceaccount:default:kb-mysql", ""},
+		"another-sa":  {"system:serviceaccount:default:default", "pod-0"},
+		"another-pod": {"system:serviceaccount:default:kb-mysql", "pod-1"},
+		"workload-sa": {"system:serviceaccount:default:default", "pod-1"},
+	}
+	pod := func(name, uid, serviceAccount string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      name,
+				UID:       types.UID(uid),
+				Labels: map[string]string{
+					constant.AppInstanceLabelKey:    "mysql",
+					constant.KBAppComponentLabelKey: "mysql",
+				},
+			},
+			Spec: corev1.PodSpec{ServiceAccountName: serviceAccount},
+		}
+	}
+	comp := &appsv1.Component{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "mysql-mysql"},
+		Spec:       appsv1.ComponentSpec{CompDef: "mysql"},
+	}
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	cli := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(pod("pod-0", "uid-0", "kb-mysql"), pod("pod-1", "uid-1", "default"), comp).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				review, ok := obj.(*authenticationv1.TokenReview)
+				if !ok {
+					return c.Create(ctx, obj, opts...)
+				}
+				reviews++
+				binding, ok := bindings[review.Spec.Token]
+				if !ok || !slices.Equal(review.Spec.Audiences, []string{proto.EventChannelTokenAudience}) {
+					return nil
+				}
+				review.Status.Authenticated = true
+				review.Status.Audiences = review.Spec.Audiences
+				review.Status.User.Username = binding[0]
+				if len(binding[1]) > 0 {
+					review.Status.User.Extra = map[string]authenticationv1.ExtraValue{
+						podNameExtraKey: {binding[1]},
+						podUIDExtraKey:  {"uid-" + binding[1][len(binding[1])-1:]},
+					}
+				}
+				return nil
+			},
+		}).Build()
+	receiver := &EventReceiver{Client: cli, Recorder: record.NewFakeRecorder(10)}
+
+	post := func(token string, events ...proto.Event) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(proto.EventBatch{Events: events})
+		req := httptest.NewRequest(http.MethodPost, proto.EventChannelPath, bytes.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		rsp := httptest.NewRecorder()
+		receiver.handle(rsp, req)
+		return rsp
+	}
+	event := func(namespace, podName, podUID string) proto.Event {
+		return proto.Event{Namespace: namespace, PodName: podName, PodUID: podUID, Reason: "unknown", Timestamp: time.Now()}
+	}
+
+	for _, token := range []string{"invalid", "unbound", "another-sa", "another-pod", "workload-sa"} {
+		if rsp := post(token, event("default", "pod-0", "uid-0")); rsp.Code != http.StatusUnauthorized {
+			t.Errorf("%s: expect unauthorized, got %d", token, rsp.Code)
+		}
+	}
+	if rsp := post("valid", event("default", "pod-1", "uid-1")); rsp.Code != http.StatusForbidden {
+		t.Errorf("expect forbidden for another pod, got %d", rsp.Code)
+	}
+	if rsp := post("valid", event("default", "pod-0", "uid-1")); rsp.Code != http.StatusForbidden {
+		t.Errorf("expect forbidden for another pod uid, got %d", rsp.Code)
+	}
+	if rsp := post("valid", event("other", "pod-0", "uid-0")); rsp.Code != http.StatusForbidden {
+		t.Errorf("expect forbidden for another namespace, got %d", rsp.Code)
+	}
+	rsp := post("valid", event("default", "pod-0", "uid-0"), event("default", "pod-0", "uid-0"))
+	if rsp.Code != http.StatusOK {
+		t.Fatalf("expect ok, got %d: %s", rsp.Code, rsp.Body.String())
+	}
+	batchRsp := &proto.EventBatchResponse{}
+	if err := json.Unmarshal(rsp.Body.Bytes(), batchRsp); err != nil || len(batchRsp.Failed) != 0 {
+		t.Errorf("unexpected response: %s", rsp.Body.String())
+	}
+	// the valid token is reviewed only once.
+	if reviews != 6 {
+		t.Errorf("expect 6 token reviews, got %d", reviews)
+	}
+}
+
+func TestBuildK8sEvent(t *testing.T) {
+	now := time.Now()
+	event := buildK8sEvent(proto.Event{
+		Namespace: "default",
+		PodName:   "pod-0",
+		PodUID:    "uid",
+		Reason:    "availableProbe",
+		Message:   "{}",
+		Timestamp: now,
+	})
+	if event.InvolvedObject.FieldPath != proto.ProbeEventFieldPath ||
+		event.ReportingController != proto.ProbeEventReportingController {
+		t.Errorf("unexpected event source: %v", event)
+	}
+	if event.InvolvedObject.Name != "pod-0" || string(event.InvolvedObject.UID) != "uid" || !event.EventTime.Time.Equal(now) {
+		t.Errorf("unexpected event: %v", event)
+	}
+	if event.Annotations[proto.EventChannelAnnotationKey] != "true" {
+		t.Errorf("expect the event to be marked as not persisted: %v", event.Annotations)
+	}
+}
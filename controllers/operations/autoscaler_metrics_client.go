@@ -0,0 +1,85 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"sync"
+
+	"k8s.io/utils/ptr"
+
+	"github.com/apecloud/kubeblocks/pkg/controller/analysis"
+)
+
+const (
+	// the clients are dropped all together once the cache grows beyond it, e.g. the addresses are changed frequently
+	maxCachedMetricsClients = 64
+)
+
+// metricsProvider is a Prometheus-compatible endpoint queried by the storage and component autoscalers.
+type metricsProvider struct {
+	address        string
+	timeoutSeconds int32
+}
+
+func newMetricsProvider(address string, timeoutSeconds *int32) metricsProvider {
+	return metricsProvider{address: address, timeoutSeconds: ptr.Deref(timeoutSeconds, 0)}
+}
+
+// metricsClientFactory creates the client of a provider, it is replaced by fakes in tests.
+var metricsClientFactory = defaultMetricsClientFactory
+
+func defaultMetricsClientFactory(provider metricsProvider) (analysis.Client, error) {
+	var timeoutSeconds *int32
+	if provider.timeoutSeconds > 0 {
+		timeoutSeconds = ptr.To(provider.timeoutSeconds)
+	}
+	return analysis.NewPrometheusClient(provider.address, timeoutSeconds)
+}
+
+// metricsClientCache shares the clients of the providers across the reconciliations, so the connections are reused.
+type metricsClientCache struct {
+	sync.Mutex
+	clients map[metricsProvider]analysis.Client
+}
+
+var metricsClients = &metricsClientCache{clients: map[metricsProvider]analysis.Client{}}
+
+func (c *metricsClientCache) get(provider metricsProvider) (analysis.Client, error) {
+	c.Lock()
+	defer c.Unlock()
+	if cli, ok := c.clients[provider]; ok {
+		return cli, nil
+	}
+	cli, err := metricsClientFactory(provider)
+	if err != nil {
+		return nil, err
+	}
+	if len(c.clients) >= maxCachedMetricsClients {
+		c.clients = map[metricsProvider]analysis.Client{}
+	}
+	c.clients[provider] = cli
+	return cli, nil
+}
+
+func (c *metricsClientCache) reset() {
+	c.Lock()
+	defer c.Unlock()
+	c.clients = map[metricsProvider]analysis.Client{}
+}
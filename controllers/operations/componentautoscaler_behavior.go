@@ -0,0 +1,263 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
+)
+
+const (
+	// the ratio of the metric value to the target within which the replicas are not changed
+	autoscalingTolerance = 0.1
+	// the max period of the scaling policies and the stabilization windows
+	maxAutoscalingHistoryPeriod = time.Hour
+)
+
+var (
+	defaultScaleUpRules = autoscalingv2.HPAScalingRules{
+		StabilizationWindowSeconds: ptr.To[int32](0),
+		SelectPolicy:               ptr.To(autoscalingv2.MaxChangePolicySelect),
+		Policies: []autoscalingv2.HPAScalingPolicy{
+			{Type: autoscalingv2.PodsScalingPolicy, Value: 4, PeriodSeconds: 15},
+			{Type: autoscalingv2.PercentScalingPolicy, Value: 100, PeriodSeconds: 15},
+		},
+	}
+	defaultScaleDownRules = autoscalingv2.HPAScalingRules{
+		StabilizationWindowSeconds: ptr.To[int32](300),
+		SelectPolicy:               ptr.To(autoscalingv2.MaxChangePolicySelect),
+		Policies: []autoscalingv2.HPAScalingPolicy{
+			{Type: autoscalingv2.PercentScalingPolicy, Value: 100, PeriodSeconds: 15},
+		},
+	}
+)
+
+// replicasForMetric calculates the replicas to bring the metric to the target,
+// it returns the current replicas if the ratio of the metric to the target is within the tolerance.
+func replicasForMetric(target autoscalingv2.MetricTarget, metric metricValue, current int32) (int32, error) {
+	var ratio float64
+	switch target.Type {
+	case autoscalingv2.UtilizationMetricType:
+		if target.AverageUtilization == nil || *target.AverageUtilization <= 0 {
+			return 0, fmt.Errorf("the averageUtilization of the target is required")
+		}
+		ratio = metric.value / float64(*target.AverageUtilization)
+	case autoscalingv2.AverageValueMetricType:
+		if target.AverageValue == nil || target.AverageValue.Sign() <= 0 {
+			return 0, fmt.Errorf("the averageValue of the target is required")
+		}
+		ratio = metric.value / target.AverageValue.AsApproximateFloat64()
+		if !metric.averaged {
+			ratio /= float64(current)
+		}
+	case autoscalingv2.ValueMetricType:
+		if target.Value == nil || target.Value.Sign() <= 0 {
+			return 0, fmt.Errorf("the value of the target is required")
+		}
+		ratio = metric.value / target.Value.AsApproximateFloat64()
+	default:
+		return 0, fmt.Errorf("unsupported target type: %s", target.Type)
+	}
+	if math.IsNaN(ratio) || math.IsInf(ratio, 0) {
+		return 0, fmt.Errorf("invalid metric value: %v", metric.value)
+	}
+	if math.Abs(ratio-1) <= autoscalingTolerance {
+		return current, nil
+	}
+	return int32(math.Ceil(ratio * float64(current))), nil
+}
+
+// stabilizeRecommendation records the recommendation, and returns the stabilized one according to the stabilization windows:
+// it scales up to the lowest recommendation in the scale-up window, and scales down to the highest one in the scale-down window.
+func stabilizeRecommendation(status *opsv1alpha1.ComponentAutoscalerStatus, behavior *autoscalingv2.HorizontalPodAutoscalerBehavior,
+	current, recommendation int32, now time.Time) int32 {
+	upRules, downRules := scalingRules(behavior)
+	upWindow := time.Duration(ptr.Deref(upRules.StabilizationWindowSeconds, 0)) * time.Second
+	downWindow := time.Duration(ptr.Deref(downRules.StabilizationWindowSeconds, 0)) * time.Second
+
+	upRecommendation, downRecommendation := recommendation, recommendation
+	recommendations := []opsv1alpha1.ReplicasRecommendation{{Timestamp: metav1.NewTime(now), Replicas: recommendation}}
+	for _, rec := range status.Recommendations {
+		if now.Sub(rec.Timestamp.Time) > maxAutoscalingHistoryPeriod {
+			continue
+		}
+		if now.Sub(rec.Timestamp.Time) <= upWindow {
+			upRecommendation = min(upRecommendation, rec.Replicas)
+		}
+		if now.Sub(rec.Timestamp.Time) <= downWindow {
+			downRecommendation = max(downRecommendation, rec.Replicas)
+		}
+		recommendations = append(recommendations, rec)
+	}
+	status.Recommendations = pruneRecommendations(recommendations, max(upWindow, downWindow), now)
+
+	stabilized := current
+	if stabilized < upRecommendation {
+		stabilized = upRecommendation
+	}
+	if stabilized > downRecommendation {
+		stabilized = downRecommendation
+	}
+	return stabilized
+}
+
+// pruneRecommendations removes the recommendations out of the stabilization windows.
+func pruneRecommendations(recommendations []opsv1alpha1.ReplicasRecommendation, window time.Duration, now time.Time) []opsv1alpha1.ReplicasRecommendation {
+	result := make([]opsv1alpha1.ReplicasRecommendation, 0, len(recommendations))
+	for _, rec := range recommendations {
+		if now.Sub(rec.Timestamp.Time) <= window || rec.Timestamp.Time.Equal(now) {
+			result = append(result, rec)
+		}
+	}
+	return result
+}
+
+// limitScaling limits the change of the replicas according to the scaling policies and the recent scale events.
+func limitScaling(status *opsv1alpha1.ComponentAutoscalerStatus, behavior *autoscalingv2.HorizontalPodAutoscalerBehavior,
+	current, desired int32, now time.Time) int32 {
+	upRules, downRules := scalingRules(behavior)
+	switch {
+	case desired > current:
+		return min(desired, scaleUpLimit(status.ScaleEvents, upRules, current, now))
+	case desired < current:
+		return max(desired, scaleDownLimit(status.ScaleEvents, downRules, current, now))
+	default:
+		return desired
+	}
+}
+
+func scaleUpLimit(events []opsv1alpha1.ScaleEvent, rules autoscalingv2.HPAScalingRules, current int32, now time.Time) int32 {
+	selectPolicy := ptr.Deref(rules.SelectPolicy, autoscalingv2.MaxChangePolicySelect)
+	if selectPolicy == autoscalingv2.DisabledPolicySelect {
+		return current
+	}
+	limit := int32(math.MinInt32)
+	if selectPolicy == autoscalingv2.MinChangePolicySelect {
+		limit = math.MaxInt32
+	}
+	for _, policy := range rules.Policies {
+		// the replicas at the beginning of the period
+		periodStart := current - replicasChangedInPeriod(events, policy.PeriodSeconds, now, true)
+		var policyLimit int32
+		if policy.Type == autoscalingv2.PodsScalingPolicy {
+			policyLimit = periodStart + policy.Value
+		} else {
+			policyLimit = int32(math.Ceil(float64(periodStart) * (1 + float64(policy.Value)/100)))
+		}
+		if selectPolicy == autoscalingv2.MinChangePolicySelect {
+			limit = min(limit, policyLimit)
+		} else {
+			limit = max(limit, policyLimit)
+		}
+	}
+	if len(rules.Policies) == 0 {
+		return math.MaxInt32
+	}
+	return max(limit, current)
+}
+
+func scaleDownLimit(events []opsv1alpha1.ScaleEvent, rules autoscalingv2.HPAScalingRules, current int32, now time.Time) int32 {
+	selectPolicy := ptr.Deref(rules.SelectPolicy, autoscalingv2.MaxChangePolicySelect)
+	if selectPolicy == autoscalingv2.DisabledPolicySelect {
+		return current
+	}
+	limit := int32(math.MaxInt32)
+	if selectPolicy == autoscalingv2.MinChangePolicySelect {
+		limit = math.MinInt32
+	}
+	for _, policy := range rules.Policies {
+		periodStart := current + replicasChangedInPeriod(events, policy.PeriodSeconds, now, false)
+		var policyLimit int32
+		if policy.Type == autoscalingv2.PodsScalingPolicy {
+			policyLimit = periodStart - policy.Value
+		} else {
+			policyLimit = int32(math.Floor(float64(periodStart) * (1 - float64(policy.Value)/100)))
+		}
+		if selectPolicy == autoscalingv2.MinChangePolicySelect {
+			limit = max(limit, policyLimit)
+		} else {
+			limit = min(limit, policyLimit)
+		}
+	}
+	if len(rules.Policies) == 0 {
+		return 0
+	}
+	return min(limit, current)
+}
+
+// replicasChangedInPeriod returns the replicas added or deleted by the scale events in the period.
+func replicasChangedInPeriod(events []opsv1alpha1.ScaleEvent, periodSeconds int32, now time.Time, up bool) int32 {
+	var changed int32
+	for _, event := range events {
+		if now.Sub(event.Timestamp.Time) > time.Duration(periodSeconds)*time.Second {
+			continue
+		}
+		delta := event.ToReplicas - event.FromReplicas
+		if up && delta > 0 {
+			changed += delta
+		}
+		if !up && delta < 0 {
+			changed -= delta
+		}
+	}
+	return changed
+}
+
+// recordScaleEvent records the scale event, and removes the ones out of the periods of the policies.
+func recordScaleEvent(status *opsv1alpha1.ComponentAutoscalerStatus, from, to int32, now time.Time) {
+	events := []opsv1alpha1.ScaleEvent{{Timestamp: metav1.NewTime(now), FromReplicas: from, ToReplicas: to}}
+	for _, event := range status.ScaleEvents {
+		if now.Sub(event.Timestamp.Time) <= maxAutoscalingHistoryPeriod {
+			events = append(events, event)
+		}
+	}
+	status.ScaleEvents = events
+}
+
+func scalingRules(behavior *autoscalingv2.HorizontalPodAutoscalerBehavior) (autoscalingv2.HPAScalingRules, autoscalingv2.HPAScalingRules) {
+	up, down := *defaultScaleUpRules.DeepCopy(), *defaultScaleDownRules.DeepCopy()
+	if behavior == nil {
+		return up, down
+	}
+	merge := func(rules *autoscalingv2.HPAScalingRules, custom *autoscalingv2.HPAScalingRules) {
+		if custom == nil {
+			return
+		}
+		if custom.StabilizationWindowSeconds != nil {
+			rules.StabilizationWindowSeconds = custom.StabilizationWindowSeconds
+		}
+		if custom.SelectPolicy != nil {
+			rules.SelectPolicy = custom.SelectPolicy
+		}
+		if len(custom.Policies) > 0 {
+			rules.Policies = custom.Policies
+		}
+	}
+	merge(&up, behavior.ScaleUp)
+	merge(&down, behavior.ScaleDown)
+	return up, down
+}
@@ -0,0 +1,336 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+const (
+	componentAutoscalingInterval = 30 * time.Second
+	// the autoscaling is backed off for a while after the OpsRequest is failed, rather than retrying it repeatedly
+	componentAutoscalingFailureBackoff = 5 * time.Minute
+	// the TTL of the OpsRequests created by the autoscaler, they are only kept for a while for troubleshooting
+	componentAutoscalingOpsTTLSeconds = 24 * 60 * 60
+)
+
+// ComponentAutoscalerReconciler scales the replicas of the Components according to the metrics,
+// by creating HorizontalScaling OpsRequests.
+type ComponentAutoscalerReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=operations.kubeblocks.io,resources=componentautoscalers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=operations.kubeblocks.io,resources=componentautoscalers/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=operations.kubeblocks.io,resources=componentautoscalers/finalizers,verbs=update
+// +kubebuilder:rbac:groups=metrics.k8s.io,resources=pods,verbs=get;list
+
+func (r *ComponentAutoscalerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqCtx := intctrlutil.RequestCtx{
+		Ctx:      ctx,
+		Req:      req,
+		Log:      log.FromContext(ctx).WithValues("componentAutoscaler", req.NamespacedName),
+		Recorder: r.Recorder,
+	}
+
+	autoscaler := &opsv1alpha1.ComponentAutoscaler{}
+	if err := r.Client.Get(reqCtx.Ctx, reqCtx.Req.NamespacedName, autoscaler); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if !autoscaler.DeletionTimestamp.IsZero() {
+		return intctrlutil.Reconciled()
+	}
+
+	statusPatch := client.MergeFrom(autoscaler.DeepCopy())
+	autoscaler.Status.ObservedGeneration = autoscaler.Generation
+	autoscaler.Status.Message = ""
+	if err := r.autoscale(reqCtx, autoscaler, time.Now()); err != nil {
+		autoscaler.Status.Phase = opsv1alpha1.UnavailablePhase
+		autoscaler.Status.Message = err.Error()
+	} else {
+		autoscaler.Status.Phase = opsv1alpha1.AvailablePhase
+	}
+	if err := r.Client.Status().Patch(reqCtx.Ctx, autoscaler, statusPatch); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	return intctrlutil.RequeueAfter(componentAutoscalingInterval, reqCtx.Log, "wait for the next evaluation")
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ComponentAutoscalerReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return intctrlutil.NewControllerManagedBy(mgr).
+		For(&opsv1alpha1.ComponentAutoscaler{}).
+		Owns(&opsv1alpha1.OpsRequest{}).
+		Complete(r)
+}
+
+// autoscale evaluates the metrics, and creates a HorizontalScaling OpsRequest if the replicas should be changed.
+func (r *ComponentAutoscalerReconciler) autoscale(reqCtx intctrlutil.RequestCtx,
+	autoscaler *opsv1alpha1.ComponentAutoscaler, now time.Time) error {
+	spec := autoscaler.Spec
+	status := &autoscaler.Status
+
+	cluster := &appsv1.Cluster{}
+	if err := r.Client.Get(reqCtx.Ctx, client.ObjectKey{Namespace: autoscaler.Namespace, Name: spec.ClusterName}, cluster); err != nil {
+		return err
+	}
+	compSpec := cluster.Spec.GetComponentByName(spec.ComponentName)
+	if compSpec == nil {
+		if cluster.Spec.GetShardingByName(spec.ComponentName) != nil {
+			return fmt.Errorf("autoscaling the sharding %s is not supported", spec.ComponentName)
+		}
+		return fmt.Errorf("the component %s is not found in the cluster %s", spec.ComponentName, spec.ClusterName)
+	}
+	current := compSpec.Replicas
+	status.CurrentReplicas = current
+
+	minReplicas, maxReplicas, err := r.replicasRange(reqCtx, autoscaler)
+	if err != nil {
+		return err
+	}
+
+	// the changes of the replicas are serialized, the next one is not evaluated until the previous one is completed.
+	activeOps, err := r.hasActiveOpsRequest(reqCtx, autoscaler)
+	if err != nil {
+		return err
+	}
+	backoff, err := r.backoffAfterFailure(reqCtx, autoscaler, now)
+	if err != nil {
+		return err
+	}
+	// the metrics are meaningless if the component is stopped or being changed
+	if spec.Suspend || activeOps || backoff || current == 0 || cluster.Status.Phase != appsv1.RunningClusterPhase {
+		status.DesiredReplicas = current
+		return nil
+	}
+
+	pods, err := component.ListOwnedPods(reqCtx.Ctx, r.Client, autoscaler.Namespace, spec.ClusterName, spec.ComponentName)
+	if err != nil {
+		return err
+	}
+	var podList []corev1.Pod
+	for _, pod := range pods {
+		podList = append(podList, *pod)
+	}
+	recommendation, metrics := r.evaluateMetrics(reqCtx.Ctx, spec.Metrics, podList, current)
+	status.CurrentMetrics = metrics
+	if recommendation == nil {
+		return fmt.Errorf("none of the metrics is available")
+	}
+
+	desired := stabilizeRecommendation(status, spec.Behavior, current, *recommendation, now)
+	desired = limitScaling(status, spec.Behavior, current, desired, now)
+	desired = max(min(desired, maxReplicas), minReplicas)
+	status.DesiredReplicas = desired
+	if desired == current {
+		return nil
+	}
+
+	ops, err := r.buildOpsRequest(autoscaler, current, desired, now)
+	if err != nil {
+		return err
+	}
+	if err = r.Client.Create(reqCtx.Ctx, ops); err != nil && !apierrors.IsAlreadyExists(err) {
+		r.Recorder.Eventf(autoscaler, corev1.EventTypeWarning, reasonComponentAutoscalingFailed,
+			"failed to create the HorizontalScaling OpsRequest: %s, error: %s", ops.Name, err.Error())
+		return err
+	}
+	r.Recorder.Eventf(autoscaler, corev1.EventTypeNormal, reasonComponentAutoscaled,
+		"created the HorizontalScaling OpsRequest %s to scale the replicas from %d to %d", ops.Name, current, desired)
+	recordScaleEvent(status, current, desired, now)
+	status.LastScaleTime = &metav1.Time{Time: now}
+	status.LastOpsRequest = ops.Name
+	status.LastOpsRequestPhase = ""
+	return nil
+}
+
+// replicasRange returns the range of the replicas, which is limited by both the autoscaler and the ComponentDefinition.
+func (r *ComponentAutoscalerReconciler) replicasRange(reqCtx intctrlutil.RequestCtx,
+	autoscaler *opsv1alpha1.ComponentAutoscaler) (int32, int32, error) {
+	minReplicas, maxReplicas := ptr.Deref(autoscaler.Spec.MinReplicas, 1), autoscaler.Spec.MaxReplicas
+
+	comp := &appsv1.Component{}
+	compKey := client.ObjectKey{
+		Namespace: autoscaler.Namespace,
+		Name:      component.FullName(autoscaler.Spec.ClusterName, autoscaler.Spec.ComponentName),
+	}
+	if err := r.Client.Get(reqCtx.Ctx, compKey, comp); err != nil {
+		return 0, 0, err
+	}
+	compDef := &appsv1.ComponentDefinition{}
+	if err := r.Client.Get(reqCtx.Ctx, client.ObjectKey{Name: comp.Spec.CompDef}, compDef); err != nil {
+		return 0, 0, err
+	}
+	if limit := compDef.Spec.ReplicasLimit; limit != nil {
+		minReplicas = max(minReplicas, limit.MinReplicas)
+		maxReplicas = min(maxReplicas, limit.MaxReplicas)
+	}
+	if minReplicas > maxReplicas {
+		return 0, 0, fmt.Errorf("the replicas range [%d, %d] does not intersect with the replicas limit of the component definition %s",
+			ptr.Deref(autoscaler.Spec.MinReplicas, 1), autoscaler.Spec.MaxReplicas, compDef.Name)
+	}
+	return minReplicas, maxReplicas, nil
+}
+
+// evaluateMetrics returns the largest recommendation of the metrics, and the status of each metric.
+func (r *ComponentAutoscalerReconciler) evaluateMetrics(ctx context.Context, metrics []opsv1alpha1.ComponentAutoscalerMetric,
+	pods []corev1.Pod, current int32) (*int32, []opsv1alpha1.ComponentAutoscalerMetricStatus) {
+	var recommendation *int32
+	statuses := make([]opsv1alpha1.ComponentAutoscalerMetricStatus, len(metrics))
+	for i, metric := range metrics {
+		replicas, value, err := r.evaluateMetric(ctx, metric, pods, current)
+		if err != nil {
+			statuses[i].Message = err.Error()
+			continue
+		}
+		statuses[i].Value = resource.NewMilliQuantity(int64(value.value*1000), resource.DecimalSI)
+		statuses[i].Recommendation = ptr.To(replicas)
+		if recommendation == nil || replicas > *recommendation {
+			recommendation = ptr.To(replicas)
+		}
+	}
+	return recommendation, statuses
+}
+
+func (r *ComponentAutoscalerReconciler) evaluateMetric(ctx context.Context, metric opsv1alpha1.ComponentAutoscalerMetric,
+	pods []corev1.Pod, current int32) (int32, metricValue, error) {
+	source, err := newMetricSource(r.Client, metric)
+	if err != nil {
+		return 0, metricValue{}, err
+	}
+	value, err := source.value(ctx, pods)
+	if err != nil {
+		return 0, metricValue{}, err
+	}
+	replicas, err := replicasForMetric(metric.Target, value, current)
+	if err != nil {
+		return 0, value, err
+	}
+	return replicas, value, nil
+}
+
+func (r *ComponentAutoscalerReconciler) hasActiveOpsRequest(reqCtx intctrlutil.RequestCtx,
+	autoscaler *opsv1alpha1.ComponentAutoscaler) (bool, error) {
+	opsList := &opsv1alpha1.OpsRequestList{}
+	if err := r.Client.List(reqCtx.Ctx, opsList, client.InNamespace(autoscaler.Namespace),
+		client.MatchingLabels{constant.ComponentAutoscalerLabelKey: autoscaler.Name}); err != nil {
+		return false, err
+	}
+	for _, ops := range opsList.Items {
+		if !ops.IsComplete() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// backoffAfterFailure checks whether the last OpsRequest is failed recently, the next OpsRequest is not created
+// until the backoff has elapsed, otherwise the failed OpsRequests pile up.
+func (r *ComponentAutoscalerReconciler) backoffAfterFailure(reqCtx intctrlutil.RequestCtx,
+	autoscaler *opsv1alpha1.ComponentAutoscaler, now time.Time) (bool, error) {
+	status := &autoscaler.Status
+	if len(status.LastOpsRequest) == 0 {
+		return false, nil
+	}
+	ops := &opsv1alpha1.OpsRequest{}
+	if err := r.Client.Get(reqCtx.Ctx, client.ObjectKey{Namespace: autoscaler.Namespace, Name: status.LastOpsRequest}, ops); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	if ops.Status.Phase != opsv1alpha1.OpsFailedPhase {
+		status.LastOpsRequestPhase = ops.Status.Phase
+		return false, nil
+	}
+	if status.LastOpsRequestPhase != opsv1alpha1.OpsFailedPhase {
+		status.LastOpsRequestPhase = opsv1alpha1.OpsFailedPhase
+		r.Recorder.Eventf(autoscaler, corev1.EventTypeWarning, reasonComponentAutoscalingFailed,
+			"the HorizontalScaling OpsRequest %s is failed", ops.Name)
+	}
+	failedTime := ops.Status.CompletionTimestamp.Time
+	if failedTime.IsZero() {
+		failedTime = ops.CreationTimestamp.Time
+	}
+	if until := failedTime.Add(componentAutoscalingFailureBackoff); now.Before(until) {
+		status.Message = fmt.Sprintf("the HorizontalScaling OpsRequest %s is failed, back off the autoscaling until %s",
+			ops.Name, until.Format(time.RFC3339))
+		return true, nil
+	}
+	return false, nil
+}
+
+func (r *ComponentAutoscalerReconciler) buildOpsRequest(autoscaler *opsv1alpha1.ComponentAutoscaler,
+	current, desired int32, now time.Time) (*opsv1alpha1.OpsRequest, error) {
+	hscale := opsv1alpha1.HorizontalScaling{
+		ComponentOps: opsv1alpha1.ComponentOps{ComponentName: autoscaler.Spec.ComponentName},
+	}
+	if desired > current {
+		hscale.ScaleOut = &opsv1alpha1.ScaleOut{
+			ReplicaChanger: opsv1alpha1.ReplicaChanger{ReplicaChanges: ptr.To(desired - current)},
+		}
+	} else {
+		hscale.ScaleIn = &opsv1alpha1.ScaleIn{
+			ReplicaChanger: opsv1alpha1.ReplicaChanger{ReplicaChanges: ptr.To(current - desired)},
+		}
+	}
+	ops := &opsv1alpha1.OpsRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			// the name is deterministic in a minute, to avoid creating the OpsRequest repeatedly.
+			Name:      fmt.Sprintf("%s-autoscaling-%d", autoscaler.Name, now.Unix()/60),
+			Namespace: autoscaler.Namespace,
+			Labels: map[string]string{
+				constant.AppInstanceLabelKey:         autoscaler.Spec.ClusterName,
+				constant.ComponentAutoscalerLabelKey: autoscaler.Name,
+			},
+		},
+		Spec: opsv1alpha1.OpsRequestSpec{
+			ClusterName:                           autoscaler.Spec.ClusterName,
+			Type:                                  opsv1alpha1.HorizontalScalingType,
+			TTLSecondsAfterSucceed:                componentAutoscalingOpsTTLSeconds,
+			TTLSecondsAfterUnsuccessfulCompletion: componentAutoscalingOpsTTLSeconds,
+			SpecificOpsRequest: opsv1alpha1.SpecificOpsRequest{
+				HorizontalScalingList: []opsv1alpha1.HorizontalScaling{hscale},
+			},
+		},
+	}
+	if err := intctrlutil.SetControllerReference(autoscaler, ops); err != nil {
+		return nil, err
+	}
+	return ops, nil
+}
@@ -0,0 +1,263 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+func TestReplicasForMetric(t *testing.T) {
+	quantity := func(s string) *resource.Quantity {
+		q := resource.MustParse(s)
+		return &q
+	}
+	for _, tc := range []struct {
+		name     string
+		target   autoscalingv2.MetricTarget
+		metric   metricValue
+		current  int32
+		expected int32
+	}{
+		{"utilization scale up", autoscalingv2.MetricTarget{Type: autoscalingv2.UtilizationMetricType, AverageUtilization: ptr.To[int32](50)},
+			metricValue{value: 90, averaged: true}, 3, 6},
+		{"utilization scale down", autoscalingv2.MetricTarget{Type: autoscalingv2.UtilizationMetricType, AverageUtilization: ptr.To[int32](50)},
+			metricValue{value: 20, averaged: true}, 5, 2},
+		{"within tolerance", autoscalingv2.MetricTarget{Type: autoscalingv2.UtilizationMetricType, AverageUtilization: ptr.To[int32](50)},
+			metricValue{value: 54, averaged: true}, 3, 3},
+		{"average value of averaged metric", autoscalingv2.MetricTarget{Type: autoscalingv2.AverageValueMetricType, AverageValue: quantity("100")},
+			metricValue{value: 150, averaged: true}, 2, 3},
+		{"average value of total metric", autoscalingv2.MetricTarget{Type: autoscalingv2.AverageValueMetricType, AverageValue: quantity("100")},
+			metricValue{value: 450}, 2, 5},
+		{"value", autoscalingv2.MetricTarget{Type: autoscalingv2.ValueMetricType, Value: quantity("10")},
+			metricValue{value: 5}, 4, 2},
+	} {
+		replicas, err := replicasForMetric(tc.target, tc.metric, tc.current)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+			continue
+		}
+		if replicas != tc.expected {
+			t.Errorf("%s: expect replicas %d, got %d", tc.name, tc.expected, replicas)
+		}
+	}
+
+	if _, err := replicasForMetric(autoscalingv2.MetricTarget{Type: autoscalingv2.ValueMetricType}, metricValue{value: 1}, 1); err == nil {
+		t.Error("expect error for the target without value")
+	}
+}
+
+func TestStabilizeRecommendation(t *testing.T) {
+	now := time.Now()
+	ago := func(d time.Duration) metav1.Time { return metav1.NewTime(now.Add(-d)) }
+	status := &opsv1alpha1.ComponentAutoscalerStatus{
+		Recommendations: []opsv1alpha1.ReplicasRecommendation{
+			{Timestamp: ago(time.Minute), Replicas: 5},
+			{Timestamp: ago(4 * time.Minute), Replicas: 4},
+			{Timestamp: ago(10 * time.Minute), Replicas: 6},
+		},
+	}
+
+	// scales down to the highest recommendation in the default 300s window
+	if replicas := stabilizeRecommendation(status.DeepCopy(), nil, 6, 2, now); replicas != 5 {
+		t.Errorf("expect replicas 5, got %d", replicas)
+	}
+	// scales up immediately by default
+	if replicas := stabilizeRecommendation(status.DeepCopy(), nil, 3, 8, now); replicas != 8 {
+		t.Errorf("expect replicas 8, got %d", replicas)
+	}
+
+	behavior := &autoscalingv2.HorizontalPodAutoscalerBehavior{
+		ScaleUp:   &autoscalingv2.HPAScalingRules{StabilizationWindowSeconds: ptr.To[int32](120)},
+		ScaleDown: &autoscalingv2.HPAScalingRules{StabilizationWindowSeconds: ptr.To[int32](0)},
+	}
+	// scales up to the lowest recommendation in the window
+	if replicas := stabilizeRecommendation(status.DeepCopy(), behavior, 3, 8, now); replicas != 5 {
+		t.Errorf("expect replicas 5, got %d", replicas)
+	}
+	if replicas := stabilizeRecommendation(status, behavior, 6, 2, now); replicas != 2 {
+		t.Errorf("expect replicas 2, got %d", replicas)
+	}
+	// the recommendations out of the windows are pruned
+	if len(status.Recommendations) != 2 || status.Recommendations[0].Replicas != 2 {
+		t.Errorf("unexpected recommendations: %v", status.Recommendations)
+	}
+}
+
+func TestLimitScaling(t *testing.T) {
+	now := time.Now()
+	status := &opsv1alpha1.ComponentAutoscalerStatus{}
+
+	// the default scale-up policies allow to add max(4, 100%) replicas in 15s
+	if replicas := limitScaling(status, nil, 2, 10, now); replicas != 6 {
+		t.Errorf("expect replicas 6, got %d", replicas)
+	}
+	if replicas := limitScaling(status, nil, 6, 20, now); replicas != 12 {
+		t.Errorf("expect replicas 12, got %d", replicas)
+	}
+
+	behavior := &autoscalingv2.HorizontalPodAutoscalerBehavior{
+		ScaleUp: &autoscalingv2.HPAScalingRules{
+			Policies: []autoscalingv2.HPAScalingPolicy{{Type: autoscalingv2.PodsScalingPolicy, Value: 2, PeriodSeconds: 60}},
+		},
+		ScaleDown: &autoscalingv2.HPAScalingRules{
+			SelectPolicy: ptr.To(autoscalingv2.DisabledPolicySelect),
+		},
+	}
+	recordScaleEvent(status, 3, 4, now.Add(-30*time.Second))
+	// one replica has been added in the period
+	if replicas := limitScaling(status, behavior, 4, 10, now); replicas != 5 {
+		t.Errorf("expect replicas 5, got %d", replicas)
+	}
+	// the event is out of the period
+	if replicas := limitScaling(status, behavior, 4, 10, now.Add(time.Minute)); replicas != 6 {
+		t.Errorf("expect replicas 6, got %d", replicas)
+	}
+	// scaling down is disabled
+	if replicas := limitScaling(status, behavior, 4, 1, now); replicas != 4 {
+		t.Errorf("expect replicas 4, got %d", replicas)
+	}
+}
+
+func TestPrometheusMetricSource(t *testing.T) {
+	fakeMetricsClients(t, map[string]float64{"mysql_global_status_threads_connected": 420})
+
+	source, err := newMetricSource(nil, opsv1alpha1.ComponentAutoscalerMetric{
+		Prometheus: &opsv1alpha1.PrometheusMetricSource{
+			Address: "http://prometheus:9090",
+			Query:   `sum(mysql_global_status_threads_connected{cluster="mysql"})`,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	value, err := source.value(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value.value != 420 || value.averaged {
+		t.Errorf("unexpected metric value: %+v", value)
+	}
+}
+
+func TestProbeMetricSource(t *testing.T) {
+	handler := &component.ProbeOutputEventHandler{}
+	report := func(podName string, code int32, output string) {
+		msg, _ := json.Marshal(proto.ProbeEvent{Instance: "mysql", Probe: "connections", Code: code, Output: []byte(output)})
+		event := &corev1.Event{
+			InvolvedObject:      corev1.ObjectReference{Namespace: "default", Name: podName, FieldPath: proto.ProbeEventFieldPath},
+			ReportingController: proto.ProbeEventReportingController,
+			Message:             string(msg),
+			LastTimestamp:       metav1.Now(),
+		}
+		if err := handler.Handle(nil, intctrlutil.RequestCtx{}, nil, event); err != nil {
+			t.Fatal(err)
+		}
+	}
+	report("mysql-server-0", 0, "30\n")
+	report("mysql-server-1", 0, "50")
+	report("mysql-server-2", -1, "failed")
+
+	source, err := newMetricSource(nil, opsv1alpha1.ComponentAutoscalerMetric{
+		Probe: &opsv1alpha1.ProbeMetricSource{Name: "connections"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var pods []corev1.Pod
+	for _, name := range []string{"mysql-server-0", "mysql-server-1", "mysql-server-2"} {
+		pods = append(pods, corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name}})
+	}
+	value, err := source.value(context.Background(), pods)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value.value != 40 || !value.averaged {
+		t.Errorf("unexpected metric value: %+v", value)
+	}
+}
+
+func TestBackoffAfterFailure(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = opsv1alpha1.AddToScheme(scheme)
+	now := time.Now()
+	newOps := func(phase opsv1alpha1.OpsPhase, completion time.Time) *opsv1alpha1.OpsRequest {
+		ops := &opsv1alpha1.OpsRequest{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "mysql-autoscaling-1"}}
+		ops.Status.Phase = phase
+		ops.Status.CompletionTimestamp = metav1.NewTime(completion)
+		return ops
+	}
+	for _, tc := range []struct {
+		name    string
+		ops     *opsv1alpha1.OpsRequest
+		backoff bool
+	}{
+		{"recently failed", newOps(opsv1alpha1.OpsFailedPhase, now.Add(-time.Minute)), true},
+		{"failed long ago", newOps(opsv1alpha1.OpsFailedPhase, now.Add(-time.Hour)), false},
+		{"succeed", newOps(opsv1alpha1.OpsSucceedPhase, now.Add(-time.Minute)), false},
+		{"deleted", nil, false},
+	} {
+		builder := fake.NewClientBuilder().WithScheme(scheme)
+		if tc.ops != nil {
+			builder.WithObjects(tc.ops)
+		}
+		recorder := record.NewFakeRecorder(10)
+		r := &ComponentAutoscalerReconciler{Client: builder.Build(), Recorder: recorder}
+		autoscaler := &opsv1alpha1.ComponentAutoscaler{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "mysql"}}
+		autoscaler.Status.LastOpsRequest = "mysql-autoscaling-1"
+
+		for i := 0; i < 2; i++ {
+			backoff, err := r.backoffAfterFailure(intctrlutil.RequestCtx{Ctx: context.Background()}, autoscaler, now)
+			if err != nil {
+				t.Fatalf("%s: %v", tc.name, err)
+			}
+			if backoff != tc.backoff {
+				t.Errorf("%s: expect backoff %v, got %v", tc.name, tc.backoff, backoff)
+			}
+		}
+		if tc.backoff && !strings.Contains(autoscaler.Status.Message, "back off") {
+			t.Errorf("%s: expect the failure to be surfaced in the status, got %q", tc.name, autoscaler.Status.Message)
+		}
+		// the failure is reported once
+		if tc.ops != nil && tc.ops.Status.Phase == opsv1alpha1.OpsFailedPhase {
+			if autoscaler.Status.LastOpsRequestPhase != opsv1alpha1.OpsFailedPhase || len(recorder.Events) != 1 {
+				t.Errorf("%s: expect the failure to be recorded once, phase: %s, events: %d",
+					tc.name, autoscaler.Status.LastOpsRequestPhase, len(recorder.Events))
+			}
+		}
+	}
+}
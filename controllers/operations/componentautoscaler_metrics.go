@@ -0,0 +1,181 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/controller/analysis"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+)
+
+const (
+	// the outputs of the probes are taken as stale if they have not been reported for a while
+	probeMetricExpiration = 10 * time.Minute
+)
+
+var podMetricsListGVK = schema.GroupVersionKind{Group: "metrics.k8s.io", Version: "v1beta1", Kind: "PodMetricsList"}
+
+// metricValue is the value of a metric, which is either averaged across the replicas or taken as a whole.
+type metricValue struct {
+	value    float64
+	averaged bool
+}
+
+// metricSource reads the value of a metric of the replicas.
+type metricSource interface {
+	value(ctx context.Context, pods []corev1.Pod) (metricValue, error)
+}
+
+func newMetricSource(cli client.Client, metric opsv1alpha1.ComponentAutoscalerMetric) (metricSource, error) {
+	switch {
+	case metric.Resource != nil:
+		return &resourceMetricSource{cli: cli, source: *metric.Resource, utilization: metric.Target.Type == autoscalingv2.UtilizationMetricType}, nil
+	case metric.Prometheus != nil:
+		metricsCli, err := metricsClients.get(newMetricsProvider(metric.Prometheus.Address, metric.Prometheus.TimeoutSeconds))
+		if err != nil {
+			return nil, err
+		}
+		return &prometheusMetricSource{client: metricsCli, query: metric.Prometheus.Query}, nil
+	case metric.Probe != nil:
+		return &probeMetricSource{probe: metric.Probe.Name}, nil
+	default:
+		return nil, fmt.Errorf("no source of the metric is specified")
+	}
+}
+
+// resourceMetricSource reads the resource usage of the pods from the resource metrics API.
+type resourceMetricSource struct {
+	cli         client.Client
+	source      opsv1alpha1.ResourceMetricSource
+	utilization bool
+}
+
+func (s *resourceMetricSource) value(ctx context.Context, pods []corev1.Pod) (metricValue, error) {
+	if len(pods) == 0 {
+		return metricValue{}, fmt.Errorf("no pod is found")
+	}
+	metricsList := &unstructured.UnstructuredList{}
+	metricsList.SetGroupVersionKind(podMetricsListGVK)
+	if err := s.cli.List(ctx, metricsList, client.InNamespace(pods[0].Namespace)); err != nil {
+		return metricValue{}, fmt.Errorf("failed to get the pod metrics: %w", err)
+	}
+	usages := map[string]float64{}
+	for _, item := range metricsList.Items {
+		containers, _, _ := unstructured.NestedSlice(item.Object, "containers")
+		var usage float64
+		for _, c := range containers {
+			container, ok := c.(map[string]any)
+			if !ok {
+				continue
+			}
+			value, _, _ := unstructured.NestedString(container, "usage", string(s.source.Name))
+			if quantity, err := resource.ParseQuantity(value); err == nil {
+				usage += quantity.AsApproximateFloat64()
+			}
+		}
+		usages[item.GetName()] = usage
+	}
+
+	var totalUsage, totalRequests float64
+	var count int
+	for _, pod := range pods {
+		usage, ok := usages[pod.Name]
+		if !ok {
+			continue
+		}
+		for _, container := range pod.Spec.Containers {
+			if request, ok := container.Resources.Requests[s.source.Name]; ok {
+				totalRequests += request.AsApproximateFloat64()
+			}
+		}
+		totalUsage += usage
+		count++
+	}
+	if count == 0 {
+		return metricValue{}, fmt.Errorf("the metrics of the pods are unavailable")
+	}
+	if !s.utilization {
+		return metricValue{value: totalUsage / float64(count), averaged: true}, nil
+	}
+	if totalRequests <= 0 {
+		return metricValue{}, fmt.Errorf("the %s requests of the pods are not specified", s.source.Name)
+	}
+	return metricValue{value: totalUsage * 100 / totalRequests, averaged: true}, nil
+}
+
+// prometheusMetricSource queries the metric from a Prometheus-compatible endpoint.
+type prometheusMetricSource struct {
+	client analysis.Client
+	query  string
+}
+
+func (s *prometheusMetricSource) value(ctx context.Context, _ []corev1.Pod) (metricValue, error) {
+	value, err := s.client.Query(ctx, s.query)
+	if err != nil {
+		return metricValue{}, err
+	}
+	return metricValue{value: value}, nil
+}
+
+// probeMetricSource reads the latest outputs of a kbagent probe reported by the pods.
+type probeMetricSource struct {
+	probe string
+}
+
+func (s *probeMetricSource) value(_ context.Context, pods []corev1.Pod) (metricValue, error) {
+	if len(pods) == 0 {
+		return metricValue{}, fmt.Errorf("no pod is found")
+	}
+	podNames := make([]string, 0, len(pods))
+	for _, pod := range pods {
+		podNames = append(podNames, pod.Name)
+	}
+	var total float64
+	var count int
+	now := time.Now()
+	for podName, output := range component.LatestProbeOutputs(pods[0].Namespace, s.probe, podNames) {
+		if output.Code != 0 || now.Sub(output.Timestamp) > probeMetricExpiration {
+			continue
+		}
+		value, err := strconv.ParseFloat(strings.TrimSpace(string(output.Output)), 64)
+		if err != nil {
+			return metricValue{}, fmt.Errorf("the output of probe %s of pod %s is not a number: %s", s.probe, podName, string(output.Output))
+		}
+		total += value
+		count++
+	}
+	if count == 0 {
+		return metricValue{}, fmt.Errorf("no output of probe %s is reported", s.probe)
+	}
+	return metricValue{value: total / float64(count), averaged: true}, nil
+}
@@ -30,3 +30,22 @@ const (
 	reasonOpsReconcileStatusFailed    = "ReconcileStatusFailed"
 	reasonOpsDoActionFailed           = "DoActionFailed"
 )
+
+const (
+	reasonOpsScheduleCreated        = "OpsRequestCreated"
+	reasonOpsScheduleCreateFailed   = "OpsRequestCreateFailed"
+	reasonOpsScheduleSkipped        = "OpsRequestSkipped"
+	reasonOpsScheduleMissed         = "OpsRequestScheduleMissed"
+	reasonOpsScheduleReplaced       = "OpsRequestReplaced"
+	reasonOpsScheduleHistoryRemoved = "OpsRequestHistoryRemoved"
+)
+
+const (
+	reasonStorageAutoscaled        = "StorageAutoscaled"
+	reasonStorageAutoscalingFailed = "StorageAutoscalingFailed"
+)
+
+const (
+	reasonComponentAutoscaled        = "ComponentAutoscaled"
+	reasonComponentAutoscalingFailed = "ComponentAutoscalingFailed"
+)
@@ -31,6 +31,7 @@ import (
 
 	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/operations/custom"
 )
 
 // OpsDefinitionReconciler reconciles a OpsDefinition object
@@ -81,6 +82,14 @@ func (r *OpsDefinitionReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		}
 	}
 
+	// check the dependencies, compensations and conditions of the actions.
+	if err = custom.ValidateActions(opsDef.Spec.Actions); err != nil {
+		if patchErr := r.updateStatusUnavailable(reqCtx, opsDef, err); patchErr != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+		return intctrlutil.Reconciled()
+	}
+
 	// TODO: check serviceKind, connectionCredentialName and serviceName
 	statusPatch := client.MergeFrom(opsDef.DeepCopy())
 	opsDef.Status.ObservedGeneration = opsDef.Generation
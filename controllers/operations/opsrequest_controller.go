@@ -52,6 +52,7 @@ import (
 	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/tracing"
 	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
@@ -104,7 +105,7 @@ func (r *OpsRequestReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Owns(&batchv1.Job{}).
 		Owns(&dpv1alpha1.Restore{}).
 		Owns(&parametersv1alpha1.Parameter{}).
-		Complete(r)
+		Complete(tracing.WrapReconciler(constant.OpsRequestKind, r))
 }
 
 // fetchOpsRequestAndCluster fetches the OpsRequest from the request.
@@ -121,6 +122,7 @@ func (r *OpsRequestReconciler) fetchOpsRequest(reqCtx intctrlutil.RequestCtx, op
 		return intctrlutil.ResultToP(intctrlutil.Reconciled())
 	}
 	opsRes.OpsRequest = opsRequest
+	tracing.SetObjectAttributes(reqCtx.Ctx, opsRequest)
 	return nil, nil
 }
 
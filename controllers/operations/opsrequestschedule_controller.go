@@ -0,0 +1,392 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/operations"
+)
+
+const (
+	defaultSuccessfulHistoryLimit = 3
+	defaultFailedHistoryLimit     = 1
+
+	// maxMissedSchedules is the max number of missed schedule times iterated, which is the same as the CronJob.
+	maxMissedSchedules = 100
+)
+
+// OpsRequestScheduleReconciler reconciles a OpsRequestSchedule object
+type OpsRequestScheduleReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=operations.kubeblocks.io,resources=opsrequestschedules,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=operations.kubeblocks.io,resources=opsrequestschedules/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=operations.kubeblocks.io,resources=opsrequestschedules/finalizers,verbs=update
+
+func (r *OpsRequestScheduleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqCtx := intctrlutil.RequestCtx{
+		Ctx:      ctx,
+		Req:      req,
+		Log:      log.FromContext(ctx).WithValues("opsRequestSchedule", req.NamespacedName),
+		Recorder: r.Recorder,
+	}
+
+	schedule := &opsv1alpha1.OpsRequestSchedule{}
+	if err := r.Client.Get(reqCtx.Ctx, reqCtx.Req.NamespacedName, schedule); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	// the OpsRequests created are garbage collected by the owner reference.
+	if !schedule.DeletionTimestamp.IsZero() {
+		return intctrlutil.Reconciled()
+	}
+
+	statusPatch := client.MergeFrom(schedule.DeepCopy())
+	cronSchedule, err := validateOpsRequestSchedule(schedule)
+	if err != nil {
+		schedule.Status.Phase = opsv1alpha1.UnavailablePhase
+		schedule.Status.Message = err.Error()
+		schedule.Status.ObservedGeneration = schedule.Generation
+		schedule.Status.NextScheduleTime = nil
+		if patchErr := r.Client.Status().Patch(reqCtx.Ctx, schedule, statusPatch); patchErr != nil {
+			return intctrlutil.CheckedRequeueWithError(patchErr, reqCtx.Log, "")
+		}
+		return intctrlutil.Reconciled()
+	}
+
+	activeOps, err := r.syncOpsRequests(reqCtx, schedule)
+	if err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+
+	var requeueAfter time.Duration
+	if schedule.Spec.Suspend {
+		schedule.Status.NextScheduleTime = nil
+	} else if requeueAfter, err = r.scheduleOpsRequest(reqCtx, schedule, cronSchedule, activeOps, time.Now()); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+
+	schedule.Status.Phase = opsv1alpha1.AvailablePhase
+	schedule.Status.Message = ""
+	schedule.Status.ObservedGeneration = schedule.Generation
+	if err = r.Client.Status().Patch(reqCtx.Ctx, schedule, statusPatch); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	intctrlutil.RecordCreatedEvent(r.Recorder, schedule)
+	if requeueAfter > 0 {
+		return intctrlutil.RequeueAfter(requeueAfter, reqCtx.Log, "wait for the next schedule time")
+	}
+	return intctrlutil.Reconciled()
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *OpsRequestScheduleReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return intctrlutil.NewControllerManagedBy(mgr).
+		For(&opsv1alpha1.OpsRequestSchedule{}).
+		Owns(&opsv1alpha1.OpsRequest{}).
+		Complete(r)
+}
+
+// validateOpsRequestSchedule validates the schedule and the OpsRequest template.
+// The spec of the OpsRequest is validated by the OpsRequest controller when it's created.
+func validateOpsRequestSchedule(schedule *opsv1alpha1.OpsRequestSchedule) (intctrlutil.CronSchedule, error) {
+	cronSchedule, err := intctrlutil.ParseCronExpression(schedule.Spec.Schedule)
+	if err != nil {
+		return nil, err
+	}
+	templateSpec := schedule.Spec.OpsRequestTemplate.Spec
+	if templateSpec.Type == "" {
+		return nil, fmt.Errorf("the type of the OpsRequest template is required")
+	}
+	if templateSpec.GetClusterName() == "" {
+		return nil, fmt.Errorf("the clusterName of the OpsRequest template is required")
+	}
+	if _, ok := opsv1alpha1.OpsRequestBehaviourMapper[templateSpec.Type]; !ok {
+		return nil, fmt.Errorf("the type of the OpsRequest template is not supported: %s", templateSpec.Type)
+	}
+	return cronSchedule, nil
+}
+
+// syncOpsRequests updates the status with the OpsRequests created by the schedule, removes the histories exceeding
+// the limits, and returns the active OpsRequests.
+func (r *OpsRequestScheduleReconciler) syncOpsRequests(reqCtx intctrlutil.RequestCtx,
+	schedule *opsv1alpha1.OpsRequestSchedule) ([]*opsv1alpha1.OpsRequest, error) {
+	opsList := &opsv1alpha1.OpsRequestList{}
+	if err := r.Client.List(reqCtx.Ctx, opsList, client.InNamespace(schedule.Namespace),
+		client.MatchingLabels{constant.OpsRequestScheduleLabelKey: schedule.Name}); err != nil {
+		return nil, err
+	}
+	var activeOps, succeedOps, failedOps []*opsv1alpha1.OpsRequest
+	for i := range opsList.Items {
+		ops := &opsList.Items[i]
+		if !metav1.IsControlledBy(ops, schedule) {
+			continue
+		}
+		switch {
+		case !ops.IsComplete():
+			activeOps = append(activeOps, ops)
+		case ops.Status.Phase == opsv1alpha1.OpsSucceedPhase:
+			succeedOps = append(succeedOps, ops)
+			completionTime := ops.Status.CompletionTimestamp
+			if schedule.Status.LastSuccessfulTime == nil || schedule.Status.LastSuccessfulTime.Before(&completionTime) {
+				schedule.Status.LastSuccessfulTime = &completionTime
+			}
+		default:
+			failedOps = append(failedOps, ops)
+		}
+	}
+
+	schedule.Status.Active = nil
+	for _, ops := range activeOps {
+		schedule.Status.Active = append(schedule.Status.Active, buildOpsRequestReference(ops))
+	}
+
+	successfulLimit, failedLimit := int32(defaultSuccessfulHistoryLimit), int32(defaultFailedHistoryLimit)
+	if schedule.Spec.SuccessfulHistoryLimit != nil {
+		successfulLimit = *schedule.Spec.SuccessfulHistoryLimit
+	}
+	if schedule.Spec.FailedHistoryLimit != nil {
+		failedLimit = *schedule.Spec.FailedHistoryLimit
+	}
+	for _, histories := range []struct {
+		opsList []*opsv1alpha1.OpsRequest
+		limit   int32
+	}{{succeedOps, successfulLimit}, {failedOps, failedLimit}} {
+		if err := r.removeHistories(reqCtx, schedule, histories.opsList, int(histories.limit)); err != nil {
+			return nil, err
+		}
+	}
+	return activeOps, nil
+}
+
+// removeHistories removes the oldest completed OpsRequests exceeding the limit.
+func (r *OpsRequestScheduleReconciler) removeHistories(reqCtx intctrlutil.RequestCtx,
+	schedule *opsv1alpha1.OpsRequestSchedule, opsList []*opsv1alpha1.OpsRequest, limit int) error {
+	if len(opsList) <= limit {
+		return nil
+	}
+	slices.SortFunc(opsList, func(a, b *opsv1alpha1.OpsRequest) int {
+		return a.CreationTimestamp.Compare(b.CreationTimestamp.Time)
+	})
+	for _, ops := range opsList[:len(opsList)-limit] {
+		if err := r.Client.Delete(reqCtx.Ctx, ops); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+		r.Recorder.Eventf(schedule, corev1.EventTypeNormal, reasonOpsScheduleHistoryRemoved,
+			"removed the history OpsRequest: %s", ops.Name)
+	}
+	return nil
+}
+
+// scheduleOpsRequest creates the OpsRequest if the schedule time has arrived, and returns the duration until the next schedule time.
+func (r *OpsRequestScheduleReconciler) scheduleOpsRequest(reqCtx intctrlutil.RequestCtx,
+	schedule *opsv1alpha1.OpsRequestSchedule,
+	cronSchedule intctrlutil.CronSchedule,
+	activeOps []*opsv1alpha1.OpsRequest,
+	now time.Time) (time.Duration, error) {
+	scheduledTime, nextTime := getScheduleTimes(reqCtx.Log, schedule, cronSchedule, now)
+	var requeueAfter time.Duration
+	if nextTime.IsZero() {
+		schedule.Status.NextScheduleTime = nil
+	} else {
+		schedule.Status.NextScheduleTime = &metav1.Time{Time: nextTime}
+		requeueAfter = nextTime.Sub(now)
+	}
+	if scheduledTime == nil {
+		return requeueAfter, nil
+	}
+
+	switch schedule.Spec.ConcurrencyPolicy {
+	case opsv1alpha1.AllowConcurrent:
+	case opsv1alpha1.ReplaceConcurrent:
+		// the new OpsRequest would be queued behind the ones can't be cancelled, it's skipped as Forbid does.
+		if i := slices.IndexFunc(activeOps, func(ops *opsv1alpha1.OpsRequest) bool { return !isOpsRequestCancelable(ops) }); i >= 0 {
+			r.skipSchedule(schedule, *scheduledTime,
+				fmt.Sprintf("the active OpsRequest %s can not be cancelled", activeOps[i].Name))
+			return requeueAfter, nil
+		}
+		for _, ops := range activeOps {
+			if err := r.cancelOpsRequest(reqCtx, ops); err != nil {
+				return 0, err
+			}
+			r.Recorder.Eventf(schedule, corev1.EventTypeNormal, reasonOpsScheduleReplaced,
+				"cancelled the active OpsRequest: %s to replace it", ops.Name)
+		}
+	default:
+		// forbid by default, it will be retried when the active OpsRequests complete unless the starting deadline exceeds.
+		if len(activeOps) > 0 {
+			r.skipSchedule(schedule, *scheduledTime, "the previous OpsRequest is still active")
+			return requeueAfter, nil
+		}
+	}
+
+	ops, err := r.buildOpsRequest(schedule, *scheduledTime)
+	if err != nil {
+		return 0, err
+	}
+	if err = r.Client.Create(reqCtx.Ctx, ops); err != nil && !apierrors.IsAlreadyExists(err) {
+		r.Recorder.Eventf(schedule, corev1.EventTypeWarning, reasonOpsScheduleCreateFailed,
+			"failed to create the OpsRequest: %s, error: %s", ops.Name, err.Error())
+		return 0, err
+	} else if err == nil {
+		r.Recorder.Eventf(schedule, corev1.EventTypeNormal, reasonOpsScheduleCreated, "created the OpsRequest: %s", ops.Name)
+		schedule.Status.Active = append(schedule.Status.Active, buildOpsRequestReference(ops))
+	}
+	schedule.Status.LastScheduleTime = &metav1.Time{Time: *scheduledTime}
+	return requeueAfter, nil
+}
+
+// getScheduleTimes returns the most recent schedule time that has not been scheduled and not missed the starting deadline,
+// and the next schedule time after now. At most maxMissedSchedules missed schedule times are iterated like the CronJob,
+// the ones before the last interval are skipped if exceeding it.
+func getScheduleTimes(logger logr.Logger, schedule *opsv1alpha1.OpsRequestSchedule,
+	cronSchedule intctrlutil.CronSchedule, now time.Time) (*time.Time, time.Time) {
+	earliestTime := schedule.CreationTimestamp.Time
+	if schedule.Status.LastScheduleTime != nil {
+		earliestTime = schedule.Status.LastScheduleTime.Time
+	}
+	if schedule.Spec.StartingDeadlineSeconds != nil {
+		deadline := now.Add(-time.Duration(*schedule.Spec.StartingDeadlineSeconds) * time.Second)
+		if deadline.After(earliestTime) {
+			earliestTime = deadline
+		}
+	}
+	var mostRecentTime *time.Time
+	iterate := func(after time.Time) (time.Time, bool) {
+		t := cronSchedule.Next(after)
+		for missed := 0; !t.IsZero() && !t.After(now); t = cronSchedule.Next(t) {
+			if missed++; missed > maxMissedSchedules {
+				return t, false
+			}
+			scheduledTime := t
+			mostRecentTime = &scheduledTime
+		}
+		return t, true
+	}
+	t, completed := iterate(earliestTime)
+	if !completed {
+		logger.Info("too many missed schedule times, set or decrease the startingDeadlineSeconds or check the clock skew",
+			"limit", maxMissedSchedules, "lastMissedTime", mostRecentTime.UTC().Format(time.RFC3339))
+		// the interval is estimated by the last two schedule times.
+		t, _ = iterate(now.Add(-t.Sub(*mostRecentTime)))
+	}
+	return mostRecentTime, t
+}
+
+func (r *OpsRequestScheduleReconciler) buildOpsRequest(schedule *opsv1alpha1.OpsRequestSchedule,
+	scheduledTime time.Time) (*opsv1alpha1.OpsRequest, error) {
+	template := schedule.Spec.OpsRequestTemplate
+	ops := &opsv1alpha1.OpsRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        buildScheduledOpsRequestName(schedule.Name, scheduledTime),
+			Namespace:   schedule.Namespace,
+			Labels:      map[string]string{},
+			Annotations: map[string]string{},
+		},
+		Spec: *template.Spec.DeepCopy(),
+	}
+	for k, v := range template.Metadata.Labels {
+		ops.Labels[k] = v
+	}
+	for k, v := range template.Metadata.Annotations {
+		ops.Annotations[k] = v
+	}
+	ops.Labels[constant.OpsRequestScheduleLabelKey] = schedule.Name
+	ops.Annotations[constant.ScheduledTimeAnnotationKey] = scheduledTime.UTC().Format(time.RFC3339)
+	if err := intctrlutil.SetControllerReference(schedule, ops); err != nil {
+		return nil, err
+	}
+	return ops, nil
+}
+
+// buildScheduledOpsRequestName builds the name of the OpsRequest, which is deterministic for the schedule time
+// to avoid creating the OpsRequest repeatedly. The schedule name is truncated and hashed if the name exceeds 63 characters.
+func buildScheduledOpsRequestName(scheduleName string, scheduledTime time.Time) string {
+	suffix := fmt.Sprintf("-%d", scheduledTime.Unix()/60)
+	if len(scheduleName)+len(suffix) <= validation.DNS1035LabelMaxLength {
+		return scheduleName + suffix
+	}
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(scheduleName))
+	hashSuffix := fmt.Sprintf("-%08x", hash.Sum32())
+	prefix := strings.TrimSuffix(scheduleName[:validation.DNS1035LabelMaxLength-len(hashSuffix)-len(suffix)], "-")
+	return prefix + hashSuffix + suffix
+}
+
+// skipSchedule records the skipped schedule time, the event is recorded once for each schedule time.
+func (r *OpsRequestScheduleReconciler) skipSchedule(schedule *opsv1alpha1.OpsRequestSchedule, scheduledTime time.Time, reason string) {
+	if skipped := schedule.Status.LastSkippedScheduleTime; skipped != nil && skipped.Time.Equal(scheduledTime) {
+		return
+	}
+	r.Recorder.Eventf(schedule, corev1.EventTypeNormal, reasonOpsScheduleSkipped,
+		"skipped the schedule at %s as %s", scheduledTime.UTC().Format(time.RFC3339), reason)
+	schedule.Status.LastSkippedScheduleTime = &metav1.Time{Time: scheduledTime}
+}
+
+// isOpsRequestCancelable checks whether the OpsRequest can be cancelled, the types without the cancel action
+// can only be cancelled before running.
+func isOpsRequestCancelable(ops *opsv1alpha1.OpsRequest) bool {
+	switch ops.Status.Phase {
+	case "", opsv1alpha1.OpsPendingPhase:
+		return true
+	}
+	return operations.GetOpsManager().OpsMap[ops.Spec.Type].CancelFunc != nil
+}
+
+func (r *OpsRequestScheduleReconciler) cancelOpsRequest(reqCtx intctrlutil.RequestCtx, ops *opsv1alpha1.OpsRequest) error {
+	if ops.Spec.Cancel {
+		return nil
+	}
+	patch := client.MergeFrom(ops.DeepCopy())
+	ops.Spec.Cancel = true
+	return r.Client.Patch(reqCtx.Ctx, ops, patch)
+}
+
+func buildOpsRequestReference(ops *opsv1alpha1.OpsRequest) corev1.ObjectReference {
+	return corev1.ObjectReference{
+		APIVersion: opsv1alpha1.GroupVersion.String(),
+		Kind:       constant.OpsRequestKind,
+		Namespace:  ops.Namespace,
+		Name:       ops.Name,
+		UID:        ops.UID,
+	}
+}
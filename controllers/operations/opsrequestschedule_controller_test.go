@@ -0,0 +1,228 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+func TestGetScheduleTimes(t *testing.T) {
+	cronSchedule, err := intctrlutil.ParseCronExpression("0 * * * *")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	created := time.Date(2024, 1, 1, 0, 30, 0, 0, time.UTC)
+	newSchedule := func() *opsv1alpha1.OpsRequestSchedule {
+		return &opsv1alpha1.OpsRequestSchedule{
+			ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.Time{Time: created}},
+		}
+	}
+
+	// not scheduled yet
+	schedule := newSchedule()
+	scheduled, next := getScheduleTimes(logr.Discard(), schedule, cronSchedule, created.Add(10*time.Minute))
+	if scheduled != nil || !next.Equal(time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC)) {
+		t.Errorf("expect no schedule time and next at 01:00, got %v %v", scheduled, next)
+	}
+
+	// the most recent one of the missed schedules
+	scheduled, next = getScheduleTimes(logr.Discard(), schedule, cronSchedule, time.Date(2024, 1, 1, 3, 10, 0, 0, time.UTC))
+	if scheduled == nil || !scheduled.Equal(time.Date(2024, 1, 1, 3, 0, 0, 0, time.UTC)) ||
+		!next.Equal(time.Date(2024, 1, 1, 4, 0, 0, 0, time.UTC)) {
+		t.Errorf("expect schedule time at 03:00 and next at 04:00, got %v %v", scheduled, next)
+	}
+
+	// already scheduled
+	schedule.Status.LastScheduleTime = &metav1.Time{Time: time.Date(2024, 1, 1, 3, 0, 0, 0, time.UTC)}
+	scheduled, _ = getScheduleTimes(logr.Discard(), schedule, cronSchedule, time.Date(2024, 1, 1, 3, 10, 0, 0, time.UTC))
+	if scheduled != nil {
+		t.Errorf("expect no schedule time, got %v", scheduled)
+	}
+
+	// missed the starting deadline
+	schedule = newSchedule()
+	schedule.Spec.StartingDeadlineSeconds = pointer.Int64(300)
+	scheduled, _ = getScheduleTimes(logr.Discard(), schedule, cronSchedule, time.Date(2024, 1, 1, 3, 10, 0, 0, time.UTC))
+	if scheduled != nil {
+		t.Errorf("expect no schedule time as the deadline exceeded, got %v", scheduled)
+	}
+	scheduled, _ = getScheduleTimes(logr.Discard(), schedule, cronSchedule, time.Date(2024, 1, 1, 3, 4, 0, 0, time.UTC))
+	if scheduled == nil || !scheduled.Equal(time.Date(2024, 1, 1, 3, 0, 0, 0, time.UTC)) {
+		t.Errorf("expect schedule time at 03:00 within the deadline, got %v", scheduled)
+	}
+
+	// too many missed schedules
+	schedule = newSchedule()
+	scheduled, next = getScheduleTimes(logr.Discard(), schedule, cronSchedule, time.Date(2025, 1, 1, 3, 10, 0, 0, time.UTC))
+	if scheduled == nil || !scheduled.Equal(time.Date(2025, 1, 1, 3, 0, 0, 0, time.UTC)) ||
+		!next.Equal(time.Date(2025, 1, 1, 4, 0, 0, 0, time.UTC)) {
+		t.Errorf("expect schedule time at 03:00 and next at 04:00 after skipping the missed ones, got %v %v", scheduled, next)
+	}
+}
+
+func TestBuildScheduledOpsRequestName(t *testing.T) {
+	scheduledTime := time.Date(2024, 1, 1, 3, 0, 0, 0, time.UTC)
+	if name := buildScheduledOpsRequestName("backup", scheduledTime); name != "backup-28401300" {
+		t.Errorf("expect the name backup-28401300, got %s", name)
+	}
+	longName := strings.Repeat("a", 60)
+	name := buildScheduledOpsRequestName(longName, scheduledTime)
+	if len(name) > 63 || !strings.HasSuffix(name, "-28401300") {
+		t.Errorf("expect the name no longer than 63 with the time suffix, got %s", name)
+	}
+	if name != buildScheduledOpsRequestName(longName, scheduledTime) {
+		t.Error("expect the name deterministic")
+	}
+	if name == buildScheduledOpsRequestName(longName+"b", scheduledTime) {
+		t.Error("expect different names for different schedules")
+	}
+}
+
+func TestScheduleOpsRequestForbidConcurrent(t *testing.T) {
+	cronSchedule, err := intctrlutil.ParseCronExpression("0 * * * *")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	recorder := record.NewFakeRecorder(10)
+	r := &OpsRequestScheduleReconciler{Recorder: recorder}
+	reqCtx := intctrlutil.RequestCtx{Ctx: context.Background(), Log: logr.Discard(), Recorder: recorder}
+	schedule := &opsv1alpha1.OpsRequestSchedule{
+		ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.Time{Time: time.Date(2024, 1, 1, 0, 30, 0, 0, time.UTC)}},
+		Spec:       opsv1alpha1.OpsRequestScheduleSpec{ConcurrencyPolicy: opsv1alpha1.ForbidConcurrent},
+	}
+	activeOps := []*opsv1alpha1.OpsRequest{{ObjectMeta: metav1.ObjectMeta{Name: "active"}}}
+
+	for _, now := range []time.Time{
+		time.Date(2024, 1, 1, 1, 10, 0, 0, time.UTC),
+		time.Date(2024, 1, 1, 1, 20, 0, 0, time.UTC),
+		time.Date(2024, 1, 1, 2, 10, 0, 0, time.UTC),
+	} {
+		if _, err = r.scheduleOpsRequest(reqCtx, schedule, cronSchedule, activeOps, now); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if len(recorder.Events) != 2 {
+		t.Errorf("expect the skipped event recorded once for each schedule time, got %d events", len(recorder.Events))
+	}
+	if schedule.Status.LastScheduleTime != nil {
+		t.Errorf("expect no OpsRequest scheduled, got %v", schedule.Status.LastScheduleTime)
+	}
+}
+
+func TestScheduleOpsRequestReplaceConcurrent(t *testing.T) {
+	cronSchedule, err := intctrlutil.ParseCronExpression("0 * * * *")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	scheme := runtime.NewScheme()
+	_ = opsv1alpha1.AddToScheme(scheme)
+	newOps := func(name string, opsType opsv1alpha1.OpsType, phase opsv1alpha1.OpsPhase) *opsv1alpha1.OpsRequest {
+		ops := &opsv1alpha1.OpsRequest{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+			Spec:       opsv1alpha1.OpsRequestSpec{ClusterName: "mysql", Type: opsType},
+		}
+		ops.Status.Phase = phase
+		return ops
+	}
+	now := time.Date(2024, 1, 1, 1, 10, 0, 0, time.UTC)
+
+	for _, tc := range []struct {
+		name     string
+		ops      *opsv1alpha1.OpsRequest
+		replaced bool
+	}{
+		{"running restart can not be cancelled", newOps("restart", opsv1alpha1.RestartType, opsv1alpha1.OpsRunningPhase), false},
+		{"pending restart", newOps("restart", opsv1alpha1.RestartType, opsv1alpha1.OpsPendingPhase), true},
+		{"running vertical scaling", newOps("vscale", opsv1alpha1.VerticalScalingType, opsv1alpha1.OpsRunningPhase), true},
+	} {
+		cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tc.ops).Build()
+		recorder := record.NewFakeRecorder(10)
+		r := &OpsRequestScheduleReconciler{Client: cli, Scheme: scheme, Recorder: recorder}
+		reqCtx := intctrlutil.RequestCtx{Ctx: context.Background(), Log: logr.Discard(), Recorder: recorder}
+		schedule := &opsv1alpha1.OpsRequestSchedule{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         "default",
+				Name:              "mysql",
+				UID:               "uid",
+				CreationTimestamp: metav1.Time{Time: time.Date(2024, 1, 1, 0, 30, 0, 0, time.UTC)},
+			},
+			Spec: opsv1alpha1.OpsRequestScheduleSpec{
+				ConcurrencyPolicy: opsv1alpha1.ReplaceConcurrent,
+				OpsRequestTemplate: opsv1alpha1.OpsRequestTemplate{
+					Spec: opsv1alpha1.OpsRequestSpec{ClusterName: "mysql", Type: opsv1alpha1.RestartType},
+				},
+			},
+		}
+		if _, err = r.scheduleOpsRequest(reqCtx, schedule, cronSchedule, []*opsv1alpha1.OpsRequest{tc.ops}, now); err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.name, err)
+		}
+
+		active := &opsv1alpha1.OpsRequest{}
+		if err = cli.Get(context.Background(), client.ObjectKeyFromObject(tc.ops), active); err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.name, err)
+		}
+		if active.Spec.Cancel != tc.replaced {
+			t.Errorf("%s: expect cancel %v, got %v", tc.name, tc.replaced, active.Spec.Cancel)
+		}
+		if scheduled := schedule.Status.LastScheduleTime != nil; scheduled != tc.replaced {
+			t.Errorf("%s: expect scheduled %v, got %v", tc.name, tc.replaced, scheduled)
+		}
+		if !tc.replaced && schedule.Status.LastSkippedScheduleTime == nil {
+			t.Errorf("%s: expect the schedule to be skipped", tc.name)
+		}
+	}
+}
+
+func TestValidateOpsRequestSchedule(t *testing.T) {
+	schedule := &opsv1alpha1.OpsRequestSchedule{
+		Spec: opsv1alpha1.OpsRequestScheduleSpec{
+			Schedule: "0 3 * * *",
+			OpsRequestTemplate: opsv1alpha1.OpsRequestTemplate{
+				Spec: opsv1alpha1.OpsRequestSpec{ClusterName: "test", Type: opsv1alpha1.RestartType},
+			},
+		},
+	}
+	if _, err := validateOpsRequestSchedule(schedule); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	schedule.Spec.Schedule = "0 3 * *"
+	if _, err := validateOpsRequestSchedule(schedule); err == nil {
+		t.Error("expect error for the invalid schedule")
+	}
+	schedule.Spec.Schedule = "0 3 * * *"
+	schedule.Spec.OpsRequestTemplate.Spec.ClusterName = ""
+	if _, err := validateOpsRequestSchedule(schedule); err == nil {
+		t.Error("expect error for the empty cluster name")
+	}
+}
@@ -0,0 +1,170 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/analysis"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	kbagt "github.com/apecloud/kubeblocks/pkg/kbagent"
+	kbacli "github.com/apecloud/kubeblocks/pkg/kbagent/client"
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+// volumeUsage is the usage of a PVC.
+type volumeUsage struct {
+	usedBytes     int64
+	capacityBytes int64
+}
+
+func (u volumeUsage) percent() int32 {
+	if u.capacityBytes <= 0 {
+		return 0
+	}
+	return int32(math.Ceil(float64(u.usedBytes) * 100 / float64(u.capacityBytes)))
+}
+
+// storageUsageSource reads the usage of the PVCs of a Component.
+type storageUsageSource interface {
+	// usages returns the usage of the PVCs keyed by the PVC name, the PVCs whose usage is unavailable are omitted.
+	usages(ctx context.Context, comp *appsv1.Component, pvcs []corev1.PersistentVolumeClaim) (map[string]volumeUsage, error)
+}
+
+// kbagentUsageSource reads the usage by calling the volume usage action of kbagent, which mounts the volumes to be autoscaled.
+type kbagentUsageSource struct {
+	cli client.Client
+}
+
+var _ storageUsageSource = &kbagentUsageSource{}
+
+func (s *kbagentUsageSource) usages(ctx context.Context, comp *appsv1.Component,
+	pvcs []corev1.PersistentVolumeClaim) (map[string]volumeUsage, error) {
+	pods := &corev1.PodList{}
+	if err := s.cli.List(ctx, pods, client.InNamespace(comp.Namespace), client.MatchingLabels{
+		constant.AppInstanceLabelKey:    comp.Labels[constant.AppInstanceLabelKey],
+		constant.KBAppComponentLabelKey: comp.Labels[constant.KBAppComponentLabelKey],
+	}); err != nil {
+		return nil, err
+	}
+	names := map[string]bool{}
+	for _, pvc := range pvcs {
+		names[pvc.Name] = true
+	}
+
+	result := map[string]volumeUsage{}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		fsUsages, err := s.podUsages(ctx, pod)
+		if err != nil || fsUsages == nil {
+			continue // the usage of the pod is unavailable, e.g. the pod is not running
+		}
+		// the usages are reported by the volume names, which are mapped to the PVCs of the pod
+		for _, vol := range pod.Spec.Volumes {
+			if vol.PersistentVolumeClaim == nil || !names[vol.PersistentVolumeClaim.ClaimName] {
+				continue
+			}
+			if usage, ok := fsUsages[vol.Name]; ok {
+				result[vol.PersistentVolumeClaim.ClaimName] = volumeUsage{usedBytes: usage.UsedBytes, capacityBytes: usage.CapacityBytes}
+			}
+		}
+	}
+	return result, nil
+}
+
+func (s *kbagentUsageSource) podUsages(ctx context.Context, pod *corev1.Pod) (map[string]proto.FSUsage, error) {
+	cli, err := kbacli.NewClient(func() (string, int32, error) {
+		port, err := intctrlutil.GetPortByName(*pod, kbagt.ContainerName, kbagt.DefaultHTTPPortName)
+		if err != nil {
+			return "", 0, nil // has no kb-agent defined
+		}
+		if pod.Status.PodIP == "" {
+			return "", 0, fmt.Errorf("pod %s has no ip", pod.Name)
+		}
+		return pod.Status.PodIP, port, nil
+	})
+	if err != nil || cli == nil {
+		return nil, err
+	}
+	defer cli.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, kbagentUsageTimeout)
+	defer cancel()
+	rsp, err := cli.Action(ctx, proto.ActionRequest{Action: component.VolumeUsageAction})
+	if err != nil {
+		return nil, err
+	}
+	if len(rsp.Error) > 0 {
+		return nil, fmt.Errorf("failed to get the volume usage of pod %s: %s, %s", pod.Name, rsp.Error, rsp.Message)
+	}
+	fsUsages := map[string]proto.FSUsage{}
+	if err = json.Unmarshal(rsp.Output, &fsUsages); err != nil {
+		return nil, err
+	}
+	return fsUsages, nil
+}
+
+// prometheusUsageSource reads the usage from the kubelet volume stats metrics collected by a Prometheus-compatible endpoint.
+type prometheusUsageSource struct {
+	client analysis.Client
+}
+
+var _ storageUsageSource = &prometheusUsageSource{}
+
+func newPrometheusUsageSource(source appsv1.PrometheusStorageUsageSource) (storageUsageSource, error) {
+	cli, err := metricsClients.get(newMetricsProvider(source.Address, source.TimeoutSeconds))
+	if err != nil {
+		return nil, err
+	}
+	return &prometheusUsageSource{client: cli}, nil
+}
+
+func (s *prometheusUsageSource) usages(ctx context.Context, comp *appsv1.Component,
+	pvcs []corev1.PersistentVolumeClaim) (map[string]volumeUsage, error) {
+	query := func(metric string, pvc corev1.PersistentVolumeClaim) (int64, error) {
+		value, err := s.client.Query(ctx, fmt.Sprintf(`max(%s{namespace="%s",persistentvolumeclaim="%s"})`, metric, pvc.Namespace, pvc.Name))
+		if err != nil {
+			return 0, err
+		}
+		return int64(value), nil
+	}
+	result := map[string]volumeUsage{}
+	for _, pvc := range pvcs {
+		used, err := query("kubelet_volume_stats_used_bytes", pvc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query the used bytes of PVC %s: %w", pvc.Name, err)
+		}
+		capacity, err := query("kubelet_volume_stats_capacity_bytes", pvc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query the capacity bytes of PVC %s: %w", pvc.Name, err)
+		}
+		result[pvc.Name] = volumeUsage{usedBytes: used, capacityBytes: capacity}
+	}
+	return result, nil
+}
@@ -0,0 +1,437 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+const (
+	storageAutoscalingInterval        = time.Minute
+	defaultStorageThresholdPercent    = 80
+	defaultStorageIncrement           = "20%"
+	defaultStorageAutoscalingCooldown = time.Hour
+	kbagentUsageTimeout               = 10 * time.Second
+
+	mi = int64(1024 * 1024)
+	gi = 1024 * mi
+)
+
+// StorageAutoscalerReconciler expands the volumes of the Components automatically according to their usage,
+// by creating VolumeExpansion OpsRequests.
+type StorageAutoscalerReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=components,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=components/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=storage.k8s.io,resources=storageclasses,verbs=get;list;watch
+
+func (r *StorageAutoscalerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqCtx := intctrlutil.RequestCtx{
+		Ctx:      ctx,
+		Req:      req,
+		Log:      log.FromContext(ctx).WithValues("component", req.NamespacedName),
+		Recorder: r.Recorder,
+	}
+
+	comp := &appsv1.Component{}
+	if err := r.Client.Get(reqCtx.Ctx, reqCtx.Req.NamespacedName, comp); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if !comp.DeletionTimestamp.IsZero() {
+		return intctrlutil.Reconciled()
+	}
+
+	statusPatch := client.MergeFrom(comp.DeepCopy())
+	policy := comp.Spec.StorageAutoscaling
+	if policy == nil {
+		if comp.Status.StorageAutoscaling == nil {
+			return intctrlutil.Reconciled()
+		}
+		comp.Status.StorageAutoscaling = nil
+		if err := r.Client.Status().Patch(reqCtx.Ctx, comp, statusPatch); err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+		return intctrlutil.Reconciled()
+	}
+
+	switch comp.Status.Phase {
+	case appsv1.CreatingComponentPhase, appsv1.StartingComponentPhase, appsv1.StoppingComponentPhase,
+		appsv1.StoppedComponentPhase, appsv1.DeletingComponentPhase:
+		// the usage is unavailable or meaningless without the running instances
+		return intctrlutil.RequeueAfter(storageAutoscalingInterval, reqCtx.Log, "wait for the component to run")
+	}
+
+	if err := r.autoscale(reqCtx, comp, policy, time.Now()); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if err := r.Client.Status().Patch(reqCtx.Ctx, comp, statusPatch); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	return intctrlutil.RequeueAfter(storageAutoscalingInterval, reqCtx.Log, "wait for the next evaluation")
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *StorageAutoscalerReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return intctrlutil.NewControllerManagedBy(mgr).
+		Named("storageautoscaler").
+		For(&appsv1.Component{}, builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+			comp, ok := obj.(*appsv1.Component)
+			return ok && (comp.Spec.StorageAutoscaling != nil || comp.Status.StorageAutoscaling != nil)
+		}))).
+		Owns(&opsv1alpha1.OpsRequest{}).
+		Complete(r)
+}
+
+// autoscale evaluates the volumes of the component, creates a VolumeExpansion OpsRequest for the volumes
+// that need to be expanded, and records the decisions in the status.
+func (r *StorageAutoscalerReconciler) autoscale(reqCtx intctrlutil.RequestCtx,
+	comp *appsv1.Component, policy *appsv1.StorageAutoscalingPolicy, now time.Time) error {
+	if comp.Status.StorageAutoscaling == nil {
+		comp.Status.StorageAutoscaling = &appsv1.StorageAutoscalingStatus{}
+	}
+	status := comp.Status.StorageAutoscaling
+	prevVolumes := status.Volumes
+
+	source, err := r.usageSource(policy)
+	if err != nil {
+		return err
+	}
+	var volumes []appsv1.VolumeAutoscalingStatus
+	for _, vct := range comp.Spec.VolumeClaimTemplates {
+		if len(policy.VolumeClaimTemplates) > 0 && !slices.Contains(policy.VolumeClaimTemplates, vct.Name) {
+			continue
+		}
+		volume, err := r.evaluateVolume(reqCtx, comp, policy, source, vct, now)
+		if err != nil {
+			return err
+		}
+		volumes = append(volumes, volume)
+	}
+	status.Volumes = volumes
+	defer func() {
+		// keep the transition time if the decision does not change, to avoid updating the status on each evaluation
+		for i, volume := range status.Volumes {
+			for _, prev := range prevVolumes {
+				if prev.Name == volume.Name && prev.Decision == volume.Decision {
+					status.Volumes[i].LastTransitionTime = prev.LastTransitionTime
+				}
+			}
+		}
+	}()
+
+	var expansions []opsv1alpha1.OpsRequestVolumeClaimTemplate
+	for _, volume := range volumes {
+		if volume.Decision == appsv1.ExpandStorageAutoscalingDecision {
+			expansions = append(expansions, opsv1alpha1.OpsRequestVolumeClaimTemplate{Name: volume.Name, Storage: *volume.TargetSize})
+		}
+	}
+	if len(expansions) == 0 {
+		return nil
+	}
+
+	deferExpansions := func(decision appsv1.StorageAutoscalingDecision) {
+		for i := range status.Volumes {
+			if status.Volumes[i].Decision == appsv1.ExpandStorageAutoscalingDecision {
+				status.Volumes[i].Decision = decision
+			}
+		}
+	}
+
+	// defer the expansion if a volume expansion of the target is still running or the cooldown period has not elapsed
+	activeOps, err := r.hasActiveOpsRequest(reqCtx, comp)
+	if err != nil {
+		return err
+	}
+	if activeOps {
+		deferExpansions(appsv1.InProgressStorageAutoscalingDecision)
+		return nil
+	}
+	if status.LastExpansionTime != nil && now.Before(status.LastExpansionTime.Add(cooldownPeriod(policy))) {
+		deferExpansions(appsv1.CooldownStorageAutoscalingDecision)
+		return nil
+	}
+
+	ops, err := r.buildOpsRequest(comp, expansions, now)
+	if err != nil {
+		return err
+	}
+	if err = r.Client.Create(reqCtx.Ctx, ops); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			// it has been created by another shard of the sharding just now
+			deferExpansions(appsv1.InProgressStorageAutoscalingDecision)
+			return nil
+		}
+		r.Recorder.Eventf(comp, corev1.EventTypeWarning, reasonStorageAutoscalingFailed,
+			"failed to create the VolumeExpansion OpsRequest: %s, error: %s", ops.Name, err.Error())
+		return err
+	}
+	r.Recorder.Eventf(comp, corev1.EventTypeNormal, reasonStorageAutoscaled,
+		"created the VolumeExpansion OpsRequest: %s", ops.Name)
+	status.LastExpansionTime = &metav1.Time{Time: now}
+	status.LastOpsRequest = ops.Name
+	return nil
+}
+
+func (r *StorageAutoscalerReconciler) usageSource(policy *appsv1.StorageAutoscalingPolicy) (storageUsageSource, error) {
+	if policy.UsageSource != nil && policy.UsageSource.Prometheus != nil {
+		return newPrometheusUsageSource(*policy.UsageSource.Prometheus)
+	}
+	return &kbagentUsageSource{cli: r.Client}, nil
+}
+
+// evaluateVolume makes the decision for the volume according to the highest usage among its PVCs.
+func (r *StorageAutoscalerReconciler) evaluateVolume(reqCtx intctrlutil.RequestCtx, comp *appsv1.Component,
+	policy *appsv1.StorageAutoscalingPolicy, source storageUsageSource,
+	vct appsv1.PersistentVolumeClaimTemplate, now time.Time) (appsv1.VolumeAutoscalingStatus, error) {
+	volume := appsv1.VolumeAutoscalingStatus{
+		Name:               vct.Name,
+		LastTransitionTime: metav1.Time{Time: now},
+	}
+	currentSize := vct.Spec.Resources.Requests.Storage().DeepCopy()
+	volume.CurrentSize = &currentSize
+
+	pvcs := &corev1.PersistentVolumeClaimList{}
+	if err := r.Client.List(reqCtx.Ctx, pvcs, client.InNamespace(comp.Namespace), client.MatchingLabels{
+		constant.AppInstanceLabelKey:             comp.Labels[constant.AppInstanceLabelKey],
+		constant.KBAppComponentLabelKey:          comp.Labels[constant.KBAppComponentLabelKey],
+		constant.VolumeClaimTemplateNameLabelKey: vct.Name,
+	}); err != nil {
+		return volume, err
+	}
+	if len(pvcs.Items) == 0 {
+		volume.Decision = appsv1.UnknownStorageAutoscalingDecision
+		volume.Message = "no PVC is found for the volume"
+		return volume, nil
+	}
+
+	usages, err := source.usages(reqCtx.Ctx, comp, pvcs.Items)
+	if err != nil {
+		volume.Decision = appsv1.UnknownStorageAutoscalingDecision
+		volume.Message = err.Error()
+		return volume, nil
+	}
+	var usagePercent *int32
+	for _, pvc := range pvcs.Items {
+		if usage, ok := usages[pvc.Name]; ok && (usagePercent == nil || usage.percent() > *usagePercent) {
+			usagePercent = ptr.To(usage.percent())
+		}
+	}
+
+	expandable := true
+	for _, pvc := range pvcs.Items {
+		allowed, err := r.allowVolumeExpansion(reqCtx, pvc.Spec.StorageClassName)
+		if err != nil {
+			return volume, err
+		}
+		if !allowed {
+			expandable = false
+			break
+		}
+	}
+	return decideVolumeAutoscaling(policy, volume, usagePercent, expandable), nil
+}
+
+func (r *StorageAutoscalerReconciler) allowVolumeExpansion(reqCtx intctrlutil.RequestCtx, storageClassName *string) (bool, error) {
+	if storageClassName == nil || len(*storageClassName) == 0 {
+		return false, nil
+	}
+	storageClass := &storagev1.StorageClass{}
+	if err := r.Client.Get(reqCtx.Ctx, client.ObjectKey{Name: *storageClassName}, storageClass); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	return ptr.Deref(storageClass.AllowVolumeExpansion, false), nil
+}
+
+// autoscalingTarget returns the target of the VolumeExpansion OpsRequest, it is the sharding name for the shards,
+// since the volumes of all the shards are expanded together.
+func autoscalingTarget(comp *appsv1.Component) string {
+	if shardingName, ok := comp.Labels[constant.KBAppShardingNameLabelKey]; ok {
+		return shardingName
+	}
+	return comp.Labels[constant.KBAppComponentLabelKey]
+}
+
+// hasActiveOpsRequest checks whether there is a running VolumeExpansion OpsRequest of the target,
+// including the ones created by the users and by the other shards of the sharding.
+func (r *StorageAutoscalerReconciler) hasActiveOpsRequest(reqCtx intctrlutil.RequestCtx, comp *appsv1.Component) (bool, error) {
+	opsList := &opsv1alpha1.OpsRequestList{}
+	if err := r.Client.List(reqCtx.Ctx, opsList, client.InNamespace(comp.Namespace),
+		client.MatchingLabels{constant.AppInstanceLabelKey: comp.Labels[constant.AppInstanceLabelKey]}); err != nil {
+		return false, err
+	}
+	target := autoscalingTarget(comp)
+	for _, ops := range opsList.Items {
+		if ops.Spec.Type != opsv1alpha1.VolumeExpansionType || ops.IsComplete() {
+			continue
+		}
+		for _, expansion := range ops.Spec.VolumeExpansionList {
+			if expansion.ComponentName == target {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+func (r *StorageAutoscalerReconciler) buildOpsRequest(comp *appsv1.Component,
+	expansions []opsv1alpha1.OpsRequestVolumeClaimTemplate, now time.Time) (*opsv1alpha1.OpsRequest, error) {
+	clusterName := comp.Labels[constant.AppInstanceLabelKey]
+	target := autoscalingTarget(comp)
+	ops := &opsv1alpha1.OpsRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			// the name is deterministic in a minute, to avoid creating the OpsRequest repeatedly,
+			// and it is shared by the shards of the sharding.
+			Name:      fmt.Sprintf("%s-%s-storage-autoscaling-%d", clusterName, target, now.Unix()/60),
+			Namespace: comp.Namespace,
+			Labels: map[string]string{
+				constant.AppInstanceLabelKey:       clusterName,
+				constant.StorageAutoscalerLabelKey: target,
+			},
+		},
+		Spec: opsv1alpha1.OpsRequestSpec{
+			ClusterName: clusterName,
+			Type:        opsv1alpha1.VolumeExpansionType,
+			SpecificOpsRequest: opsv1alpha1.SpecificOpsRequest{
+				VolumeExpansionList: []opsv1alpha1.VolumeExpansion{
+					{
+						ComponentOps:         opsv1alpha1.ComponentOps{ComponentName: target},
+						VolumeClaimTemplates: expansions,
+					},
+				},
+			},
+		},
+	}
+	if err := intctrlutil.SetControllerReference(comp, ops); err != nil {
+		return nil, err
+	}
+	return ops, nil
+}
+
+// decideVolumeAutoscaling decides whether to expand the volume by its usage and the policy.
+func decideVolumeAutoscaling(policy *appsv1.StorageAutoscalingPolicy, volume appsv1.VolumeAutoscalingStatus,
+	usagePercent *int32, expandable bool) appsv1.VolumeAutoscalingStatus {
+	volume.UsagePercent = usagePercent
+	if usagePercent == nil {
+		volume.Decision = appsv1.UnknownStorageAutoscalingDecision
+		volume.Message = "the usage of the volume is unavailable"
+		return volume
+	}
+	threshold := policy.ThresholdPercent
+	if threshold <= 0 {
+		threshold = defaultStorageThresholdPercent
+	}
+	if *usagePercent < threshold {
+		volume.Decision = appsv1.NoActionStorageAutoscalingDecision
+		return volume
+	}
+	if !expandable {
+		volume.Decision = appsv1.NotSupportedStorageAutoscalingDecision
+		volume.Message = "the StorageClass of the volume does not allow volume expansion"
+		return volume
+	}
+	if volume.CurrentSize.Cmp(policy.MaxSize) >= 0 {
+		volume.Decision = appsv1.MaxSizeReachedStorageAutoscalingDecision
+		volume.Message = fmt.Sprintf("the volume has reached the max size %s", policy.MaxSize.String())
+		return volume
+	}
+	targetSize, err := expansionTargetSize(policy, *volume.CurrentSize)
+	if err != nil {
+		volume.Decision = appsv1.UnknownStorageAutoscalingDecision
+		volume.Message = err.Error()
+		return volume
+	}
+	volume.Decision = appsv1.ExpandStorageAutoscalingDecision
+	volume.TargetSize = &targetSize
+	volume.Message = fmt.Sprintf("the usage %d%% exceeds the threshold %d%%", *usagePercent, threshold)
+	return volume
+}
+
+// expansionTargetSize returns the size to expand the volume to, which is rounded up to Mi and capped by the max size.
+func expansionTargetSize(policy *appsv1.StorageAutoscalingPolicy, currentSize resource.Quantity) (resource.Quantity, error) {
+	increment := policy.Increment
+	if increment.Type == intstr.String && len(increment.StrVal) == 0 {
+		increment = intstr.FromString(defaultStorageIncrement)
+	}
+	var delta int64
+	if increment.Type == intstr.Int {
+		if increment.IntVal <= 0 {
+			return resource.Quantity{}, fmt.Errorf("invalid increment: %d", increment.IntVal)
+		}
+		delta = int64(increment.IntVal) * gi // an integer is a size in GiB
+	} else if str := increment.String(); strings.HasSuffix(str, "%") {
+		percent, err := strconv.Atoi(strings.TrimSuffix(str, "%"))
+		if err != nil || percent <= 0 {
+			return resource.Quantity{}, fmt.Errorf("invalid increment: %s", str)
+		}
+		delta = currentSize.Value() * int64(percent) / 100
+	} else {
+		if _, err := strconv.ParseInt(str, 10, 64); err == nil {
+			// the unit of a quantity without suffix is byte, it's rejected rather than expanding the volume by a few bytes
+			return resource.Quantity{}, fmt.Errorf("invalid increment: %s, the unit of the size is required", str)
+		}
+		quantity, err := resource.ParseQuantity(str)
+		if err != nil || quantity.Sign() <= 0 {
+			return resource.Quantity{}, fmt.Errorf("invalid increment: %s", str)
+		}
+		delta = quantity.Value()
+	}
+	size := currentSize.Value() + delta
+	size = (size + mi - 1) / mi * mi
+	if size > policy.MaxSize.Value() {
+		return policy.MaxSize.DeepCopy(), nil
+	}
+	return *resource.NewQuantity(size, resource.BinarySI), nil
+}
+
+func cooldownPeriod(policy *appsv1.StorageAutoscalingPolicy) time.Duration {
+	if policy.CooldownPeriod.Duration <= 0 {
+		return defaultStorageAutoscalingCooldown
+	}
+	return policy.CooldownPeriod.Duration
+}
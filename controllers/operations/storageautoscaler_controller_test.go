@@ -0,0 +1,348 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/analysis"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	kbacli "github.com/apecloud/kubeblocks/pkg/kbagent/client"
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+func TestDecideVolumeAutoscaling(t *testing.T) {
+	policy := &appsv1.StorageAutoscalingPolicy{
+		ThresholdPercent: 80,
+		Increment:        intstr.FromString("50%"),
+		MaxSize:          resource.MustParse("20Gi"),
+	}
+	newVolume := func(size string) appsv1.VolumeAutoscalingStatus {
+		currentSize := resource.MustParse(size)
+		return appsv1.VolumeAutoscalingStatus{Name: "data", CurrentSize: &currentSize}
+	}
+
+	for _, tc := range []struct {
+		name         string
+		size         string
+		usagePercent *int32
+		expandable   bool
+		decision     appsv1.StorageAutoscalingDecision
+		targetSize   string
+	}{
+		{"unknown usage", "10Gi", nil, true, appsv1.UnknownStorageAutoscalingDecision, ""},
+		{"below threshold", "10Gi", ptr.To[int32](79), true, appsv1.NoActionStorageAutoscalingDecision, ""},
+		{"not expandable", "10Gi", ptr.To[int32](90), false, appsv1.NotSupportedStorageAutoscalingDecision, ""},
+		{"max size reached", "20Gi", ptr.To[int32](90), true, appsv1.MaxSizeReachedStorageAutoscalingDecision, ""},
+		{"expand", "10Gi", ptr.To[int32](80), true, appsv1.ExpandStorageAutoscalingDecision, "15Gi"},
+		{"capped by max size", "16Gi", ptr.To[int32](95), true, appsv1.ExpandStorageAutoscalingDecision, "20Gi"},
+	} {
+		volume := decideVolumeAutoscaling(policy, newVolume(tc.size), tc.usagePercent, tc.expandable)
+		if volume.Decision != tc.decision {
+			t.Errorf("%s: expect decision %s, got %s", tc.name, tc.decision, volume.Decision)
+		}
+		if len(tc.targetSize) > 0 && (volume.TargetSize == nil || volume.TargetSize.Cmp(resource.MustParse(tc.targetSize)) != 0) {
+			t.Errorf("%s: expect target size %s, got %v", tc.name, tc.targetSize, volume.TargetSize)
+		}
+	}
+}
+
+func TestExpansionTargetSize(t *testing.T) {
+	currentSize := resource.MustParse("10Gi")
+	for _, tc := range []struct {
+		increment intstr.IntOrString
+		expected  string
+		invalid   bool
+	}{
+		{intstr.IntOrString{Type: intstr.String}, "12Gi", false}, // defaults to 20%
+		{intstr.FromString("5Gi"), "15Gi", false},
+		{intstr.FromString("3%"), "10548Mi", false}, // rounded up to Mi
+		{intstr.FromString("0%"), "", true},
+		{intstr.FromString("abc"), "", true},
+		{intstr.FromInt32(2), "12Gi", false}, // the unit of integers is GiB
+		{intstr.FromInt32(0), "", true},
+		{intstr.FromString("10"), "", true}, // the unit is required
+	} {
+		size, err := expansionTargetSize(&appsv1.StorageAutoscalingPolicy{Increment: tc.increment, MaxSize: resource.MustParse("1Ti")}, currentSize)
+		if tc.invalid {
+			if err == nil {
+				t.Errorf("expect error for increment %s", tc.increment.String())
+			}
+			continue
+		}
+		if err != nil || size.Cmp(resource.MustParse(tc.expected)) != 0 {
+			t.Errorf("increment %s: expect %s, got %s, error: %v", tc.increment.String(), tc.expected, size.String(), err)
+		}
+	}
+}
+
+type fakeMetricsClient struct {
+	values map[string]float64
+}
+
+func (c *fakeMetricsClient) Query(_ context.Context, query string) (float64, error) {
+	for metric, value := range c.values {
+		if strings.Contains(query, metric) {
+			return value, nil
+		}
+	}
+	return 0, nil
+}
+
+// fakeMetricsClients replaces the clients of the metrics providers with the fake one.
+func fakeMetricsClients(t *testing.T, values map[string]float64) {
+	metricsClients.reset()
+	metricsClientFactory = func(provider metricsProvider) (analysis.Client, error) {
+		return &fakeMetricsClient{values: values}, nil
+	}
+	t.Cleanup(func() {
+		metricsClients.reset()
+		metricsClientFactory = defaultMetricsClientFactory
+	})
+}
+
+func TestMetricsClientCache(t *testing.T) {
+	created := 0
+	metricsClients.reset()
+	metricsClientFactory = func(provider metricsProvider) (analysis.Client, error) {
+		created++
+		return &fakeMetricsClient{}, nil
+	}
+	defer func() {
+		metricsClients.reset()
+		metricsClientFactory = defaultMetricsClientFactory
+	}()
+
+	for _, provider := range []metricsProvider{
+		newMetricsProvider("http://prometheus:9090", nil),
+		newMetricsProvider("http://prometheus:9090", nil),
+		newMetricsProvider("http://prometheus:9090", ptr.To[int32](30)),
+		newMetricsProvider("http://victoria-metrics:8428", nil),
+	} {
+		if _, err := metricsClients.get(provider); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if created != 3 {
+		t.Errorf("expect 3 clients created, got %d", created)
+	}
+}
+
+func TestPrometheusUsageSource(t *testing.T) {
+	fakeMetricsClients(t, map[string]float64{
+		"kubelet_volume_stats_used_bytes":     85,
+		"kubelet_volume_stats_capacity_bytes": 100,
+	})
+
+	source, err := newPrometheusUsageSource(appsv1.PrometheusStorageUsageSource{Address: "http://prometheus:9090"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	pvcs := []corev1.PersistentVolumeClaim{{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "data-mysql-server-0"}}}
+	usages, err := source.usages(context.Background(), &appsv1.Component{}, pvcs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if usage, ok := usages["data-mysql-server-0"]; !ok || usage.percent() != 85 {
+		t.Errorf("unexpected usages: %v", usages)
+	}
+}
+
+// fakeKBAgentClient responds the volume usage actions with the same response.
+type fakeKBAgentClient struct {
+	rsp      proto.ActionResponse
+	requests []proto.ActionRequest
+}
+
+var _ kbacli.Client = &fakeKBAgentClient{}
+
+func (c *fakeKBAgentClient) Action(_ context.Context, req proto.ActionRequest) (proto.ActionResponse, error) {
+	c.requests = append(c.requests, req)
+	return c.rsp, nil
+}
+
+func (c *fakeKBAgentClient) Close() error {
+	return nil
+}
+
+func TestKBAgentUsageSource(t *testing.T) {
+	labels := map[string]string{
+		constant.AppInstanceLabelKey:    "mysql",
+		constant.KBAppComponentLabelKey: "server",
+	}
+	newPod := func(name string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, Labels: labels},
+			Spec: corev1.PodSpec{
+				Volumes: []corev1.Volume{
+					{
+						Name: "data",
+						VolumeSource: corev1.VolumeSource{
+							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "data-" + name},
+						},
+					},
+				},
+			},
+		}
+	}
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	cli := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(newPod("mysql-server-0"), newPod("mysql-server-1")).Build()
+	source := &kbagentUsageSource{cli: cli}
+	comp := &appsv1.Component{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "mysql-server", Labels: labels}}
+	pvcs := []corev1.PersistentVolumeClaim{
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "data-mysql-server-0"}},
+	}
+
+	agent := &fakeKBAgentClient{rsp: proto.ActionResponse{Output: []byte(`{"data":{"usedBytes":85,"capacityBytes":100}}`)}}
+	kbacli.SetMockClient(agent, nil)
+	defer kbacli.UnsetMockClient()
+	usages, err := source.usages(context.Background(), comp, pvcs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the usages of the PVCs not to be autoscaled are omitted
+	if len(usages) != 1 || usages["data-mysql-server-0"].percent() != 85 {
+		t.Errorf("unexpected usages: %v", usages)
+	}
+	if len(agent.requests) != 2 || agent.requests[0].Action != component.VolumeUsageAction {
+		t.Errorf("unexpected requests: %v", agent.requests)
+	}
+
+	// the failed actions are ignored
+	agent.rsp = proto.ActionResponse{Error: proto.Error2Type(proto.ErrFailed), Message: "statfs failed"}
+	usages, err = source.usages(context.Background(), comp, pvcs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(usages) != 0 {
+		t.Errorf("unexpected usages: %v", usages)
+	}
+}
+
+func TestHasActiveOpsRequest(t *testing.T) {
+	newOps := func(name string, opsType opsv1alpha1.OpsType, compName string, phase opsv1alpha1.OpsPhase) *opsv1alpha1.OpsRequest {
+		ops := &opsv1alpha1.OpsRequest{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      name,
+				Labels:    map[string]string{constant.AppInstanceLabelKey: "redis"},
+			},
+			Spec: opsv1alpha1.OpsRequestSpec{ClusterName: "redis", Type: opsType},
+		}
+		switch opsType {
+		case opsv1alpha1.VolumeExpansionType:
+			ops.Spec.VolumeExpansionList = []opsv1alpha1.VolumeExpansion{{ComponentOps: opsv1alpha1.ComponentOps{ComponentName: compName}}}
+		case opsv1alpha1.RestartType:
+			ops.Spec.RestartList = []opsv1alpha1.ComponentOps{{ComponentName: compName}}
+		}
+		ops.Status.Phase = phase
+		return ops
+	}
+	shard := &appsv1.Component{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "redis-shard-abc",
+			Labels: map[string]string{
+				constant.AppInstanceLabelKey:       "redis",
+				constant.KBAppComponentLabelKey:    "shard-abc",
+				constant.KBAppShardingNameLabelKey: "shard",
+			},
+		},
+	}
+	proxy := &appsv1.Component{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "redis-proxy",
+			Labels: map[string]string{
+				constant.AppInstanceLabelKey:    "redis",
+				constant.KBAppComponentLabelKey: "proxy",
+			},
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = opsv1alpha1.AddToScheme(scheme)
+	for _, tc := range []struct {
+		name     string
+		ops      *opsv1alpha1.OpsRequest
+		comp     *appsv1.Component
+		expected bool
+	}{
+		{"user-created expansion of the sharding", newOps("user-expansion", opsv1alpha1.VolumeExpansionType, "shard", opsv1alpha1.OpsRunningPhase), shard, true},
+		{"completed expansion", newOps("user-expansion", opsv1alpha1.VolumeExpansionType, "shard", opsv1alpha1.OpsSucceedPhase), shard, false},
+		{"expansion of another component", newOps("user-expansion", opsv1alpha1.VolumeExpansionType, "proxy", opsv1alpha1.OpsRunningPhase), shard, false},
+		{"expansion of the component", newOps("user-expansion", opsv1alpha1.VolumeExpansionType, "proxy", opsv1alpha1.OpsPendingPhase), proxy, true},
+		{"other ops", newOps("restart", opsv1alpha1.RestartType, "proxy", opsv1alpha1.OpsRunningPhase), proxy, false},
+	} {
+		r := &StorageAutoscalerReconciler{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(tc.ops).Build()}
+		active, err := r.hasActiveOpsRequest(intctrlutil.RequestCtx{Ctx: context.Background()}, tc.comp)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if active != tc.expected {
+			t.Errorf("%s: expect %v, got %v", tc.name, tc.expected, active)
+		}
+	}
+}
+
+func TestBuildStorageAutoscalingOpsRequest(t *testing.T) {
+	shard := &appsv1.Component{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "redis-shard-abc",
+			UID:       "uid",
+			Labels: map[string]string{
+				constant.AppInstanceLabelKey:       "redis",
+				constant.KBAppComponentLabelKey:    "shard-abc",
+				constant.KBAppShardingNameLabelKey: "shard",
+			},
+		},
+	}
+	now := time.Unix(600, 0)
+	ops, err := (&StorageAutoscalerReconciler{}).buildOpsRequest(shard, nil, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ops.Name != "redis-shard-storage-autoscaling-10" {
+		t.Errorf("unexpected name: %s", ops.Name)
+	}
+	if ops.Labels[constant.StorageAutoscalerLabelKey] != "shard" || ops.Spec.VolumeExpansionList[0].ComponentName != "shard" {
+		t.Errorf("unexpected target: %v, %v", ops.Labels, ops.Spec.VolumeExpansionList)
+	}
+}
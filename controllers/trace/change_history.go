@@ -0,0 +1,196 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package trace
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	tracev1 "github.com/apecloud/kubeblocks/apis/trace/v1"
+)
+
+const (
+	changeHistoryBucket = "changes"
+	// changeHistoryPruneInterval specifies how often the change history is pruned by the retention period.
+	changeHistoryPruneInterval = 10 * time.Minute
+)
+
+// ChangeHistory records the object changes of the traced Clusters durably,
+// which are kept even if they are truncated from the ReconciliationTrace status, or the ReconciliationTrace is deleted.
+type ChangeHistory interface {
+	// Append records the changes of the Cluster.
+	Append(target types.NamespacedName, changes []tracev1.ObjectChange) error
+	// Query returns the changes of the Cluster happened between the start and end time, sorted by revision.
+	Query(target types.NamespacedName, start, end time.Time) ([]tracev1.ObjectChange, error)
+	// Prune removes the changes exceeding the retention limits of all the Clusters.
+	Prune(now time.Time) error
+}
+
+// ChangeHistoryRetention defines the retention limits of the change history.
+type ChangeHistoryRetention struct {
+	// Period specifies how long the changes are kept, zero means forever.
+	Period time.Duration
+	// MaxChanges specifies the max number of changes kept for each Cluster, zero means no limit.
+	MaxChanges int
+}
+
+type changeHistory struct {
+	backend   StorageBackend
+	retention ChangeHistoryRetention
+	lock      sync.Mutex
+}
+
+func (h *changeHistory) Append(target types.NamespacedName, changes []tracev1.ObjectChange) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	for i := range changes {
+		value, err := json.Marshal(&changes[i])
+		if err != nil {
+			return err
+		}
+		// the same change captured by multiple traces of the Cluster has the same key, so it's recorded only once.
+		if err = h.backend.Put(changeHistoryBucket, buildChangeKey(target, &changes[i]), value); err != nil {
+			return err
+		}
+	}
+	return h.prune(target, time.Now())
+}
+
+func (h *changeHistory) Query(target types.NamespacedName, start, end time.Time) ([]tracev1.ObjectChange, error) {
+	keys, err := h.backend.Keys(changeHistoryBucket, buildChangeKeyPrefix(target))
+	if err != nil {
+		return nil, err
+	}
+	var changes []tracev1.ObjectChange
+	for _, key := range keys {
+		change, err := h.get(key)
+		if err != nil {
+			return nil, err
+		}
+		if change == nil || change.Timestamp == nil {
+			continue
+		}
+		if change.Timestamp.Time.Before(start) || change.Timestamp.Time.After(end) {
+			continue
+		}
+		changes = append(changes, *change)
+	}
+	return changes, nil
+}
+
+func (h *changeHistory) Prune(now time.Time) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	keys, err := h.backend.Keys(changeHistoryBucket, "")
+	if err != nil {
+		return err
+	}
+	// the keys are prefixed by the namespace and name of the Cluster.
+	targets := sets.New[types.NamespacedName]()
+	for _, key := range keys {
+		segments := strings.SplitN(key, "/", 3)
+		if len(segments) < 3 {
+			continue
+		}
+		targets.Insert(types.NamespacedName{Namespace: segments[0], Name: segments[1]})
+	}
+	for target := range targets {
+		if err = h.prune(target, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// prune removes the changes exceeding the retention limits, the oldest ones are removed first.
+func (h *changeHistory) prune(target types.NamespacedName, now time.Time) error {
+	keys, err := h.backend.Keys(changeHistoryBucket, buildChangeKeyPrefix(target))
+	if err != nil {
+		return err
+	}
+	removeCount := 0
+	if h.retention.MaxChanges > 0 && len(keys) > h.retention.MaxChanges {
+		removeCount = len(keys) - h.retention.MaxChanges
+	}
+	if h.retention.Period > 0 {
+		// the keys are sorted by revision, which increases over time.
+		for ; removeCount < len(keys); removeCount++ {
+			change, err := h.get(keys[removeCount])
+			if err != nil {
+				return err
+			}
+			if change != nil && change.Timestamp != nil && now.Sub(change.Timestamp.Time) <= h.retention.Period {
+				break
+			}
+		}
+	}
+	for _, key := range keys[:removeCount] {
+		if err = h.backend.Delete(changeHistoryBucket, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h *changeHistory) get(key string) (*tracev1.ObjectChange, error) {
+	value, err := h.backend.Get(changeHistoryBucket, key)
+	if errors.Is(err, ErrStorageKeyNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	change := &tracev1.ObjectChange{}
+	if err = json.Unmarshal(value, change); err != nil {
+		return nil, err
+	}
+	return change, nil
+}
+
+func buildChangeKeyPrefix(target types.NamespacedName) string {
+	return fmt.Sprintf("%s/%s/", target.Namespace, target.Name)
+}
+
+func buildChangeKey(target types.NamespacedName, change *tracev1.ObjectChange) string {
+	name := change.ObjectReference.Name
+	if change.EventAttributes != nil {
+		name = change.EventAttributes.Name
+	}
+	return fmt.Sprintf("%s%020d/%s/%s/%s/%s", buildChangeKeyPrefix(target), change.Revision,
+		change.ChangeType, change.ObjectReference.Kind, change.ObjectReference.Namespace, name)
+}
+
+func NewChangeHistory(backend StorageBackend, retention ChangeHistoryRetention) ChangeHistory {
+	return &changeHistory{
+		backend:   backend,
+		retention: retention,
+	}
+}
+
+var _ ChangeHistory = &changeHistory{}
@@ -0,0 +1,119 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package trace
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	tracev1 "github.com/apecloud/kubeblocks/apis/trace/v1"
+)
+
+var _ = Describe("change_history test", func() {
+	buildChange := func(name string, revision int64, timestamp time.Time) tracev1.ObjectChange {
+		t := metav1.NewTime(timestamp)
+		return tracev1.ObjectChange{
+			ObjectReference: corev1.ObjectReference{
+				APIVersion: "apps.kubeblocks.io/v1",
+				Kind:       "Component",
+				Namespace:  namespace,
+				Name:       name,
+			},
+			ChangeType: tracev1.ObjectUpdateType,
+			Revision:   revision,
+			Timestamp:  &t,
+		}
+	}
+	target := types.NamespacedName{Namespace: namespace, Name: name}
+
+	Context("Testing change_history", func() {
+		It("should query changes by time range", func() {
+			history := NewChangeHistory(NewMemoryStorage(), ChangeHistoryRetention{})
+			now := time.Now()
+			changes := []tracev1.ObjectChange{
+				buildChange("comp-1", 1, now.Add(-3*time.Hour)),
+				buildChange("comp-1", 2, now.Add(-2*time.Hour)),
+				buildChange("comp-2", 3, now.Add(-time.Hour)),
+			}
+			Expect(history.Append(target, changes)).Should(Succeed())
+
+			By("append the same changes again")
+			Expect(history.Append(target, changes[1:])).Should(Succeed())
+
+			result, err := history.Query(target, now.Add(-150*time.Minute), now)
+			Expect(err).Should(BeNil())
+			Expect(result).Should(HaveLen(2))
+			Expect(result[0].Revision).Should(Equal(int64(2)))
+			Expect(result[1].Revision).Should(Equal(int64(3)))
+
+			By("query another cluster")
+			result, err = history.Query(types.NamespacedName{Namespace: namespace, Name: "other"}, now.Add(-4*time.Hour), now)
+			Expect(err).Should(BeNil())
+			Expect(result).Should(BeEmpty())
+		})
+
+		It("should prune changes exceeding the retention limits", func() {
+			history := NewChangeHistory(NewMemoryStorage(), ChangeHistoryRetention{Period: 90 * time.Minute, MaxChanges: 2})
+			now := time.Now()
+			Expect(history.Append(target, []tracev1.ObjectChange{
+				buildChange("comp-1", 1, now.Add(-2*time.Hour)),
+				buildChange("comp-1", 2, now.Add(-time.Hour)),
+				buildChange("comp-1", 3, now.Add(-30*time.Minute)),
+				buildChange("comp-1", 4, now),
+			})).Should(Succeed())
+
+			result, err := history.Query(target, now.Add(-4*time.Hour), now)
+			Expect(err).Should(BeNil())
+			Expect(result).Should(HaveLen(2))
+			Expect(result[0].Revision).Should(Equal(int64(3)))
+			Expect(result[1].Revision).Should(Equal(int64(4)))
+		})
+
+		It("should prune the expired changes periodically", func() {
+			history := NewChangeHistory(NewMemoryStorage(), ChangeHistoryRetention{Period: time.Hour})
+			now := time.Now()
+			other := types.NamespacedName{Namespace: namespace, Name: "other"}
+			Expect(history.Append(target, []tracev1.ObjectChange{
+				buildChange("comp-1", 1, now.Add(-30*time.Minute)),
+				buildChange("comp-1", 2, now),
+			})).Should(Succeed())
+			Expect(history.Append(other, []tracev1.ObjectChange{
+				buildChange("comp-2", 1, now.Add(-20*time.Minute)),
+			})).Should(Succeed())
+
+			By("prune without any new change appended")
+			Expect(history.Prune(now.Add(50 * time.Minute))).Should(Succeed())
+
+			result, err := history.Query(target, now.Add(-4*time.Hour), now)
+			Expect(err).Should(BeNil())
+			Expect(result).Should(HaveLen(1))
+			Expect(result[0].Revision).Should(Equal(int64(2)))
+			result, err = history.Query(other, now.Add(-4*time.Hour), now)
+			Expect(err).Should(BeNil())
+			Expect(result).Should(BeEmpty())
+		})
+	})
+})
@@ -35,10 +35,11 @@ import (
 )
 
 type traceCalculator struct {
-	ctx    context.Context
-	cli    client.Client
-	scheme *runtime.Scheme
-	store  ObjectRevisionStore
+	ctx     context.Context
+	cli     client.Client
+	scheme  *runtime.Scheme
+	store   ObjectRevisionStore
+	history ChangeHistory
 }
 
 func (c *traceCalculator) PreCondition(tree *kubebuilderx.ObjectTree) *kubebuilderx.CheckResult {
@@ -106,6 +107,13 @@ func (c *traceCalculator) Reconcile(tree *kubebuilderx.ObjectTree) (kubebuilderx
 	// concat it to current changes
 	currentState.Changes = append(currentState.Changes, changes...)
 
+	// record them into the history, which outlives the truncation of current changes.
+	if c.history != nil {
+		if err = c.history.Append(objectKey, changes); err != nil {
+			return kubebuilderx.Commit, err
+		}
+	}
+
 	// save new version objects to store
 	for _, object := range newObjectMap {
 		if err = c.store.Insert(object, trace); err != nil {
@@ -190,12 +198,13 @@ func filterEvents(eventLister func() ([]client.Object, error), objectMap map[mod
 	return matchedEventMap, nil
 }
 
-func updateCurrentState(ctx context.Context, cli client.Client, scheme *runtime.Scheme, store ObjectRevisionStore) kubebuilderx.Reconciler {
+func updateCurrentState(ctx context.Context, cli client.Client, scheme *runtime.Scheme, store ObjectRevisionStore, history ChangeHistory) kubebuilderx.Reconciler {
 	return &traceCalculator{
-		ctx:    ctx,
-		cli:    cli,
-		scheme: scheme,
-		store:  store,
+		ctx:     ctx,
+		cli:     cli,
+		scheme:  scheme,
+		store:   store,
+		history: history,
 	}
 }
 
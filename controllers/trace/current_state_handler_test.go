@@ -56,7 +56,7 @@ var _ = Describe("current_state_handler test", func() {
 	Context("Testing current_state_handler", func() {
 		It("should work well", func() {
 			store := NewObjectStore(scheme.Scheme)
-			reconciler := updateCurrentState(ctx, k8sMock, scheme.Scheme, store)
+			reconciler := updateCurrentState(ctx, k8sMock, scheme.Scheme, store, nil)
 
 			primary, _ := mockObjects(k8sMock)
 			trace := &tracev1.ReconciliationTrace{
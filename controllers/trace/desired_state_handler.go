@@ -26,6 +26,7 @@ import (
 	"github.com/google/cel-go/cel"
 	"github.com/google/cel-go/checker/decls"
 	"github.com/google/cel-go/common/types"
+	"go.opentelemetry.io/otel/attribute"
 	"google.golang.org/protobuf/types/known/structpb"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -36,6 +37,7 @@ import (
 	tracev1 "github.com/apecloud/kubeblocks/apis/trace/v1"
 	"github.com/apecloud/kubeblocks/pkg/controller/kubebuilderx"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	"github.com/apecloud/kubeblocks/pkg/tracing"
 )
 
 type stateEvaluation struct {
@@ -148,6 +150,9 @@ func (s *stateEvaluation) Reconcile(tree *kubebuilderx.ObjectTree) (kubebuilderx
 	}
 	trace.Status.DesiredState = &plan.Plan
 
+	// export the completed reconciliation cycle before truncating it
+	recordReconciliationCycle(s.ctx, root, trace.Status.CurrentState.Changes[:latestReconciliationCycleStart])
+
 	// delete unused object revisions
 	deleteUnusedRevisions(s.store, trace.Status.CurrentState.Changes[:latestReconciliationCycleStart], trace)
 
@@ -166,6 +171,29 @@ func updateDesiredState(ctx context.Context, cli client.Client, scheme *runtime.
 	}
 }
 
+func recordReconciliationCycle(ctx context.Context, root *kbappsv1.Cluster, changes []tracev1.ObjectChange) {
+	var events []tracing.SpanEvent
+	for _, change := range changes {
+		if change.Timestamp == nil {
+			continue
+		}
+		events = append(events, tracing.SpanEvent{
+			Name:      change.Description,
+			Timestamp: change.Timestamp.Time,
+			Attributes: []attribute.KeyValue{
+				tracing.ObjectKindKey.String(change.ObjectReference.Kind),
+				tracing.ObjectNameKey.String(change.ObjectReference.Name),
+				tracing.ChangeTypeKey.String(string(change.ChangeType)),
+			},
+		})
+	}
+	if len(events) == 0 {
+		return
+	}
+	tracing.RecordSpan(ctx, "ReconciliationCycle "+kbappsv1.ClusterKind, events[0].Timestamp, events[len(events)-1].Timestamp,
+		tracing.ObjectAttributes(root), events)
+}
+
 func doStateEvaluation(object client.Object, expression tracev1.StateEvaluationExpression) (bool, error) {
 	if expression.CELExpression == nil {
 		return false, fmt.Errorf("CEL expression can't be empty")
@@ -81,15 +81,25 @@ func (r *dryRunner) Reconcile(tree *kubebuilderx.ObjectTree) (kubebuilderx.Resul
 		cacheObjectLoader(r.ctx, r.cli, root, getKBOwnershipRules()),
 		buildDescriptionFormatter(i18nResource, defaultLocale, trace.Spec.Locale))
 
-	desiredRoot, err := applySpec(root.DeepCopy(), trace.Spec.DryRun.DesiredSpec)
-	if err != nil {
-		return kubebuilderx.Commit, err
-	}
-	plan, err := generator.generatePlan(desiredRoot)
-	if err != nil {
-		return kubebuilderx.Commit, err
+	var plan *tracev1.DryRunResult
+	if len(trace.Spec.DryRun.OpsRequest) > 0 {
+		opsRequest, err := parseOpsRequest(trace.Spec.DryRun.OpsRequest, root)
+		if err != nil {
+			return kubebuilderx.Commit, err
+		}
+		if plan, err = generator.generatePlanByMutation(objectKey, opsRequestMutator(opsRequest)); err != nil {
+			return kubebuilderx.Commit, err
+		}
+	} else {
+		desiredRoot, err := applySpec(root.DeepCopy(), trace.Spec.DryRun.DesiredSpec)
+		if err != nil {
+			return kubebuilderx.Commit, err
+		}
+		if plan, err = generator.generatePlan(desiredRoot); err != nil {
+			return kubebuilderx.Commit, err
+		}
 	}
-	plan.DesiredSpecRevision = getDesiredSpecRevision(trace.Spec.DryRun.DesiredSpec)
+	plan.DesiredSpecRevision = getDesiredSpecRevision(trace.Spec.DryRun)
 	trace.Status.DryRunResult = plan
 
 	return kubebuilderx.Continue, nil
@@ -166,13 +176,16 @@ func isDesiredSpecChanged(v *tracev1.ReconciliationTrace) bool {
 	if v.Spec.DryRun == nil || v.Status.DryRunResult == nil {
 		return true
 	}
-	revision := getDesiredSpecRevision(v.Spec.DryRun.DesiredSpec)
+	revision := getDesiredSpecRevision(v.Spec.DryRun)
 	return revision != v.Status.DryRunResult.DesiredSpecRevision
 }
 
-func getDesiredSpecRevision(desiredSpec string) string {
+func getDesiredSpecRevision(dryRun *tracev1.DryRun) string {
 	hf := fnv.New32()
-	_, _ = hf.Write([]byte(desiredSpec))
+	_, _ = hf.Write([]byte(dryRun.DesiredSpec))
+	if len(dryRun.OpsRequest) > 0 {
+		_, _ = hf.Write([]byte(dryRun.OpsRequest))
+	}
 	return rand.SafeEncodeString(fmt.Sprint(hf.Sum32()))
 }
 
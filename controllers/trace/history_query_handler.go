@@ -0,0 +1,89 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package trace
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	tracev1 "github.com/apecloud/kubeblocks/apis/trace/v1"
+	"github.com/apecloud/kubeblocks/pkg/controller/kubebuilderx"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+)
+
+// maxHistoryQueryResultChanges limits the changes returned in the status to keep the object size reasonable.
+const maxHistoryQueryResultChanges = 500
+
+type historyQuerier struct {
+	history ChangeHistory
+}
+
+func (q *historyQuerier) PreCondition(tree *kubebuilderx.ObjectTree) *kubebuilderx.CheckResult {
+	if tree.GetRoot() == nil || model.IsObjectDeleting(tree.GetRoot()) {
+		return kubebuilderx.ConditionUnsatisfied
+	}
+	return kubebuilderx.ConditionSatisfied
+}
+
+func (q *historyQuerier) Reconcile(tree *kubebuilderx.ObjectTree) (kubebuilderx.Result, error) {
+	trace, _ := tree.GetRoot().(*tracev1.ReconciliationTrace)
+	query := trace.Spec.HistoryQuery
+	if query == nil || q.history == nil {
+		trace.Status.HistoryQueryResult = nil
+		return kubebuilderx.Continue, nil
+	}
+
+	objectKey := client.ObjectKeyFromObject(trace)
+	if trace.Spec.TargetObject != nil {
+		objectKey = client.ObjectKey{
+			Namespace: trace.Spec.TargetObject.Namespace,
+			Name:      trace.Spec.TargetObject.Name,
+		}
+	}
+	startTime := query.StartTime
+	endTime := metav1.NewTime(time.Now())
+	if query.EndTime != nil {
+		endTime = *query.EndTime
+	}
+	changes, err := q.history.Query(objectKey, startTime.Time, endTime.Time)
+	if err != nil {
+		return kubebuilderx.Commit, err
+	}
+	result := &tracev1.HistoryQueryResult{
+		StartTime: startTime,
+		EndTime:   endTime,
+		Changes:   changes,
+	}
+	if len(changes) > maxHistoryQueryResultChanges {
+		result.Changes = changes[:maxHistoryQueryResultChanges]
+		result.Truncated = true
+	}
+	trace.Status.HistoryQueryResult = result
+
+	return kubebuilderx.Continue, nil
+}
+
+func queryHistory(history ChangeHistory) kubebuilderx.Reconciler {
+	return &historyQuerier{history: history}
+}
+
+var _ kubebuilderx.Reconciler = &historyQuerier{}
@@ -20,6 +20,10 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package trace
 
 import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -29,6 +33,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
 )
@@ -52,6 +57,11 @@ type objectRevisionStore struct {
 	counterLock      sync.Mutex
 
 	scheme *runtime.Scheme
+
+	// backend persists the revisions if not nil, so they survive controller restarts.
+	backend StorageBackend
+	// maxRevisions specifies the max number of revisions kept for each object, zero means no limit.
+	maxRevisions int
 }
 
 type revisionObjectRef struct {
@@ -59,6 +69,14 @@ type revisionObjectRef struct {
 	revision int64
 }
 
+const objectRevisionBucket = "revisions"
+
+// objectRevisionRecord is the persisted form of an object revision.
+type objectRevisionRecord struct {
+	References []types.UID     `json:"references"`
+	Object     json.RawMessage `json:"object"`
+}
+
 func (s *objectRevisionStore) Insert(object, reference client.Object) error {
 	// insert into store
 	s.storeLock.Lock()
@@ -96,6 +114,11 @@ func (s *objectRevisionStore) Insert(object, reference client.Object) error {
 	referenceMap.Insert(reference.GetUID())
 	s.referenceCounter[revObjectRef] = referenceMap
 
+	if err = s.persist(&revObjectRef, object, referenceMap); err != nil {
+		return err
+	}
+	s.evictRevisions(objectRef, revisionMap)
+
 	return nil
 }
 
@@ -155,10 +178,39 @@ func (s *objectRevisionStore) Delete(objectRef *model.GVKNObjKey, reference clie
 		referenceMap.Delete(reference.GetUID())
 	}
 	if len(referenceMap) > 0 {
+		if object := s.getObject(objectRef, revision); object != nil {
+			if err := s.persist(&revObjectRef, object, referenceMap); err != nil {
+				log.Log.Error(err, "failed to persist object revision", "object", objectRef, "revision", revision)
+			}
+		}
 		return
 	}
 
-	// delete object
+	s.deleteRevision(&revObjectRef)
+}
+
+func (s *objectRevisionStore) getObject(objectRef *model.GVKNObjKey, revision int64) client.Object {
+	objectMap, ok := s.store[objectRef.GroupVersionKind]
+	if !ok {
+		return nil
+	}
+	revisionMap, ok := objectMap[objectRef.ObjectKey]
+	if !ok {
+		return nil
+	}
+	return revisionMap[revision]
+}
+
+// deleteRevision deletes the revision and its reference counter, the caller should hold both locks.
+func (s *objectRevisionStore) deleteRevision(revObjectRef *revisionObjectRef) {
+	delete(s.referenceCounter, *revObjectRef)
+	if s.backend != nil {
+		if err := s.backend.Delete(objectRevisionBucket, buildRevisionKey(revObjectRef)); err != nil {
+			log.Log.Error(err, "failed to delete persisted object revision", "object", revObjectRef.GVKNObjKey, "revision", revObjectRef.revision)
+		}
+	}
+
+	objectRef := &revObjectRef.GVKNObjKey
 	objectMap, ok := s.store[objectRef.GroupVersionKind]
 	if !ok {
 		return
@@ -167,8 +219,8 @@ func (s *objectRevisionStore) Delete(objectRef *model.GVKNObjKey, reference clie
 	if !ok {
 		return
 	}
-	delete(revisionMap, revision)
-	if len(referenceMap) == 0 {
+	delete(revisionMap, revObjectRef.revision)
+	if len(revisionMap) == 0 {
 		delete(objectMap, objectRef.ObjectKey)
 	}
 	if len(objectMap) == 0 {
@@ -176,6 +228,109 @@ func (s *objectRevisionStore) Delete(objectRef *model.GVKNObjKey, reference clie
 	}
 }
 
+// evictRevisions removes the oldest revisions of the object exceeding the max revisions limit.
+func (s *objectRevisionStore) evictRevisions(objectRef *model.GVKNObjKey, revisionMap map[int64]client.Object) {
+	if s.maxRevisions <= 0 || len(revisionMap) <= s.maxRevisions {
+		return
+	}
+	revisions := make([]int64, 0, len(revisionMap))
+	for revision := range revisionMap {
+		revisions = append(revisions, revision)
+	}
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i] < revisions[j]
+	})
+	for _, revision := range revisions[:len(revisions)-s.maxRevisions] {
+		s.deleteRevision(&revisionObjectRef{GVKNObjKey: *objectRef, revision: revision})
+	}
+}
+
+func (s *objectRevisionStore) persist(revObjectRef *revisionObjectRef, object client.Object, references sets.Set[types.UID]) error {
+	if s.backend == nil {
+		return nil
+	}
+	data, err := json.Marshal(object)
+	if err != nil {
+		return err
+	}
+	refs := sets.List(references)
+	value, err := json.Marshal(&objectRevisionRecord{References: refs, Object: data})
+	if err != nil {
+		return err
+	}
+	return s.backend.Put(objectRevisionBucket, buildRevisionKey(revObjectRef), value)
+}
+
+// load restores all the persisted revisions from the backend.
+func (s *objectRevisionStore) load() error {
+	keys, err := s.backend.Keys(objectRevisionBucket, "")
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		revObjectRef, err := parseRevisionKey(key)
+		if err != nil {
+			return err
+		}
+		value, err := s.backend.Get(objectRevisionBucket, key)
+		if err != nil {
+			return err
+		}
+		record := &objectRevisionRecord{}
+		if err = json.Unmarshal(value, record); err != nil {
+			return err
+		}
+		runtimeObject, err := s.scheme.New(revObjectRef.GroupVersionKind)
+		if err != nil {
+			// the kind may be unregistered after upgrading, skip it.
+			continue
+		}
+		object, ok := runtimeObject.(client.Object)
+		if !ok {
+			continue
+		}
+		if err = json.Unmarshal(record.Object, object); err != nil {
+			return err
+		}
+		objectMap, ok := s.store[revObjectRef.GroupVersionKind]
+		if !ok {
+			objectMap = make(map[types.NamespacedName]map[int64]client.Object)
+			s.store[revObjectRef.GroupVersionKind] = objectMap
+		}
+		revisionMap, ok := objectMap[revObjectRef.ObjectKey]
+		if !ok {
+			revisionMap = make(map[int64]client.Object)
+			objectMap[revObjectRef.ObjectKey] = revisionMap
+		}
+		revisionMap[revObjectRef.revision] = object
+		s.referenceCounter[*revObjectRef] = sets.New(record.References...)
+	}
+	return nil
+}
+
+func buildRevisionKey(revObjectRef *revisionObjectRef) string {
+	return fmt.Sprintf("%s/%s/%s/%s/%s/%020d", revObjectRef.Group, revObjectRef.Version, revObjectRef.Kind,
+		revObjectRef.Namespace, revObjectRef.Name, revObjectRef.revision)
+}
+
+func parseRevisionKey(key string) (*revisionObjectRef, error) {
+	segments := strings.Split(key, "/")
+	if len(segments) != 6 {
+		return nil, fmt.Errorf("invalid object revision key: %s", key)
+	}
+	revision, err := strconv.ParseInt(segments[5], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid object revision key: %s", key)
+	}
+	return &revisionObjectRef{
+		GVKNObjKey: model.GVKNObjKey{
+			GroupVersionKind: schema.GroupVersionKind{Group: segments[0], Version: segments[1], Kind: segments[2]},
+			ObjectKey:        types.NamespacedName{Namespace: segments[3], Name: segments[4]},
+		},
+		revision: revision,
+	}, nil
+}
+
 func NewObjectStore(scheme *runtime.Scheme) ObjectRevisionStore {
 	return &objectRevisionStore{
 		store:            make(map[schema.GroupVersionKind]map[types.NamespacedName]map[int64]client.Object),
@@ -184,4 +339,20 @@ func NewObjectStore(scheme *runtime.Scheme) ObjectRevisionStore {
 	}
 }
 
+// NewPersistentObjectStore creates an ObjectRevisionStore which persists the revisions into the backend,
+// and keeps at most maxRevisions revisions for each object, zero means no limit.
+func NewPersistentObjectStore(scheme *runtime.Scheme, backend StorageBackend, maxRevisions int) (ObjectRevisionStore, error) {
+	store := &objectRevisionStore{
+		store:            make(map[schema.GroupVersionKind]map[types.NamespacedName]map[int64]client.Object),
+		referenceCounter: make(map[revisionObjectRef]sets.Set[types.UID]),
+		scheme:           scheme,
+		backend:          backend,
+		maxRevisions:     maxRevisions,
+	}
+	if err := store.load(); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
 var _ ObjectRevisionStore = &objectRevisionStore{}
@@ -77,5 +77,52 @@ var _ = Describe("object_revision_store test", func() {
 			Expect(err).ShouldNot(BeNil())
 			Expect(apierrors.IsNotFound(err)).Should(BeTrue())
 		})
+
+		It("should persist the revisions", func() {
+			backend := NewMemoryStorage()
+			store, err := NewPersistentObjectStore(scheme.Scheme, backend, 2)
+			Expect(err).Should(BeNil())
+
+			By("Insert three revisions of a component")
+			primary := builder.NewClusterBuilder(namespace, name).SetUID(uid).SetResourceVersion(resourceVersion).GetObject()
+			fullCompName := fmt.Sprintf("%s-%s", primary.Name, "test")
+			var secondaries []*kbappsv1.Component
+			for i := 1; i <= 3; i++ {
+				secondary := builder.NewComponentBuilder(namespace, fullCompName, "").
+					SetOwnerReferences(kbappsv1.APIVersion, kbappsv1.ClusterKind, primary).
+					SetUID(uid).
+					GetObject()
+				secondary.ResourceVersion = fmt.Sprintf("%d", i)
+				Expect(store.Insert(secondary, primary)).Should(Succeed())
+				secondaries = append(secondaries, secondary)
+			}
+			objectRef, err := getObjectRef(secondaries[0], scheme.Scheme)
+			Expect(err).Should(BeNil())
+
+			By("The oldest revision should be evicted")
+			_, err = store.Get(objectRef, 1)
+			Expect(apierrors.IsNotFound(err)).Should(BeTrue())
+
+			By("Reload the store from the backend")
+			store, err = NewPersistentObjectStore(scheme.Scheme, backend, 2)
+			Expect(err).Should(BeNil())
+			_, err = store.Get(objectRef, 1)
+			Expect(apierrors.IsNotFound(err)).Should(BeTrue())
+			for _, revision := range []int64{2, 3} {
+				obj, err := store.Get(objectRef, revision)
+				Expect(err).Should(BeNil())
+				Expect(obj.GetName()).Should(Equal(fullCompName))
+				Expect(obj.GetResourceVersion()).Should(Equal(fmt.Sprintf("%d", revision)))
+			}
+
+			By("Delete a revision and reload the store")
+			store.Delete(objectRef, primary, 2)
+			store, err = NewPersistentObjectStore(scheme.Scheme, backend, 2)
+			Expect(err).Should(BeNil())
+			_, err = store.Get(objectRef, 2)
+			Expect(apierrors.IsNotFound(err)).Should(BeTrue())
+			_, err = store.Get(objectRef, 3)
+			Expect(err).Should(BeNil())
+		})
 	})
 })
@@ -0,0 +1,134 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package trace
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/yaml"
+
+	kbappsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/operations"
+)
+
+// opsDryRunClient drops the writes to OpsRequests, such as aborting the earlier ones or patching the status,
+// which are side effects of the OpsRequest itself rather than the changes of the TargetObject.
+type opsDryRunClient struct {
+	client.Client
+}
+
+type opsDryRunSubResourceClient struct {
+	client.SubResourceWriter
+}
+
+func (c *opsDryRunClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if isOpsRequest(obj) {
+		return nil
+	}
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+func (c *opsDryRunClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	if isOpsRequest(obj) {
+		return nil
+	}
+	return c.Client.Delete(ctx, obj, opts...)
+}
+
+func (c *opsDryRunClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if isOpsRequest(obj) {
+		return nil
+	}
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+func (c *opsDryRunClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if isOpsRequest(obj) {
+		return nil
+	}
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+func (c *opsDryRunClient) Status() client.SubResourceWriter {
+	return &opsDryRunSubResourceClient{SubResourceWriter: c.Client.Status()}
+}
+
+func (c *opsDryRunSubResourceClient) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	if isOpsRequest(obj) {
+		return nil
+	}
+	return c.SubResourceWriter.Update(ctx, obj, opts...)
+}
+
+func (c *opsDryRunSubResourceClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	if isOpsRequest(obj) {
+		return nil
+	}
+	return c.SubResourceWriter.Patch(ctx, obj, patch, opts...)
+}
+
+func isOpsRequest(obj client.Object) bool {
+	_, ok := obj.(*opsv1alpha1.OpsRequest)
+	return ok
+}
+
+// parseOpsRequest parses the OpsRequest manifest and binds it to the TargetObject.
+func parseOpsRequest(manifest string, root *kbappsv1.Cluster) (*opsv1alpha1.OpsRequest, error) {
+	opsRequest := &opsv1alpha1.OpsRequest{}
+	if err := yaml.Unmarshal([]byte(manifest), opsRequest); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal opsRequest: %w", err)
+	}
+	opsRequest.Namespace = root.Namespace
+	opsRequest.Spec.ClusterName = root.Name
+	if len(opsRequest.Name) == 0 {
+		opsRequest.Name = fmt.Sprintf("%s-dry-run", root.Name)
+	}
+	if opsRequest.Labels == nil {
+		opsRequest.Labels = map[string]string{}
+	}
+	opsRequest.Labels[constant.AppInstanceLabelKey] = root.Name
+	opsRequest.Labels[constant.OpsRequestTypeLabelKey] = string(opsRequest.Spec.Type)
+	opsRequest.Status = opsv1alpha1.OpsRequestStatus{Phase: opsv1alpha1.OpsPendingPhase}
+	return opsRequest, nil
+}
+
+// opsRequestMutator performs the action of the OpsRequest to mutate the TargetObject.
+func opsRequestMutator(opsRequest *opsv1alpha1.OpsRequest) objectMutator {
+	return func(ctx context.Context, cli client.Client, recorder record.EventRecorder, currentRoot *kbappsv1.Cluster) error {
+		reqCtx := intctrlutil.RequestCtx{
+			Ctx:      ctx,
+			Log:      log.FromContext(ctx).WithValues("OpsRequest", client.ObjectKeyFromObject(opsRequest)),
+			Recorder: recorder,
+		}
+		opsRes := &operations.OpsResource{
+			OpsRequest: opsRequest.DeepCopy(),
+			Cluster:    currentRoot,
+			Recorder:   recorder,
+		}
+		return operations.GetOpsManager().DryRun(reqCtx, &opsDryRunClient{Client: cli}, opsRes)
+	}
+}
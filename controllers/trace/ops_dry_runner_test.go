@@ -0,0 +1,168 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package trace
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/golang/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kbappsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/controller/builder"
+	testutil "github.com/apecloud/kubeblocks/pkg/testutil/k8s"
+	"github.com/apecloud/kubeblocks/pkg/testutil/k8s/mocks"
+)
+
+var _ = Describe("ops_dry_runner test", func() {
+	var (
+		k8sMock    *mocks.MockClient
+		controller *gomock.Controller
+	)
+
+	BeforeEach(func() {
+		controller, k8sMock = testutil.SetupK8sMock()
+	})
+
+	AfterEach(func() {
+		controller.Finish()
+	})
+
+	Context("Testing ops_dry_runner", func() {
+		It("should parse the OpsRequest manifest", func() {
+			cluster := builder.NewClusterBuilder(namespace, name).GetObject()
+			manifest := `
+apiVersion: operations.kubeblocks.io/v1alpha1
+kind: OpsRequest
+metadata:
+  name: vscale
+  namespace: other
+spec:
+  clusterName: other
+  type: VerticalScaling
+  verticalScaling:
+  - componentName: test
+    requests:
+      cpu: "2"
+`
+			opsRequest, err := parseOpsRequest(manifest, cluster)
+			Expect(err).Should(BeNil())
+			Expect(opsRequest.Name).Should(Equal("vscale"))
+			Expect(opsRequest.Namespace).Should(Equal(namespace))
+			Expect(opsRequest.Spec.ClusterName).Should(Equal(name))
+			Expect(opsRequest.Spec.Type).Should(Equal(opsv1alpha1.VerticalScalingType))
+			Expect(opsRequest.Status.Phase).Should(Equal(opsv1alpha1.OpsPendingPhase))
+
+			_, err = parseOpsRequest("spec: [", cluster)
+			Expect(err).ShouldNot(BeNil())
+		})
+
+		It("should mutate the cluster by the OpsRequest action", func() {
+			compName := "test"
+			cluster := builder.NewClusterBuilder(namespace, name).
+				SetComponentSpecs([]kbappsv1.ClusterComponentSpec{{
+					Name: compName,
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+					},
+				}}).
+				GetObject()
+			opsRequest := &opsv1alpha1.OpsRequest{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: namespace,
+					Name:      "vscale",
+				},
+				Spec: opsv1alpha1.OpsRequestSpec{
+					ClusterName: name,
+					Type:        opsv1alpha1.VerticalScalingType,
+					SpecificOpsRequest: opsv1alpha1.SpecificOpsRequest{
+						VerticalScalingList: []opsv1alpha1.VerticalScaling{{
+							ComponentOps: opsv1alpha1.ComponentOps{ComponentName: compName},
+							ResourceRequirements: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+							},
+						}},
+					},
+				},
+			}
+
+			var desired *kbappsv1.Cluster
+			k8sMock.EXPECT().
+				Update(gomock.Any(), gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, obj *kbappsv1.Cluster, _ ...client.UpdateOption) error {
+					desired = obj.DeepCopy()
+					return nil
+				}).Times(1)
+
+			mutate := opsRequestMutator(opsRequest)
+			Expect(mutate(ctx, k8sMock, nil, cluster.DeepCopy())).Should(Succeed())
+			Expect(desired).ShouldNot(BeNil())
+			Expect(desired.Spec.ComponentSpecs[0].Resources.Requests.Cpu().String()).Should(Equal("2"))
+		})
+
+		It("should reject the opsRequest whose changes are made by the ReconcileAction", func() {
+			cluster := builder.NewClusterBuilder(namespace, name).GetObject()
+			opsRequest := &opsv1alpha1.OpsRequest{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: namespace,
+					Name:      "switchover",
+				},
+				Spec: opsv1alpha1.OpsRequestSpec{
+					ClusterName: name,
+					Type:        opsv1alpha1.SwitchoverType,
+					SpecificOpsRequest: opsv1alpha1.SpecificOpsRequest{
+						SwitchoverList: []opsv1alpha1.Switchover{{
+							ComponentName: "test",
+							InstanceName:  "pod-0",
+						}},
+					},
+				},
+			}
+
+			mutate := opsRequestMutator(opsRequest)
+			err := mutate(ctx, k8sMock, nil, cluster.DeepCopy())
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring("not supported in dry-run"))
+		})
+
+		It("should drop the writes to OpsRequests", func() {
+			cli := &opsDryRunClient{Client: k8sMock}
+			opsRequest := &opsv1alpha1.OpsRequest{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: namespace,
+					Name:      "vscale",
+				},
+			}
+			Expect(cli.Update(ctx, opsRequest)).Should(Succeed())
+			Expect(cli.Patch(ctx, opsRequest, client.MergeFrom(opsRequest.DeepCopy()))).Should(Succeed())
+			Expect(cli.Delete(ctx, opsRequest)).Should(Succeed())
+
+			k8sMock.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+			Expect(cli.Create(ctx, builder.NewClusterBuilder(namespace, name).GetObject())).Should(Succeed())
+		})
+	})
+})
@@ -27,6 +27,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	kbappsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
@@ -36,9 +37,13 @@ import (
 
 type PlanGenerator interface {
 	generatePlan(desiredRoot *kbappsv1.Cluster) (*tracev1.DryRunResult, error)
+	generatePlanByMutation(root client.ObjectKey, mutate objectMutator) (*tracev1.DryRunResult, error)
 }
 
 type objectLoader func() (map[model.GVKNObjKey]client.Object, error)
+
+// objectMutator mutates the root and other objects to the desired state through the mock client and the mock event recorder.
+type objectMutator func(ctx context.Context, cli client.Client, recorder record.EventRecorder, currentRoot *kbappsv1.Cluster) error
 type descriptionFormatter func(client.Object, client.Object, tracev1.ObjectChangeType, *schema.GroupVersionKind) (string, *string)
 
 type planGenerator struct {
@@ -50,6 +55,13 @@ type planGenerator struct {
 }
 
 func (g *planGenerator) generatePlan(desiredRoot *kbappsv1.Cluster) (*tracev1.DryRunResult, error) {
+	return g.generatePlanByMutation(client.ObjectKeyFromObject(desiredRoot),
+		func(ctx context.Context, cli client.Client, _ record.EventRecorder, _ *kbappsv1.Cluster) error {
+			return cli.Update(ctx, desiredRoot)
+		})
+}
+
+func (g *planGenerator) generatePlanByMutation(root client.ObjectKey, mutate objectMutator) (*tracev1.DryRunResult, error) {
 	// create mock client and mock event recorder
 	// kbagent client is running in dry-run mode by setting context key-value pair: dry-run=true
 	store := newChangeCaptureStore(g.scheme, g.formatter)
@@ -76,7 +88,20 @@ func (g *planGenerator) generatePlan(desiredRoot *kbappsv1.Cluster) (*tracev1.Dr
 
 	// get current root
 	currentRoot := &kbappsv1.Cluster{}
-	if err = mClient.Get(g.ctx, client.ObjectKeyFromObject(desiredRoot), currentRoot); err != nil {
+	if err = mClient.Get(g.ctx, root, currentRoot); err != nil {
+		return nil, err
+	}
+	// mutate to the desired state
+	if err = mutate(g.ctx, mClient, mEventRecorder, currentRoot.DeepCopy()); err != nil {
+		return &tracev1.DryRunResult{
+			Phase:                    tracev1.DryRunFailedPhase,
+			Reason:                   "MutationError",
+			Message:                  err.Error(),
+			ObservedTargetGeneration: currentRoot.Generation,
+		}, nil
+	}
+	desiredRoot := &kbappsv1.Cluster{}
+	if err = mClient.Get(g.ctx, root, desiredRoot); err != nil {
 		return nil, err
 	}
 	// build spec diff
@@ -84,9 +109,6 @@ func (g *planGenerator) generatePlan(desiredRoot *kbappsv1.Cluster) (*tracev1.Dr
 	if specDiff, err = buildSpecDiff(currentRoot, desiredRoot); err != nil {
 		return nil, err
 	}
-	if err = mClient.Update(g.ctx, desiredRoot); err != nil {
-		return nil, err
-	}
 
 	// generate plan with timeout
 	startTime := time.Now()
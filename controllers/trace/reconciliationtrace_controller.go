@@ -21,17 +21,22 @@ package trace
 
 import (
 	"context"
+	"time"
 
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	tracev1 "github.com/apecloud/kubeblocks/apis/trace/v1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/kubebuilderx"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
 func init() {
@@ -44,6 +49,7 @@ type ReconciliationTraceReconciler struct {
 	Scheme               *runtime.Scheme
 	Recorder             record.EventRecorder
 	ObjectRevisionStore  ObjectRevisionStore
+	ChangeHistory        ChangeHistory
 	ObjectTreeRootFinder ObjectTreeRootFinder
 	InformerManager      InformerManager
 }
@@ -66,8 +72,9 @@ func (r *ReconciliationTraceReconciler) Reconcile(ctx context.Context, req ctrl.
 		Do(assureFinalizer()).
 		Do(handleDeletion(r.ObjectRevisionStore)).
 		Do(dryRun(ctx, r.Client, r.Scheme)).
-		Do(updateCurrentState(ctx, r.Client, r.Scheme, r.ObjectRevisionStore)).
+		Do(updateCurrentState(ctx, r.Client, r.Scheme, r.ObjectRevisionStore, r.ChangeHistory)).
 		Do(updateDesiredState(ctx, r.Client, r.Scheme, r.ObjectRevisionStore)).
+		Do(queryHistory(r.ChangeHistory)).
 		Commit()
 
 	return res, err
@@ -75,12 +82,54 @@ func (r *ReconciliationTraceReconciler) Reconcile(ctx context.Context, req ctrl.
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *ReconciliationTraceReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	r.ObjectRevisionStore = NewObjectStore(r.Scheme)
+	if err := r.setupStorage(); err != nil {
+		return err
+	}
 	r.ObjectTreeRootFinder = NewObjectTreeRootFinder(r.Client)
 	r.InformerManager = NewInformerManager(r.Client, mgr.GetCache(), r.Scheme, r.ObjectTreeRootFinder.GetEventChannel())
 
+	// the changes are pruned on appending, prune them periodically as well to expire the ones of the idle Clusters.
+	if err := mgr.Add(manager.RunnableFunc(r.pruneChangeHistory)); err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&tracev1.ReconciliationTrace{}).
 		WatchesRawSource(&source.Channel{Source: r.ObjectTreeRootFinder.GetEventChannel()}, r.ObjectTreeRootFinder.GetEventHandler()).
 		Complete(r)
 }
+
+// pruneChangeHistory prunes the change history periodically until the context is done, it runs on the leader only.
+func (r *ReconciliationTraceReconciler) pruneChangeHistory(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("ChangeHistoryPruner")
+	wait.UntilWithContext(ctx, func(context.Context) {
+		if err := r.ChangeHistory.Prune(time.Now()); err != nil {
+			logger.Error(err, "failed to prune the change history")
+		}
+	}, changeHistoryPruneInterval)
+	return nil
+}
+
+// setupStorage persists the object revisions and the change history into the storage dir if it's configured,
+// otherwise they are kept in memory and lost after the Controller restarts.
+func (r *ReconciliationTraceReconciler) setupStorage() error {
+	retention := ChangeHistoryRetention{
+		Period:     viper.GetDuration(constant.CfgKeyTraceStorageRetention),
+		MaxChanges: viper.GetInt(constant.CfgKeyTraceStorageMaxChanges),
+	}
+	dir := viper.GetString(constant.CfgKeyTraceStorageDir)
+	if len(dir) == 0 {
+		r.ObjectRevisionStore = NewObjectStore(r.Scheme)
+		r.ChangeHistory = NewChangeHistory(NewMemoryStorage(), retention)
+		return nil
+	}
+	backend, err := NewFileStorage(dir)
+	if err != nil {
+		return err
+	}
+	if r.ObjectRevisionStore, err = NewPersistentObjectStore(r.Scheme, backend, viper.GetInt(constant.CfgKeyTraceStorageMaxRevisions)); err != nil {
+		return err
+	}
+	r.ChangeHistory = NewChangeHistory(backend, retention)
+	return nil
+}
@@ -0,0 +1,240 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package trace
+
+import (
+	"errors"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ErrStorageKeyNotFound is returned by the StorageBackend if the key doesn't exist.
+var ErrStorageKeyNotFound = errors.New("storage key not found")
+
+// StorageBackend defines a key-value storage which persists the object revisions and changes recorded by the
+// Reconciliation Trace Controller, so that they survive the controller restarts and the leader failovers.
+// Keys are organized in buckets, and are listed in lexical order.
+// The segments of a key are separated by "/", and the keys in a bucket should have the same number of segments.
+type StorageBackend interface {
+	Put(bucket, key string, value []byte) error
+	Get(bucket, key string) ([]byte, error)
+	Delete(bucket, key string) error
+	Keys(bucket, prefix string) ([]string, error)
+}
+
+type memoryStorage struct {
+	buckets map[string]map[string][]byte
+	lock    sync.RWMutex
+}
+
+func (s *memoryStorage) Put(bucket, key string, value []byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	keyMap, ok := s.buckets[bucket]
+	if !ok {
+		keyMap = make(map[string][]byte)
+		s.buckets[bucket] = keyMap
+	}
+	keyMap[key] = append([]byte(nil), value...)
+	return nil
+}
+
+func (s *memoryStorage) Get(bucket, key string) ([]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	value, ok := s.buckets[bucket][key]
+	if !ok {
+		return nil, ErrStorageKeyNotFound
+	}
+	return append([]byte(nil), value...), nil
+}
+
+func (s *memoryStorage) Delete(bucket, key string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(s.buckets[bucket], key)
+	return nil
+}
+
+func (s *memoryStorage) Keys(bucket, prefix string) ([]string, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	var keys []string
+	for key := range s.buckets[bucket] {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// NewMemoryStorage creates a StorageBackend in memory, which doesn't survive the controller restarts.
+func NewMemoryStorage() StorageBackend {
+	return &memoryStorage{
+		buckets: make(map[string]map[string][]byte),
+	}
+}
+
+// fileStorage stores each key as a file in the directory of the bucket,
+// the segments of the key separated by "/" are mapped to the nested directories to keep the file names short.
+type fileStorage struct {
+	dir  string
+	lock sync.RWMutex
+}
+
+const (
+	tmpFilePrefix = ".tmp-"
+	// segmentPrefix is prepended to each escaped segment, so that the empty segment and the segments like "." and ".."
+	// are valid file names.
+	segmentPrefix = "_"
+)
+
+func (s *fileStorage) Put(bucket, key string, value []byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	path := s.path(bucket, key)
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return err
+	}
+	// write to a temporary file and rename it, to avoid leaving a partial file if the controller crashes.
+	tmpFile, err := os.CreateTemp(filepath.Dir(path), tmpFilePrefix)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile.Name())
+	if _, err = tmpFile.Write(value); err != nil {
+		tmpFile.Close()
+		return err
+	}
+	if err = tmpFile.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpFile.Name(), path)
+}
+
+func (s *fileStorage) Get(bucket, key string) ([]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	value, err := os.ReadFile(s.path(bucket, key))
+	if os.IsNotExist(err) {
+		return nil, ErrStorageKeyNotFound
+	}
+	return value, err
+}
+
+func (s *fileStorage) Delete(bucket, key string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	path := s.path(bucket, key)
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	// remove the empty parent directories, it fails if the directory is not empty.
+	bucketDir := s.bucketDir(bucket)
+	for dir := filepath.Dir(path); strings.HasPrefix(dir, bucketDir) && dir != bucketDir; dir = filepath.Dir(dir) {
+		if os.Remove(dir) != nil {
+			break
+		}
+	}
+	return nil
+}
+
+func (s *fileStorage) Keys(bucket, prefix string) ([]string, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	bucketDir := s.bucketDir(bucket)
+	// only walk the directory of the complete segments of the prefix, the last one may be partial.
+	root := bucketDir
+	if i := strings.LastIndex(prefix, "/"); i >= 0 {
+		root = s.path(bucket, prefix[:i])
+	}
+	var keys []string
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), tmpFilePrefix) {
+			return nil
+		}
+		relPath, err := filepath.Rel(bucketDir, path)
+		if err != nil {
+			return err
+		}
+		var segments []string
+		for _, segment := range strings.Split(filepath.ToSlash(relPath), "/") {
+			unescaped, err := url.PathUnescape(strings.TrimPrefix(segment, segmentPrefix))
+			if err != nil {
+				return nil
+			}
+			segments = append(segments, unescaped)
+		}
+		if key := strings.Join(segments, "/"); strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+func (s *fileStorage) bucketDir(bucket string) string {
+	return filepath.Join(s.dir, segmentPrefix+url.PathEscape(bucket))
+}
+
+func (s *fileStorage) path(bucket, key string) string {
+	elems := []string{s.bucketDir(bucket)}
+	for _, segment := range strings.Split(key, "/") {
+		elems = append(elems, segmentPrefix+url.PathEscape(segment))
+	}
+	return filepath.Join(elems...)
+}
+
+// NewFileStorage creates a StorageBackend in the local directory, which should be backed by a persistent volume
+// to survive the controller restarts. The volume must be mountable by the new leader when the leader fails over,
+// so a ReadWriteOnce volume only works with a single replica of the controller.
+func NewFileStorage(dir string) (StorageBackend, error) {
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return nil, err
+	}
+	return &fileStorage{dir: dir}, nil
+}
+
+var _ StorageBackend = &memoryStorage{}
+var _ StorageBackend = &fileStorage{}
@@ -0,0 +1,92 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package trace
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("storage test", func() {
+	testBackend := func(backend StorageBackend) {
+		By("put and get")
+		Expect(backend.Put("bucket", "ns/b/2", []byte("b2"))).Should(Succeed())
+		Expect(backend.Put("bucket", "ns/a/1", []byte("a1"))).Should(Succeed())
+		Expect(backend.Put("bucket", "ns/a/2", []byte("a2"))).Should(Succeed())
+		Expect(backend.Put("other", "ns/a/1", []byte("other"))).Should(Succeed())
+		value, err := backend.Get("bucket", "ns/a/1")
+		Expect(err).Should(BeNil())
+		Expect(value).Should(Equal([]byte("a1")))
+
+		By("overwrite")
+		Expect(backend.Put("bucket", "ns/a/1", []byte("a1-new"))).Should(Succeed())
+		value, err = backend.Get("bucket", "ns/a/1")
+		Expect(err).Should(BeNil())
+		Expect(value).Should(Equal([]byte("a1-new")))
+
+		By("list keys")
+		keys, err := backend.Keys("bucket", "")
+		Expect(err).Should(BeNil())
+		Expect(keys).Should(Equal([]string{"ns/a/1", "ns/a/2", "ns/b/2"}))
+		keys, err = backend.Keys("bucket", "ns/a/")
+		Expect(err).Should(BeNil())
+		Expect(keys).Should(Equal([]string{"ns/a/1", "ns/a/2"}))
+		keys, err = backend.Keys("bucket", "ns/b")
+		Expect(err).Should(BeNil())
+		Expect(keys).Should(Equal([]string{"ns/b/2"}))
+		keys, err = backend.Keys("bucket", "ns/c/")
+		Expect(err).Should(BeNil())
+		Expect(keys).Should(BeEmpty())
+
+		By("delete")
+		Expect(backend.Delete("bucket", "ns/a/1")).Should(Succeed())
+		Expect(backend.Delete("bucket", "ns/a/1")).Should(Succeed())
+		_, err = backend.Get("bucket", "ns/a/1")
+		Expect(err).Should(MatchError(ErrStorageKeyNotFound))
+		keys, err = backend.Keys("bucket", "")
+		Expect(err).Should(BeNil())
+		Expect(keys).Should(Equal([]string{"ns/a/2", "ns/b/2"}))
+		keys, err = backend.Keys("other", "")
+		Expect(err).Should(BeNil())
+		Expect(keys).Should(Equal([]string{"ns/a/1"}))
+	}
+
+	Context("Testing memory storage", func() {
+		It("should work well", func() {
+			testBackend(NewMemoryStorage())
+		})
+	})
+
+	Context("Testing file storage", func() {
+		It("should work well", func() {
+			dir := GinkgoT().TempDir()
+			backend, err := NewFileStorage(dir)
+			Expect(err).Should(BeNil())
+			testBackend(backend)
+
+			By("reopen the storage")
+			backend, err = NewFileStorage(dir)
+			Expect(err).Should(BeNil())
+			value, err := backend.Get("bucket", "ns/b/2")
+			Expect(err).Should(BeNil())
+			Expect(value).Should(Equal([]byte("b2")))
+		})
+	})
+})
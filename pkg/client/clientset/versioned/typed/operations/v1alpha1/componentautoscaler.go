@@ -0,0 +1,195 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	"time"
+
+	v1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
+	scheme "github.com/apecloud/kubeblocks/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// ComponentAutoscalersGetter has a method to return a ComponentAutoscalerInterface.
+// A group's client should implement this interface.
+type ComponentAutoscalersGetter interface {
+	ComponentAutoscalers(namespace string) ComponentAutoscalerInterface
+}
+
+// ComponentAutoscalerInterface has methods to work with ComponentAutoscaler resources.
+type ComponentAutoscalerInterface interface {
+	Create(ctx context.Context, componentAutoscaler *v1alpha1.ComponentAutoscaler, opts v1.CreateOptions) (*v1alpha1.ComponentAutoscaler, error)
+	Update(ctx context.Context, componentAutoscaler *v1alpha1.ComponentAutoscaler, opts v1.UpdateOptions) (*v1alpha1.ComponentAutoscaler, error)
+	UpdateStatus(ctx context.Context, componentAutoscaler *v1alpha1.ComponentAutoscaler, opts v1.UpdateOptions) (*v1alpha1.ComponentAutoscaler, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.ComponentAutoscaler, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.ComponentAutoscalerList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.ComponentAutoscaler, err error)
+	ComponentAutoscalerExpansion
+}
+
+// componentAutoscalers implements ComponentAutoscalerInterface
+type componentAutoscalers struct {
+	client rest.Interface
+	ns     string
+}
+
+// newComponentAutoscalers returns a ComponentAutoscalers
+func newComponentAutoscalers(c *OperationsV1alpha1Client, namespace string) *componentAutoscalers {
+	return &componentAutoscalers{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the componentAutoscaler, and returns the corresponding componentAutoscaler object, and an error if there is any.
+func (c *componentAutoscalers) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.ComponentAutoscaler, err error) {
+	result = &v1alpha1.ComponentAutoscaler{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("componentautoscalers").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of ComponentAutoscalers that match those selectors.
+func (c *componentAutoscalers) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.ComponentAutoscalerList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha1.ComponentAutoscalerList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("componentautoscalers").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested componentAutoscalers.
+func (c *componentAutoscalers) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("componentautoscalers").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a componentAutoscaler and creates it.  Returns the server's representation of the componentAutoscaler, and an error, if there is any.
+func (c *componentAutoscalers) Create(ctx context.Context, componentAutoscaler *v1alpha1.ComponentAutoscaler, opts v1.CreateOptions) (result *v1alpha1.ComponentAutoscaler, err error) {
+	result = &v1alpha1.ComponentAutoscaler{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("componentautoscalers").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(componentAutoscaler).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a componentAutoscaler and updates it. Returns the server's representation of the componentAutoscaler, and an error, if there is any.
+func (c *componentAutoscalers) Update(ctx context.Context, componentAutoscaler *v1alpha1.ComponentAutoscaler, opts v1.UpdateOptions) (result *v1alpha1.ComponentAutoscaler, err error) {
+	result = &v1alpha1.ComponentAutoscaler{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("componentautoscalers").
+		Name(componentAutoscaler.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(componentAutoscaler).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *componentAutoscalers) UpdateStatus(ctx context.Context, componentAutoscaler *v1alpha1.ComponentAutoscaler, opts v1.UpdateOptions) (result *v1alpha1.ComponentAutoscaler, err error) {
+	result = &v1alpha1.ComponentAutoscaler{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("componentautoscalers").
+		Name(componentAutoscaler.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(componentAutoscaler).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the componentAutoscaler and deletes it. Returns an error if one occurs.
+func (c *componentAutoscalers) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("componentautoscalers").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *componentAutoscalers) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("componentautoscalers").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched componentAutoscaler.
+func (c *componentAutoscalers) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.ComponentAutoscaler, err error) {
+	result = &v1alpha1.ComponentAutoscaler{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("componentautoscalers").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
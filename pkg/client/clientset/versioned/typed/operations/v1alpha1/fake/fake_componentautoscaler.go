@@ -0,0 +1,141 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeComponentAutoscalers implements ComponentAutoscalerInterface
+type FakeComponentAutoscalers struct {
+	Fake *FakeOperationsV1alpha1
+	ns   string
+}
+
+var componentautoscalersResource = v1alpha1.SchemeGroupVersion.WithResource("componentautoscalers")
+
+var componentautoscalersKind = v1alpha1.SchemeGroupVersion.WithKind("ComponentAutoscaler")
+
+// Get takes name of the componentAutoscaler, and returns the corresponding componentAutoscaler object, and an error if there is any.
+func (c *FakeComponentAutoscalers) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.ComponentAutoscaler, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(componentautoscalersResource, c.ns, name), &v1alpha1.ComponentAutoscaler{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ComponentAutoscaler), err
+}
+
+// List takes label and field selectors, and returns the list of ComponentAutoscalers that match those selectors.
+func (c *FakeComponentAutoscalers) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.ComponentAutoscalerList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(componentautoscalersResource, componentautoscalersKind, c.ns, opts), &v1alpha1.ComponentAutoscalerList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.ComponentAutoscalerList{ListMeta: obj.(*v1alpha1.ComponentAutoscalerList).ListMeta}
+	for _, item := range obj.(*v1alpha1.ComponentAutoscalerList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested componentAutoscalers.
+func (c *FakeComponentAutoscalers) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(componentautoscalersResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a componentAutoscaler and creates it.  Returns the server's representation of the componentAutoscaler, and an error, if there is any.
+func (c *FakeComponentAutoscalers) Create(ctx context.Context, componentAutoscaler *v1alpha1.ComponentAutoscaler, opts v1.CreateOptions) (result *v1alpha1.ComponentAutoscaler, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(componentautoscalersResource, c.ns, componentAutoscaler), &v1alpha1.ComponentAutoscaler{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ComponentAutoscaler), err
+}
+
+// Update takes the representation of a componentAutoscaler and updates it. Returns the server's representation of the componentAutoscaler, and an error, if there is any.
+func (c *FakeComponentAutoscalers) Update(ctx context.Context, componentAutoscaler *v1alpha1.ComponentAutoscaler, opts v1.UpdateOptions) (result *v1alpha1.ComponentAutoscaler, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(componentautoscalersResource, c.ns, componentAutoscaler), &v1alpha1.ComponentAutoscaler{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ComponentAutoscaler), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeComponentAutoscalers) UpdateStatus(ctx context.Context, componentAutoscaler *v1alpha1.ComponentAutoscaler, opts v1.UpdateOptions) (*v1alpha1.ComponentAutoscaler, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(componentautoscalersResource, "status", c.ns, componentAutoscaler), &v1alpha1.ComponentAutoscaler{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ComponentAutoscaler), err
+}
+
+// Delete takes name of the componentAutoscaler and deletes it. Returns an error if one occurs.
+func (c *FakeComponentAutoscalers) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(componentautoscalersResource, c.ns, name, opts), &v1alpha1.ComponentAutoscaler{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeComponentAutoscalers) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(componentautoscalersResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.ComponentAutoscalerList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched componentAutoscaler.
+func (c *FakeComponentAutoscalers) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.ComponentAutoscaler, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(componentautoscalersResource, c.ns, name, pt, data, subresources...), &v1alpha1.ComponentAutoscaler{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ComponentAutoscaler), err
+}
@@ -28,6 +28,10 @@ type FakeOperationsV1alpha1 struct {
 	*testing.Fake
 }
 
+func (c *FakeOperationsV1alpha1) ComponentAutoscalers(namespace string) v1alpha1.ComponentAutoscalerInterface {
+	return &FakeComponentAutoscalers{c, namespace}
+}
+
 func (c *FakeOperationsV1alpha1) OpsDefinitions() v1alpha1.OpsDefinitionInterface {
 	return &FakeOpsDefinitions{c}
 }
@@ -36,6 +40,10 @@ func (c *FakeOperationsV1alpha1) OpsRequests(namespace string) v1alpha1.OpsReque
 	return &FakeOpsRequests{c, namespace}
 }
 
+func (c *FakeOperationsV1alpha1) OpsRequestSchedules(namespace string) v1alpha1.OpsRequestScheduleInterface {
+	return &FakeOpsRequestSchedules{c, namespace}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeOperationsV1alpha1) RESTClient() rest.Interface {
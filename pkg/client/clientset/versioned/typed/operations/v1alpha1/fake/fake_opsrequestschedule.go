@@ -0,0 +1,141 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeOpsRequestSchedules implements OpsRequestScheduleInterface
+type FakeOpsRequestSchedules struct {
+	Fake *FakeOperationsV1alpha1
+	ns   string
+}
+
+var opsrequestschedulesResource = v1alpha1.SchemeGroupVersion.WithResource("opsrequestschedules")
+
+var opsrequestschedulesKind = v1alpha1.SchemeGroupVersion.WithKind("OpsRequestSchedule")
+
+// Get takes name of the opsRequestSchedule, and returns the corresponding opsRequestSchedule object, and an error if there is any.
+func (c *FakeOpsRequestSchedules) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.OpsRequestSchedule, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(opsrequestschedulesResource, c.ns, name), &v1alpha1.OpsRequestSchedule{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.OpsRequestSchedule), err
+}
+
+// List takes label and field selectors, and returns the list of OpsRequestSchedules that match those selectors.
+func (c *FakeOpsRequestSchedules) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.OpsRequestScheduleList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(opsrequestschedulesResource, opsrequestschedulesKind, c.ns, opts), &v1alpha1.OpsRequestScheduleList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.OpsRequestScheduleList{ListMeta: obj.(*v1alpha1.OpsRequestScheduleList).ListMeta}
+	for _, item := range obj.(*v1alpha1.OpsRequestScheduleList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested opsRequestSchedules.
+func (c *FakeOpsRequestSchedules) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(opsrequestschedulesResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a opsRequestSchedule and creates it.  Returns the server's representation of the opsRequestSchedule, and an error, if there is any.
+func (c *FakeOpsRequestSchedules) Create(ctx context.Context, opsRequestSchedule *v1alpha1.OpsRequestSchedule, opts v1.CreateOptions) (result *v1alpha1.OpsRequestSchedule, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(opsrequestschedulesResource, c.ns, opsRequestSchedule), &v1alpha1.OpsRequestSchedule{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.OpsRequestSchedule), err
+}
+
+// Update takes the representation of a opsRequestSchedule and updates it. Returns the server's representation of the opsRequestSchedule, and an error, if there is any.
+func (c *FakeOpsRequestSchedules) Update(ctx context.Context, opsRequestSchedule *v1alpha1.OpsRequestSchedule, opts v1.UpdateOptions) (result *v1alpha1.OpsRequestSchedule, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(opsrequestschedulesResource, c.ns, opsRequestSchedule), &v1alpha1.OpsRequestSchedule{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.OpsRequestSchedule), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeOpsRequestSchedules) UpdateStatus(ctx context.Context, opsRequestSchedule *v1alpha1.OpsRequestSchedule, opts v1.UpdateOptions) (*v1alpha1.OpsRequestSchedule, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(opsrequestschedulesResource, "status", c.ns, opsRequestSchedule), &v1alpha1.OpsRequestSchedule{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.OpsRequestSchedule), err
+}
+
+// Delete takes name of the opsRequestSchedule and deletes it. Returns an error if one occurs.
+func (c *FakeOpsRequestSchedules) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(opsrequestschedulesResource, c.ns, name, opts), &v1alpha1.OpsRequestSchedule{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeOpsRequestSchedules) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(opsrequestschedulesResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.OpsRequestScheduleList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched opsRequestSchedule.
+func (c *FakeOpsRequestSchedules) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.OpsRequestSchedule, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(opsrequestschedulesResource, c.ns, name, pt, data, subresources...), &v1alpha1.OpsRequestSchedule{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.OpsRequestSchedule), err
+}
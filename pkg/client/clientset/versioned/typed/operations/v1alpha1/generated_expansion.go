@@ -18,6 +18,10 @@ limitations under the License.
 
 package v1alpha1
 
+type ComponentAutoscalerExpansion interface{}
+
 type OpsDefinitionExpansion interface{}
 
 type OpsRequestExpansion interface{}
+
+type OpsRequestScheduleExpansion interface{}
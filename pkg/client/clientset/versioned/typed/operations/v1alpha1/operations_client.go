@@ -28,8 +28,10 @@ import (
 
 type OperationsV1alpha1Interface interface {
 	RESTClient() rest.Interface
+	ComponentAutoscalersGetter
 	OpsDefinitionsGetter
 	OpsRequestsGetter
+	OpsRequestSchedulesGetter
 }
 
 // OperationsV1alpha1Client is used to interact with features provided by the operations.kubeblocks.io group.
@@ -37,6 +39,10 @@ type OperationsV1alpha1Client struct {
 	restClient rest.Interface
 }
 
+func (c *OperationsV1alpha1Client) ComponentAutoscalers(namespace string) ComponentAutoscalerInterface {
+	return newComponentAutoscalers(c, namespace)
+}
+
 func (c *OperationsV1alpha1Client) OpsDefinitions() OpsDefinitionInterface {
 	return newOpsDefinitions(c)
 }
@@ -45,6 +51,10 @@ func (c *OperationsV1alpha1Client) OpsRequests(namespace string) OpsRequestInter
 	return newOpsRequests(c, namespace)
 }
 
+func (c *OperationsV1alpha1Client) OpsRequestSchedules(namespace string) OpsRequestScheduleInterface {
+	return newOpsRequestSchedules(c, namespace)
+}
+
 // NewForConfig creates a new OperationsV1alpha1Client for the given config.
 // NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
 // where httpClient was generated with rest.HTTPClientFor(c).
@@ -123,10 +123,14 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Extensions().V1alpha1().Addons().Informer()}, nil
 
 		// Group=operations.kubeblocks.io, Version=v1alpha1
+	case operationsv1alpha1.SchemeGroupVersion.WithResource("componentautoscalers"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Operations().V1alpha1().ComponentAutoscalers().Informer()}, nil
 	case operationsv1alpha1.SchemeGroupVersion.WithResource("opsdefinitions"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Operations().V1alpha1().OpsDefinitions().Informer()}, nil
 	case operationsv1alpha1.SchemeGroupVersion.WithResource("opsrequests"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Operations().V1alpha1().OpsRequests().Informer()}, nil
+	case operationsv1alpha1.SchemeGroupVersion.WithResource("opsrequestschedules"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Operations().V1alpha1().OpsRequestSchedules().Informer()}, nil
 
 		// Group=parameters.kubeblocks.io, Version=v1alpha1
 	case parametersv1alpha1.SchemeGroupVersion.WithResource("componentparameters"):
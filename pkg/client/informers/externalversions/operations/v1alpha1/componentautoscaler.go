@@ -0,0 +1,90 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	time "time"
+
+	operationsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
+	versioned "github.com/apecloud/kubeblocks/pkg/client/clientset/versioned"
+	internalinterfaces "github.com/apecloud/kubeblocks/pkg/client/informers/externalversions/internalinterfaces"
+	v1alpha1 "github.com/apecloud/kubeblocks/pkg/client/listers/operations/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// ComponentAutoscalerInformer provides access to a shared informer and lister for
+// ComponentAutoscalers.
+type ComponentAutoscalerInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1alpha1.ComponentAutoscalerLister
+}
+
+type componentAutoscalerInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+	namespace        string
+}
+
+// NewComponentAutoscalerInformer constructs a new informer for ComponentAutoscaler type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewComponentAutoscalerInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredComponentAutoscalerInformer(client, namespace, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredComponentAutoscalerInformer constructs a new informer for ComponentAutoscaler type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredComponentAutoscalerInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.OperationsV1alpha1().ComponentAutoscalers(namespace).List(context.TODO(), options)
+			},
+			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.OperationsV1alpha1().ComponentAutoscalers(namespace).Watch(context.TODO(), options)
+			},
+		},
+		&operationsv1alpha1.ComponentAutoscaler{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *componentAutoscalerInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredComponentAutoscalerInformer(client, f.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *componentAutoscalerInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&operationsv1alpha1.ComponentAutoscaler{}, f.defaultInformer)
+}
+
+func (f *componentAutoscalerInformer) Lister() v1alpha1.ComponentAutoscalerLister {
+	return v1alpha1.NewComponentAutoscalerLister(f.Informer().GetIndexer())
+}
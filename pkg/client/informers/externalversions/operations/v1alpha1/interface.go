@@ -24,10 +24,14 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// ComponentAutoscalers returns a ComponentAutoscalerInformer.
+	ComponentAutoscalers() ComponentAutoscalerInformer
 	// OpsDefinitions returns a OpsDefinitionInformer.
 	OpsDefinitions() OpsDefinitionInformer
 	// OpsRequests returns a OpsRequestInformer.
 	OpsRequests() OpsRequestInformer
+	// OpsRequestSchedules returns a OpsRequestScheduleInformer.
+	OpsRequestSchedules() OpsRequestScheduleInformer
 }
 
 type version struct {
@@ -41,6 +45,11 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// ComponentAutoscalers returns a ComponentAutoscalerInformer.
+func (v *version) ComponentAutoscalers() ComponentAutoscalerInformer {
+	return &componentAutoscalerInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // OpsDefinitions returns a OpsDefinitionInformer.
 func (v *version) OpsDefinitions() OpsDefinitionInformer {
 	return &opsDefinitionInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
@@ -50,3 +59,8 @@ func (v *version) OpsDefinitions() OpsDefinitionInformer {
 func (v *version) OpsRequests() OpsRequestInformer {
 	return &opsRequestInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
+
+// OpsRequestSchedules returns a OpsRequestScheduleInformer.
+func (v *version) OpsRequestSchedules() OpsRequestScheduleInformer {
+	return &opsRequestScheduleInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
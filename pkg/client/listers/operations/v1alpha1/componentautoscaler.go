@@ -0,0 +1,99 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ComponentAutoscalerLister helps list ComponentAutoscalers.
+// All objects returned here must be treated as read-only.
+type ComponentAutoscalerLister interface {
+	// List lists all ComponentAutoscalers in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.ComponentAutoscaler, err error)
+	// ComponentAutoscalers returns an object that can list and get ComponentAutoscalers.
+	ComponentAutoscalers(namespace string) ComponentAutoscalerNamespaceLister
+	ComponentAutoscalerListerExpansion
+}
+
+// componentAutoscalerLister implements the ComponentAutoscalerLister interface.
+type componentAutoscalerLister struct {
+	indexer cache.Indexer
+}
+
+// NewComponentAutoscalerLister returns a new ComponentAutoscalerLister.
+func NewComponentAutoscalerLister(indexer cache.Indexer) ComponentAutoscalerLister {
+	return &componentAutoscalerLister{indexer: indexer}
+}
+
+// List lists all ComponentAutoscalers in the indexer.
+func (s *componentAutoscalerLister) List(selector labels.Selector) (ret []*v1alpha1.ComponentAutoscaler, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.ComponentAutoscaler))
+	})
+	return ret, err
+}
+
+// ComponentAutoscalers returns an object that can list and get ComponentAutoscalers.
+func (s *componentAutoscalerLister) ComponentAutoscalers(namespace string) ComponentAutoscalerNamespaceLister {
+	return componentAutoscalerNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// ComponentAutoscalerNamespaceLister helps list and get ComponentAutoscalers.
+// All objects returned here must be treated as read-only.
+type ComponentAutoscalerNamespaceLister interface {
+	// List lists all ComponentAutoscalers in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.ComponentAutoscaler, err error)
+	// Get retrieves the ComponentAutoscaler from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha1.ComponentAutoscaler, error)
+	ComponentAutoscalerNamespaceListerExpansion
+}
+
+// componentAutoscalerNamespaceLister implements the ComponentAutoscalerNamespaceLister
+// interface.
+type componentAutoscalerNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all ComponentAutoscalers in the indexer for a given namespace.
+func (s componentAutoscalerNamespaceLister) List(selector labels.Selector) (ret []*v1alpha1.ComponentAutoscaler, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.ComponentAutoscaler))
+	})
+	return ret, err
+}
+
+// Get retrieves the ComponentAutoscaler from the indexer for a given namespace and name.
+func (s componentAutoscalerNamespaceLister) Get(name string) (*v1alpha1.ComponentAutoscaler, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("opsrequest"), name)
+	}
+	return obj.(*v1alpha1.ComponentAutoscaler), nil
+}
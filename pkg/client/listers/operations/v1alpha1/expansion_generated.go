@@ -18,6 +18,14 @@ limitations under the License.
 
 package v1alpha1
 
+// ComponentAutoscalerListerExpansion allows custom methods to be added to
+// ComponentAutoscalerLister.
+type ComponentAutoscalerListerExpansion interface{}
+
+// ComponentAutoscalerNamespaceListerExpansion allows custom methods to be added to
+// ComponentAutoscalerNamespaceLister.
+type ComponentAutoscalerNamespaceListerExpansion interface{}
+
 // OpsDefinitionListerExpansion allows custom methods to be added to
 // OpsDefinitionLister.
 type OpsDefinitionListerExpansion interface{}
@@ -29,3 +37,11 @@ type OpsRequestListerExpansion interface{}
 // OpsRequestNamespaceListerExpansion allows custom methods to be added to
 // OpsRequestNamespaceLister.
 type OpsRequestNamespaceListerExpansion interface{}
+
+// OpsRequestScheduleListerExpansion allows custom methods to be added to
+// OpsRequestScheduleLister.
+type OpsRequestScheduleListerExpansion interface{}
+
+// OpsRequestScheduleNamespaceListerExpansion allows custom methods to be added to
+// OpsRequestScheduleNamespaceLister.
+type OpsRequestScheduleNamespaceListerExpansion interface{}
@@ -51,6 +51,15 @@ const (
 	NodeSelectorOnceAnnotationKey = "workloads.kubeblocks.io/node-selector-once"
 
 	PVCNamePrefixAnnotationKey = "apps.kubeblocks.io/pvc-name-prefix"
+
+	// SystemAccountRotationAnnotationKey records the on-demand password rotation requests of system accounts,
+	// the value is a JSON map from the account name to the request time in RFC3339 format.
+	SystemAccountRotationAnnotationKey = "apps.kubeblocks.io/system-account-rotation"
+
+	// MaintenanceWindowBypassAnnotationKey records the OpsRequests that bypass the maintenance window,
+	// the value is a JSON list of the OpsRequests, the components they operate on and the expiration time.
+	// The components and workloads only inherit the bypasses of themselves.
+	MaintenanceWindowBypassAnnotationKey = "apps.kubeblocks.io/maintenance-window-bypass"
 )
 
 const (
@@ -47,6 +47,7 @@ const (
 	RoleBindingKind           = "RoleBinding"
 	ServiceAccountKind        = "ServiceAccount"
 	EventKind                 = "Event"
+	OpsRequestKind            = "OpsRequest"
 )
 
 // username and password are keys in created secrets for others to refer to.
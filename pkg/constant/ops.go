@@ -24,6 +24,9 @@ const (
 	OpsRequestTypeLabelKey      = "operations.kubeblocks.io/ops-type"
 	OpsRequestNameLabelKey      = "operations.kubeblocks.io/ops-name"
 	OpsRequestNamespaceLabelKey = "operations.kubeblocks.io/ops-namespace"
+	OpsRequestScheduleLabelKey  = "operations.kubeblocks.io/ops-schedule"
+	StorageAutoscalerLabelKey   = "operations.kubeblocks.io/storage-autoscaler"
+	ComponentAutoscalerLabelKey = "operations.kubeblocks.io/component-autoscaler"
 )
 
 // annotations
@@ -33,4 +36,5 @@ const (
 	RelatedOpsAnnotationKey            = "operations.kubeblocks.io/related-ops"
 	OpsDependentOnSuccessfulOpsAnnoKey = "operations.kubeblocks.io/dependent-on-successful-ops" // OpsDependentOnSuccessfulOpsAnnoKey wait for the dependent ops to succeed before executing the current ops. If it fails, this ops will also fail.
 	IgnoreHscaleValidateAnnoKey        = "apps.kubeblocks.io/ignore-strict-horizontal-scale-validation"
+	ScheduledTimeAnnotationKey         = "operations.kubeblocks.io/scheduled-time"
 )
@@ -78,6 +78,12 @@ func GetCompEnvCMName(compObjName string) string {
 	return fmt.Sprintf("%s-env", compObjName)
 }
 
+// GenerateComponentKBAgentEventCAPattern generates the name of the config map which provides the CA certificate
+// of the kbagent event channel for the component.
+func GenerateComponentKBAgentEventCAPattern(clusterName, compName string) string {
+	return fmt.Sprintf("%s-%s-kbagent-event-ca", clusterName, compName)
+}
+
 // GenerateDefaultServiceAccountName generates default service account name for a component.
 func GenerateDefaultServiceAccountName(cmpdName string) string {
 	return fmt.Sprintf("%s-%s", KBLowerPrefix, cmpdName)
@@ -56,6 +56,22 @@ const (
 	CfgClientQPS          = "CLIENT_QPS"
 	CfgClientBurst        = "CLIENT_BURST"
 
+	// kbagent event channel config keys, the events are pushed to the controller directly if the endpoint is set.
+	CfgKeyKBAgentEventReceiverAddr     = "KBAGENT_EVENT_RECEIVER_ADDRESS"
+	CfgKeyKBAgentEventReceiverEndpoint = "KBAGENT_EVENT_RECEIVER_ENDPOINT"
+	CfgKeyKBAgentEventReceiverCAFile   = "KBAGENT_EVENT_RECEIVER_CA_FILE"
+
+	// reconciliation trace storage config keys, the revisions and changes are persisted into the directory if it's set.
+	CfgKeyTraceStorageDir          = "TRACE_STORAGE_DIR"
+	CfgKeyTraceStorageRetention    = "TRACE_STORAGE_RETENTION"
+	CfgKeyTraceStorageMaxChanges   = "TRACE_STORAGE_MAX_CHANGES"
+	CfgKeyTraceStorageMaxRevisions = "TRACE_STORAGE_MAX_REVISIONS"
+
+	// OpenTelemetry tracing config keys, the spans are exported to the OTLP collector if the endpoint is set.
+	CfgKeyTracingOTLPEndpoint = "TRACING_OTLP_ENDPOINT"
+	CfgKeyTracingOTLPInsecure = "TRACING_OTLP_INSECURE"
+	CfgKeyTracingSampleRatio  = "TRACING_SAMPLE_RATIO"
+
 	CfgRegistries     = "registries"
 	I18nResourcesName = "I18N_RESOURCES_NAME"
 )
@@ -0,0 +1,83 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package analysis
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+)
+
+// Client queries a metrics endpoint and returns the value of the query.
+type Client interface {
+	Query(ctx context.Context, query string) (float64, error)
+}
+
+// ClientFactory creates a metrics client for the given provider.
+type ClientFactory func(provider appsv1alpha1.RolloutAnalysisProvider) (Client, error)
+
+var clientFactory ClientFactory = newClient
+
+// SetClientFactory replaces the factory used to create metrics clients, it is used to plug in other metrics backends or fakes.
+func SetClientFactory(factory ClientFactory) {
+	if factory == nil {
+		factory = newClient
+	}
+	clientFactory = factory
+}
+
+// NewClient creates a metrics client for the given provider.
+func NewClient(provider appsv1alpha1.RolloutAnalysisProvider) (Client, error) {
+	return clientFactory(provider)
+}
+
+func newClient(provider appsv1alpha1.RolloutAnalysisProvider) (Client, error) {
+	if provider.Prometheus != nil {
+		return newPrometheusClient(*provider.Prometheus)
+	}
+	return nil, fmt.Errorf("no metrics provider is specified")
+}
+
+// Evaluate checks whether the value meets the thresholds of the metric.
+func Evaluate(metric appsv1alpha1.RolloutAnalysisMetric, value float64) bool {
+	if math.IsNaN(value) {
+		return false
+	}
+	if metric.Min != nil && value < quantityToFloat64(*metric.Min) {
+		return false
+	}
+	if metric.Max != nil && value > quantityToFloat64(*metric.Max) {
+		return false
+	}
+	return true
+}
+
+func quantityToFloat64(q resource.Quantity) float64 {
+	// parse the decimal string to avoid the precision loss of the approximate conversion, e.g. 950m
+	if f, err := strconv.ParseFloat(q.AsDec().String(), 64); err == nil {
+		return f
+	}
+	return q.AsApproximateFloat64()
+}
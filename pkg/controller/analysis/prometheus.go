@@ -0,0 +1,148 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package analysis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/utils/ptr"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+)
+
+const (
+	prometheusQueryPath     = "/api/v1/query"
+	defaultQueryTimeoutSecs = 10
+)
+
+// prometheusClient queries an endpoint that is compatible with the Prometheus HTTP API.
+type prometheusClient struct {
+	address string
+	client  *http.Client
+}
+
+var _ Client = &prometheusClient{}
+
+type prometheusResponse struct {
+	Status    string         `json:"status"`
+	Data      prometheusData `json:"data"`
+	ErrorType string         `json:"errorType,omitempty"`
+	Error     string         `json:"error,omitempty"`
+}
+
+type prometheusData struct {
+	ResultType string          `json:"resultType"`
+	Result     json.RawMessage `json:"result"`
+}
+
+type prometheusSample struct {
+	Metric map[string]string `json:"metric"`
+	Value  []any             `json:"value"`
+}
+
+func newPrometheusClient(provider appsv1alpha1.PrometheusAnalysisProvider) (Client, error) {
+	return NewPrometheusClient(provider.Address, provider.TimeoutSeconds)
+}
+
+// NewPrometheusClient creates a client of the endpoint that is compatible with the Prometheus HTTP API,
+// the timeout of a query defaults to 10 seconds.
+func NewPrometheusClient(address string, timeoutSeconds *int32) (Client, error) {
+	u, err := url.Parse(address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid prometheus address %s: %w", address, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid prometheus address %s: unsupported scheme", address)
+	}
+	timeout := ptr.Deref(timeoutSeconds, defaultQueryTimeoutSecs)
+	return &prometheusClient{
+		address: strings.TrimSuffix(address, "/"),
+		client:  &http.Client{Timeout: time.Duration(timeout) * time.Second},
+	}, nil
+}
+
+func (c *prometheusClient) Query(ctx context.Context, query string) (float64, error) {
+	params := url.Values{}
+	params.Set("query", query)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.address+prometheusQueryPath+"?"+params.Encode(), nil)
+	if err != nil {
+		return 0, err
+	}
+	rsp, err := c.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer rsp.Body.Close()
+
+	body, err := io.ReadAll(rsp.Body)
+	if err != nil {
+		return 0, err
+	}
+	result := &prometheusResponse{}
+	if err = json.Unmarshal(body, result); err != nil {
+		return 0, fmt.Errorf("failed to decode the query response, status code: %d, error: %w", rsp.StatusCode, err)
+	}
+	if result.Status != "success" {
+		return 0, fmt.Errorf("query failed, status code: %d, error type: %s, error: %s", rsp.StatusCode, result.ErrorType, result.Error)
+	}
+	return parsePrometheusResult(result.Data)
+}
+
+func parsePrometheusResult(data prometheusData) (float64, error) {
+	switch data.ResultType {
+	case "scalar":
+		var value []any
+		if err := json.Unmarshal(data.Result, &value); err != nil {
+			return 0, err
+		}
+		return parsePrometheusValue(value)
+	case "vector":
+		var samples []prometheusSample
+		if err := json.Unmarshal(data.Result, &samples); err != nil {
+			return 0, err
+		}
+		if len(samples) != 1 {
+			return 0, fmt.Errorf("the query should return exactly one sample, but got %d", len(samples))
+		}
+		return parsePrometheusValue(samples[0].Value)
+	default:
+		return 0, fmt.Errorf("unsupported result type %s", data.ResultType)
+	}
+}
+
+// parsePrometheusValue parses the value in the form of [<unix_time>, "<sample_value>"].
+func parsePrometheusValue(value []any) (float64, error) {
+	if len(value) != 2 {
+		return 0, fmt.Errorf("invalid sample value: %v", value)
+	}
+	str, ok := value[1].(string)
+	if !ok {
+		return 0, fmt.Errorf("invalid sample value: %v", value[1])
+	}
+	return strconv.ParseFloat(str, 64)
+}
@@ -0,0 +1,126 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package analysis
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+)
+
+func newFakePrometheusServer(responses map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != prometheusQueryPath {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		rsp, ok := responses[r.URL.Query().Get("query")]
+		if !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"status":"error","errorType":"bad_data","error":"unknown query"}`))
+			return
+		}
+		_, _ = w.Write([]byte(rsp))
+	}))
+}
+
+func TestPrometheusClientQuery(t *testing.T) {
+	server := newFakePrometheusServer(map[string]string{
+		"vector":  `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1700000000.0,"0.98"]}]}}`,
+		"scalar":  `{"status":"success","data":{"resultType":"scalar","result":[1700000000.0,"12"]}}`,
+		"empty":   `{"status":"success","data":{"resultType":"vector","result":[]}}`,
+		"matrix":  `{"status":"success","data":{"resultType":"matrix","result":[]}}`,
+		"invalid": `not json`,
+	})
+	defer server.Close()
+
+	cli, err := NewClient(appsv1alpha1.RolloutAnalysisProvider{
+		Prometheus: &appsv1alpha1.PrometheusAnalysisProvider{Address: server.URL + "/", TimeoutSeconds: ptr.To[int32](5)},
+	})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	cases := []struct {
+		query   string
+		value   float64
+		wantErr bool
+	}{
+		{query: "vector", value: 0.98},
+		{query: "scalar", value: 12},
+		{query: "empty", wantErr: true},
+		{query: "matrix", wantErr: true},
+		{query: "invalid", wantErr: true},
+		{query: "unknown", wantErr: true},
+	}
+	for _, c := range cases {
+		value, err := cli.Query(context.Background(), c.query)
+		if c.wantErr {
+			if err == nil {
+				t.Errorf("query %s: expected error, got value %v", c.query, value)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("query %s: unexpected error: %v", c.query, err)
+			continue
+		}
+		if value != c.value {
+			t.Errorf("query %s: expected %v, got %v", c.query, c.value, value)
+		}
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	if _, err := NewClient(appsv1alpha1.RolloutAnalysisProvider{}); err == nil {
+		t.Errorf("expected error for empty provider")
+	}
+	if _, err := NewClient(appsv1alpha1.RolloutAnalysisProvider{
+		Prometheus: &appsv1alpha1.PrometheusAnalysisProvider{Address: "prometheus:9090"},
+	}); err == nil {
+		t.Errorf("expected error for address without scheme")
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	metric := appsv1alpha1.RolloutAnalysisMetric{
+		Name: "success-rate",
+		Min:  ptr.To(resource.MustParse("0.95")),
+		Max:  ptr.To(resource.MustParse("1")),
+	}
+	cases := map[float64]bool{
+		0.94: false,
+		0.95: true,
+		0.99: true,
+		1:    true,
+		1.01: false,
+	}
+	for value, expected := range cases {
+		if Evaluate(metric, value) != expected {
+			t.Errorf("evaluate %v: expected %v", value, expected)
+		}
+	}
+}
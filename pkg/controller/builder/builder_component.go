@@ -101,6 +101,11 @@ func (builder *ComponentBuilder) SetInstanceUpdateStrategy(strategy *appsv1.Inst
 	return builder
 }
 
+func (builder *ComponentBuilder) SetPodDisruptionBudget(policy *appsv1.PodDisruptionBudgetPolicy) *ComponentBuilder {
+	builder.get().Spec.PodDisruptionBudget = policy
+	return builder
+}
+
 func (builder *ComponentBuilder) SetResources(resources corev1.ResourceRequirements) *ComponentBuilder {
 	builder.get().Spec.Resources = resources
 	return builder
@@ -197,7 +202,22 @@ func (builder *ComponentBuilder) SetStop(stop *bool) *ComponentBuilder {
 	return builder
 }
 
+func (builder *ComponentBuilder) SetReadonly(readonly *bool) *ComponentBuilder {
+	builder.get().Spec.Readonly = readonly
+	return builder
+}
+
 func (builder *ComponentBuilder) SetSidecars(sidecars []appsv1.Sidecar) *ComponentBuilder {
 	builder.get().Spec.Sidecars = sidecars
 	return builder
 }
+
+func (builder *ComponentBuilder) SetMaintenanceWindow(window *appsv1.MaintenanceWindow) *ComponentBuilder {
+	builder.get().Spec.MaintenanceWindow = window
+	return builder
+}
+
+func (builder *ComponentBuilder) SetStorageAutoscaling(policy *appsv1.StorageAutoscalingPolicy) *ComponentBuilder {
+	builder.get().Spec.StorageAutoscaling = policy
+	return builder
+}
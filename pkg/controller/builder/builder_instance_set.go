@@ -115,17 +115,27 @@ func (builder *InstanceSetBuilder) SetInstanceUpdateStrategy(strategy *workloads
 	return builder
 }
 
+func (builder *InstanceSetBuilder) SetPodDisruptionBudget(policy *workloads.PodDisruptionBudgetPolicy) *InstanceSetBuilder {
+	builder.get().Spec.PodDisruptionBudget = policy
+	return builder
+}
+
 func (builder *InstanceSetBuilder) SetMemberUpdateStrategy(strategy *workloads.MemberUpdateStrategy) *InstanceSetBuilder {
 	builder.get().Spec.MemberUpdateStrategy = strategy
 	return builder
 }
 
 func (builder *InstanceSetBuilder) SetLifecycleActions(lifecycleActions *kbappsv1.ComponentLifecycleActions) *InstanceSetBuilder {
-	if lifecycleActions != nil && lifecycleActions.Switchover != nil {
+	if lifecycleActions == nil {
+		return builder
+	}
+	if lifecycleActions.Switchover != nil || lifecycleActions.MemberJoin != nil || lifecycleActions.MemberLeave != nil {
 		if builder.get().Spec.MembershipReconfiguration == nil {
 			builder.get().Spec.MembershipReconfiguration = &workloads.MembershipReconfiguration{}
 		}
 		builder.get().Spec.MembershipReconfiguration.Switchover = lifecycleActions.Switchover
+		builder.get().Spec.MembershipReconfiguration.MemberJoin = lifecycleActions.MemberJoin
+		builder.get().Spec.MembershipReconfiguration.MemberLeave = lifecycleActions.MemberLeave
 	}
 	return builder
 }
@@ -164,3 +174,8 @@ func (builder *InstanceSetBuilder) SetDisableDefaultHeadlessService(disable bool
 	builder.get().Spec.DisableDefaultHeadlessService = disable
 	return builder
 }
+
+func (builder *InstanceSetBuilder) SetMaintenanceWindow(window *kbappsv1.MaintenanceWindow) *InstanceSetBuilder {
+	builder.get().Spec.MaintenanceWindow = window
+	return builder
+}
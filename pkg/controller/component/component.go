@@ -32,6 +32,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/builder"
 	"github.com/apecloud/kubeblocks/pkg/controller/scheduling"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 )
 
 func FullName(clusterName, compName string) string {
@@ -65,6 +66,7 @@ func BuildComponent(cluster *appsv1.Cluster, compSpec *appsv1.ClusterComponentSp
 		AddAnnotations(constant.CRDAPIVersionAnnotationKey, appsv1.GroupVersion.String()).
 		AddAnnotations(constant.KBAppClusterUIDKey, string(cluster.UID)).
 		AddAnnotationsInMap(inheritedAnnotations(cluster)).
+		AddAnnotationsInMap(maintenanceWindowBypassAnnotations(cluster, compSpec.Name, labels)).
 		AddAnnotationsInMap(annotations). // annotations added by the cluster controller
 		AddLabelsInMap(constant.GetCompLabelsWithDef(cluster.Name, compSpec.Name, compSpec.ComponentDef, labels)).
 		SetTerminationPolicy(cluster.Spec.TerminationPolicy).
@@ -80,6 +82,7 @@ func BuildComponent(cluster *appsv1.Cluster, compSpec *appsv1.ClusterComponentSp
 		SetParallelPodManagementConcurrency(compSpec.ParallelPodManagementConcurrency).
 		SetPodUpdatePolicy(compSpec.PodUpdatePolicy).
 		SetInstanceUpdateStrategy(compSpec.InstanceUpdateStrategy).
+		SetPodDisruptionBudget(compSpec.PodDisruptionBudget).
 		SetVolumeClaimTemplates(compSpec.VolumeClaimTemplates).
 		SetPVCRetentionPolicy(compSpec.PersistentVolumeClaimRetentionPolicy).
 		SetVolumes(compSpec.Volumes).
@@ -94,7 +97,10 @@ func BuildComponent(cluster *appsv1.Cluster, compSpec *appsv1.ClusterComponentSp
 		SetRuntimeClassName(cluster.Spec.RuntimeClassName).
 		SetSystemAccounts(compSpec.SystemAccounts).
 		SetStop(compSpec.Stop).
-		SetSidecars(nil)
+		SetReadonly(compSpec.Readonly).
+		SetSidecars(nil).
+		SetMaintenanceWindow(cluster.Spec.MaintenanceWindow).
+		SetStorageAutoscaling(compSpec.StorageAutoscaling)
 	return compBuilder.GetObject(), nil
 }
 
@@ -111,6 +117,17 @@ func inheritedAnnotations(cluster *appsv1.Cluster) map[string]string {
 	return m
 }
 
+// maintenanceWindowBypassAnnotations returns the maintenance window bypasses of the OpsRequests that operate on the component.
+func maintenanceWindowBypassAnnotations(cluster *appsv1.Cluster, compName string, labels map[string]string) map[string]string {
+	bypasses, ok := intctrlutil.ComponentMaintenanceWindowBypasses(cluster.Annotations, compName, labels[constant.KBAppShardingNameLabelKey])
+	if !ok {
+		return nil
+	}
+	m := map[string]string{}
+	intctrlutil.SetMaintenanceWindowBypasses(m, bypasses)
+	return m
+}
+
 func getCompAnnotationValue(comp *appsv1.Component, annotation string) (string, error) {
 	return getCompValueFromMap(comp, comp.Annotations, "annotation", annotation)
 }
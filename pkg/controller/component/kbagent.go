@@ -22,6 +22,9 @@ package component
 import (
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
 	"strconv"
 
 	corev1 "k8s.io/api/core/v1"
@@ -48,6 +51,11 @@ const (
 
 	defaultProbeReportPeriodSeconds = 60
 	minProbeReportPeriodSeconds     = 15
+
+	// VolumeUsageAction is the built-in action of kbagent which reports the usage of the volumes for the storage autoscaling,
+	// its output is a map of proto.FSUsage keyed by the volume names.
+	VolumeUsageAction    = "volumeUsage"
+	volumeUsageMountPath = "/kubeblocks-volumes"
 )
 
 var (
@@ -128,6 +136,12 @@ func buildKBAgentContainer(synthesizedComp *SynthesizedComponent) error {
 	if err != nil {
 		return err
 	}
+	var eventChannelEnvVars []corev1.EnvVar
+	if len(eventChannelCACert()) > 0 {
+		eventChannelEnvVars = kbagent.BuildEnv4EventChannel(viper.GetString(constant.CfgKeyKBAgentEventReceiverEndpoint))
+	}
+	eventChannelVolume, eventChannelVolumeMount := kbagent.BuildVolume4EventChannel(
+		constant.GenerateComponentKBAgentEventCAPattern(synthesizedComp.ClusterName, synthesizedComp.Name))
 
 	newContainer := func(name string, f func(*builder.ContainerBuilder) error) (*corev1.Container, error) {
 		b := builder.NewContainerBuilder(name).
@@ -136,9 +150,13 @@ func buildKBAgentContainer(synthesizedComp *SynthesizedComponent) error {
 			AddCommands(kbAgentCommand).
 			AddEnv(mergedActionEnv4KBAgent(synthesizedComp)...).
 			AddEnv(envVars...).
+			AddEnv(eventChannelEnvVars...).
 			SetSecurityContext(corev1.SecurityContext{
 				RunAsGroup: &[]int64{1000}[0],
 			})
+		if len(eventChannelEnvVars) > 0 {
+			b.AddVolumeMounts(eventChannelVolumeMount)
+		}
 		if f != nil {
 			if err1 := f(b); err1 != nil {
 				return nil, err1
@@ -154,6 +172,7 @@ func buildKBAgentContainer(synthesizedComp *SynthesizedComponent) error {
 			return err1
 		}
 		httpPort, streamingPort := int(ports[0]), int(ports[1])
+		b.AddVolumeMounts(buildVolumeUsageMounts(volumesToProbeUsage(synthesizedComp))...)
 		b.AddArgs("--port", strconv.Itoa(httpPort)).
 			AddArgs("--streaming-port", strconv.Itoa(streamingPort)).
 			AddPorts(
@@ -210,6 +229,9 @@ func buildKBAgentContainer(synthesizedComp *SynthesizedComponent) error {
 
 	synthesizedComp.PodSpec.Containers = append(synthesizedComp.PodSpec.Containers, *container)
 	synthesizedComp.PodSpec.InitContainers = append(synthesizedComp.PodSpec.InitContainers, *workerContainer)
+	if len(eventChannelEnvVars) > 0 {
+		synthesizedComp.PodSpec.Volumes = append(synthesizedComp.PodSpec.Volumes, eventChannelVolume)
+	}
 
 	return nil
 }
@@ -312,6 +334,10 @@ func buildKBAgentStartupEnvs(synthesizedComp *SynthesizedComponent) ([]corev1.En
 		}
 	}
 
+	if volumes := volumesToProbeUsage(synthesizedComp); len(volumes) > 0 {
+		actions = append(actions, *buildVolumeUsageAction4KBAgent(volumes))
+	}
+
 	traverseUserDefinedActions(synthesizedComp, func(name string, action *appsv1.Action) {
 		if a := buildAction4KBAgent(action, name); a != nil {
 			actions = append(actions, *a)
@@ -321,6 +347,45 @@ func buildKBAgentStartupEnvs(synthesizedComp *SynthesizedComponent) ([]corev1.En
 	return kbagent.BuildEnv4Server(actions, probes, streaming)
 }
 
+// volumesToProbeUsage returns the volumes whose usage is reported by kbagent for the storage autoscaling,
+// kbagent is the default source of the usage if no other source is specified.
+func volumesToProbeUsage(synthesizedComp *SynthesizedComponent) []string {
+	policy := synthesizedComp.StorageAutoscaling
+	if policy == nil || (policy.UsageSource != nil && policy.UsageSource.Prometheus != nil) {
+		return nil
+	}
+	var volumes []string
+	for _, vct := range synthesizedComp.VolumeClaimTemplates {
+		if len(policy.VolumeClaimTemplates) == 0 || slices.Contains(policy.VolumeClaimTemplates, vct.Name) {
+			volumes = append(volumes, vct.Name)
+		}
+	}
+	return volumes
+}
+
+func buildVolumeUsageMounts(volumes []string) []corev1.VolumeMount {
+	mounts := make([]corev1.VolumeMount, 0, len(volumes))
+	for _, volume := range volumes {
+		mounts = append(mounts, corev1.VolumeMount{
+			Name:      volume,
+			MountPath: filepath.Join(volumeUsageMountPath, volume),
+			ReadOnly:  true,
+		})
+	}
+	return mounts
+}
+
+func buildVolumeUsageAction4KBAgent(volumes []string) *proto.Action {
+	paths := make(map[string]string, len(volumes))
+	for _, volume := range volumes {
+		paths[volume] = filepath.Join(volumeUsageMountPath, volume)
+	}
+	return &proto.Action{
+		Name:       VolumeUsageAction,
+		FileSystem: &proto.FSAction{Paths: paths},
+	}
+}
+
 func probeReportPeriodSeconds(periodSeconds int32) int32 {
 	if periodSeconds <= 0 {
 		return defaultProbeReportPeriodSeconds
@@ -332,16 +397,29 @@ func probeReportPeriodSeconds(periodSeconds int32) int32 {
 }
 
 func buildAction4KBAgent(action *appsv1.Action, name string) *proto.Action {
-	if action == nil || action.Exec == nil {
+	if !action.Defined() {
 		return nil
 	}
 	a := &proto.Action{
-		Name: name,
-		Exec: &proto.ExecAction{
+		Name:           name,
+		TimeoutSeconds: action.TimeoutSeconds,
+	}
+	switch {
+	case action.Exec != nil:
+		a.Exec = &proto.ExecAction{
 			Commands: action.Exec.Command,
 			Args:     action.Exec.Args,
-		},
-		TimeoutSeconds: action.TimeoutSeconds,
+		}
+	case action.HTTP != nil:
+		a.HTTP = buildHTTPAction4KBAgent(action.HTTP)
+	case action.GRPC != nil:
+		a.GRPC = &proto.GRPCAction{
+			Port:    action.GRPC.Port,
+			Host:    action.GRPC.Host,
+			Service: action.GRPC.Service,
+			Method:  action.GRPC.Method,
+			Request: action.GRPC.Request,
+		}
 	}
 	if action.RetryPolicy != nil {
 		a.RetryPolicy = &proto.RetryPolicy{
@@ -352,8 +430,27 @@ func buildAction4KBAgent(action *appsv1.Action, name string) *proto.Action {
 	return a
 }
 
+func buildHTTPAction4KBAgent(action *appsv1.HTTPAction) *proto.HTTPAction {
+	a := &proto.HTTPAction{
+		Port:                action.Port,
+		Host:                action.Host,
+		Scheme:              string(action.Scheme),
+		Method:              action.Method,
+		Path:                action.Path,
+		Body:                action.Body,
+		ExpectedStatusCodes: action.ExpectedStatusCodes,
+	}
+	for _, header := range action.HTTPHeaders {
+		a.Headers = append(a.Headers, proto.HTTPHeader{
+			Name:  header.Name,
+			Value: header.Value,
+		})
+	}
+	return a
+}
+
 func buildProbe4KBAgent(probe *appsv1.Probe, name, instance string) (*proto.Action, *proto.Probe) {
-	if probe == nil || probe.Exec == nil {
+	if probe == nil || !probe.Defined() {
 		return nil, nil
 	}
 	a := buildAction4KBAgent(&probe.Action, name)
@@ -512,7 +609,7 @@ func iterAvailablePort(port int32, set map[int32]bool) (int32, error) {
 }
 
 func hasActionDefined(synthesizedComp *SynthesizedComponent) bool {
-	if synthesizedComp.LifecycleActions != nil {
+	if synthesizedComp.LifecycleActions != nil || len(volumesToProbeUsage(synthesizedComp)) > 0 {
 		return true
 	}
 	for _, tpl := range synthesizedComp.FileTemplates {
@@ -532,3 +629,30 @@ func traverseUserDefinedActions(synthesizedComp *SynthesizedComponent, f func(na
 		}
 	}
 }
+
+// KBAgentEventChannelCAData returns the data of the config map which provides the CA certificate of the event channel
+// to the kbagent containers of the component, it returns nil if the event channel is not enabled for the component.
+func KBAgentEventChannelCAData(synthesizedComp *SynthesizedComponent) map[string]string {
+	if !hasActionDefined(synthesizedComp) || len(viper.GetString(constant.CfgKeyKBAgentEventReceiverEndpoint)) == 0 {
+		return nil
+	}
+	caCert := eventChannelCACert()
+	if len(caCert) == 0 {
+		return nil
+	}
+	return map[string]string{proto.EventChannelCAKey: string(caCert)}
+}
+
+// eventChannelCACert returns the CA certificate that kbagent verifies the event receiver with,
+// the event channel is disabled if it is not provided.
+func eventChannelCACert() []byte {
+	caFile := viper.GetString(constant.CfgKeyKBAgentEventReceiverCAFile)
+	if len(caFile) == 0 {
+		return nil
+	}
+	caCert, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil
+	}
+	return caCert
+}
@@ -22,6 +22,8 @@ package component
 import (
 	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
 	"reflect"
 	"time"
 
@@ -29,6 +31,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 
 	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
@@ -442,5 +445,157 @@ var _ = Describe("kb-agent", func() {
 				Value: "/var/run/server.conf",
 			}))
 		})
+
+		It("http & grpc actions", func() {
+			synthesizedComp.LifecycleActions.Switchover = &appsv1.Action{
+				HTTP: &appsv1.HTTPAction{
+					Port:   8080,
+					Method: "POST",
+					Path:   "/switchover",
+					HTTPHeaders: []corev1.HTTPHeader{
+						{
+							Name:  "Content-Type",
+							Value: "application/json",
+						},
+					},
+					Body: `{"candidate": "{{ .KB_SWITCHOVER_CANDIDATE_NAME }}"}`,
+				},
+				TimeoutSeconds: 10,
+			}
+			synthesizedComp.LifecycleActions.MemberLeave = &appsv1.Action{
+				GRPC: &appsv1.GRPCAction{
+					Port:    9090,
+					Service: "admin.v1.Admin",
+					Method:  "Leave",
+					Request: `{"member": "{{ .KB_LEAVE_MEMBER_POD_NAME }}"}`,
+				},
+			}
+
+			err := buildKBAgentContainer(synthesizedComp)
+			Expect(err).Should(BeNil())
+
+			c := kbAgentContainer()
+			var val string
+			for _, e := range c.Env {
+				if e.Name == "KB_AGENT_ACTION" {
+					val = e.Value
+				}
+			}
+			Expect(val).ShouldNot(BeEmpty())
+
+			actions := make([]proto.Action, 0)
+			Expect(json.Unmarshal([]byte(val), &actions)).Should(BeNil())
+
+			Expect(actions).Should(ContainElement(proto.Action{
+				Name: "switchover",
+				HTTP: &proto.HTTPAction{
+					Port:   8080,
+					Method: "POST",
+					Path:   "/switchover",
+					Headers: []proto.HTTPHeader{
+						{
+							Name:  "Content-Type",
+							Value: "application/json",
+						},
+					},
+					Body: `{"candidate": "{{ .KB_SWITCHOVER_CANDIDATE_NAME }}"}`,
+				},
+				TimeoutSeconds: 10,
+			}))
+			Expect(actions).Should(ContainElement(proto.Action{
+				Name: "memberLeave",
+				GRPC: &proto.GRPCAction{
+					Port:    9090,
+					Service: "admin.v1.Admin",
+					Method:  "Leave",
+					Request: `{"member": "{{ .KB_LEAVE_MEMBER_POD_NAME }}"}`,
+				},
+			}))
+		})
+
+		It("volume usage probe", func() {
+			synthesizedComp.LifecycleActions = nil
+			synthesizedComp.VolumeClaimTemplates = []corev1.PersistentVolumeClaimTemplate{
+				{ObjectMeta: metav1.ObjectMeta{Name: "data"}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "log"}},
+			}
+			synthesizedComp.StorageAutoscaling = &appsv1.StorageAutoscalingPolicy{
+				VolumeClaimTemplates: []string{"data"},
+			}
+
+			err := buildKBAgentContainer(synthesizedComp)
+			Expect(err).Should(BeNil())
+
+			c := kbAgentContainer()
+			Expect(c).ShouldNot(BeNil())
+			Expect(c.VolumeMounts).Should(ContainElement(corev1.VolumeMount{
+				Name:      "data",
+				MountPath: "/kubeblocks-volumes/data",
+				ReadOnly:  true,
+			}))
+			Expect(c.VolumeMounts).ShouldNot(ContainElement(HaveField("Name", "log")))
+
+			var actionVal, probeVal string
+			for _, e := range c.Env {
+				switch e.Name {
+				case "KB_AGENT_ACTION":
+					actionVal = e.Value
+				case "KB_AGENT_PROBE":
+					probeVal = e.Value
+				}
+			}
+			actions := make([]proto.Action, 0)
+			Expect(json.Unmarshal([]byte(actionVal), &actions)).Should(BeNil())
+			Expect(actions).Should(ContainElement(proto.Action{
+				Name:       VolumeUsageAction,
+				FileSystem: &proto.FSAction{Paths: map[string]string{"data": "/kubeblocks-volumes/data"}},
+			}))
+			// the usage is queried on demand, rather than probed periodically
+			Expect(probeVal).ShouldNot(ContainSubstring(VolumeUsageAction))
+		})
+
+		It("event channel", func() {
+			caFile := filepath.Join(GinkgoT().TempDir(), "ca.crt")
+			Expect(os.WriteFile(caFile, []byte("ca-cert"), 0644)).Should(Succeed())
+			viperx.Set(constant.CfgKeyKBAgentEventReceiverEndpoint, "https://kubeblocks.kb-system.svc:9444")
+			viperx.Set(constant.CfgKeyKBAgentEventReceiverCAFile, caFile)
+			defer func() {
+				viperx.Set(constant.CfgKeyKBAgentEventReceiverEndpoint, "")
+				viperx.Set(constant.CfgKeyKBAgentEventReceiverCAFile, "")
+			}()
+			synthesizedComp.ClusterName = "test-cluster"
+			synthesizedComp.Name = "test-comp"
+
+			err := buildKBAgentContainer(synthesizedComp)
+			Expect(err).Should(BeNil())
+
+			// the CA certificate is projected from the config map rather than the env, renewing it doesn't restart the pods
+			c := kbAgentContainer()
+			Expect(c).ShouldNot(BeNil())
+			for _, env := range c.Env {
+				Expect(env.Value).ShouldNot(ContainSubstring("ca-cert"))
+			}
+			Expect(c.VolumeMounts).Should(ContainElement(HaveField("MountPath", filepath.Dir(proto.EventChannelCAPath))))
+			volume := synthesizedComp.PodSpec.Volumes[len(synthesizedComp.PodSpec.Volumes)-1]
+			Expect(volume.Projected).ShouldNot(BeNil())
+			Expect(volume.Projected.Sources).Should(ContainElement(HaveField("ConfigMap.Name", "test-cluster-test-comp-kbagent-event-ca")))
+			Expect(KBAgentEventChannelCAData(synthesizedComp)).Should(Equal(map[string]string{proto.EventChannelCAKey: "ca-cert"}))
+		})
+
+		It("volume usage probe - other usage source", func() {
+			synthesizedComp.LifecycleActions = nil
+			synthesizedComp.VolumeClaimTemplates = []corev1.PersistentVolumeClaimTemplate{
+				{ObjectMeta: metav1.ObjectMeta{Name: "data"}},
+			}
+			synthesizedComp.StorageAutoscaling = &appsv1.StorageAutoscalingPolicy{
+				UsageSource: &appsv1.StorageUsageSource{
+					Prometheus: &appsv1.PrometheusStorageUsageSource{Address: "http://prometheus:9090"},
+				},
+			}
+
+			err := buildKBAgentContainer(synthesizedComp)
+			Expect(err).Should(BeNil())
+			Expect(kbAgentContainer()).Should(BeNil())
+		})
 	})
 })
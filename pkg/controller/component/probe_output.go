@@ -0,0 +1,114 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+const (
+	probeOutputExpiration    = time.Hour
+	probeOutputPruneInterval = 10 * time.Minute
+)
+
+// ProbeOutput is the latest output of a kbagent probe of a pod.
+type ProbeOutput struct {
+	Code      int32
+	Output    []byte
+	Timestamp time.Time
+}
+
+type probeOutputCache struct {
+	sync.Mutex
+	outputs    map[string]ProbeOutput
+	lastPruned time.Time
+}
+
+var probeOutputs = &probeOutputCache{outputs: map[string]ProbeOutput{}}
+
+func probeOutputKey(namespace, podName, probe string) string {
+	return namespace + "/" + podName + "/" + probe
+}
+
+// ProbeOutputEventHandler caches the latest outputs of the kbagent probes, they are consumed as the metrics of the replicas.
+type ProbeOutputEventHandler struct{}
+
+func (h *ProbeOutputEventHandler) Handle(_ client.Client, _ intctrlutil.RequestCtx, _ record.EventRecorder, event *corev1.Event) error {
+	if event.ReportingController != proto.ProbeEventReportingController || event.InvolvedObject.FieldPath != proto.ProbeEventFieldPath {
+		return nil
+	}
+	ppEvent := &proto.ProbeEvent{}
+	if err := json.Unmarshal([]byte(event.Message), ppEvent); err != nil {
+		return nil // the malformed events are reported by the other handlers
+	}
+	timestamp := event.LastTimestamp.Time
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+
+	probeOutputs.Lock()
+	defer probeOutputs.Unlock()
+	key := probeOutputKey(event.InvolvedObject.Namespace, event.InvolvedObject.Name, ppEvent.Probe)
+	if prev, ok := probeOutputs.outputs[key]; ok && prev.Timestamp.After(timestamp) {
+		return nil
+	}
+	probeOutputs.outputs[key] = ProbeOutput{
+		Code:      ppEvent.Code,
+		Output:    ppEvent.Output,
+		Timestamp: timestamp,
+	}
+	probeOutputs.prune(time.Now())
+	return nil
+}
+
+// prune removes the outputs of the pods which have not reported for a long time, e.g. the deleted pods.
+func (c *probeOutputCache) prune(now time.Time) {
+	if now.Sub(c.lastPruned) < probeOutputPruneInterval {
+		return
+	}
+	c.lastPruned = now
+	for key, output := range c.outputs {
+		if now.Sub(output.Timestamp) > probeOutputExpiration {
+			delete(c.outputs, key)
+		}
+	}
+}
+
+// LatestProbeOutputs returns the latest outputs of the probe reported by the pods, keyed by the pod name.
+func LatestProbeOutputs(namespace, probe string, podNames []string) map[string]ProbeOutput {
+	probeOutputs.Lock()
+	defer probeOutputs.Unlock()
+	result := map[string]ProbeOutput{}
+	for _, podName := range podNames {
+		if output, ok := probeOutputs.outputs[probeOutputKey(namespace, podName, probe)]; ok {
+			result[podName] = output
+		}
+	}
+	return result
+}
@@ -114,11 +114,15 @@ func BuildSynthesizedComponent(ctx context.Context, cli client.Reader,
 		OfflineInstances:                 comp.Spec.OfflineInstances,
 		DisableExporter:                  comp.Spec.DisableExporter,
 		Stop:                             comp.Spec.Stop,
+		Readonly:                         comp.Spec.Readonly,
 		PodManagementPolicy:              compDef.Spec.PodManagementPolicy,
 		ParallelPodManagementConcurrency: comp.Spec.ParallelPodManagementConcurrency,
 		PodUpdatePolicy:                  comp.Spec.PodUpdatePolicy,
 		UpdateStrategy:                   compDef.Spec.UpdateStrategy,
 		InstanceUpdateStrategy:           comp.Spec.InstanceUpdateStrategy,
+		PodDisruptionBudget:              comp.Spec.PodDisruptionBudget,
+		MaintenanceWindow:                comp.Spec.MaintenanceWindow,
+		StorageAutoscaling:               comp.Spec.StorageAutoscaling,
 	}
 
 	// build scheduling policy for workload
@@ -70,6 +70,9 @@ type SynthesizedComponent struct {
 	PodUpdatePolicy                  *kbappsv1.PodUpdatePolicyType       `json:"podUpdatePolicy,omitempty"`
 	UpdateStrategy                   *kbappsv1.UpdateStrategy            `json:"updateStrategy,omitempty"`
 	InstanceUpdateStrategy           *kbappsv1.InstanceUpdateStrategy    `json:"instanceUpdateStrategy,omitempty"`
+	PodDisruptionBudget              *kbappsv1.PodDisruptionBudgetPolicy `json:"podDisruptionBudget,omitempty"`
+	MaintenanceWindow                *kbappsv1.MaintenanceWindow         `json:"maintenanceWindow,omitempty"`
+	StorageAutoscaling               *kbappsv1.StorageAutoscalingPolicy  `json:"storageAutoscaling,omitempty"`
 	PolicyRules                      []rbacv1.PolicyRule                 `json:"policyRules,omitempty"`
 	LifecycleActions                 *kbappsv1.ComponentLifecycleActions `json:"lifecycleActions,omitempty"`
 	SystemAccounts                   []kbappsv1.SystemAccount            `json:"systemAccounts,omitempty"`
@@ -79,6 +82,7 @@ type SynthesizedComponent struct {
 	MinReadySeconds                  int32                               `json:"minReadySeconds,omitempty"`
 	DisableExporter                  *bool                               `json:"disableExporter,omitempty"`
 	Stop                             *bool
+	Readonly                         *bool
 }
 
 type SynthesizedFileTemplate struct {
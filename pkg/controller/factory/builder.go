@@ -81,6 +81,8 @@ func BuildInstanceSet(synthesizedComp *component.SynthesizedComponent, compDef *
 		SetParallelPodManagementConcurrency(getParallelPodManagementConcurrency(synthesizedComp)).
 		SetPodUpdatePolicy(getPodUpdatePolicy(synthesizedComp)).
 		SetInstanceUpdateStrategy(getInstanceUpdateStrategy(synthesizedComp)).
+		SetPodDisruptionBudget(synthesizedComp.PodDisruptionBudget).
+		SetMaintenanceWindow(synthesizedComp.MaintenanceWindow).
 		SetMemberUpdateStrategy(getMemberUpdateStrategy(synthesizedComp)).
 		SetLifecycleActions(synthesizedComp.LifecycleActions).
 		SetTemplateVars(synthesizedComp.TemplateVars)
@@ -92,6 +94,9 @@ func BuildInstanceSet(synthesizedComp *component.SynthesizedComponent, compDef *
 		itsBuilder.AddAnnotations(constant.FeatureReconciliationInCompactModeAnnotationKey,
 			synthesizedComp.Annotations[constant.FeatureReconciliationInCompactModeAnnotationKey])
 	}
+	if bypasses, ok := synthesizedComp.Annotations[constant.MaintenanceWindowBypassAnnotationKey]; ok {
+		itsBuilder.AddAnnotations(constant.MaintenanceWindowBypassAnnotationKey, bypasses)
+	}
 
 	itsObj := itsBuilder.GetObject()
 
@@ -28,6 +28,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/tracing"
 )
 
 // TransformContext is used by Transformer.Transform
@@ -38,6 +39,12 @@ type TransformContext interface {
 	GetLogger() logr.Logger
 }
 
+// ContextSetter is implemented by the TransformContext whose context can be replaced,
+// the context of the transformer span is set to it, so the spans started by the transformer are nested under it.
+type ContextSetter interface {
+	SetContext(ctx context.Context)
+}
+
 // Transformer transforms a DAG to a new version
 type Transformer interface {
 	Transform(ctx TransformContext, dag *DAG) error
@@ -54,7 +61,8 @@ var ErrPrematureStop = errors.New("Premature-Stop")
 func (r TransformerChain) ApplyTo(ctx TransformContext, dag *DAG) error {
 	var delayedError error
 	for _, transformer := range r {
-		if err := transformer.Transform(ctx, dag); err != nil {
+		err := r.transform(ctx, transformer, dag)
+		if err != nil {
 			if intctrlutil.IsDelayedRequeueError(err) {
 				if delayedError == nil {
 					delayedError = err
@@ -67,6 +75,18 @@ func (r TransformerChain) ApplyTo(ctx TransformContext, dag *DAG) error {
 	return delayedError
 }
 
+func (r TransformerChain) transform(ctx TransformContext, transformer Transformer, dag *DAG) error {
+	parent := ctx.GetContext()
+	spanCtx, span := tracing.StartTransformer(parent, transformer)
+	if setter, ok := ctx.(ContextSetter); ok {
+		setter.SetContext(spanCtx)
+		defer setter.SetContext(parent)
+	}
+	err := transformer.Transform(ctx, dag)
+	tracing.End(span, ignoredIfPrematureStop(err))
+	return err
+}
+
 func ignoredIfPrematureStop(err error) error {
 	if err == ErrPrematureStop {
 		return nil
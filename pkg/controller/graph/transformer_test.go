@@ -0,0 +1,117 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package graph
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/apecloud/kubeblocks/pkg/tracing"
+)
+
+type testTransformContext struct {
+	ctx context.Context
+}
+
+var _ TransformContext = &testTransformContext{}
+var _ ContextSetter = &testTransformContext{}
+
+func (c *testTransformContext) GetContext() context.Context {
+	return c.ctx
+}
+
+func (c *testTransformContext) SetContext(ctx context.Context) {
+	c.ctx = ctx
+}
+
+func (c *testTransformContext) GetClient() client.Reader {
+	return nil
+}
+
+func (c *testTransformContext) GetRecorder() record.EventRecorder {
+	return nil
+}
+
+func (c *testTransformContext) GetLogger() logr.Logger {
+	return logr.Discard()
+}
+
+// testExporter keeps the exported spans, they are kept after shutdown, unlike the in-memory exporter.
+type testExporter struct {
+	spans tracetest.SpanStubs
+}
+
+func (e *testExporter) ExportSpans(_ context.Context, spans []sdktrace.ReadOnlySpan) error {
+	e.spans = append(e.spans, tracetest.SpanStubsFromReadOnlySpans(spans)...)
+	return nil
+}
+
+func (e *testExporter) Shutdown(context.Context) error {
+	return nil
+}
+
+// testSpanTransformer starts a span in the transformer, as the lifecycle actions do.
+type testSpanTransformer struct{}
+
+func (t *testSpanTransformer) Transform(ctx TransformContext, _ *DAG) error {
+	_, span := tracing.Start(ctx.GetContext(), "action")
+	tracing.End(span, nil)
+	return nil
+}
+
+func TestApplyToNestsSpans(t *testing.T) {
+	exporter := &testExporter{}
+	shutdown := tracing.Setup(exporter, 1)
+
+	ctx, reconcileSpan := tracing.Start(context.Background(), "reconcile")
+	transCtx := &testTransformContext{ctx: ctx}
+	if err := (TransformerChain{&testSpanTransformer{}}).ApplyTo(transCtx, NewDAG()); err != nil {
+		t.Fatal(err)
+	}
+	if transCtx.ctx != ctx {
+		t.Error("the context should be restored after the transformer")
+	}
+	tracing.End(reconcileSpan, nil)
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	spans := map[string]tracetest.SpanStub{}
+	for _, span := range exporter.spans {
+		spans[span.Name] = span
+	}
+	transformerSpan, ok := spans["Transform *graph.testSpanTransformer"]
+	if !ok {
+		t.Fatalf("transformer span not found: %v", spans)
+	}
+	if transformerSpan.Parent.SpanID() != trace.SpanFromContext(ctx).SpanContext().SpanID() {
+		t.Error("the transformer span should be a child of the reconcile span")
+	}
+	if spans["action"].Parent.SpanID() != transformerSpan.SpanContext.SpanID() {
+		t.Error("the span started in the transformer should be a child of the transformer span")
+	}
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/klauspost/compress/zstd"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -590,6 +591,14 @@ func copyAndMerge(oldObj, newObj client.Object) client.Object {
 		return oldPVC
 	}
 
+	copyAndMergePDB := func(oldPDB, newPDB *policyv1.PodDisruptionBudget) client.Object {
+		mergeMap(&newPDB.Labels, &oldPDB.Labels)
+		oldPDB.Spec.Selector = newPDB.Spec.Selector
+		oldPDB.Spec.MinAvailable = newPDB.Spec.MinAvailable
+		oldPDB.Spec.MaxUnavailable = newPDB.Spec.MaxUnavailable
+		return oldPDB
+	}
+
 	targetObj := oldObj.DeepCopyObject()
 	switch o := newObj.(type) {
 	case *corev1.Service:
@@ -600,6 +609,8 @@ func copyAndMerge(oldObj, newObj client.Object) client.Object {
 		return copyAndMergePod(targetObj.(*corev1.Pod), o)
 	case *corev1.PersistentVolumeClaim:
 		return copyAndMergePVC(targetObj.(*corev1.PersistentVolumeClaim), o)
+	case *policyv1.PodDisruptionBudget:
+		return copyAndMergePDB(targetObj.(*policyv1.PodDisruptionBudget), o)
 	default:
 		return newObj
 	}
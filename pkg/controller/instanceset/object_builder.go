@@ -24,9 +24,14 @@ import (
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
 
 	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/builder"
 )
 
@@ -61,3 +66,75 @@ func buildHeadlessSvc(its workloads.InstanceSet, labels, selectors map[string]st
 func getHeadlessSvcName(itsName string) string {
 	return strings.Join([]string{itsName, "headless"}, "-")
 }
+
+// buildPDB builds the PodDisruptionBudget guarding the instances against voluntary disruptions, e.g. node drains.
+//
+// If any role participates in quorum, only the pods with those roles are selected, and a majority of
+// the quorum members must stay available. Otherwise, at most one instance can be disrupted at a time.
+// The quorum size follows the members observed, and falls back to the replicas before any role is reported,
+// so the budget is adjusted automatically during horizontal scaling.
+//
+// Returns nil if the budget is disabled or there is nothing to guard.
+func buildPDB(its *workloads.InstanceSet, labels map[string]string) *policyv1.PodDisruptionBudget {
+	policy := its.Spec.PodDisruptionBudget
+	if policy != nil && policy.Disabled {
+		return nil
+	}
+
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{}}
+	for k, v := range its.Spec.Selector.MatchLabels {
+		selector.MatchLabels[k] = v
+	}
+	pdbBuilder := builder.NewPDBBuilder(its.Namespace, its.Name).
+		AddLabelsInMap(labels).
+		SetSelector(selector)
+
+	if policy != nil && (policy.MinAvailable != nil || policy.MaxUnavailable != nil) {
+		return pdbBuilder.
+			SetMinAvailable(policy.MinAvailable).
+			SetMaxUnavailable(policy.MaxUnavailable).
+			GetObject()
+	}
+
+	replicas := int32(1)
+	if its.Spec.Replicas != nil {
+		replicas = *its.Spec.Replicas
+	}
+	var quorumRoles []string
+	for _, role := range its.Spec.Roles {
+		if role.ParticipatesInQuorum {
+			quorumRoles = append(quorumRoles, role.Name)
+		}
+	}
+	if len(quorumRoles) == 0 {
+		if replicas <= 1 {
+			return nil
+		}
+		return pdbBuilder.SetMinAvailable(ptr.To(intstr.FromInt32(replicas - 1))).GetObject()
+	}
+
+	members := quorumMembers(its)
+	if members == 0 {
+		members = replicas
+	}
+	if members <= 1 {
+		return nil
+	}
+	selector.MatchExpressions = append(selector.MatchExpressions, metav1.LabelSelectorRequirement{
+		Key:      constant.RoleLabelKey,
+		Operator: metav1.LabelSelectorOpIn,
+		Values:   quorumRoles,
+	})
+	return pdbBuilder.SetMinAvailable(ptr.To(intstr.FromInt32(members/2 + 1))).GetObject()
+}
+
+// quorumMembers returns the number of members whose role participates in quorum.
+func quorumMembers(its *workloads.InstanceSet) int32 {
+	var members int32
+	for _, member := range its.Status.MembersStatus {
+		if member.ReplicaRole != nil && member.ReplicaRole.ParticipatesInQuorum {
+			members++
+		}
+	}
+	return members
+}
@@ -24,7 +24,10 @@ import (
 	. "github.com/onsi/gomega"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
 
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/builder"
 )
@@ -66,4 +69,55 @@ var _ = Describe("object generation transformer test.", func() {
 			Expect(svc.Spec.Ports[1].Name).ShouldNot(Equal(port.Name))
 		})
 	})
+
+	Context("pod disruption budget", func() {
+		It("quorum roles", func() {
+			pdb := buildPDB(its, nil)
+			Expect(pdb).ShouldNot(BeNil())
+			Expect(pdb.Name).Should(Equal(its.Name))
+			Expect(pdb.Spec.MinAvailable.IntValue()).Should(Equal(2))
+			Expect(pdb.Spec.Selector.MatchExpressions).Should(HaveLen(1))
+			Expect(pdb.Spec.Selector.MatchExpressions[0].Key).Should(Equal(constant.RoleLabelKey))
+			Expect(pdb.Spec.Selector.MatchExpressions[0].Values).Should(ConsistOf("leader", "follower"))
+
+			By("scale out, follow the quorum members observed")
+			its.Spec.Replicas = ptr.To[int32](5)
+			for i, role := range []string{"leader", "follower", "follower", "follower", "learner"} {
+				for j := range roles {
+					if roles[j].Name == role {
+						its.Status.MembersStatus = append(its.Status.MembersStatus, workloads.MemberStatus{
+							PodName:     getPodName(its.Name, i),
+							ReplicaRole: &roles[j],
+						})
+					}
+				}
+			}
+			pdb = buildPDB(its, nil)
+			Expect(pdb.Spec.MinAvailable.IntValue()).Should(Equal(3))
+		})
+
+		It("no quorum roles", func() {
+			its.Spec.Roles = nil
+			pdb := buildPDB(its, nil)
+			Expect(pdb).ShouldNot(BeNil())
+			Expect(pdb.Spec.MinAvailable.IntValue()).Should(Equal(2))
+			Expect(pdb.Spec.Selector.MatchExpressions).Should(BeEmpty())
+
+			its.Spec.Replicas = ptr.To[int32](1)
+			Expect(buildPDB(its, nil)).Should(BeNil())
+		})
+
+		It("override", func() {
+			maxUnavailable := intstr.FromString("50%")
+			its.Spec.PodDisruptionBudget = &workloads.PodDisruptionBudgetPolicy{MaxUnavailable: &maxUnavailable}
+			pdb := buildPDB(its, nil)
+			Expect(pdb).ShouldNot(BeNil())
+			Expect(pdb.Spec.MinAvailable).Should(BeNil())
+			Expect(*pdb.Spec.MaxUnavailable).Should(Equal(maxUnavailable))
+			Expect(pdb.Spec.Selector.MatchExpressions).Should(BeEmpty())
+
+			its.Spec.PodDisruptionBudget = &workloads.PodDisruptionBudgetPolicy{Disabled: true}
+			Expect(buildPDB(its, nil)).Should(BeNil())
+		})
+	})
 })
@@ -93,6 +93,11 @@ func (h *PodRoleEventHandler) Handle(cli client.Client, reqCtx intctrlutil.Reque
 		return err
 	}
 
+	// the events received through the event channel are not persisted, and they are delivered in order
+	if annotations[proto.EventChannelAnnotationKey] == "true" {
+		return nil
+	}
+
 	// event order is crucial in role probing, but it's not guaranteed when controller restarted, so we have to mark them to be filtered
 	patch := client.MergeFrom(event.DeepCopy())
 	if event.Annotations == nil {
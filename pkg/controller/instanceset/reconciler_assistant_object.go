@@ -21,6 +21,7 @@ package instanceset
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -30,7 +31,7 @@ import (
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 )
 
-// assistantObjectReconciler manages non-workload objects, such as Service, ConfigMap, etc.
+// assistantObjectReconciler manages non-workload objects, such as Service, ConfigMap, PodDisruptionBudget, etc.
 type assistantObjectReconciler struct{}
 
 func NewAssistantObjectReconciler() kubebuilderx.Reconciler {
@@ -60,6 +61,9 @@ func (a *assistantObjectReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (ku
 		headLessSvc := buildHeadlessSvc(*its, labels, headlessSelectors)
 		objects = append(objects, headLessSvc)
 	}
+	if pdb := buildPDB(its, getMatchLabels(its.Name)); pdb != nil {
+		objects = append(objects, pdb)
+	}
 	for _, object := range objects {
 		if err := intctrlutil.SetOwnership(its, object, model.GetScheme(), finalizer); err != nil {
 			return kubebuilderx.Continue, err
@@ -77,11 +81,12 @@ func (a *assistantObjectReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (ku
 	oldSnapshot := make(map[model.GVKNObjKey]client.Object)
 	svcList := tree.List(&corev1.Service{})
 	cmList := tree.List(&corev1.ConfigMap{})
+	pdbList := tree.List(&policyv1.PodDisruptionBudget{})
 	cmListFiltered, err := filterTemplate(cmList, its.Annotations)
 	if err != nil {
 		return kubebuilderx.Continue, err
 	}
-	for _, objectList := range [][]client.Object{svcList, cmListFiltered} {
+	for _, objectList := range [][]client.Object{svcList, cmListFiltered, pdbList} {
 		for _, object := range objectList {
 			name, err := model.GetGVKName(object)
 			if err != nil {
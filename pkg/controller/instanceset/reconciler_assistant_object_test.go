@@ -53,13 +53,18 @@ var _ = Describe("assistant object reconciler test", func() {
 			res, err := reconciler.Reconcile(tree)
 			Expect(err).Should(BeNil())
 			Expect(res).Should(Equal(kubebuilderx.Continue))
-			// desired: svc: "bar-headless"
+			// desired: svc: "bar-headless", pdb: "bar"
 			objects := tree.GetSecondaryObjects()
-			Expect(objects).Should(HaveLen(1))
+			Expect(objects).Should(HaveLen(2))
 			svc := builder.NewHeadlessServiceBuilder(namespace, name+"-headless").GetObject()
-			name, err := model.GetGVKName(svc)
+			svcName, err := model.GetGVKName(svc)
 			Expect(err).Should(BeNil())
-			_, ok := objects[*name]
+			_, ok := objects[*svcName]
+			Expect(ok).Should(BeTrue())
+			pdb := builder.NewPDBBuilder(namespace, name).GetObject()
+			pdbName, err := model.GetGVKName(pdb)
+			Expect(err).Should(BeNil())
+			_, ok = objects[*pdbName]
 			Expect(ok).Should(BeTrue())
 
 		})
@@ -80,7 +80,7 @@ func (r *instanceAlignmentReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (
 	}
 	oldNameSet := sets.New[string]()
 	oldInstanceMap := make(map[string]*corev1.Pod)
-	oldInstanceList := tree.List(&corev1.Pod{})
+	oldInstanceList := filterSurgeInstances(tree.List(&corev1.Pod{}))
 	oldPVCList := tree.List(&corev1.PersistentVolumeClaim{})
 	for _, object := range oldInstanceList {
 		oldNameSet.Insert(object.GetName())
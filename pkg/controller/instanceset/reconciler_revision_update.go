@@ -89,7 +89,7 @@ func (r *revisionUpdateReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (kub
 		updateRevision = instanceRevisionList[len(instanceRevisionList)-1].revision
 	}
 	its.Status.UpdateRevision = updateRevision
-	updatedReplicas, err := calculateUpdatedReplicas(its, tree.List(&corev1.Pod{}))
+	updatedReplicas, err := calculateUpdatedReplicas(its, filterSurgeInstances(tree.List(&corev1.Pod{})))
 	if err != nil {
 		return kubebuilderx.Continue, err
 	}
@@ -54,8 +54,10 @@ func (r *statusReconciler) PreCondition(tree *kubebuilderx.ObjectTree) *kubebuil
 
 func (r *statusReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (kubebuilderx.Result, error) {
 	its, _ := tree.GetRoot().(*workloads.InstanceSet)
-	// 1. get all pods
-	pods := tree.List(&corev1.Pod{})
+	// 1. get all pods, the surge instances are not counted
+	allPods := tree.List(&corev1.Pod{})
+	pods := filterSurgeInstances(allPods)
+	surging := len(pods) != len(allPods)
 	var podList []*corev1.Pod
 	for _, object := range pods {
 		pod, _ := object.(*corev1.Pod)
@@ -156,7 +158,7 @@ func (r *statusReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (kubebuilder
 	if its.Spec.Replicas != nil {
 		totalReplicas = *its.Spec.Replicas
 	}
-	if its.Status.Replicas == totalReplicas && its.Status.UpdatedReplicas == totalReplicas {
+	if its.Status.Replicas == totalReplicas && its.Status.UpdatedReplicas == totalReplicas && !surging {
 		its.Status.CurrentRevision = its.Status.UpdateRevision
 		its.Status.CurrentReplicas = totalReplicas
 	}
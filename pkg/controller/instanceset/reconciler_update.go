@@ -43,6 +43,7 @@ import (
 
 // updateReconciler handles the updates of instances based on the UpdateStrategy.
 // Currently, two update strategies are supported: 'OnDelete' and 'RollingUpdate'.
+// The 'RollingUpdate' can be surge-based, see reconciler_update_surge.go.
 type updateReconciler struct{}
 
 var _ kubebuilderx.Reconciler = &updateReconciler{}
@@ -85,23 +86,42 @@ func (r *updateReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (kubebuilder
 	}
 	oldNameSet := sets.New[string]()
 	oldInstanceMap := make(map[string]*corev1.Pod)
-	var oldPodList []*corev1.Pod
+	var oldPodList, surgePodList []*corev1.Pod
 	for _, object := range tree.List(&corev1.Pod{}) {
-		oldNameSet.Insert(object.GetName())
 		pod, _ := object.(*corev1.Pod)
+		if isSurgeInstance(pod) {
+			surgePodList = append(surgePodList, pod)
+			continue
+		}
+		oldNameSet.Insert(object.GetName())
 		oldInstanceMap[object.GetName()] = pod
 		oldPodList = append(oldPodList, pod)
 	}
+
+	// the surge instances should be progressed even if the instances are not aligned,
+	// as the instances they stand in for are recreated in the middle.
+	surgingNameSet, needRetry, err := r.progressSurgeInstances(tree, its, surgePodList, oldInstanceMap, newNameSet)
+	if err != nil {
+		return kubebuilderx.Continue, err
+	}
+	retryResult := func() kubebuilderx.Result {
+		if needRetry {
+			// at least one second to poll the member join action in progress.
+			return kubebuilderx.RetryAfter(time.Second * time.Duration(max(its.Spec.MinReadySeconds, 1)))
+		}
+		return kubebuilderx.Continue
+	}
+
 	updateNameSet := oldNameSet.Intersection(newNameSet)
 	if len(updateNameSet) != len(oldNameSet) || len(updateNameSet) != len(newNameSet) {
 		tree.Logger.Info(fmt.Sprintf("InstanceSet %s/%s instances are not aligned", its.Namespace, its.Name))
-		return kubebuilderx.Continue, nil
+		return retryResult(), nil
 	}
 
 	// 3. do update
 	// do nothing if update strategy type is 'OnDelete'
 	if its.Spec.InstanceUpdateStrategy != nil && its.Spec.InstanceUpdateStrategy.Type == kbappsv1.OnDeleteStrategyType {
-		return kubebuilderx.Continue, nil
+		return retryResult(), nil
 	}
 
 	// handle 'RollingUpdate'
@@ -117,6 +137,16 @@ func (r *updateReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (kubebuilder
 	}
 	unavailable := maxUnavailable - currentUnavailable
 
+	// with surge, the instances to be recreated are replaced by surge instances first,
+	// so the redundancy never drops and the concurrency is limited by the max surge instead.
+	maxSurge, err := parseMaxSurge(its.Spec.InstanceUpdateStrategy, len(oldPodList))
+	if err != nil {
+		return kubebuilderx.Continue, err
+	}
+	if maxSurge > 0 {
+		unavailable = maxSurge
+	}
+
 	// if it's a roleful InstanceSet, we use updateCount to represent Pods can be updated according to the spec.memberUpdateStrategy.
 	updateCount := len(oldPodList)
 	if len(its.Spec.Roles) > 0 {
@@ -128,13 +158,22 @@ func (r *updateReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (kubebuilder
 		updateCount = len(podsToBeUpdated)
 	}
 
-	updatingPods := 0
+	// the instances being replaced by surge instances are counted as updating
+	updatingPods := len(surgingNameSet)
 	updatedPods := 0
 	priorities := ComposeRolePriorityMap(its.Spec.Roles)
 	isBlocked := false
-	needRetry := false
 	sortObjects(oldPodList, priorities, false)
 
+	// the pods are only allowed to be recreated or updated in-place in the maintenance window, unless it's bypassed.
+	now := time.Now()
+	windowOpen, nextOpenTime, err := intctrlutil.CheckMaintenanceWindow(its.Spec.MaintenanceWindow, now)
+	if err != nil {
+		return kubebuilderx.Continue, err
+	}
+	windowOpen = windowOpen || intctrlutil.IsMaintenanceWindowBypassed(its.Annotations, now)
+	blockedByWindow := false
+
 	// treat old and Pending pod as a special case, as they can be updated without a consequence
 	// PodUpdatePolicy is ignored here since in-place update for a pending pod doesn't make much sense.
 	for _, pod := range oldPodList {
@@ -180,6 +219,11 @@ func (r *updateReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (kubebuilder
 			break
 		}
 
+		if surgingNameSet.Has(pod.Name) {
+			updatedPods++
+			continue
+		}
+
 		if !canBeUpdated(pod) {
 			break
 		}
@@ -198,6 +242,15 @@ func (r *updateReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (kubebuilder
 			isBlocked = true
 			break
 		}
+		if !windowOpen && updatePolicy != NoOpsPolicy {
+			message := fmt.Sprintf("InstanceSet %s/%s blocks on update as it's out of the maintenance window, the window opens at %s",
+				its.Namespace, its.Name, nextOpenTime.Format(time.RFC3339))
+			tree.Logger.Info(message)
+			meta.SetStatusCondition(&its.Status.Conditions, *buildOutOfMaintenanceWindowCondition(its, message))
+			isBlocked = true
+			blockedByWindow = true
+			break
+		}
 		if updatePolicy == InPlaceUpdatePolicy {
 			newPod, err := buildInstancePodByTemplate(pod.Name, nameToTemplateMap[pod.Name], its, getPodRevision(pod))
 			if err != nil {
@@ -225,7 +278,11 @@ func (r *updateReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (kubebuilder
 			}
 			updatingPods++
 		} else if updatePolicy == RecreatePolicy {
-			if !isTerminating(pod) {
+			if maxSurge > 0 {
+				if err = r.createSurgeInstance(tree, its, pod, nameToTemplateMap[pod.Name]); err != nil {
+					return kubebuilderx.Continue, err
+				}
+			} else if !isTerminating(pod) {
 				if err = r.switchover(tree, its, pod); err != nil {
 					return kubebuilderx.Continue, err
 				}
@@ -252,10 +309,11 @@ func (r *updateReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (kubebuilder
 	if !isBlocked {
 		meta.RemoveStatusCondition(&its.Status.Conditions, string(workloads.InstanceUpdateRestricted))
 	}
-	if needRetry {
-		return kubebuilderx.RetryAfter(time.Second * time.Duration(its.Spec.MinReadySeconds)), nil
+	if blockedByWindow && !needRetry {
+		// no event will trigger the reconciliation when the window opens
+		return kubebuilderx.RetryAfter(nextOpenTime.Sub(now)), nil
 	}
-	return kubebuilderx.Continue, nil
+	return retryResult(), nil
 }
 
 func (r *updateReconciler) switchover(tree *kubebuilderx.ObjectTree, its *workloads.InstanceSet, pod *corev1.Pod) error {
@@ -438,6 +496,16 @@ func buildBlockedCondition(its *workloads.InstanceSet, message string) *metav1.C
 	}
 }
 
+func buildOutOfMaintenanceWindowCondition(its *workloads.InstanceSet, message string) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               string(workloads.InstanceUpdateRestricted),
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: its.Generation,
+		Reason:             workloads.ReasonOutOfMaintenanceWindow,
+		Message:            message,
+	}
+}
+
 func parseReplicasNMaxUnavailable(updateStrategy *workloads.InstanceUpdateStrategy, totalReplicas int) (int, int, error) {
 	replicas := totalReplicas
 	maxUnavailable := 1
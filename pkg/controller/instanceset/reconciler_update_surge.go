@@ -0,0 +1,312 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package instanceset
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kbappsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/instanceset/instancetemplate"
+	"github.com/apecloud/kubeblocks/pkg/controller/kubebuilderx"
+	"github.com/apecloud/kubeblocks/pkg/controller/lifecycle"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+// surgePhase is the phase of a surge instance, a surge instance goes through the phases in order:
+//
+//  1. Created: the surge instance is created with the new revision, and joins the member set once it's ready.
+//     It's counted only after the member join action completes, which should return after the data is in sync.
+//  2. Joined: the instance it stands in for leaves the member set and is deleted once the surge instance is available.
+//  3. Replacing: the instance is recreated with the new revision, and joins the member set again once it's ready.
+//  4. Rejoined: the surge instance leaves the member set and is retired once the recreated instance is available.
+type surgePhase string
+
+const (
+	surgePhaseCreated   surgePhase = ""
+	surgePhaseJoined    surgePhase = "Joined"
+	surgePhaseReplacing surgePhase = "Replacing"
+	surgePhaseRejoined  surgePhase = "Rejoined"
+)
+
+func surgeInstanceName(name string) string {
+	return fmt.Sprintf("%s-surge", name)
+}
+
+func isSurgeInstance(object client.Object) bool {
+	_, ok := object.GetLabels()[SurgeInstanceLabelKey]
+	return ok
+}
+
+// filterSurgeInstances removes the surge instances from the objects.
+func filterSurgeInstances(objects []client.Object) []client.Object {
+	var filtered []client.Object
+	for _, object := range objects {
+		if !isSurgeInstance(object) {
+			filtered = append(filtered, object)
+		}
+	}
+	return filtered
+}
+
+func parseMaxSurge(updateStrategy *workloads.InstanceUpdateStrategy, totalReplicas int) (int, error) {
+	if updateStrategy == nil || updateStrategy.RollingUpdate == nil || updateStrategy.RollingUpdate.MaxSurge == nil {
+		return 0, nil
+	}
+	maxSurge, err := intstr.GetScaledValueFromIntOrPercent(updateStrategy.RollingUpdate.MaxSurge, totalReplicas, true)
+	if err != nil {
+		return 0, err
+	}
+	if maxSurge < 0 {
+		return 0, nil
+	}
+	return maxSurge, nil
+}
+
+// createSurgeInstance creates a surge instance with the new revision to stand in for the pod during its update.
+func (r *updateReconciler) createSurgeInstance(tree *kubebuilderx.ObjectTree, its *workloads.InstanceSet,
+	pod *corev1.Pod, template *instancetemplate.InstanceTemplateExt) error {
+	name := surgeInstanceName(pod.Name)
+	surge, err := buildInstancePodByTemplate(name, template, its, "")
+	if err != nil {
+		return err
+	}
+	surge.Labels[SurgeInstanceLabelKey] = pod.Name
+	if err = tree.Add(surge); err != nil {
+		return err
+	}
+	pvcs, err := buildInstancePVCByTemplate(name, template, its)
+	if err != nil {
+		return err
+	}
+	for _, pvc := range pvcs {
+		pvc.Labels[SurgeInstanceLabelKey] = pod.Name
+		if oldPVC, err := tree.Get(pvc); err != nil {
+			return err
+		} else if oldPVC != nil {
+			continue
+		}
+		if err = tree.Add(pvc); err != nil {
+			return err
+		}
+	}
+	if tree.EventRecorder != nil {
+		tree.EventRecorder.Eventf(its, corev1.EventTypeNormal, EventReasonSurgeInstance,
+			"create surge instance %s for updating the instance %s", name, pod.Name)
+	}
+	return nil
+}
+
+// progressSurgeInstances drives the surge instances through their phases,
+// and returns the names of the instances they stand in for.
+func (r *updateReconciler) progressSurgeInstances(tree *kubebuilderx.ObjectTree, its *workloads.InstanceSet,
+	surges []*corev1.Pod, instances map[string]*corev1.Pod, desiredNameSet sets.Set[string]) (sets.Set[string], bool, error) {
+	names := sets.New[string]()
+	needRetry := false
+	for _, surge := range surges {
+		name := surge.Labels[SurgeInstanceLabelKey]
+		names.Insert(name)
+		if isTerminating(surge) {
+			continue
+		}
+		retry, err := r.progressSurgeInstance(tree, its, surge, instances[name], desiredNameSet.Has(name))
+		if err != nil {
+			return nil, false, err
+		}
+		needRetry = needRetry || retry
+	}
+	return names, needRetry, nil
+}
+
+func (r *updateReconciler) progressSurgeInstance(tree *kubebuilderx.ObjectTree, its *workloads.InstanceSet,
+	surge, pod *corev1.Pod, desired bool) (bool, error) {
+	isPodAvailable := func(pod *corev1.Pod) (bool, bool) {
+		if !intctrlutil.IsPodReady(pod) || !isRoleReady(pod, its.Spec.Roles) {
+			return false, false
+		}
+		// no pod event will trigger the next reconciliation, so retry it
+		available := intctrlutil.IsPodAvailable(pod, its.Spec.MinReadySeconds)
+		return available, !available
+	}
+
+	phase := surgePhase(surge.Annotations[surgePhaseAnnotationKey])
+	if !desired && phase != surgePhaseCreated {
+		// the instance has been scaled in, retire the surge instance directly
+		phase = surgePhaseRejoined
+	}
+	switch phase {
+	case surgePhaseCreated:
+		if !intctrlutil.IsPodReady(surge) {
+			return false, nil
+		}
+		if joined, err := r.memberJoin(tree, its, surge); err != nil || !joined {
+			return !joined, err
+		}
+		return false, r.setSurgePhase(tree, surge, surgePhaseJoined)
+
+	case surgePhaseJoined:
+		if available, retry := isPodAvailable(surge); !available {
+			return retry, nil
+		}
+		if pod != nil && !isTerminating(pod) {
+			if err := r.switchover(tree, its, pod); err != nil {
+				return false, err
+			}
+			if err := r.memberLeave(tree, its, pod); err != nil {
+				return false, err
+			}
+			if err := tree.Delete(pod); err != nil {
+				return false, err
+			}
+		}
+		return false, r.setSurgePhase(tree, surge, surgePhaseReplacing)
+
+	case surgePhaseReplacing:
+		if pod == nil || isTerminating(pod) || !intctrlutil.IsPodReady(pod) {
+			return false, nil
+		}
+		if joined, err := r.memberJoin(tree, its, pod); err != nil || !joined {
+			return !joined, err
+		}
+		return false, r.setSurgePhase(tree, surge, surgePhaseRejoined)
+
+	default:
+		if desired {
+			if pod == nil {
+				return false, nil
+			}
+			if available, retry := isPodAvailable(pod); !available {
+				return retry, nil
+			}
+		}
+		return false, r.retireSurgeInstance(tree, its, surge)
+	}
+}
+
+func (r *updateReconciler) retireSurgeInstance(tree *kubebuilderx.ObjectTree, its *workloads.InstanceSet, surge *corev1.Pod) error {
+	if err := r.switchover(tree, its, surge); err != nil {
+		return err
+	}
+	if err := r.memberLeave(tree, its, surge); err != nil {
+		return err
+	}
+	if err := tree.Delete(surge); err != nil {
+		return err
+	}
+	for _, pvc := range tree.List(&corev1.PersistentVolumeClaim{}) {
+		if pvc.GetLabels()[constant.KBAppPodNameLabelKey] != surge.Name {
+			continue
+		}
+		if err := tree.Delete(pvc); err != nil {
+			return err
+		}
+	}
+	if tree.EventRecorder != nil {
+		tree.EventRecorder.Eventf(its, corev1.EventTypeNormal, EventReasonSurgeInstance,
+			"retire surge instance %s as the instance %s has been updated", surge.Name, surge.Labels[SurgeInstanceLabelKey])
+	}
+	return nil
+}
+
+func (r *updateReconciler) setSurgePhase(tree *kubebuilderx.ObjectTree, surge *corev1.Pod, phase surgePhase) error {
+	surgeCopy := surge.DeepCopy()
+	if surgeCopy.Annotations == nil {
+		surgeCopy.Annotations = map[string]string{}
+	}
+	surgeCopy.Annotations[surgePhaseAnnotationKey] = string(phase)
+	return tree.Update(surgeCopy)
+}
+
+// memberJoin calls the member join action in the non-blocking mode and checks whether it has completed,
+// the data sync of the new member may take a long time, and the member is not counted until the action returns.
+func (r *updateReconciler) memberJoin(tree *kubebuilderx.ObjectTree, its *workloads.InstanceSet, pod *corev1.Pod) (bool, error) {
+	if its.Spec.MembershipReconfiguration == nil || its.Spec.MembershipReconfiguration.MemberJoin == nil {
+		return true, nil
+	}
+	lfa, err := r.newLifecycleAction(its, pod, &kbappsv1.ComponentLifecycleActions{
+		MemberJoin: its.Spec.MembershipReconfiguration.MemberJoin,
+	})
+	if err != nil {
+		return false, err
+	}
+	err = lfa.MemberJoin(tree.Context, nil, &lifecycle.Options{NonBlocking: ptr.To(true)})
+	if errors.Is(err, lifecycle.ErrActionInProgress) {
+		tree.Logger.Info("wait for the member join action to complete for pod", "pod", pod.Name)
+		return false, nil
+	}
+	if err = r.checkMembershipActionError(err, "member join", pod); err != nil {
+		return false, err
+	}
+	tree.Logger.Info("successfully call member join action for pod", "pod", pod.Name)
+	return true, nil
+}
+
+func (r *updateReconciler) memberLeave(tree *kubebuilderx.ObjectTree, its *workloads.InstanceSet, pod *corev1.Pod) error {
+	if its.Spec.MembershipReconfiguration == nil || its.Spec.MembershipReconfiguration.MemberLeave == nil {
+		return nil
+	}
+	lfa, err := r.newLifecycleAction(its, pod, &kbappsv1.ComponentLifecycleActions{
+		MemberLeave: its.Spec.MembershipReconfiguration.MemberLeave,
+	})
+	if err != nil {
+		return err
+	}
+	if err = r.checkMembershipActionError(lfa.MemberLeave(tree.Context, nil, nil), "member leave", pod); err != nil {
+		return err
+	}
+	tree.Logger.Info("successfully call member leave action for pod", "pod", pod.Name)
+	return nil
+}
+
+func (r *updateReconciler) checkMembershipActionError(err error, action string, pod *corev1.Pod) error {
+	if err == nil || errors.Is(err, lifecycle.ErrActionNotDefined) {
+		return nil
+	}
+	if errors.Is(err, lifecycle.ErrPreconditionFailed) {
+		return intctrlutil.NewDelayedRequeueError(time.Second,
+			fmt.Sprintf("precondition not satisfied when calling %s action for pod %s: %s", action, pod.Name, err.Error()))
+	}
+	return err
+}
+
+func (r *updateReconciler) newLifecycleAction(its *workloads.InstanceSet, pod *corev1.Pod,
+	lifecycleActions *kbappsv1.ComponentLifecycleActions) (lifecycle.Lifecycle, error) {
+	clusterName, err := r.clusterName(its)
+	if err != nil {
+		return nil, err
+	}
+	var templateVars map[string]any
+	if its.Spec.TemplateVars != nil {
+		templateVars = make(map[string]any)
+		for k, v := range its.Spec.TemplateVars {
+			templateVars[k] = v
+		}
+	}
+	return lifecycle.New(its.Namespace, clusterName, its.Name, lifecycleActions, templateVars, pod)
+}
@@ -20,14 +20,18 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package instanceset
 
 import (
+	"context"
+	"fmt"
 	"slices"
 	"time"
 
+	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -40,6 +44,8 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/controller/builder"
 	"github.com/apecloud/kubeblocks/pkg/controller/kubebuilderx"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	kbacli "github.com/apecloud/kubeblocks/pkg/kbagent/client"
+	kbagentproto "github.com/apecloud/kubeblocks/pkg/kbagent/proto"
 	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
@@ -410,5 +416,221 @@ var _ = Describe("update reconciler test", func() {
 		It("inplace updates pod resource using resize subresource", func() {
 			testInplacePodVerticalScaling(true)
 		})
+
+		It("updates with surge instances", func() {
+			tree := kubebuilderx.NewObjectTree()
+			its.Spec.PodManagementPolicy = appsv1.ParallelPodManagement
+			maxSurge := intstr.FromInt32(1)
+			its.Spec.InstanceUpdateStrategy = &workloads.InstanceUpdateStrategy{
+				RollingUpdate: &workloads.RollingUpdate{
+					MaxSurge: &maxSurge,
+				},
+			}
+			tree.SetRoot(its)
+
+			prepareForUpdate(tree)
+
+			makePodAvailable := func(name string) *corev1.Pod {
+				object, err := tree.Get(builder.NewPodBuilder(namespace, name).GetObject())
+				Expect(err).Should(BeNil())
+				Expect(object).ShouldNot(BeNil())
+				pod, _ := object.(*corev1.Pod)
+				pod.Status.Phase = corev1.PodRunning
+				pod.Status.Conditions = append(pod.Status.Conditions, getPodReadyCondition())
+				return pod
+			}
+			for _, name := range []string{"bar-0", "bar-1", "bar-2"} {
+				pod := makePodAvailable(name)
+				pod.Labels[appsv1.ControllerRevisionHashLabelKey] = "old-revision"
+			}
+			surgeName := surgeInstanceName("bar-2")
+			getSurgePhase := func() surgePhase {
+				object, err := tree.Get(builder.NewPodBuilder(namespace, surgeName).GetObject())
+				Expect(err).Should(BeNil())
+				Expect(object).ShouldNot(BeNil())
+				return surgePhase(object.GetAnnotations()[surgePhaseAnnotationKey])
+			}
+			reconcile := func() {
+				reconciler = NewUpdateReconciler()
+				res, err := reconciler.Reconcile(tree)
+				Expect(err).Should(BeNil())
+				Expect(res).Should(Equal(kubebuilderx.Continue))
+			}
+
+			By("create a surge instance rather than deleting the instance")
+			reconcile()
+			Expect(tree.List(&corev1.Pod{})).Should(HaveLen(4))
+			Expect(getSurgePhase()).Should(Equal(surgePhaseCreated))
+			Expect(tree.List(&corev1.PersistentVolumeClaim{})).ShouldNot(BeEmpty())
+
+			By("wait for the surge instance to be ready")
+			reconcile()
+			Expect(tree.List(&corev1.Pod{})).Should(HaveLen(4))
+			makePodAvailable(surgeName)
+			reconcile()
+			Expect(getSurgePhase()).Should(Equal(surgePhaseJoined))
+
+			By("delete the instance after the surge instance is available")
+			reconcile()
+			Expect(getSurgePhase()).Should(Equal(surgePhaseReplacing))
+			object, err := tree.Get(builder.NewPodBuilder(namespace, "bar-2").GetObject())
+			Expect(err).Should(BeNil())
+			Expect(object).Should(BeNil())
+
+			By("recreate the instance")
+			reconciler = NewReplicasAlignmentReconciler()
+			_, err = reconciler.Reconcile(tree)
+			Expect(err).Should(BeNil())
+			Expect(tree.List(&corev1.Pod{})).Should(HaveLen(4))
+			reconcile()
+			Expect(getSurgePhase()).Should(Equal(surgePhaseReplacing))
+			makePodAvailable("bar-2")
+			reconcile()
+			Expect(getSurgePhase()).Should(Equal(surgePhaseRejoined))
+
+			By("retire the surge instance")
+			reconcile()
+			pods := tree.List(&corev1.Pod{})
+			Expect(pods).Should(HaveLen(3))
+			Expect(slices.IndexFunc(pods, isSurgeInstance)).Should(BeNumerically("<", 0))
+			for _, pvc := range tree.List(&corev1.PersistentVolumeClaim{}) {
+				Expect(pvc.GetLabels()[constant.KBAppPodNameLabelKey]).ShouldNot(Equal(surgeName))
+			}
+
+			By("continue with the next instance")
+			reconcile()
+			Expect(tree.List(&corev1.Pod{})).Should(HaveLen(4))
+			object, err = tree.Get(builder.NewPodBuilder(namespace, surgeInstanceName("bar-1")).GetObject())
+			Expect(err).Should(BeNil())
+			Expect(object).ShouldNot(BeNil())
+		})
+		It("counts the surge instance after the member join action completes", func() {
+			tree := kubebuilderx.NewObjectTree()
+			tree.Context = ctx
+			its.Labels = map[string]string{constant.AppInstanceLabelKey: name}
+			its.Spec.PodManagementPolicy = appsv1.ParallelPodManagement
+			maxSurge := intstr.FromInt32(1)
+			its.Spec.InstanceUpdateStrategy = &workloads.InstanceUpdateStrategy{
+				RollingUpdate: &workloads.RollingUpdate{
+					MaxSurge: &maxSurge,
+				},
+			}
+			its.Spec.MembershipReconfiguration = &workloads.MembershipReconfiguration{
+				MemberJoin: &kbappsv1.Action{Exec: &kbappsv1.ExecAction{Command: []string{"join"}}},
+			}
+			tree.SetRoot(its)
+
+			prepareForUpdate(tree)
+
+			getPod := func(name string) *corev1.Pod {
+				object, err := tree.Get(builder.NewPodBuilder(namespace, name).GetObject())
+				Expect(err).Should(BeNil())
+				Expect(object).ShouldNot(BeNil())
+				pod, _ := object.(*corev1.Pod)
+				return pod
+			}
+			makePodReady := func(pod *corev1.Pod) {
+				pod.Status.Phase = corev1.PodRunning
+				pod.Status.Conditions = append(pod.Status.Conditions, getPodReadyCondition())
+			}
+			for _, name := range []string{"bar-0", "bar-1", "bar-2"} {
+				pod := getPod(name)
+				makePodReady(pod)
+				pod.Labels[appsv1.ControllerRevisionHashLabelKey] = "old-revision"
+			}
+			surgeName := surgeInstanceName("bar-2")
+
+			By("create a surge instance")
+			reconciler = NewUpdateReconciler()
+			_, err := reconciler.Reconcile(tree)
+			Expect(err).Should(BeNil())
+			makePodReady(getPod(surgeName))
+
+			By("wait for the member join action in progress")
+			joinCompleted := false
+			kbacli.SetMockClient(kbacli.NewMockClient(gomock.NewController(GinkgoT())), nil)
+			defer kbacli.UnsetMockClient()
+			mockClient := kbacli.GetMockClient().(*kbacli.MockClient)
+			mockClient.EXPECT().Action(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, req kbagentproto.ActionRequest) (kbagentproto.ActionResponse, error) {
+					Expect(req.Action).Should(Equal("memberJoin"))
+					Expect(req.NonBlocking).ShouldNot(BeNil())
+					Expect(*req.NonBlocking).Should(BeTrue())
+					if !joinCompleted {
+						return kbagentproto.ActionResponse{Error: kbagentproto.Error2Type(kbagentproto.ErrInProgress)}, nil
+					}
+					return kbagentproto.ActionResponse{}, nil
+				}).AnyTimes()
+			res, err := reconciler.Reconcile(tree)
+			Expect(err).Should(BeNil())
+			Expect(res.RetryAfter).Should(BeNumerically(">", 0))
+			Expect(surgePhase(getPod(surgeName).Annotations[surgePhaseAnnotationKey])).Should(Equal(surgePhaseCreated))
+			Expect(getPod("bar-2")).ShouldNot(BeNil())
+
+			By("count the surge instance after the member join action completes")
+			joinCompleted = true
+			_, err = reconciler.Reconcile(tree)
+			Expect(err).Should(BeNil())
+			Expect(surgePhase(getPod(surgeName).Annotations[surgePhaseAnnotationKey])).Should(Equal(surgePhaseJoined))
+		})
+
+		It("blocks the update outside the maintenance window unless bypassed", func() {
+			tree := kubebuilderx.NewObjectTree()
+			its.Spec.PodManagementPolicy = appsv1.ParallelPodManagement
+			// the window opens 12 hours later
+			its.Spec.MaintenanceWindow = &kbappsv1.MaintenanceWindow{
+				Schedule: fmt.Sprintf("0 %d * * *", (time.Now().UTC().Hour()+12)%24),
+				Duration: metav1.Duration{Duration: time.Hour},
+			}
+			tree.SetRoot(its)
+
+			prepareForUpdate(tree)
+
+			for _, object := range tree.List(&corev1.Pod{}) {
+				pod, ok := object.(*corev1.Pod)
+				Expect(ok).Should(BeTrue())
+				pod.Labels[appsv1.ControllerRevisionHashLabelKey] = "old-revision"
+				pod.Status.Phase = corev1.PodRunning
+				pod.Status.Conditions = append(pod.Status.Conditions, getPodReadyCondition())
+			}
+
+			By("the update is blocked outside the window")
+			blockedTree, err := tree.DeepCopy()
+			Expect(err).Should(BeNil())
+			reconciler = NewUpdateReconciler()
+			_, err = reconciler.Reconcile(blockedTree)
+			Expect(err).Should(BeNil())
+			expectUpdatedPods(blockedTree, []string{})
+			root, ok := blockedTree.GetRoot().(*workloads.InstanceSet)
+			Expect(ok).Should(BeTrue())
+			condition := meta.FindStatusCondition(root.Status.Conditions, string(workloads.InstanceUpdateRestricted))
+			Expect(condition).ShouldNot(BeNil())
+			Expect(condition.Reason).Should(Equal(workloads.ReasonOutOfMaintenanceWindow))
+
+			By("the update is blocked if the bypasses have been removed")
+			removedTree, err := tree.DeepCopy()
+			Expect(err).Should(BeNil())
+			root, ok = removedTree.GetRoot().(*workloads.InstanceSet)
+			Expect(ok).Should(BeTrue())
+			root.Annotations = map[string]string{}
+			intctrlutil.SetMaintenanceWindowBypasses(root.Annotations, nil)
+			_, err = reconciler.Reconcile(removedTree)
+			Expect(err).Should(BeNil())
+			expectUpdatedPods(removedTree, []string{})
+
+			By("the update is allowed if an OpsRequest bypasses the window")
+			bypassedTree, err := tree.DeepCopy()
+			Expect(err).Should(BeNil())
+			root, ok = bypassedTree.GetRoot().(*workloads.InstanceSet)
+			Expect(ok).Should(BeTrue())
+			root.Annotations = map[string]string{}
+			intctrlutil.SetMaintenanceWindowBypasses(root.Annotations, []intctrlutil.MaintenanceWindowBypass{
+				{OpsRequest: "restart", Components: []string{"bar"}, Until: time.Now().Add(time.Hour)},
+			})
+			res, err := reconciler.Reconcile(bypassedTree)
+			Expect(err).Should(BeNil())
+			Expect(res).Should(Equal(kubebuilderx.Continue))
+			expectUpdatedPods(bypassedTree, []string{"bar-2"})
+		})
 	})
 })
@@ -24,11 +24,15 @@ const (
 	WorkloadsInstanceLabelKey  = "workloads.kubeblocks.io/instance"
 
 	RoleLabelKey = "kubeblocks.io/role"
+
+	// SurgeInstanceLabelKey marks a surge instance, the value is the name of the instance it stands in for.
+	SurgeInstanceLabelKey = "workloads.kubeblocks.io/surge-for"
 )
 
 const (
 	EventReasonInvalidSpec   = "InvalidSpec"
 	EventReasonStrictInPlace = "StrictInPlace"
+	EventReasonSurgeInstance = "SurgeInstance"
 )
 
 const (
@@ -43,6 +47,8 @@ const (
 	FeatureGateIgnorePodVerticalScaling = "IGNORE_POD_VERTICAL_SCALING"
 
 	finalizer = "instanceset.workloads.kubeblocks.io/finalizer"
+
+	surgePhaseAnnotationKey = "workloads.kubeblocks.io/surge-phase"
 )
 
 // AnnotationScope defines scope that annotations belong to.
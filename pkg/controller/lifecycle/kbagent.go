@@ -23,6 +23,7 @@ import (
 	"context"
 	"fmt"
 	"math/rand"
+	"time"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -37,6 +38,8 @@ import (
 	kbagt "github.com/apecloud/kubeblocks/pkg/kbagent"
 	kbacli "github.com/apecloud/kubeblocks/pkg/kbagent/client"
 	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+	"github.com/apecloud/kubeblocks/pkg/metrics"
+	"github.com/apecloud/kubeblocks/pkg/tracing"
 )
 
 type lifecycleAction interface {
@@ -113,6 +116,14 @@ func (a *kbagent) MemberLeave(ctx context.Context, cli client.Reader, opts *Opti
 	return a.ignoreOutput(a.checkedCallAction(ctx, cli, a.lifecycleActions.MemberLeave, lfa, opts))
 }
 
+func (a *kbagent) Readonly(ctx context.Context, cli client.Reader, opts *Options) error {
+	return a.ignoreOutput(a.checkedCallAction(ctx, cli, a.lifecycleActions.Readonly, &readonly{}, opts))
+}
+
+func (a *kbagent) Readwrite(ctx context.Context, cli client.Reader, opts *Options) error {
+	return a.ignoreOutput(a.checkedCallAction(ctx, cli, a.lifecycleActions.Readwrite, &readwrite{}, opts))
+}
+
 func (a *kbagent) Reconfigure(ctx context.Context, cli client.Reader, opts *Options, args map[string]string) error {
 	lfa := &reconfigure{
 		args: args,
@@ -142,7 +153,7 @@ func (a *kbagent) ignoreOutput(_ []byte, err error) error {
 }
 
 func (a *kbagent) checkedCallAction(ctx context.Context, cli client.Reader, spec *appsv1.Action, lfa lifecycleAction, opts *Options) ([]byte, error) {
-	if spec == nil || spec.Exec == nil {
+	if !spec.Defined() {
 		return nil, errors.Wrap(ErrActionNotDefined, lfa.name())
 	}
 	if err := a.precondition(ctx, cli, spec); err != nil {
@@ -153,7 +164,7 @@ func (a *kbagent) checkedCallAction(ctx context.Context, cli client.Reader, spec
 }
 
 func (a *kbagent) checkedCallProbe(ctx context.Context, cli client.Reader, spec *appsv1.Probe, lfa lifecycleAction, opts *Options) ([]byte, error) {
-	if spec == nil || spec.Exec == nil {
+	if spec == nil || !spec.Defined() {
 		return nil, errors.Wrap(ErrActionNotDefined, lfa.name())
 	}
 	return a.checkedCallAction(ctx, cli, &spec.Action, lfa, opts)
@@ -222,7 +233,12 @@ func (a *kbagent) callAction(ctx context.Context, cli client.Reader, spec *appsv
 	if err1 != nil {
 		return nil, err1
 	}
-	return a.callActionWithSelector(ctx, spec, lfa, req)
+	ctx, span := tracing.StartLifecycleAction(ctx, a.namespace, a.clusterName, a.compName, lfa.name())
+	start := time.Now()
+	rsp, err := a.callActionWithSelector(ctx, spec, lfa, req)
+	metrics.ObserveLifecycleAction(lfa.name(), time.Since(start), err)
+	tracing.End(span, err)
+	return rsp, err
 }
 
 func (a *kbagent) buildActionRequest(ctx context.Context, cli client.Reader, lfa lifecycleAction, opts *Options) (*proto.ActionRequest, error) {
@@ -315,6 +331,7 @@ func (a *kbagent) callActionWithSelector(ctx context.Context, spec *appsv1.Actio
 			continue // not kb-agent container and port defined, for test only
 		}
 
+		tracing.AddEvent(ctx, "CallKBAgent", tracing.PodKey.String(pod.Name))
 		rsp, err := cli.Action(ctx, *req)
 		_ = cli.Close()
 
@@ -39,6 +39,31 @@ func FileTemplateChanges(created, removed, updated string) map[string]string {
 	}
 }
 
+type readonly struct{}
+
+var _ lifecycleAction = &readonly{}
+
+func (a *readonly) name() string {
+	return "readonly"
+}
+
+func (a *readonly) parameters(ctx context.Context, cli client.Reader) (map[string]string, error) {
+	// the action is executed on the target replica, which has access to its own variables, e.g. KB_POD_FQDN.
+	return nil, nil
+}
+
+type readwrite struct{}
+
+var _ lifecycleAction = &readwrite{}
+
+func (a *readwrite) name() string {
+	return "readwrite"
+}
+
+func (a *readwrite) parameters(ctx context.Context, cli client.Reader) (map[string]string, error) {
+	return nil, nil
+}
+
 type reconfigure struct {
 	args map[string]string
 }
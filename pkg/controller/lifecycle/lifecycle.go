@@ -48,9 +48,9 @@ type Lifecycle interface {
 
 	MemberLeave(ctx context.Context, cli client.Reader, opts *Options) error
 
-	// Readonly(ctx context.Context, cli client.Reader, opts *Options) error
+	Readonly(ctx context.Context, cli client.Reader, opts *Options) error
 
-	// Readwrite(ctx context.Context, cli client.Reader, opts *Options) error
+	Readwrite(ctx context.Context, cli client.Reader, opts *Options) error
 
 	Reconfigure(ctx context.Context, cli client.Reader, opts *Options, args map[string]string) error
 
@@ -0,0 +1,121 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package multicluster
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// capacityCacheTTL is how long the free capacity of a context is reused before it is re-calculated,
+// listing all the nodes and pods of a k8s cluster is expensive and the capacity is only a hint for placement.
+const capacityCacheTTL = time.Minute
+
+type capacityEntry struct {
+	capacity  corev1.ResourceList
+	timestamp time.Time
+}
+
+// capacityCache caches the free capacity of contexts.
+type capacityCache struct {
+	ttl     time.Duration
+	now     func() time.Time
+	mu      sync.Mutex
+	entries map[string]capacityEntry
+}
+
+func newCapacityCache(ttl time.Duration) *capacityCache {
+	return &capacityCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[string]capacityEntry),
+	}
+}
+
+func (c *capacityCache) get(ctx context.Context, context string, cli client.Reader) (corev1.ResourceList, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[context]
+	c.mu.Unlock()
+	if ok && c.now().Sub(entry.timestamp) < c.ttl {
+		return entry.capacity.DeepCopy(), nil
+	}
+
+	capacity, err := freeCapacity(ctx, cli)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	c.entries[context] = capacityEntry{capacity: capacity, timestamp: c.now()}
+	c.mu.Unlock()
+	return capacity.DeepCopy(), nil
+}
+
+// freeCapacity calculates the free capacity of a k8s cluster, which is the allocatable resources of
+// the schedulable nodes minus the requested resources of the non-terminated pods.
+func freeCapacity(ctx context.Context, cli client.Reader) (corev1.ResourceList, error) {
+	nodes := &corev1.NodeList{}
+	if err := cli.List(ctx, nodes); err != nil {
+		return nil, err
+	}
+	pods := &corev1.PodList{}
+	if err := cli.List(ctx, pods); err != nil {
+		return nil, err
+	}
+
+	free := corev1.ResourceList{}
+	schedulable := map[string]bool{}
+	for _, node := range nodes.Items {
+		if node.Spec.Unschedulable {
+			continue
+		}
+		schedulable[node.Name] = true
+		for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+			if q, ok := node.Status.Allocatable[name]; ok {
+				total := free[name]
+				total.Add(q)
+				free[name] = total
+			}
+		}
+	}
+	for _, pod := range pods.Items {
+		if !schedulable[pod.Spec.NodeName] || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		for _, c := range pod.Spec.Containers {
+			for name, total := range free {
+				if q, ok := c.Resources.Requests[name]; ok {
+					total.Sub(q)
+					free[name] = total
+				}
+			}
+		}
+	}
+	for name, total := range free {
+		if total.Sign() < 0 {
+			total.Set(0)
+			free[name] = total
+		}
+	}
+	return free, nil
+}
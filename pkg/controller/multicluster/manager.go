@@ -20,9 +20,11 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package multicluster
 
 import (
+	"context"
 	"fmt"
 
 	"golang.org/x/exp/maps"
+	corev1 "k8s.io/api/core/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
@@ -36,6 +38,15 @@ type Manager interface {
 
 	GetContexts() []string
 
+	// GetContextLabels returns the labels of the context.
+	GetContextLabels(context string) map[string]string
+
+	// IsContextAvailable checks whether the context is available.
+	IsContextAvailable(context string) bool
+
+	// GetContextCapacity returns the free capacity of the context.
+	GetContextCapacity(ctx context.Context, context string) (corev1.ResourceList, error)
+
 	Bind(mgr ctrl.Manager) error
 
 	Own(b *builder.Builder, obj, owner client.Object) Manager
@@ -44,8 +55,11 @@ type Manager interface {
 }
 
 type manager struct {
-	cli    client.Client
-	caches map[string]cache.Cache
+	cli        client.Client
+	clients    map[string]client.Client
+	caches     map[string]cache.Cache
+	labels     map[string]map[string]string
+	capacities *capacityCache
 }
 
 var _ Manager = &manager{}
@@ -58,6 +72,22 @@ func (m *manager) GetContexts() []string {
 	return maps.Keys(m.caches)
 }
 
+func (m *manager) GetContextLabels(context string) map[string]string {
+	return m.labels[context]
+}
+
+func (m *manager) IsContextAvailable(context string) bool {
+	cli, ok := m.clients[context]
+	return ok && !isUnavailableClient(cli)
+}
+
+func (m *manager) GetContextCapacity(ctx context.Context, context string) (corev1.ResourceList, error) {
+	if !m.IsContextAvailable(context) {
+		return nil, fmt.Errorf("context %s is unavailable", context)
+	}
+	return m.capacities.get(ctx, context, m.clients[context])
+}
+
 func (m *manager) Bind(mgr ctrl.Manager) error {
 	for k, c := range m.caches {
 		if c != nil {
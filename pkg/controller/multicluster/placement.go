@@ -21,6 +21,7 @@ package multicluster
 
 import (
 	"context"
+	"slices"
 	"strings"
 
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -43,16 +44,19 @@ func FromContext(ctx context.Context) (string, error) {
 // TODO: replace it with a new client option and automatically perform the assignment based on ordinal.
 
 func Assign(ctx context.Context, obj client.Object, ordinal func() int) client.Object {
-	// has been set
-	if obj.GetAnnotations() != nil && obj.GetAnnotations()[constant.KBAppMultiClusterPlacementKey] != "" {
-		return obj
-	}
-
 	placement, err := FromContext(ctx)
 	if err != nil || len(placement) == 0 {
 		return obj
 	}
 	contexts := strings.Split(placement, ",")
+
+	// has been set, and the context is still in the placement. otherwise the context has been replaced,
+	// re-assign the object to the context at the same position.
+	if obj.GetAnnotations() != nil && obj.GetAnnotations()[constant.KBAppMultiClusterPlacementKey] != "" {
+		if slices.Contains(contexts, obj.GetAnnotations()[constant.KBAppMultiClusterPlacementKey]) {
+			return obj
+		}
+	}
 	context := contexts[ordinal()%len(contexts)]
 
 	if obj.GetAnnotations() == nil {
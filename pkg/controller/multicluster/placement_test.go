@@ -0,0 +1,70 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package multicluster
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+func TestAssign(t *testing.T) {
+	ctx := IntoContext(context.Background(), "c1,c4,c3")
+	pod := func(name, context string) *corev1.Pod {
+		p := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		if len(context) > 0 {
+			p.Annotations = map[string]string{constant.KBAppMultiClusterPlacementKey: context}
+		}
+		return p
+	}
+	ordinal := func(o int) func() int {
+		return func() int { return o }
+	}
+
+	cases := []struct {
+		name     string
+		obj      *corev1.Pod
+		ordinal  int
+		expected string
+	}{
+		{"not assigned", pod("p-0", ""), 0, "c1"},
+		{"not assigned with ordinal overflow", pod("p-4", ""), 4, "c4"},
+		{"assigned", pod("p-0", "c3"), 0, "c3"},
+		{"assigned to a replaced context", pod("p-1", "c2"), 1, "c4"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			obj := Assign(ctx, c.obj, ordinal(c.ordinal))
+			if got := obj.GetAnnotations()[constant.KBAppMultiClusterPlacementKey]; got != c.expected {
+				t.Errorf("expected context %s, got %s", c.expected, got)
+			}
+		})
+	}
+
+	// no placement in the context
+	obj := Assign(context.Background(), pod("p-0", "c2"), ordinal(0))
+	if got := obj.GetAnnotations()[constant.KBAppMultiClusterPlacementKey]; got != "c2" {
+		t.Errorf("expected context c2, got %s", got)
+	}
+}
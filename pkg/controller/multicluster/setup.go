@@ -41,11 +41,16 @@ var (
 	scheme *runtime.Scheme
 )
 
-func Setup(scheme *runtime.Scheme, cfg *rest.Config, cli client.Client, kubeConfig, contexts, disabledContexts string) (Manager, error) {
+func Setup(scheme *runtime.Scheme, cfg *rest.Config, cli client.Client, kubeConfig, contexts, disabledContexts, contextLabels string) (Manager, error) {
 	if len(contexts) == 0 {
 		return nil, nil
 	}
 
+	labels, err := parseContextLabels(contextLabels)
+	if err != nil {
+		return nil, err
+	}
+
 	mcc, err := newClientNCache(scheme, kubeConfig, contexts, disabledContexts)
 	if err != nil {
 		return nil, err
@@ -79,11 +84,40 @@ func Setup(scheme *runtime.Scheme, cfg *rest.Config, cli client.Client, kubeConf
 	}
 	setupScheme(scheme)
 	return &manager{
-		cli:    NewClient(cli, clients()),
-		caches: caches(),
+		cli:        NewClient(cli, clients()),
+		clients:    clients(),
+		caches:     caches(),
+		labels:     labels,
+		capacities: newCapacityCache(capacityCacheTTL),
 	}, nil
 }
 
+// parseContextLabels parses the labels of contexts, in the format of
+// "context1:key1=value1;key2=value2,context2:key1=value3".
+func parseContextLabels(contextLabels string) (map[string]map[string]string, error) {
+	labels := make(map[string]map[string]string)
+	if len(strings.TrimSpace(contextLabels)) == 0 {
+		return labels, nil
+	}
+	for _, entry := range strings.Split(contextLabels, ",") {
+		context, kvs, ok := strings.Cut(strings.TrimSpace(entry), ":")
+		if !ok || len(context) == 0 {
+			return nil, fmt.Errorf("invalid context labels: %s", entry)
+		}
+		if _, ok := labels[context]; !ok {
+			labels[context] = make(map[string]string)
+		}
+		for _, kv := range strings.Split(kvs, ";") {
+			k, v, ok := strings.Cut(strings.TrimSpace(kv), "=")
+			if !ok || len(k) == 0 {
+				return nil, fmt.Errorf("invalid label %s of context %s", kv, context)
+			}
+			labels[context][k] = v
+		}
+	}
+	return labels, nil
+}
+
 func setupScheme(s *runtime.Scheme) {
 	scheme = s
 }
@@ -0,0 +1,140 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package multicluster
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestParseContextLabels(t *testing.T) {
+	labels, err := parseContextLabels("c1:region=east;zone=a, c2:region=west")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]map[string]string{
+		"c1": {"region": "east", "zone": "a"},
+		"c2": {"region": "west"},
+	}
+	if !reflect.DeepEqual(labels, expected) {
+		t.Errorf("expected %v, got %v", expected, labels)
+	}
+
+	for _, invalid := range []string{"c1", ":region=east", "c1:region"} {
+		if _, err := parseContextLabels(invalid); err == nil {
+			t.Errorf("expected error for %q", invalid)
+		}
+	}
+}
+
+func TestFreeCapacity(t *testing.T) {
+	node := func(name string, unschedulable bool) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       corev1.NodeSpec{Unschedulable: unschedulable},
+			Status: corev1.NodeStatus{
+				Allocatable: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("4"),
+					corev1.ResourceMemory: resource.MustParse("8Gi"),
+				},
+			},
+		}
+	}
+	pod := func(name, nodeName string, phase corev1.PodPhase) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+			Spec: corev1.PodSpec{
+				NodeName: nodeName,
+				Containers: []corev1.Container{{
+					Name: "c",
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("1"),
+							corev1.ResourceMemory: resource.MustParse("2Gi"),
+						},
+					},
+				}},
+			},
+			Status: corev1.PodStatus{Phase: phase},
+		}
+	}
+	cli := fake.NewClientBuilder().WithObjects(
+		node("n1", false), node("n2", false), node("n3", true),
+		pod("p1", "n1", corev1.PodRunning),
+		pod("p2", "n3", corev1.PodRunning),
+		pod("p3", "n2", corev1.PodSucceeded),
+	).Build()
+
+	free, err := freeCapacity(context.Background(), cli)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cpu := free[corev1.ResourceCPU]; cpu.Cmp(resource.MustParse("7")) != 0 {
+		t.Errorf("expected free cpu 7, got %s", cpu.String())
+	}
+	if mem := free[corev1.ResourceMemory]; mem.Cmp(resource.MustParse("14Gi")) != 0 {
+		t.Errorf("expected free memory 14Gi, got %s", mem.String())
+	}
+}
+
+func TestCapacityCache(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "n1"},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")},
+		},
+	}
+	cli := fake.NewClientBuilder().WithObjects(node).Build()
+
+	now := time.Now()
+	cache := newCapacityCache(time.Minute)
+	cache.now = func() time.Time { return now }
+
+	cpu := func() string {
+		free, err := cache.get(context.Background(), "ctx", cli)
+		if err != nil {
+			t.Fatal(err)
+		}
+		q := free[corev1.ResourceCPU]
+		return q.String()
+	}
+	if got := cpu(); got != "4" {
+		t.Fatalf("expected free cpu 4, got %s", got)
+	}
+
+	if err := cli.Delete(context.Background(), node); err != nil {
+		t.Fatal(err)
+	}
+	if got := cpu(); got != "4" {
+		t.Errorf("expected the cached free cpu 4 within ttl, got %s", got)
+	}
+
+	now = now.Add(time.Minute)
+	if got := cpu(); got != "0" {
+		t.Errorf("expected the free cpu to be re-calculated after ttl, got %s", got)
+	}
+}
@@ -173,8 +173,15 @@ func buildShardTemplates(clusterName string, sharding *appsv1.ClusterSharding, s
 		nameToIndex[defaultShardTemplateName] = len(templates) - 1
 	}
 
+	addShard := func(idx int, compName string) {
+		spec := templates[idx].template.DeepCopy()
+		spec.Name, _ = strings.CutPrefix(compName, fmt.Sprintf("%s-", clusterName))
+		templates[idx].shards = append(templates[idx].shards, spec)
+	}
+
 	offline := sets.New(sharding.Offline...)
 	takeOverByTemplate := shardNamesTakeOverByTemplateMap(clusterName, sharding)
+	orphans := make([]string, 0)
 	for _, comp := range shardingComps {
 		if model.IsObjectDeleting(&comp) || offline.Has(comp.Name) {
 			continue
@@ -185,18 +192,31 @@ func buildShardTemplates(clusterName string, sharding *appsv1.ClusterSharding, s
 				tplName = name
 			}
 		}
-		if tplName == defaultShardTemplateName {
+		if _, ok := nameToIndex[tplName]; !ok || tplName == defaultShardTemplateName {
 			if name, ok := takeOverByTemplate[comp.Name]; ok {
 				tplName = name
 			}
 		}
 		idx, ok := nameToIndex[tplName]
 		if !ok {
+			if tplName != defaultShardTemplateName {
+				orphans = append(orphans, comp.Name)
+			}
 			continue // ignore the component
 		}
-		spec := templates[idx].template.DeepCopy()
-		spec.Name, _ = strings.CutPrefix(comp.Name, fmt.Sprintf("%s-", clusterName))
-		templates[idx].shards = append(templates[idx].shards, spec)
+		addShard(idx, comp.Name)
+	}
+
+	// the shards whose template has been removed are adopted by the default template if it still has room,
+	// otherwise they will be scaled in.
+	if idx, ok := nameToIndex[defaultShardTemplateName]; ok {
+		slices.Sort(orphans)
+		for _, compName := range orphans {
+			if len(templates[idx].shards) >= int(templates[idx].count) {
+				break
+			}
+			addShard(idx, compName)
+		}
 	}
 
 	slices.SortFunc(templates, func(a, b *shardTemplate) int {
@@ -357,6 +357,79 @@ var _ = Describe("sharding", func() {
 			Expect(specs[defaultShardTemplateName][0].Name).Should(HaveSuffix(ids[9])) // runningComp1 belongs to the shard template
 		})
 
+		It("scale in - adopt by the default template", func() {
+			runningComp1 := appsv1.Component{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: fmt.Sprintf("%s-%s-%s", clusterName, shardingName, ids[8]),
+					Labels: map[string]string{
+						constant.KBAppShardTemplateLabelKey: shardTemplateName, // shard template
+					},
+				},
+			}
+			runningComp2 := appsv1.Component{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: fmt.Sprintf("%s-%s-%s", clusterName, shardingName, ids[9]),
+					Labels: map[string]string{
+						constant.KBAppShardTemplateLabelKey: defaultShardTemplateName,
+					},
+				},
+			}
+			sharding := &appsv1.ClusterSharding{
+				Name:   shardingName,
+				Shards: 2, // still 2 shards
+				Template: appsv1.ClusterComponentSpec{
+					Replicas: 3,
+				},
+				// the shard template is removed
+			}
+
+			specs, err := buildShardingCompSpecs(clusterName, sharding, []appsv1.Component{runningComp1, runningComp2})
+			Expect(err).Should(Succeed())
+
+			Expect(len(specs)).Should(BeEquivalentTo(1))
+			Expect(specs).Should(HaveKey(defaultShardTemplateName))
+			Expect(specs[defaultShardTemplateName]).Should(HaveLen(2))
+			Expect(specs[defaultShardTemplateName][0].Name).Should(HaveSuffix(ids[9]))
+			Expect(specs[defaultShardTemplateName][0].Replicas).Should(Equal(int32(3)))
+			Expect(specs[defaultShardTemplateName][1].Name).Should(HaveSuffix(ids[8])) // runningComp1 is adopted by the default template
+			Expect(specs[defaultShardTemplateName][1].Replicas).Should(Equal(int32(3)))
+		})
+
+		It("take over - from the removed template", func() {
+			runningComp1 := appsv1.Component{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: fmt.Sprintf("%s-%s-%s", clusterName, shardingName, ids[8]),
+					Labels: map[string]string{
+						constant.KBAppShardTemplateLabelKey: fmt.Sprintf("%s-0", shardTemplateName), // removed shard template
+					},
+				},
+			}
+			sharding := &appsv1.ClusterSharding{
+				Name:   shardingName,
+				Shards: 1,
+				Template: appsv1.ClusterComponentSpec{
+					Replicas: 3,
+				},
+				ShardTemplates: []appsv1.ShardTemplate{
+					{
+						Name:     shardTemplateName,
+						Shards:   ptr.To[int32](1),
+						ShardIDs: []string{ids[8]}, // take over
+						Replicas: ptr.To[int32](5),
+					},
+				},
+			}
+
+			specs, err := buildShardingCompSpecs(clusterName, sharding, []appsv1.Component{runningComp1})
+			Expect(err).Should(Succeed())
+
+			Expect(len(specs)).Should(BeEquivalentTo(1))
+			Expect(specs).Should(HaveKey(shardTemplateName))
+			Expect(specs[shardTemplateName]).Should(HaveLen(1))
+			Expect(specs[shardTemplateName][0].Name).Should(HaveSuffix(ids[8]))
+			Expect(specs[shardTemplateName][0].Replicas).Should(Equal(int32(5)))
+		})
+
 		It("scale in - offline", func() {
 			runningComp1 := appsv1.Component{
 				ObjectMeta: metav1.ObjectMeta{
@@ -0,0 +1,171 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package controllerutil
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxCronSearchDays limits the search of the next schedule time, it covers the leap years.
+const maxCronSearchDays = 366 * 5
+
+// cronSchedule is a parsed standard Cron expression with five fields: minute, hour, day of month, month and day of week.
+type cronSchedule struct {
+	location *time.Location
+	minutes  map[int]bool
+	hours    map[int]bool
+	days     map[int]bool
+	months   map[int]bool
+	weekdays map[int]bool
+	// whether the day of month or day of week is restricted, see the man page of crontab(5).
+	dayRestricted     bool
+	weekdayRestricted bool
+}
+
+// parseCronSchedule parses the Cron expression with an optional "CRON_TZ=" or "TZ=" prefix for the time zone.
+func parseCronSchedule(expression string) (*cronSchedule, error) {
+	schedule := &cronSchedule{location: time.UTC}
+	expression = strings.TrimSpace(expression)
+	if strings.HasPrefix(expression, "TZ=") || strings.HasPrefix(expression, "CRON_TZ=") {
+		i := strings.Index(expression, " ")
+		if i < 0 {
+			return nil, fmt.Errorf("invalid cron expression: %s", expression)
+		}
+		location, err := time.LoadLocation(expression[strings.Index(expression, "=")+1 : i])
+		if err != nil {
+			return nil, err
+		}
+		schedule.location = location
+		expression = strings.TrimSpace(expression[i:])
+	}
+	fields := strings.Fields(expression)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid cron expression, expect 5 fields but got %d: %s", len(fields), expression)
+	}
+	var err error
+	if schedule.minutes, err = parseCronField(fields[0], 0, 59); err != nil {
+		return nil, err
+	}
+	if schedule.hours, err = parseCronField(fields[1], 0, 23); err != nil {
+		return nil, err
+	}
+	if schedule.days, err = parseCronField(fields[2], 1, 31); err != nil {
+		return nil, err
+	}
+	if schedule.months, err = parseCronField(fields[3], 1, 12); err != nil {
+		return nil, err
+	}
+	if schedule.weekdays, err = parseCronField(fields[4], 0, 7); err != nil {
+		return nil, err
+	}
+	// both 0 and 7 stand for Sunday
+	if schedule.weekdays[7] {
+		schedule.weekdays[0] = true
+	}
+	schedule.dayRestricted = fields[2] != "*" && fields[2] != "?"
+	schedule.weekdayRestricted = fields[4] != "*" && fields[4] != "?"
+	return schedule, nil
+}
+
+// parseCronField parses a Cron field, which is a comma-separated list of "*", "n", "n-m" with an optional "/step".
+func parseCronField(field string, min, max int) (map[int]bool, error) {
+	values := map[int]bool{}
+	for _, item := range strings.Split(field, ",") {
+		rangeExpr, step := item, 1
+		if i := strings.Index(item, "/"); i >= 0 {
+			var err error
+			if step, err = strconv.Atoi(item[i+1:]); err != nil || step <= 0 {
+				return nil, fmt.Errorf("invalid step in cron field: %s", field)
+			}
+			rangeExpr = item[:i]
+		}
+		start, end := min, max
+		switch {
+		case rangeExpr == "*" || rangeExpr == "?":
+		case strings.Contains(rangeExpr, "-"):
+			bounds := strings.SplitN(rangeExpr, "-", 2)
+			var err1, err2 error
+			start, err1 = strconv.Atoi(bounds[0])
+			end, err2 = strconv.Atoi(bounds[1])
+			if err1 != nil || err2 != nil {
+				return nil, fmt.Errorf("invalid range in cron field: %s", field)
+			}
+		default:
+			value, err := strconv.Atoi(rangeExpr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value in cron field: %s", field)
+			}
+			start = value
+			if strings.Contains(item, "/") {
+				end = max
+			} else {
+				end = value
+			}
+		}
+		if start < min || end > max || start > end {
+			return nil, fmt.Errorf("value out of range [%d, %d] in cron field: %s", min, max, field)
+		}
+		for v := start; v <= end; v += step {
+			values[v] = true
+		}
+	}
+	return values, nil
+}
+
+func (s *cronSchedule) matchDay(t time.Time) bool {
+	if !s.months[int(t.Month())] {
+		return false
+	}
+	dayMatched, weekdayMatched := s.days[t.Day()], s.weekdays[int(t.Weekday())]
+	if s.dayRestricted && s.weekdayRestricted {
+		return dayMatched || weekdayMatched
+	}
+	return dayMatched && weekdayMatched
+}
+
+func (s *cronSchedule) Next(after time.Time) time.Time {
+	t := after.In(s.location).Truncate(time.Minute).Add(time.Minute)
+	for i := 0; i < maxCronSearchDays; i++ {
+		year, month, day := t.Date()
+		if s.matchDay(t) {
+			for ; t.Day() == day; t = t.Add(time.Minute) {
+				if s.hours[t.Hour()] && s.minutes[t.Minute()] {
+					return t
+				}
+			}
+		}
+		t = time.Date(year, month, day+1, 0, 0, 0, 0, s.location)
+	}
+	return time.Time{}
+}
+
+// CronSchedule is a parsed Cron expression.
+type CronSchedule interface {
+	// Next returns the first schedule time after the given time, or the zero time if not found.
+	Next(after time.Time) time.Time
+}
+
+// ParseCronExpression parses the Cron expression, with an optional "CRON_TZ=" or "TZ=" prefix for the time zone.
+func ParseCronExpression(expression string) (CronSchedule, error) {
+	return parseCronSchedule(expression)
+}
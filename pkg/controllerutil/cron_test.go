@@ -0,0 +1,62 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package controllerutil
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCronSchedule(t *testing.T) {
+	for _, expr := range []string{"0 2 * * *", "*/15 1-3 * * 1-5", "CRON_TZ=Asia/Shanghai 30 22 1,15 * 0", "TZ=UTC 0 0 * 1/2 7"} {
+		if _, err := parseCronSchedule(expr); err != nil {
+			t.Errorf("unexpected error for %q: %v", expr, err)
+		}
+	}
+	for _, expr := range []string{"", "0 2 * *", "60 * * * *", "0 24 * * *", "0 0 0 * *", "*/0 * * * *", "CRON_TZ=Invalid/Zone 0 0 * * *"} {
+		if _, err := parseCronSchedule(expr); err == nil {
+			t.Errorf("expected error for %q", expr)
+		}
+	}
+}
+
+func TestCronScheduleNext(t *testing.T) {
+	base := time.Date(2024, 1, 1, 10, 30, 0, 0, time.UTC) // Monday
+	cases := []struct {
+		expr   string
+		expect time.Time
+	}{
+		{"0 2 * * *", time.Date(2024, 1, 2, 2, 0, 0, 0, time.UTC)},
+		{"45 10 * * *", time.Date(2024, 1, 1, 10, 45, 0, 0, time.UTC)},
+		{"0 0 * * 6", time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 0 15 * 0", time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC)},
+		{"CRON_TZ=Asia/Shanghai 0 20 * * *", time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)},
+	}
+	for _, c := range cases {
+		schedule, err := parseCronSchedule(c.expr)
+		if err != nil {
+			t.Fatalf("unexpected error for %q: %v", c.expr, err)
+		}
+		if next := schedule.Next(base); !next.Equal(c.expect) {
+			t.Errorf("next of %q: expect %v, got %v", c.expr, c.expect, next)
+		}
+	}
+}
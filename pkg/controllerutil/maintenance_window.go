@@ -0,0 +1,128 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package controllerutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+// ValidateMaintenanceWindow validates the schedule and duration of the maintenance window.
+func ValidateMaintenanceWindow(window *appsv1.MaintenanceWindow) error {
+	if window == nil {
+		return nil
+	}
+	if window.Duration.Duration <= 0 {
+		return fmt.Errorf("the duration of the maintenance window must be positive")
+	}
+	schedule, err := parseCronSchedule(window.Schedule)
+	if err != nil {
+		return err
+	}
+	if schedule.Next(time.Now()).IsZero() {
+		return fmt.Errorf("the maintenance window never opens: %s", window.Schedule)
+	}
+	return nil
+}
+
+// CheckMaintenanceWindow checks whether the maintenance window is open at the given time.
+// It returns the time when the window closes if it's open, or the time when the window opens next if it's closed.
+// A nil window is always open.
+func CheckMaintenanceWindow(window *appsv1.MaintenanceWindow, now time.Time) (bool, time.Time, error) {
+	if window == nil {
+		return true, time.Time{}, nil
+	}
+	schedule, err := parseCronSchedule(window.Schedule)
+	if err != nil {
+		return false, time.Time{}, err
+	}
+	// the window is open if it opened within the last duration.
+	openTime := schedule.Next(now.Add(-window.Duration.Duration))
+	if openTime.IsZero() {
+		return false, time.Time{}, fmt.Errorf("the maintenance window never opens: %s", window.Schedule)
+	}
+	if !openTime.After(now) {
+		return true, openTime.Add(window.Duration.Duration), nil
+	}
+	return false, openTime, nil
+}
+
+// MaintenanceWindowBypass records an OpsRequest that bypasses the maintenance window,
+// the instances of the components it operates on can be updated outside the window until it completes or expires.
+type MaintenanceWindowBypass struct {
+	OpsRequest string    `json:"opsRequest"`
+	Components []string  `json:"components,omitempty"`
+	Until      time.Time `json:"until"`
+}
+
+// GetMaintenanceWindowBypasses returns the bypasses recorded in the annotation.
+func GetMaintenanceWindowBypasses(annotations map[string]string) []MaintenanceWindowBypass {
+	value, ok := annotations[constant.MaintenanceWindowBypassAnnotationKey]
+	if !ok || len(value) == 0 {
+		return nil
+	}
+	var bypasses []MaintenanceWindowBypass
+	if err := json.Unmarshal([]byte(value), &bypasses); err != nil {
+		return nil
+	}
+	return bypasses
+}
+
+// SetMaintenanceWindowBypasses records the bypasses in the annotation, the annotation is kept even if there is no bypass,
+// to overwrite the ones inherited by the components and workloads.
+func SetMaintenanceWindowBypasses(annotations map[string]string, bypasses []MaintenanceWindowBypass) {
+	if bypasses == nil {
+		bypasses = []MaintenanceWindowBypass{}
+	}
+	value, _ := json.Marshal(bypasses)
+	annotations[constant.MaintenanceWindowBypassAnnotationKey] = string(value)
+}
+
+// ComponentMaintenanceWindowBypasses returns the bypasses of the component or the sharding, and whether the annotation exists.
+func ComponentMaintenanceWindowBypasses(annotations map[string]string, compNames ...string) ([]MaintenanceWindowBypass, bool) {
+	if _, ok := annotations[constant.MaintenanceWindowBypassAnnotationKey]; !ok {
+		return nil, false
+	}
+	bypasses := []MaintenanceWindowBypass{}
+	for _, bypass := range GetMaintenanceWindowBypasses(annotations) {
+		for _, compName := range compNames {
+			if len(compName) > 0 && slices.Contains(bypass.Components, compName) {
+				bypasses = append(bypasses, bypass)
+				break
+			}
+		}
+	}
+	return bypasses, true
+}
+
+// IsMaintenanceWindowBypassed checks whether the maintenance window is bypassed by any OpsRequest recorded in the annotation at the given time.
+func IsMaintenanceWindowBypassed(annotations map[string]string, now time.Time) bool {
+	for _, bypass := range GetMaintenanceWindowBypasses(annotations) {
+		if now.Before(bypass.Until) {
+			return true
+		}
+	}
+	return false
+}
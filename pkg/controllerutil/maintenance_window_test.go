@@ -0,0 +1,93 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package controllerutil
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+func TestCheckMaintenanceWindow(t *testing.T) {
+	window := &appsv1.MaintenanceWindow{
+		Schedule: "0 2 * * *",
+		Duration: metav1.Duration{Duration: 2 * time.Hour},
+	}
+	open, closeTime, err := CheckMaintenanceWindow(window, time.Date(2024, 1, 1, 3, 0, 0, 0, time.UTC))
+	if err != nil || !open || !closeTime.Equal(time.Date(2024, 1, 1, 4, 0, 0, 0, time.UTC)) {
+		t.Errorf("expect window open until 04:00, got %v %v %v", open, closeTime, err)
+	}
+	open, openTime, err := CheckMaintenanceWindow(window, time.Date(2024, 1, 1, 4, 0, 0, 0, time.UTC))
+	if err != nil || open || !openTime.Equal(time.Date(2024, 1, 2, 2, 0, 0, 0, time.UTC)) {
+		t.Errorf("expect window closed until next day 02:00, got %v %v %v", open, openTime, err)
+	}
+	if open, _, _ := CheckMaintenanceWindow(nil, time.Now()); !open {
+		t.Error("nil window should be always open")
+	}
+}
+
+func TestIsMaintenanceWindowBypassed(t *testing.T) {
+	now := time.Date(2024, 1, 1, 3, 0, 0, 0, time.UTC)
+	annotations := map[string]string{}
+	SetMaintenanceWindowBypasses(annotations, []MaintenanceWindowBypass{
+		{OpsRequest: "restart", Components: []string{"mysql"}, Until: now.Add(time.Hour)},
+	})
+	if !IsMaintenanceWindowBypassed(annotations, now) {
+		t.Error("should be bypassed before the until time")
+	}
+	if IsMaintenanceWindowBypassed(annotations, now.Add(2*time.Hour)) {
+		t.Error("should not be bypassed after the until time")
+	}
+	if IsMaintenanceWindowBypassed(nil, now) {
+		t.Error("should not be bypassed without the annotation")
+	}
+	SetMaintenanceWindowBypasses(annotations, nil)
+	if annotations[constant.MaintenanceWindowBypassAnnotationKey] != "[]" || IsMaintenanceWindowBypassed(annotations, now) {
+		t.Error("should not be bypassed after the bypasses are removed")
+	}
+}
+
+func TestComponentMaintenanceWindowBypasses(t *testing.T) {
+	now := time.Date(2024, 1, 1, 3, 0, 0, 0, time.UTC)
+	if _, ok := ComponentMaintenanceWindowBypasses(nil, "mysql"); ok {
+		t.Error("should not be found without the annotation")
+	}
+	annotations := map[string]string{}
+	SetMaintenanceWindowBypasses(annotations, []MaintenanceWindowBypass{
+		{OpsRequest: "restart", Components: []string{"mysql"}, Until: now.Add(time.Hour)},
+		{OpsRequest: "upgrade", Components: []string{"shard"}, Until: now.Add(time.Hour)},
+	})
+	bypasses, ok := ComponentMaintenanceWindowBypasses(annotations, "proxy")
+	if !ok || len(bypasses) != 0 {
+		t.Errorf("the other components should not be bypassed, got %v", bypasses)
+	}
+	bypasses, _ = ComponentMaintenanceWindowBypasses(annotations, "mysql")
+	if len(bypasses) != 1 || bypasses[0].OpsRequest != "restart" {
+		t.Errorf("expect the bypass of the component, got %v", bypasses)
+	}
+	bypasses, _ = ComponentMaintenanceWindowBypasses(annotations, "shard-abc", "shard")
+	if len(bypasses) != 1 || bypasses[0].OpsRequest != "upgrade" {
+		t.Errorf("expect the bypass of the sharding, got %v", bypasses)
+	}
+}
@@ -37,6 +37,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/common"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	ctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	dpencryption "github.com/apecloud/kubeblocks/pkg/dataprotection/encryption"
 	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
 	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
 	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils/boolptr"
@@ -225,12 +226,16 @@ func (d *Deleter) createDeleteBackupFilesJob(
 	backup *dpv1alpha1.Backup,
 	backupRepo *dpv1alpha1.BackupRepo,
 	legacyPVCName string) error {
+	container := d.buildDeleteContainer(backup.Status.Path)
+	return d.createDeleteJob(container, jobKey, backup, backupRepo, legacyPVCName)
+}
 
+func (d *Deleter) buildDeleteContainer(backupPath string) corev1.Container {
 	runAsUser := int64(0)
-	container := corev1.Container{
+	return corev1.Container{
 		Name:            deleteContainerName,
 		Command:         []string{"sh", "-c"},
-		Args:            []string{d.buildDeleteBackupFilesScript(backup.Status.Path)},
+		Args:            []string{d.buildDeleteBackupFilesScript(backupPath)},
 		Image:           viper.GetString(constant.KBToolsImage),
 		ImagePullPolicy: corev1.PullPolicy(viper.GetString(constant.KBImagePullPolicy)),
 		SecurityContext: &corev1.SecurityContext{
@@ -238,7 +243,43 @@ func (d *Deleter) createDeleteBackupFilesJob(
 			RunAsUser:                &runAsUser,
 		},
 	}
-	return d.createDeleteJob(container, jobKey, backup, backupRepo, legacyPVCName)
+}
+
+// DeleteReplicaFiles builds a job to delete the files of the backup copy in the secondary
+// backup repo, and returns the deletion status. If the deletion job exists, it will check
+// the job status and return the corresponding deletion status.
+func (d *Deleter) DeleteReplicaFiles(backup *dpv1alpha1.Backup, replica *dpv1alpha1.BackupReplicaStatus) (DeletionStatus, error) {
+	jobKey := BuildDeleteReplicaFilesJobKey(backup, replica.BackupRepoName)
+	job := &batchv1.Job{}
+	exists, err := ctrlutil.CheckResourceExists(d.Ctx, d.Client, jobKey, job)
+	if err != nil {
+		return DeletionStatusUnknown, err
+	}
+	if exists {
+		_, finishedType, msg := utils.IsJobFinished(job)
+		switch finishedType {
+		case batchv1.JobComplete:
+			return DeletionStatusSucceeded, nil
+		case batchv1.JobFailed:
+			return DeletionStatusFailed,
+				fmt.Errorf("deletion replica files job \"%s\" failed, you can delete it to re-delete the replica files, %s", job.Name, msg)
+		}
+		return DeletionStatusDeleting, nil
+	}
+
+	backupRepo := &dpv1alpha1.BackupRepo{}
+	if err = d.Client.Get(d.Ctx, client.ObjectKey{Name: replica.BackupRepoName}, backupRepo); err != nil {
+		if apierrors.IsNotFound(err) {
+			return DeletionStatusSucceeded, nil
+		}
+		return DeletionStatusUnknown, err
+	}
+	if replica.Path == "" || !strings.Contains(replica.Path, backup.Name) {
+		d.Log.Info("skip deleting replica files because the replica path is invalid",
+			"replicaPath", replica.Path, "backup", backup.Name)
+		return DeletionStatusSucceeded, nil
+	}
+	return DeletionStatusDeleting, d.createDeleteJob(d.buildDeleteContainer(replica.Path), jobKey, backup, backupRepo, "")
 }
 
 func (d *Deleter) createDeleteJob(container corev1.Container,
@@ -258,8 +299,12 @@ func (d *Deleter) createDeleteJob(container corev1.Container,
 		return err
 	}
 	kopiaRepoPath := backup.Status.KopiaRepoPath
-	encryptionConfig := backup.Status.EncryptionConfig
 	if backupRepo != nil {
+		encryptionConfig, err := dpencryption.EnsureDataKeySecret(d.Ctx, d.Client, d.Scheme, backup, backup,
+			dpencryption.GetDataKeySecretName(backup, backup), BuildBackupWorkloadLabels(backup))
+		if err != nil {
+			return err
+		}
 		utils.InjectDatasafed(&podSpec, backupRepo, RepoVolumeMountPath, encryptionConfig, kopiaRepoPath)
 	} else {
 		utils.InjectDatasafedWithPVC(&podSpec, legacyPVCName, RepoVolumeMountPath, kopiaRepoPath)
@@ -0,0 +1,305 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package backup
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	ctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils/boolptr"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+const (
+	manifestJobNamePrefix = "manifest-"
+	manifestContainerName = "manifest"
+	manifestEnvName       = "DP_BACKUP_MANIFEST"
+
+	// CatalogConfigMapKey is the key of the ConfigMaps which carry the chunks of the backup catalog
+	// collected by the sync job.
+	CatalogConfigMapKey = "catalog"
+)
+
+// catalogChunkSize is the max size of a catalog chunk, which must fit in a ConfigMap.
+var catalogChunkSize = "900k"
+
+// Manifest is a self-describing record of a backup, it is stored next to the backup
+// data, so that the backup can be rediscovered from the backup repository without
+// the original Kubernetes cluster.
+type Manifest struct {
+	metav1.TypeMeta `json:",inline"`
+	Metadata        ManifestMetadata        `json:"metadata"`
+	Spec            dpv1alpha1.BackupSpec   `json:"spec"`
+	Status          dpv1alpha1.BackupStatus `json:"status"`
+}
+
+// ManifestMetadata is the subset of the backup metadata kept in the manifest.
+type ManifestMetadata struct {
+	Name        string            `json:"name"`
+	Namespace   string            `json:"namespace"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// BuildManifest builds the manifest of the backup.
+func BuildManifest(backup *dpv1alpha1.Backup) *Manifest {
+	status := backup.Status.DeepCopy()
+	// the repo may be registered with another name when the manifest is imported.
+	status.BackupRepoName = ""
+	status.PersistentVolumeClaimName = ""
+	status.FailureReason = ""
+	return &Manifest{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: dpv1alpha1.GroupVersion.String(),
+			Kind:       "Backup",
+		},
+		Metadata: ManifestMetadata{
+			Name:        backup.Name,
+			Namespace:   backup.Namespace,
+			Labels:      backup.Labels,
+			Annotations: backup.Annotations,
+		},
+		Spec:   *backup.Spec.DeepCopy(),
+		Status: *status,
+	}
+}
+
+// ToBackup converts the manifest to a backup object which refers to the backup repo.
+// The status of the returned backup should be updated separately after it is created.
+func (m *Manifest) ToBackup(repoName string) *dpv1alpha1.Backup {
+	backup := &dpv1alpha1.Backup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        m.Metadata.Name,
+			Namespace:   m.Metadata.Namespace,
+			Labels:      map[string]string{},
+			Annotations: map[string]string{},
+		},
+		Spec:   *m.Spec.DeepCopy(),
+		Status: *m.Status.DeepCopy(),
+	}
+	for k, v := range m.Metadata.Labels {
+		backup.Labels[k] = v
+	}
+	for k, v := range m.Metadata.Annotations {
+		backup.Annotations[k] = v
+	}
+	delete(backup.Annotations, dptypes.SkipReconciliationAnnotationKey)
+	backup.Annotations[dptypes.ImportedFromRepoAnnotationKey] = repoName
+	backup.Status.BackupRepoName = repoName
+	// the copies in other repos are owned by the original backup.
+	backup.Status.Replicas = nil
+	// the manifest of a running continuous backup is a snapshot of its progress, the imported
+	// backup is no longer written, and can be restored to any time in the recorded time range.
+	if backup.Status.Phase == dpv1alpha1.BackupPhaseRunning {
+		backup.Status.Phase = dpv1alpha1.BackupPhaseCompleted
+		if backup.Status.TimeRange != nil && backup.Status.TimeRange.End != nil {
+			backup.Status.CompletionTimestamp = backup.Status.TimeRange.End.DeepCopy()
+		}
+	}
+	return backup
+}
+
+// EncodeManifest encodes the manifest into a single line of JSON.
+func EncodeManifest(manifest *Manifest) (string, error) {
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// ParseCatalog parses the manifests from the backup catalog collected by the sync job,
+// which contains one manifest per line. The invalid manifests are reported by the
+// returned errors and will not interrupt the parsing.
+func ParseCatalog(catalog []byte) ([]*Manifest, []error) {
+	var (
+		manifests []*Manifest
+		errs      []error
+	)
+	scanner := bufio.NewScanner(bytes.NewReader(catalog))
+	scanner.Buffer(make([]byte, 0, 64*1024), len(catalog)+1)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		manifest := &Manifest{}
+		if err := json.Unmarshal(line, manifest); err != nil {
+			errs = append(errs, fmt.Errorf("failed to parse backup manifest: %w", err))
+			continue
+		}
+		if manifest.Kind != "Backup" || manifest.Metadata.Name == "" || manifest.Metadata.Namespace == "" {
+			errs = append(errs, fmt.Errorf("invalid backup manifest of %s/%s",
+				manifest.Metadata.Namespace, manifest.Metadata.Name))
+			continue
+		}
+		manifests = append(manifests, manifest)
+	}
+	if err := scanner.Err(); err != nil {
+		errs = append(errs, err)
+	}
+	return manifests, errs
+}
+
+// BuildSyncManifestsScript builds the script to collect all backup manifests under the root
+// path of the backup repo into a catalog file in catalogDir, one manifest per line. The catalog
+// is then split into chunks, and each chunk is stored in a ConfigMap named with the prefix
+// configMapName and labeled with the labels, from which the controller reads the catalog.
+func BuildSyncManifestsScript(rootPath, catalogDir, namespace, configMapName string, labels map[string]string) string {
+	var selector, labelArgs []string
+	for _, k := range slices.Sorted(maps.Keys(labels)) {
+		selector = append(selector, fmt.Sprintf("%s=%s", k, labels[k]))
+	}
+	for _, l := range selector {
+		labelArgs = append(labelArgs, fmt.Sprintf("%q", l))
+	}
+	return fmt.Sprintf(`
+set -e
+export PATH="$PATH:$%s"
+root="%s"
+catalog="%s/catalog"
+: > "${catalog}"
+datasafed list -r -f --name "%s" "${root}" | while read -r file; do
+	case "${file}" in
+	/*) ;;
+	*) file="${root%%/}/${file}" ;;
+	esac
+	{ datasafed pull "${file}" - || true; echo; } >> "${catalog}"
+done
+
+# store the catalog in ConfigMaps
+kubectl -n "%s" delete configmap -l "%s" --ignore-not-found
+cd "$(dirname "${catalog}")"
+split -b %s "${catalog}" chunk-
+for chunk in chunk-*; do
+	[ -f "${chunk}" ] || continue
+	name="%s-${chunk#chunk-}"
+	kubectl -n "%s" create configmap "${name}" --from-file=%s="${chunk}"
+	kubectl -n "%s" label configmap "${name}" %s
+done
+`, dptypes.DPDatasafedBinPath, rootPath, catalogDir, BackupManifestFileName,
+		namespace, strings.Join(selector, ","), catalogChunkSize,
+		configMapName, namespace, CatalogConfigMapKey, namespace, strings.Join(labelArgs, " "))
+}
+
+// ManifestWriter writes the manifests of backups into the backup repo.
+type ManifestWriter struct {
+	ctrlutil.RequestCtx
+	Client               client.Client
+	Scheme               *runtime.Scheme
+	WorkerServiceAccount string
+}
+
+// Write builds a job to write the manifest of the backup into the backup repo, and returns
+// the job. If the job exists, it is returned directly.
+func (w *ManifestWriter) Write(backup *dpv1alpha1.Backup, repo *dpv1alpha1.BackupRepo) (*batchv1.Job, error) {
+	jobKey := BuildManifestJobKey(backup)
+	job := &batchv1.Job{}
+	exists, err := ctrlutil.CheckResourceExists(w.Ctx, w.Client, jobKey, job)
+	if err != nil || exists {
+		return job, err
+	}
+
+	manifest, err := EncodeManifest(BuildManifest(backup))
+	if err != nil {
+		return nil, err
+	}
+	runAsUser := int64(0)
+	container := corev1.Container{
+		Name:    manifestContainerName,
+		Command: []string{"sh", "-c"},
+		Args: []string{fmt.Sprintf(`
+set -e
+export PATH="$PATH:$%s"
+printf '%%s' "${%s}" | datasafed push - "%s"
+`, dptypes.DPDatasafedBinPath, manifestEnvName, filepath.Join("/", backup.Status.Path, BackupManifestFileName))},
+		Env:             []corev1.EnvVar{{Name: manifestEnvName, Value: manifest}},
+		Image:           viper.GetString(constant.KBToolsImage),
+		ImagePullPolicy: corev1.PullPolicy(viper.GetString(constant.KBImagePullPolicy)),
+		SecurityContext: &corev1.SecurityContext{
+			AllowPrivilegeEscalation: boolptr.False(),
+			RunAsUser:                &runAsUser,
+		},
+	}
+	ctrlutil.InjectZeroResourcesLimitsIfEmpty(&container)
+	podSpec := corev1.PodSpec{
+		Containers:         []corev1.Container{container},
+		RestartPolicy:      corev1.RestartPolicyNever,
+		ServiceAccountName: w.WorkerServiceAccount,
+	}
+	if err = utils.AddTolerations(&podSpec); err != nil {
+		return nil, err
+	}
+	// the manifest is neither encrypted nor stored in the kopia repository, so that it
+	// can be read without any knowledge of the backup.
+	utils.InjectDatasafed(&podSpec, repo, RepoVolumeMountPath, nil, "")
+
+	labels := BuildBackupWorkloadLabels(backup)
+	labels[constant.AppManagedByLabelKey] = dptypes.AppName
+	job = &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: jobKey.Namespace,
+			Name:      jobKey.Name,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: jobKey.Namespace,
+					Name:      jobKey.Name,
+					Labels:    labels,
+				},
+				Spec: podSpec,
+			},
+			BackoffLimit: &dptypes.DefaultBackOffLimit,
+		},
+	}
+	if err = utils.SetControllerReference(backup, job, w.Scheme); err != nil {
+		return nil, err
+	}
+	w.Log.V(1).Info("create a job to write the backup manifest", "job", client.ObjectKeyFromObject(job))
+	return job, client.IgnoreAlreadyExists(w.Client.Create(w.Ctx, job))
+}
+
+// BuildManifestJobKey builds the key of the job which writes the backup manifest.
+func BuildManifestJobKey(backup *dpv1alpha1.Backup) client.ObjectKey {
+	jobName := fmt.Sprintf("%s-%s%s", backup.UID[:8], manifestJobNamePrefix, backup.Name)
+	if len(jobName) > 63 {
+		jobName = strings.TrimSuffix(jobName[:63], "-")
+	}
+	return client.ObjectKey{Namespace: backup.Namespace, Name: jobName}
+}
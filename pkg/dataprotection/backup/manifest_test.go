@@ -0,0 +1,202 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package backup
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+)
+
+func TestManifestRoundTrip(t *testing.T) {
+	now := metav1.NewTime(time.Now().UTC().Truncate(time.Second))
+	backup := &dpv1alpha1.Backup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "backup",
+			Namespace: "default",
+			Labels:    map[string]string{"app": "test"},
+			Annotations: map[string]string{
+				dptypes.SkipReconciliationAnnotationKey: "true",
+			},
+		},
+		Spec: dpv1alpha1.BackupSpec{
+			BackupPolicyName: "policy",
+			BackupMethod:     "xtrabackup",
+		},
+		Status: dpv1alpha1.BackupStatus{
+			Phase:                     dpv1alpha1.BackupPhaseCompleted,
+			Path:                      "/default/backup",
+			BackupRepoName:            "old-repo",
+			PersistentVolumeClaimName: "old-pvc",
+			TimeRange:                 &dpv1alpha1.BackupTimeRange{Start: &now, End: &now},
+			EncryptionKey: &dpv1alpha1.BackupEncryptionKey{
+				Provider: "aws-kms",
+				KeyID:    "key-id",
+			},
+		},
+	}
+
+	data, err := EncodeManifest(BuildManifest(backup))
+	assert.NoError(t, err)
+	assert.NotContains(t, data, "\n")
+
+	catalog := data + "\n\n{invalid\n"
+	manifests, errs := ParseCatalog([]byte(catalog))
+	assert.Len(t, errs, 1)
+	assert.Len(t, manifests, 1)
+
+	imported := manifests[0].ToBackup("new-repo")
+	assert.Equal(t, backup.Name, imported.Name)
+	assert.Equal(t, backup.Namespace, imported.Namespace)
+	assert.Equal(t, backup.Spec, imported.Spec)
+	assert.Equal(t, "test", imported.Labels["app"])
+	assert.Equal(t, "new-repo", imported.Annotations[dptypes.ImportedFromRepoAnnotationKey])
+	assert.NotContains(t, imported.Annotations, dptypes.SkipReconciliationAnnotationKey)
+	assert.Equal(t, "new-repo", imported.Status.BackupRepoName)
+	assert.Empty(t, imported.Status.PersistentVolumeClaimName)
+	assert.Equal(t, backup.Status.Path, imported.Status.Path)
+	assert.Equal(t, backup.Status.EncryptionKey, imported.Status.EncryptionKey)
+	assert.True(t, backup.Status.TimeRange.Start.Equal(imported.Status.TimeRange.Start))
+}
+
+func TestImportRunningContinuousBackup(t *testing.T) {
+	start := metav1.NewTime(time.Now().Add(-time.Hour).UTC().Truncate(time.Second))
+	end := metav1.NewTime(time.Now().UTC().Truncate(time.Second))
+	backup := &dpv1alpha1.Backup{
+		ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: "default"},
+		Status: dpv1alpha1.BackupStatus{
+			Phase:     dpv1alpha1.BackupPhaseRunning,
+			TimeRange: &dpv1alpha1.BackupTimeRange{Start: &start, End: &end},
+		},
+	}
+	imported := BuildManifest(backup).ToBackup("repo")
+	assert.Equal(t, dpv1alpha1.BackupPhaseCompleted, imported.Status.Phase)
+	assert.True(t, end.Equal(imported.Status.CompletionTimestamp))
+}
+
+func TestSyncManifestsScript(t *testing.T) {
+	for _, tool := range []string{"sh", "split", "find"} {
+		if _, err := exec.LookPath(tool); err != nil {
+			t.Skipf("%s is not found", tool)
+		}
+	}
+	dir := t.TempDir()
+	binDir := filepath.Join(dir, "bin")
+	repoDir := filepath.Join(dir, "repo")
+	catalogDir := filepath.Join(dir, "catalog")
+	outDir := filepath.Join(dir, "out")
+	for _, d := range []string{binDir, repoDir, catalogDir, outDir} {
+		require.NoError(t, os.MkdirAll(d, 0755))
+	}
+	// fakeDatasafed lists and pulls the files in the directory of $FAKE_REPO.
+	fakeDatasafed := `#!/bin/sh
+case "$1" in
+list)
+	root="${FAKE_REPO}${6}"
+	find "${root}" -type f -name "$5" | sed "s|^${root}/||"
+	;;
+pull)
+	cat "${FAKE_REPO}$2"
+	;;
+esac
+`
+	// fakeKubectl stores the created ConfigMaps in $FAKE_OUT.
+	fakeKubectl := `#!/bin/sh
+shift 2
+case "$1" in
+create)
+	cp "${4#--from-file=catalog=}" "${FAKE_OUT}/$3"
+	;;
+label)
+	shift 3
+	echo "$@" > "${FAKE_OUT}/labels"
+	;;
+esac
+`
+	require.NoError(t, os.WriteFile(filepath.Join(binDir, "datasafed"), []byte(fakeDatasafed), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(binDir, "kubectl"), []byte(fakeKubectl), 0755))
+
+	var expected []string
+	for _, name := range []string{"b1", "b2", "b3"} {
+		data, err := EncodeManifest(BuildManifest(&dpv1alpha1.Backup{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Status:     dpv1alpha1.BackupStatus{Phase: dpv1alpha1.BackupPhaseCompleted},
+		}))
+		require.NoError(t, err)
+		path := filepath.Join(repoDir, "prefix", "default", name)
+		require.NoError(t, os.MkdirAll(path, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(path, BackupManifestFileName), []byte(data), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(path, "data.xbstream"), []byte("data"), 0644))
+		expected = append(expected, name)
+	}
+
+	// split the catalog into multiple chunks
+	catalogChunkSize = "100"
+	defer func() { catalogChunkSize = "900k" }()
+	script := BuildSyncManifestsScript("/prefix", catalogDir, "kb-system", "sync-repo",
+		map[string]string{"repo": "repo", "catalog": "sync-repo"})
+	cmd := exec.Command("sh", "-c", script)
+	cmd.Env = []string{
+		"PATH=" + os.Getenv("PATH"),
+		dptypes.DPDatasafedBinPath + "=" + binDir,
+		"FAKE_REPO=" + repoDir,
+		"FAKE_OUT=" + outDir,
+	}
+	output, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(output))
+
+	labels, err := os.ReadFile(filepath.Join(outDir, "labels"))
+	require.NoError(t, err)
+	assert.Equal(t, "catalog=sync-repo repo=repo", strings.TrimSpace(string(labels)))
+
+	entries, err := os.ReadDir(outDir)
+	require.NoError(t, err)
+	var catalog []byte
+	chunks := 0
+	for _, entry := range entries {
+		if !strings.HasPrefix(entry.Name(), "sync-repo-") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(outDir, entry.Name()))
+		require.NoError(t, err)
+		catalog = append(catalog, data...)
+		chunks++
+	}
+	assert.Greater(t, chunks, 1)
+	manifests, errs := ParseCatalog(catalog)
+	assert.Empty(t, errs)
+	var names []string
+	for _, m := range manifests {
+		names = append(names, m.Metadata.Name)
+	}
+	slices.Sort(names)
+	assert.Equal(t, expected, names)
+}
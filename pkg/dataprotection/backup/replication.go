@@ -0,0 +1,268 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package backup
+
+import (
+	"fmt"
+	"hash/fnv"
+	"path/filepath"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	ctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils/boolptr"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+const (
+	replicationJobNamePrefix   = "replicate-"
+	deleteReplicaJobNamePrefix = "delete-replica-"
+	replicationContainerName   = "replicate"
+	sourceRepoVolumeName       = "dp-source-backup-repo"
+	sourceRepoMountPath        = "/source-backup-repo"
+	sourceDatasafedConfigPath  = "/etc/datasafed-source"
+)
+
+// listBackupFilesFunc lists the files in the backup path relative to the backup path,
+// the datasafed command to list the files is passed as the arguments.
+const listBackupFilesFunc = `
+list_files() {
+	listed="$(mktemp)"
+	"$@" list -r -f "${backupPath}" > "${listed}"
+	while read -r file; do
+		case "${file}" in
+		/*) echo "${file#"${backupPath%/}/"}" ;;
+		*) echo "${file}" ;;
+		esac
+	done < "${listed}" | sort
+	rm -f "${listed}"
+}
+`
+
+// Replicator copies the backup files to the secondary backup repos.
+type Replicator struct {
+	ctrlutil.RequestCtx
+	Client               client.Client
+	Scheme               *runtime.Scheme
+	WorkerServiceAccount string
+}
+
+// Replicate builds a job to copy the backup files from the backup repo of the backup to
+// the target backup repo, and returns the job. If the job exists, it is returned directly.
+//
+// The files are streamed from the source repo to the target repo as they are stored, so
+// the copy of an encrypted backup is encrypted in the same way, and can be restored with
+// the encryption config of the backup. The files that already exist in the target repo
+// are skipped, except the manifest and the checksum file, which makes the continuous
+// backups to be copied incrementally.
+func (r *Replicator) Replicate(backup *dpv1alpha1.Backup,
+	sourceRepo, targetRepo *dpv1alpha1.BackupRepo) (*batchv1.Job, error) {
+	jobKey := BuildReplicationJobKey(backup, targetRepo.Name)
+	job := &batchv1.Job{}
+	exists, err := ctrlutil.CheckResourceExists(r.Ctx, r.Client, jobKey, job)
+	if err != nil || exists {
+		return job, err
+	}
+
+	runAsUser := int64(0)
+	container := corev1.Container{
+		Name:            replicationContainerName,
+		Command:         []string{"sh", "-c"},
+		Args:            []string{buildReplicationScript(filepath.Join("/", backup.Status.Path), sourceRepo)},
+		Image:           viper.GetString(constant.KBToolsImage),
+		ImagePullPolicy: corev1.PullPolicy(viper.GetString(constant.KBImagePullPolicy)),
+		SecurityContext: &corev1.SecurityContext{
+			AllowPrivilegeEscalation: boolptr.False(),
+			RunAsUser:                &runAsUser,
+		},
+		VolumeMounts: []corev1.VolumeMount{{
+			Name:      sourceRepoVolumeName,
+			MountPath: sourceRepoMountPath,
+			ReadOnly:  true,
+		}},
+	}
+	ctrlutil.InjectZeroResourcesLimitsIfEmpty(&container)
+	podSpec := corev1.PodSpec{
+		RestartPolicy:      corev1.RestartPolicyNever,
+		ServiceAccountName: r.WorkerServiceAccount,
+		Volumes:            []corev1.Volume{buildSourceRepoVolume(sourceRepo)},
+		Containers:         []corev1.Container{container},
+	}
+	if sourceRepo.AccessByTool() {
+		podSpec.Containers[0].VolumeMounts[0].MountPath = sourceDatasafedConfigPath
+	}
+	// the datasafed of the container accesses the target repo by default.
+	utils.InjectDatasafed(&podSpec, targetRepo, RepoVolumeMountPath, nil, "")
+	if err = utils.AddTolerations(&podSpec); err != nil {
+		return nil, err
+	}
+
+	labels := BuildReplicationLabels(backup, targetRepo.Name)
+	job = &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   jobKey.Namespace,
+			Name:        jobKey.Name,
+			Labels:      labels,
+			Annotations: map[string]string{},
+		},
+		Spec: batchv1.JobSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec:       podSpec,
+			},
+			BackoffLimit: &dptypes.DefaultBackOffLimit,
+		},
+	}
+	// record the time range of the data to copy, which is recorded in the replica
+	// status after the job is completed.
+	if backup.Status.TimeRange != nil && backup.Status.TimeRange.End != nil {
+		job.Annotations[dptypes.ReplicatedTimeRangeEndAnnotationKey] = backup.Status.TimeRange.End.UTC().Format(time.RFC3339)
+	}
+	if err = utils.SetControllerReference(backup, job, r.Scheme); err != nil {
+		return nil, err
+	}
+	r.Log.V(1).Info("create a job to replicate the backup", "job", jobKey, "backupRepo", targetRepo.Name)
+	return job, client.IgnoreAlreadyExists(r.Client.Create(r.Ctx, job))
+}
+
+// buildSourceRepoVolume builds the volume to access the source repo, which is the PVC
+// of the repo or the datasafed config of the repo.
+func buildSourceRepoVolume(sourceRepo *dpv1alpha1.BackupRepo) corev1.Volume {
+	if sourceRepo.AccessByTool() {
+		return corev1.Volume{
+			Name: sourceRepoVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: sourceRepo.Status.ToolConfigSecretName},
+			},
+		}
+	}
+	return corev1.Volume{
+		Name: sourceRepoVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: sourceRepo.Status.BackupPVCName,
+				ReadOnly:  true,
+			},
+		},
+	}
+}
+
+// buildReplicationScript builds the script which streams the files from the source repo
+// to the target repo without staging them in the pod.
+func buildReplicationScript(backupPath string, sourceRepo *dpv1alpha1.BackupRepo) string {
+	// the local backend path overrides the datasafed config, unset it for the source
+	// repo accessed by tool, as the target repo may be accessed by mount.
+	sourceDatasafed := fmt.Sprintf(`%s=%s datasafed "$@"`, dptypes.DPDatasafedLocalBackendPath, sourceRepoMountPath)
+	if sourceRepo.AccessByTool() {
+		sourceDatasafed = fmt.Sprintf(`env -u %s datasafed -c %s/datasafed.conf "$@"`,
+			dptypes.DPDatasafedLocalBackendPath, sourceDatasafedConfigPath)
+	}
+	return fmt.Sprintf(`
+set -e
+export PATH="$PATH:$%[1]s"
+backupPath="%[2]s"
+%[3]s
+source_datasafed() {
+	%[4]s
+}
+copy_file() {
+	pullFailed="$(mktemp -u)"
+	{ source_datasafed pull "${backupPath%%/}/$1" - || touch "${pullFailed}"; } | datasafed push - "${backupPath%%/}/$1"
+	if [ -f "${pullFailed}" ]; then
+		# remove the incomplete copy, otherwise it will be skipped in the retry.
+		datasafed rm "${backupPath%%/}/$1" || true
+		echo "failed to copy $1"
+		exit 1
+	fi
+}
+sourceFiles="$(mktemp)"
+targetFiles="$(mktemp)"
+list_files source_datasafed > "${sourceFiles}"
+list_files datasafed > "${targetFiles}"
+while read -r relPath; do
+	# the manifest is copied at last, so the copy is not imported before it is complete.
+	if [ "${relPath}" = "%[5]s" ]; then
+		continue
+	fi
+	# the checksum file is always copied, as it may be rewritten.
+	if [ "${relPath}" != "%[6]s" ] && grep -qxF "${relPath}" "${targetFiles}"; then
+		continue
+	fi
+	copy_file "${relPath}"
+done < "${sourceFiles}"
+if grep -qxF "%[5]s" "${sourceFiles}"; then
+	copy_file "%[5]s"
+fi
+`, dptypes.DPDatasafedBinPath, backupPath, listBackupFilesFunc, sourceDatasafed,
+		BackupManifestFileName, BackupChecksumFileName)
+}
+
+// BuildReplicationLabels builds the labels of the job which copies the backup to the backup repo.
+func BuildReplicationLabels(backup *dpv1alpha1.Backup, backupRepoName string) map[string]string {
+	return map[string]string{
+		constant.AppManagedByLabelKey:     dptypes.AppName,
+		dptypes.BackupReplicationLabelKey: backup.Name,
+		dptypes.BackupReplicaRepoLabelKey: backupRepoName,
+	}
+}
+
+// BuildReplicationJobKey builds the key of the job which copies the backup to the backup repo.
+func BuildReplicationJobKey(backup *dpv1alpha1.Backup, backupRepoName string) client.ObjectKey {
+	return buildReplicaJobKey(backup, replicationJobNamePrefix, backupRepoName)
+}
+
+// BuildDeleteReplicaFilesJobKey builds the key of the job which deletes the copy of the backup in the backup repo.
+func BuildDeleteReplicaFilesJobKey(backup *dpv1alpha1.Backup, backupRepoName string) client.ObjectKey {
+	return buildReplicaJobKey(backup, deleteReplicaJobNamePrefix, backupRepoName)
+}
+
+func buildReplicaJobKey(backup *dpv1alpha1.Backup, prefix, backupRepoName string) client.ObjectKey {
+	// the repo name is hashed to keep the job name short and unique.
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(backupRepoName))
+	jobName := fmt.Sprintf("%s-%s%x-%s", backup.UID[:8], prefix, hash.Sum32(), backup.Name)
+	if len(jobName) > 63 {
+		jobName = strings.TrimSuffix(jobName[:63], "-")
+	}
+	return client.ObjectKey{Namespace: backup.Namespace, Name: jobName}
+}
+
+// GetReplicatedTimeRangeEnd returns the end of the time range of the data copied by the replication job.
+func GetReplicatedTimeRangeEnd(job *batchv1.Job) *metav1.Time {
+	value := job.Annotations[dptypes.ReplicatedTimeRangeEndAnnotationKey]
+	if value == "" {
+		return nil
+	}
+	end, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil
+	}
+	return &metav1.Time{Time: end}
+}
@@ -0,0 +1,107 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package backup
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	ctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+)
+
+func TestBuildReplicationJobKey(t *testing.T) {
+	backup := &dpv1alpha1.Backup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      strings.Repeat("b", 60),
+			Namespace: "default",
+			UID:       "12345678-abcd",
+		},
+	}
+	key1 := BuildReplicationJobKey(backup, "repo-1")
+	key2 := BuildReplicationJobKey(backup, "repo-2")
+	assert.NotEqual(t, key1.Name, key2.Name)
+	assert.LessOrEqual(t, len(key1.Name), 63)
+	assert.True(t, strings.HasPrefix(key1.Name, "12345678-replicate-"))
+	assert.NotEqual(t, key1.Name, BuildDeleteReplicaFilesJobKey(backup, "repo-1").Name)
+}
+
+func TestReplicate(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = dpv1alpha1.AddToScheme(scheme)
+
+	backup := &dpv1alpha1.Backup{
+		ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: "default", UID: "12345678-abcd"},
+		Status:     dpv1alpha1.BackupStatus{Path: "/default/backup"},
+	}
+	toolRepo := &dpv1alpha1.BackupRepo{
+		ObjectMeta: metav1.ObjectMeta{Name: "tool-repo"},
+		Spec:       dpv1alpha1.BackupRepoSpec{AccessMethod: dpv1alpha1.AccessMethodTool},
+		Status:     dpv1alpha1.BackupRepoStatus{ToolConfigSecretName: "tool-config"},
+	}
+	mountRepo := &dpv1alpha1.BackupRepo{
+		ObjectMeta: metav1.ObjectMeta{Name: "mount-repo"},
+		Spec:       dpv1alpha1.BackupRepoSpec{AccessMethod: dpv1alpha1.AccessMethodMount},
+		Status:     dpv1alpha1.BackupRepoStatus{BackupPVCName: "mount-pvc"},
+	}
+	for _, tc := range []struct {
+		source, target *dpv1alpha1.BackupRepo
+	}{
+		{source: toolRepo, target: mountRepo},
+		{source: mountRepo, target: toolRepo},
+	} {
+		replicator := &Replicator{
+			RequestCtx: ctrlutil.RequestCtx{Ctx: context.Background(), Log: logr.Discard()},
+			Client:     fake.NewClientBuilder().WithScheme(scheme).Build(),
+			Scheme:     scheme,
+		}
+		job, err := replicator.Replicate(backup, tc.source, tc.target)
+		assert.NoError(t, err)
+
+		// the files are streamed in one container without staging volumes
+		podSpec := job.Spec.Template.Spec
+		assert.Len(t, podSpec.Containers, 1)
+		for _, volume := range podSpec.Volumes {
+			assert.False(t, volume.EmptyDir != nil && volume.Name != "dp-datasafed-bin", "unexpected staging volume %s", volume.Name)
+		}
+		assert.Equal(t, sourceRepoVolumeName, podSpec.Volumes[0].Name)
+		script := podSpec.Containers[0].Args[0]
+		assert.Contains(t, script, `{ source_datasafed pull "${backupPath%/}/$1" - || touch "${pullFailed}"; } | datasafed push - "${backupPath%/}/$1"`)
+		if tc.source.AccessByTool() {
+			assert.Equal(t, "tool-config", podSpec.Volumes[0].Secret.SecretName)
+			assert.Equal(t, sourceDatasafedConfigPath, podSpec.Containers[0].VolumeMounts[0].MountPath)
+			assert.Contains(t, script, "datasafed -c "+sourceDatasafedConfigPath+"/datasafed.conf")
+		} else {
+			assert.Equal(t, "mount-pvc", podSpec.Volumes[0].PersistentVolumeClaim.ClaimName)
+			assert.Equal(t, sourceRepoMountPath, podSpec.Containers[0].VolumeMounts[0].MountPath)
+			assert.Contains(t, script, dptypes.DPDatasafedLocalBackendPath+"="+sourceRepoMountPath)
+		}
+	}
+}
@@ -47,6 +47,7 @@ const (
 	managerContainerName    = "manager"
 	managerSharedVolumeName = "manager-shared-volume"
 	managerSharedMountPath  = "/dp-manager"
+	checksumsFileName       = "checksums.sha256"
 )
 
 // Request is a request for a backup, with all references to other objects.
@@ -67,6 +68,9 @@ type Request struct {
 	Target               *dpv1alpha1.BackupTarget
 	ParentBackup         *dpv1alpha1.Backup
 	BaseBackup           *dpv1alpha1.Backup
+	// JobEncryptionConfig is the encryption config used by the backup jobs, it refers to
+	// the data key secret of the backup if the envelope encryption is enabled.
+	JobEncryptionConfig *dpv1alpha1.EncryptionConfig
 }
 
 func (r *Request) GetBackupType() string {
@@ -177,7 +181,13 @@ func (r *Request) buildBackupDataAction(targetPod *corev1.Pod, name string) (act
 		if err != nil {
 			return nil, fmt.Errorf("failed to build job action pod spec: %w", err)
 		}
-		r.InjectManagerContainer(podSpec, backupDataAct.SyncProgress, r.buildSyncProgressCommand())
+		// record the checksums of the backup files while they are uploaded, the
+		// checksum file is pushed to the backup repo by the manager container.
+		utils.InjectDatasafedChecksum(podSpec, BackupChecksumFileName,
+			corev1.EnvVar{Name: dptypes.DPChecksumMode, Value: utils.ChecksumModeRecord},
+			corev1.EnvVar{Name: dptypes.DPChecksumFile, Value: managerSharedMountPath + "/" + checksumsFileName})
+		r.InjectManagerContainer(podSpec, backupDataAct.SyncProgress,
+			r.buildSyncProgressCommand(BuildTargetRelativePath(r.Target, targetPod.Name) == ""))
 		return &action.JobAction{
 			Name:         name,
 			ObjectMeta:   *buildBackupJobObjMeta(r.Backup, name),
@@ -472,28 +482,38 @@ func (r *Request) BuildJobActionPodSpec(targetPod *corev1.Pod,
 		}
 	}
 
+	encryptionConfig := r.Status.EncryptionConfig
+	if r.JobEncryptionConfig != nil {
+		encryptionConfig = r.JobEncryptionConfig
+	}
 	utils.InjectDatasafed(podSpec, r.BackupRepo, RepoVolumeMountPath,
-		r.Status.EncryptionConfig, r.Status.KopiaRepoPath)
+		encryptionConfig, r.Status.KopiaRepoPath)
 	return podSpec, nil
 }
 
-func (r *Request) buildSyncProgressCommand() string {
+func (r *Request) buildSyncProgressCommand(reportChecksum bool) string {
 	// sync progress script will wait for the backup info file to be created,
-	// if the file is created, it will update the backup status and exit.
+	// if the file is created, it will push the checksums of the backup files,
+	// update the backup status and exit.
 	// If an exit file named with the backup info file with .exit suffix exists,
 	// it indicates that the container for backing up data exited abnormally,
 	// this script will exit.
+	// The checksum is reported only if the target path is the backup path, as
+	// the backup with multiple targets has a checksum file for each target.
 	return fmt.Sprintf(`
 set -o errexit
 set -o nounset
 
 export PATH="$PATH:$DP_DATASAFED_BIN_PATH"
 export DATASAFED_BACKEND_BASE_PATH="$DP_BACKUP_BASE_PATH"
+unset %[5]s
 
-backup_info_file="${%s}"
-sleep_seconds="${%s}"
-namespace="%s"
-backup_name="%s"
+backup_info_file="${%[1]s}"
+sleep_seconds="${%[2]s}"
+namespace="%[3]s"
+backup_name="%[4]s"
+checksum_file="${%[6]s:-}"
+report_checksum="%[8]t"
 
 if [ "$sleep_seconds" -le 0 ]; then
   sleep_seconds=30
@@ -515,12 +535,22 @@ done
 backup_info=$(cat "$backup_info_file")
 echo "backupInfo:${backup_info}"
 
+# push the checksums recorded while the backup files are uploaded
+if [ -n "$checksum_file" ] && [ -s "$checksum_file" ]; then
+  datasafed push "$checksum_file" "/%[7]s"
+  if [ "$report_checksum" = "true" ]; then
+    checksum="sha256:$(sha256sum "$checksum_file" | awk '{print $1}')"
+    kubectl -n "$namespace" patch backups.dataprotection.kubeblocks.io "$backup_name" --subresource=status --type=merge --patch "{\"status\":{\"checksum\":\"${checksum}\"}}"
+  fi
+fi
+
 status="{\"status\":${backup_info}}"
 kubectl -n "$namespace" patch backups.dataprotection.kubeblocks.io "$backup_name" --subresource=status --type=merge --patch "${status}"
 
 # save the backup CR object to the backup repo
 kubectl -n "$namespace" get backups.dataprotection.kubeblocks.io "$backup_name" -o json | datasafed push - "/kubeblocks-backup.json"
-`, dptypes.DPBackupInfoFile, dptypes.DPCheckInterval, r.Backup.Namespace, r.Backup.Name)
+`, dptypes.DPBackupInfoFile, dptypes.DPCheckInterval, r.Backup.Namespace, r.Backup.Name,
+		dptypes.DPChecksumMode, dptypes.DPChecksumFile, BackupChecksumFileName, reportChecksum)
 }
 
 func (r *Request) buildContinuousSyncProgressCommand() string {
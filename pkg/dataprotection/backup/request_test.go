@@ -20,10 +20,14 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package backup
 
 import (
+	"strings"
+	"testing"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
@@ -146,3 +150,19 @@ var _ = Describe("Request Test", func() {
 		})
 	})
 })
+
+func TestBuildSyncProgressCommand(t *testing.T) {
+	request := &Request{Backup: &dpv1alpha1.Backup{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "backup"},
+	}}
+	command := request.buildSyncProgressCommand(true)
+	if !strings.Contains(command, `datasafed push "$checksum_file" "/`+BackupChecksumFileName+`"`) {
+		t.Errorf("the checksum file is not pushed: %s", command)
+	}
+	if !strings.Contains(command, `report_checksum="true"`) || !strings.Contains(command, `\"checksum\":\"${checksum}\"`) {
+		t.Errorf("the checksum is not reported: %s", command)
+	}
+	if !strings.Contains(request.buildSyncProgressCommand(false), `report_checksum="false"`) {
+		t.Errorf("the checksum of the backup with multiple targets should not be reported")
+	}
+}
@@ -103,6 +103,14 @@ func (s *Scheduler) validate() error {
 		}
 	}
 
+	// validate the backup method to verify
+	if verification := s.BackupSchedule.Spec.Verification; verification != nil && boolptr.IsSetToTrue(verification.Enabled) {
+		if methodInBackupPolicy(verification.BackupMethod) == nil {
+			return fmt.Errorf("backup method %s to verify is not in backup policy %s/%s",
+				verification.BackupMethod, s.BackupPolicy.Namespace, s.BackupPolicy.Name)
+		}
+	}
+
 	return nil
 }
 
@@ -28,4 +28,10 @@ const (
 
 	// BackupInfoFileName is the backup info file name in the backup path.
 	BackupInfoFileName = "backup.info"
+
+	// BackupManifestFileName is the file name of the backup manifest in the backup path.
+	BackupManifestFileName = "kubeblocks-backup.json"
+
+	// BackupChecksumFileName is the file name of the checksums of backup files in the backup path.
+	BackupChecksumFileName = "kubeblocks-checksums.sha256"
 )
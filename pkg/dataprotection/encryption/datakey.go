@@ -0,0 +1,171 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package encryption
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+)
+
+const (
+	// dataKeyLength is the length of the data key in bytes.
+	dataKeyLength = 32
+	// dataKeySecretKey is the key of the data key in the data key secret.
+	dataKeySecretKey = "dataKey"
+)
+
+// IsEnvelopeEncryption returns true if the envelope encryption is enabled by the encryption config.
+func IsEnvelopeEncryption(config *dpv1alpha1.EncryptionConfig) bool {
+	return config != nil && config.KeyManagement != nil
+}
+
+// NewBackupEncryptionKey generates a random data key and wraps it with the key-encryption key
+// specified by the key management config.
+func NewBackupEncryptionKey(ctx context.Context, cli client.Reader, namespace string,
+	config *dpv1alpha1.EncryptionKeyManagement) (*dpv1alpha1.BackupEncryptionKey, error) {
+	provider, err := NewKeyProvider(ctx, cli, namespace, config)
+	if err != nil {
+		return nil, err
+	}
+	dataKey := make([]byte, dataKeyLength)
+	if _, err = rand.Read(dataKey); err != nil {
+		return nil, err
+	}
+	wrappedKey, err := provider.WrapKey(ctx, config.KeyID, dataKey)
+	if err != nil {
+		return nil, err
+	}
+	return &dpv1alpha1.BackupEncryptionKey{
+		Provider:       getProviderName(config),
+		KeyID:          config.KeyID,
+		WrappedDataKey: base64.StdEncoding.EncodeToString(wrappedKey),
+	}, nil
+}
+
+// UnwrapDataKey unwraps the data key of the backup with the key-encryption key recorded in the backup status.
+func UnwrapDataKey(ctx context.Context, cli client.Reader, backup *dpv1alpha1.Backup) ([]byte, error) {
+	encryptionKey := backup.Status.EncryptionKey
+	if !IsEnvelopeEncryption(backup.Status.EncryptionConfig) || encryptionKey == nil {
+		return nil, fmt.Errorf("backup %s/%s has no wrapped data key", backup.Namespace, backup.Name)
+	}
+	config := backup.Status.EncryptionConfig.KeyManagement.DeepCopy()
+	if len(encryptionKey.Provider) != 0 {
+		config.Provider = encryptionKey.Provider
+	}
+	provider, err := NewKeyProvider(ctx, cli, backup.Namespace, config)
+	if err != nil {
+		return nil, err
+	}
+	wrappedKey, err := base64.StdEncoding.DecodeString(encryptionKey.WrappedDataKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the wrapped data key: %w", err)
+	}
+	return provider.UnwrapKey(ctx, encryptionKey.KeyID, wrappedKey)
+}
+
+// EnsureDataKeySecret ensures the secret which stores the data key of the backup in the namespace of the owner,
+// and returns the encryption config whose passphrase refers to the data key. The returned config is used by the
+// jobs which access the backup data. If the envelope encryption is not enabled, the encryption config of the
+// backup is returned.
+//
+// The data key is stored unwrapped since datasafed reads the passphrase from the secret directly, so the secret
+// only lives as long as the jobs need it: it is owned by the owner and removed by DeleteDataKeySecrets once the
+// jobs are finished, the data key can be unwrapped again from the backup status when it is needed later.
+func EnsureDataKeySecret(ctx context.Context, cli client.Client, scheme *runtime.Scheme, owner client.Object,
+	backup *dpv1alpha1.Backup, secretName string, labels map[string]string) (*dpv1alpha1.EncryptionConfig, error) {
+	if !IsEnvelopeEncryption(backup.Status.EncryptionConfig) {
+		return backup.Status.EncryptionConfig, nil
+	}
+	jobEncryptionConfig := &dpv1alpha1.EncryptionConfig{
+		Algorithm: backup.Status.EncryptionConfig.Algorithm,
+		PassPhraseSecretKeyRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+			Key:                  dataKeySecretKey,
+		},
+	}
+	secret := &corev1.Secret{}
+	err := cli.Get(ctx, client.ObjectKey{Name: secretName, Namespace: owner.GetNamespace()}, secret)
+	if err == nil {
+		return jobEncryptionConfig, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+	dataKey, err := UnwrapDataKey(ctx, cli, backup)
+	if err != nil {
+		return nil, err
+	}
+	secret = &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      secretName,
+			Namespace: owner.GetNamespace(),
+			Labels:    labels,
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			dataKeySecretKey: []byte(hex.EncodeToString(dataKey)),
+		},
+	}
+	if err = controllerutil.SetControllerReference(owner, secret, scheme); err != nil {
+		return nil, err
+	}
+	if err = cli.Create(ctx, secret); err != nil && !apierrors.IsAlreadyExists(err) {
+		return nil, err
+	}
+	return jobEncryptionConfig, nil
+}
+
+// DeleteDataKeySecrets deletes the data key secrets owned by the owner which match the labels.
+func DeleteDataKeySecrets(ctx context.Context, cli client.Client, owner client.Object, labels map[string]string) error {
+	secrets := &corev1.SecretList{}
+	if err := cli.List(ctx, secrets, client.InNamespace(owner.GetNamespace()), client.MatchingLabels(labels)); err != nil {
+		return err
+	}
+	for i := range secrets.Items {
+		secret := &secrets.Items[i]
+		if _, ok := secret.Data[dataKeySecretKey]; !ok || !metav1.IsControlledBy(secret, owner) {
+			continue
+		}
+		if err := cli.Delete(ctx, secret); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetDataKeySecretName returns the name of the data key secret of the backup which is owned by the owner.
+func GetDataKeySecretName(owner client.Object, backup *dpv1alpha1.Backup) string {
+	if _, ok := owner.(*dpv1alpha1.Backup); ok {
+		return fmt.Sprintf("%s-data-key", backup.Name)
+	}
+	return fmt.Sprintf("%s-%s-data-key", owner.GetName(), backup.Name)
+}
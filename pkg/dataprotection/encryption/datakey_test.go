@@ -0,0 +1,139 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package encryption
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+)
+
+func TestEnvelopeEncryption(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, dpv1alpha1.AddToScheme(scheme))
+
+	kekSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "kek", Namespace: "default"},
+		Data:       map[string][]byte{"key-1": []byte("old-key")},
+	}
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(kekSecret).Build()
+	keyManagement := &dpv1alpha1.EncryptionKeyManagement{
+		KeyID:     "key-1",
+		SecretRef: &corev1.LocalObjectReference{Name: kekSecret.Name},
+	}
+
+	encryptionKey, err := NewBackupEncryptionKey(ctx, cli, "default", keyManagement)
+	require.NoError(t, err)
+	assert.Equal(t, dpv1alpha1.DefaultEncryptionKeyProvider, encryptionKey.Provider)
+	assert.Equal(t, "key-1", encryptionKey.KeyID)
+
+	// rotate the key-encryption key, the old key is kept for the existing backups.
+	kekSecret.Data["key-2"] = []byte("new-key")
+	require.NoError(t, cli.Update(ctx, kekSecret))
+	backup := &dpv1alpha1.Backup{
+		ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: "default", UID: "uid"},
+		Status: dpv1alpha1.BackupStatus{
+			EncryptionConfig: &dpv1alpha1.EncryptionConfig{
+				Algorithm: "AES-256-CFB",
+				KeyManagement: &dpv1alpha1.EncryptionKeyManagement{
+					KeyID:     "key-2",
+					SecretRef: keyManagement.SecretRef,
+				},
+			},
+			EncryptionKey: encryptionKey,
+		},
+	}
+	dataKey, err := UnwrapDataKey(ctx, cli, backup)
+	require.NoError(t, err)
+	assert.Len(t, dataKey, dataKeyLength)
+
+	config, err := EnsureDataKeySecret(ctx, cli, scheme, backup, backup, GetDataKeySecretName(backup, backup), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "AES-256-CFB", config.Algorithm)
+	assert.Equal(t, "backup-data-key", config.PassPhraseSecretKeyRef.Name)
+	secret := &corev1.Secret{}
+	require.NoError(t, cli.Get(ctx, client.ObjectKey{Name: "backup-data-key", Namespace: "default"}, secret))
+	assert.Equal(t, backup.Name, secret.OwnerReferences[0].Name)
+
+	// the data key secret is deleted once the jobs are finished, and the other secrets are kept.
+	require.NoError(t, DeleteDataKeySecrets(ctx, cli, backup, nil))
+	assert.True(t, apierrors.IsNotFound(cli.Get(ctx, client.ObjectKeyFromObject(secret), &corev1.Secret{})))
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(kekSecret), &corev1.Secret{}))
+
+	// the wrapped data key can not be unwrapped by another key.
+	backup.Status.EncryptionKey = &dpv1alpha1.BackupEncryptionKey{KeyID: "key-2", WrappedDataKey: encryptionKey.WrappedDataKey}
+	_, err = UnwrapDataKey(ctx, cli, backup)
+	assert.Error(t, err)
+
+	// the key provider is not registered.
+	_, err = NewBackupEncryptionKey(ctx, cli, "default", &dpv1alpha1.EncryptionKeyManagement{Provider: "Vault", KeyID: "key-1"})
+	assert.Error(t, err)
+}
+
+// stubKeyProvider is a stub of the external key management service, which xors the data key with the key ID.
+type stubKeyProvider struct{}
+
+func (p *stubKeyProvider) WrapKey(_ context.Context, keyID string, dataKey []byte) ([]byte, error) {
+	wrapped := make([]byte, len(dataKey))
+	for i := range dataKey {
+		wrapped[i] = dataKey[i] ^ keyID[i%len(keyID)]
+	}
+	return wrapped, nil
+}
+
+func (p *stubKeyProvider) UnwrapKey(ctx context.Context, keyID string, wrappedKey []byte) ([]byte, error) {
+	return p.WrapKey(ctx, keyID, wrappedKey)
+}
+
+func TestRegisterKeyProvider(t *testing.T) {
+	ctx := context.Background()
+	RegisterKeyProvider("Stub", func(context.Context, client.Reader, string, *dpv1alpha1.EncryptionKeyManagement) (KeyProvider, error) {
+		return &stubKeyProvider{}, nil
+	})
+	cli := fake.NewClientBuilder().Build()
+	encryptionKey, err := NewBackupEncryptionKey(ctx, cli, "default", &dpv1alpha1.EncryptionKeyManagement{Provider: "Stub", KeyID: "kms-key"})
+	require.NoError(t, err)
+	assert.Equal(t, "Stub", encryptionKey.Provider)
+
+	backup := &dpv1alpha1.Backup{
+		Status: dpv1alpha1.BackupStatus{
+			EncryptionConfig: &dpv1alpha1.EncryptionConfig{
+				KeyManagement: &dpv1alpha1.EncryptionKeyManagement{Provider: "Stub", KeyID: "kms-key"},
+			},
+			EncryptionKey: encryptionKey,
+		},
+	}
+	dataKey, err := UnwrapDataKey(ctx, cli, backup)
+	require.NoError(t, err)
+	assert.Len(t, dataKey, dataKeyLength)
+}
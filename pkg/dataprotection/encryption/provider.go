@@ -0,0 +1,144 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package encryption
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+)
+
+// KeyProvider manages the key-encryption keys, which are used to wrap and unwrap the data keys of backups.
+// The key-encryption keys never leave the provider, which is the same as the interface of a KMIP server
+// or a Vault transit engine.
+type KeyProvider interface {
+	// WrapKey encrypts the data key with the key-encryption key identified by keyID.
+	WrapKey(ctx context.Context, keyID string, dataKey []byte) ([]byte, error)
+
+	// UnwrapKey decrypts the wrapped data key with the key-encryption key identified by keyID.
+	UnwrapKey(ctx context.Context, keyID string, wrappedKey []byte) ([]byte, error)
+}
+
+// KeyProviderFactory creates a KeyProvider for the key management config in the namespace.
+type KeyProviderFactory func(ctx context.Context, cli client.Reader, namespace string, config *dpv1alpha1.EncryptionKeyManagement) (KeyProvider, error)
+
+var (
+	keyProvidersLock sync.RWMutex
+	keyProviders     = map[string]KeyProviderFactory{
+		dpv1alpha1.DefaultEncryptionKeyProvider: newSecretKeyProvider,
+	}
+)
+
+// RegisterKeyProvider registers a key provider with the name, the existing provider with the same name will be replaced.
+func RegisterKeyProvider(name string, factory KeyProviderFactory) {
+	keyProvidersLock.Lock()
+	defer keyProvidersLock.Unlock()
+	keyProviders[name] = factory
+}
+
+// NewKeyProvider creates the KeyProvider specified by the key management config.
+func NewKeyProvider(ctx context.Context, cli client.Reader, namespace string, config *dpv1alpha1.EncryptionKeyManagement) (KeyProvider, error) {
+	keyProvidersLock.RLock()
+	factory, ok := keyProviders[getProviderName(config)]
+	keyProvidersLock.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("the key provider %s is not registered", getProviderName(config))
+	}
+	return factory(ctx, cli, namespace, config)
+}
+
+func getProviderName(config *dpv1alpha1.EncryptionKeyManagement) string {
+	if len(config.Provider) == 0 {
+		return dpv1alpha1.DefaultEncryptionKeyProvider
+	}
+	return config.Provider
+}
+
+// secretKeyProvider is the built-in key provider, which reads the key-encryption keys from a secret
+// and wraps the data keys with AES-256-GCM.
+type secretKeyProvider struct {
+	secret *corev1.Secret
+}
+
+var _ KeyProvider = &secretKeyProvider{}
+
+func newSecretKeyProvider(ctx context.Context, cli client.Reader, namespace string, config *dpv1alpha1.EncryptionKeyManagement) (KeyProvider, error) {
+	if config.SecretRef == nil || len(config.SecretRef.Name) == 0 {
+		return nil, fmt.Errorf("keyManagement.secretRef is empty")
+	}
+	secret := &corev1.Secret{}
+	if err := cli.Get(ctx, client.ObjectKey{Name: config.SecretRef.Name, Namespace: namespace}, secret); err != nil {
+		return nil, fmt.Errorf("failed to get the key-encryption key secret %s: %w", config.SecretRef.Name, err)
+	}
+	return &secretKeyProvider{secret: secret}, nil
+}
+
+func (p *secretKeyProvider) aead(keyID string) (cipher.AEAD, error) {
+	kek, ok := p.secret.Data[keyID]
+	if !ok || len(kek) == 0 {
+		return nil, fmt.Errorf("the key-encryption key %s is not found in secret %s", keyID, p.secret.Name)
+	}
+	// derive a 256-bit key from the key material.
+	key := sha256.Sum256(kek)
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func (p *secretKeyProvider) WrapKey(_ context.Context, keyID string, dataKey []byte) ([]byte, error) {
+	gcm, err := p.aead(keyID)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	// the key ID is authenticated as the additional data, so that the wrapped key can not be
+	// unwrapped by another key with the same material.
+	return gcm.Seal(nonce, nonce, dataKey, []byte(keyID)), nil
+}
+
+func (p *secretKeyProvider) UnwrapKey(_ context.Context, keyID string, wrappedKey []byte) ([]byte, error) {
+	gcm, err := p.aead(keyID)
+	if err != nil {
+		return nil, err
+	}
+	if len(wrappedKey) < gcm.NonceSize() {
+		return nil, fmt.Errorf("the wrapped data key is malformed")
+	}
+	nonce, ciphertext := wrappedKey[:gcm.NonceSize()], wrappedKey[gcm.NonceSize():]
+	dataKey, err := gcm.Open(nil, nonce, ciphertext, []byte(keyID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap the data key with the key-encryption key %s: %w", keyID, err)
+	}
+	return dataKey, nil
+}
@@ -36,6 +36,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/common"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	dpbackup "github.com/apecloud/kubeblocks/pkg/dataprotection/backup"
 	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
 	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
 	viper "github.com/apecloud/kubeblocks/pkg/viperx"
@@ -47,6 +48,7 @@ type restoreJobBuilder struct {
 	backupSet          BackupActionSet
 	backupRepo         *dpv1alpha1.BackupRepo
 	buildWithRepo      bool
+	encryptionConfig   *dpv1alpha1.EncryptionConfig
 	env                []corev1.EnvVar
 	envFrom            []corev1.EnvFromSource
 	commonVolumes      []corev1.Volume
@@ -70,6 +72,7 @@ func newRestoreJobBuilder(restore *dpv1alpha1.Restore, backupSet BackupActionSet
 		restore:            restore,
 		backupSet:          backupSet,
 		backupRepo:         backupRepo,
+		encryptionConfig:   backupSet.Backup.Status.EncryptionConfig,
 		stage:              stage,
 		commonVolumes:      []corev1.Volume{},
 		commonVolumeMounts: []corev1.VolumeMount{},
@@ -132,6 +135,12 @@ func (r *restoreJobBuilder) addToSpecificVolumesAndMounts(volume *corev1.Volume,
 	return r
 }
 
+// setEncryptionConfig sets the encryption config used to access the backup data.
+func (r *restoreJobBuilder) setEncryptionConfig(encryptionConfig *dpv1alpha1.EncryptionConfig) *restoreJobBuilder {
+	r.encryptionConfig = encryptionConfig
+	return r
+}
+
 func (r *restoreJobBuilder) setImage(image string) *restoreJobBuilder {
 	r.image = image
 	return r
@@ -425,15 +434,18 @@ func (r *restoreJobBuilder) build() *batchv1.Job {
 	if r.buildWithRepo {
 		mountPath := "/backupdata"
 		kopiaRepoPath := r.backupSet.Backup.Status.KopiaRepoPath
-		encryptionConfig := r.backupSet.Backup.Status.EncryptionConfig
 		if r.backupRepo != nil {
 			utils.InjectDatasafed(&job.Spec.Template.Spec, r.backupRepo, mountPath,
-				encryptionConfig, kopiaRepoPath)
+				r.encryptionConfig, kopiaRepoPath)
 		} else if pvcName := r.backupSet.Backup.Status.PersistentVolumeClaimName; pvcName != "" {
 			// If the backup object was created in an old version that doesn't have the backupRepo field,
 			// use the PVC name field as a fallback.
 			utils.InjectDatasafedWithPVC(&job.Spec.Template.Spec, pvcName, mountPath, kopiaRepoPath)
 		}
+		// verify the backup files against the checksums recorded in the backup while they are downloaded.
+		utils.InjectDatasafedChecksum(&job.Spec.Template.Spec, dpbackup.BackupChecksumFileName,
+			corev1.EnvVar{Name: dptypes.DPChecksumMode, Value: utils.ChecksumModeVerify},
+			corev1.EnvVar{Name: dptypes.DPBackupChecksum, Value: r.backupSet.Backup.Status.Checksum})
 	}
 	return job
 }
@@ -23,6 +23,7 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	vsv1 "github.com/kubernetes-csi/external-snapshotter/client/v6/apis/volumesnapshot/v1"
@@ -40,6 +41,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/instanceset"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	dpencryption "github.com/apecloud/kubeblocks/pkg/dataprotection/encryption"
 	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
 	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
 	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils/boolptr"
@@ -441,9 +443,13 @@ func (r *RestoreManager) RestorePVCFromSnapshot(reqCtx intctrlutil.RequestCtx, c
 }
 
 func (r *RestoreManager) prepareBackupRepo(reqCtx intctrlutil.RequestCtx, cli client.Client, backupSet BackupActionSet) (*dpv1alpha1.BackupRepo, error) {
-	if backupSet.Backup.Status.BackupRepoName != "" {
+	backupRepoName, err := SelectBackupRepoName(reqCtx, cli, r.Restore, backupSet.Backup)
+	if err != nil {
+		return nil, err
+	}
+	if backupRepoName != "" {
 		backupRepo := &dpv1alpha1.BackupRepo{}
-		err := cli.Get(reqCtx.Ctx, client.ObjectKey{Name: backupSet.Backup.Status.BackupRepoName}, backupRepo)
+		err := cli.Get(reqCtx.Ctx, client.ObjectKey{Name: backupRepoName}, backupRepo)
 		if err != nil {
 			if apierrors.IsNotFound(err) {
 				err = intctrlutil.NewFatalError(err.Error())
@@ -455,6 +461,63 @@ func (r *RestoreManager) prepareBackupRepo(reqCtx intctrlutil.RequestCtx, cli cl
 	return nil, nil
 }
 
+// SelectBackupRepoName selects the backup repo to restore the backup from. The repo specified
+// by the restore is preferred, otherwise the repo of the backup is used, unless it is not ready
+// and an available copy of the backup exists in a ready secondary repo.
+func SelectBackupRepoName(reqCtx intctrlutil.RequestCtx, cli client.Client, restore *dpv1alpha1.Restore, backup *dpv1alpha1.Backup) (string, error) {
+	primaryRepoName := backup.Status.BackupRepoName
+	if specifiedRepoName := restore.Spec.Backup.BackupRepoName; specifiedRepoName != "" {
+		if specifiedRepoName == primaryRepoName {
+			return primaryRepoName, nil
+		}
+		if replica := backup.Status.GetReplica(specifiedRepoName); replica == nil || !replica.IsAvailable() {
+			return "", intctrlutil.NewFatalError(fmt.Sprintf(`backup "%s" has no available copy in backup repo "%s"`,
+				backup.Name, specifiedRepoName))
+		}
+		return specifiedRepoName, nil
+	}
+	if primaryRepoName == "" || len(backup.Status.Replicas) == 0 {
+		return primaryRepoName, nil
+	}
+
+	isRepoReady := func(name string) (bool, error) {
+		backupRepo := &dpv1alpha1.BackupRepo{}
+		if err := cli.Get(reqCtx.Ctx, client.ObjectKey{Name: name}, backupRepo); err != nil {
+			return false, client.IgnoreNotFound(err)
+		}
+		return backupRepo.Status.Phase == dpv1alpha1.BackupRepoReady, nil
+	}
+	if ready, err := isRepoReady(primaryRepoName); err != nil || ready {
+		return primaryRepoName, err
+	}
+	for _, replica := range backup.Status.Replicas {
+		if !replica.IsAvailable() {
+			continue
+		}
+		ready, err := isRepoReady(replica.BackupRepoName)
+		if err != nil {
+			return "", err
+		}
+		if ready {
+			reqCtx.Log.V(1).Info("the backup repo is not ready, restore from the copy in the secondary repo",
+				"backup", backup.Name, "backupRepo", primaryRepoName, "secondaryRepo", replica.BackupRepoName)
+			return replica.BackupRepoName, nil
+		}
+	}
+	return primaryRepoName, nil
+}
+
+// prepareEncryptionConfig returns the encryption config for the restore jobs. If the backup is encrypted with
+// the envelope encryption, the data key is unwrapped to a secret owned by the restore.
+func (r *RestoreManager) prepareEncryptionConfig(reqCtx intctrlutil.RequestCtx, cli client.Client, backupSet BackupActionSet) (*dpv1alpha1.EncryptionConfig, error) {
+	encryptionConfig, err := dpencryption.EnsureDataKeySecret(reqCtx.Ctx, cli, r.Schema, r.Restore, backupSet.Backup,
+		dpencryption.GetDataKeySecretName(r.Restore, backupSet.Backup), BuildRestoreLabels(r.Restore.Name))
+	if err != nil {
+		return nil, intctrlutil.NewFatalError(fmt.Sprintf("failed to prepare the data key of backup %s: %s", backupSet.Backup.Name, err.Error()))
+	}
+	return encryptionConfig, nil
+}
+
 // BuildPrepareDataJobs builds the restore jobs for prepare pvc's data, and will create the target pvcs if not exist.
 func (r *RestoreManager) BuildPrepareDataJobs(reqCtx intctrlutil.RequestCtx, cli client.Client, backupSet BackupActionSet, target *dpv1alpha1.BackupStatusTarget, actionName string) ([]*batchv1.Job, error) {
 	prepareDataConfig := r.Restore.Spec.PrepareDataConfig
@@ -468,7 +531,12 @@ func (r *RestoreManager) BuildPrepareDataJobs(reqCtx intctrlutil.RequestCtx, cli
 	if err != nil {
 		return nil, err
 	}
+	encryptionConfig, err := r.prepareEncryptionConfig(reqCtx, cli, backupSet)
+	if err != nil {
+		return nil, err
+	}
 	jobBuilder := newRestoreJobBuilder(r.Restore, backupSet, backupRepo, dpv1alpha1.PrepareData).
+		setEncryptionConfig(encryptionConfig).
 		setImage(backupSet.ActionSet.Spec.Restore.PrepareData.Image).
 		setCommand(backupSet.ActionSet.Spec.Restore.PrepareData.Command).
 		setServiceAccount(r.WorkerServiceAccount).
@@ -577,11 +645,16 @@ func (r *RestoreManager) BuildVolumePopulateJob(
 	if err != nil {
 		return nil, err
 	}
+	encryptionConfig, err := r.prepareEncryptionConfig(reqCtx, cli, backupSet)
+	if err != nil {
+		return nil, err
+	}
 	sourceTargetPodName, err := GetSourcePodNameFromTarget(target, prepareDataConfig.RequiredPolicyForAllPodSelection, 0)
 	if err != nil {
 		return nil, err
 	}
 	jobBuilder := newRestoreJobBuilder(r.Restore, backupSet, backupRepo, dpv1alpha1.PrepareData).
+		setEncryptionConfig(encryptionConfig).
 		setJobName(fmt.Sprintf("%s-%d", populatePVC.Name, index)).
 		addLabel(DataProtectionPopulatePVCLabelKey, populatePVC.Name).
 		setImage(backupSet.ActionSet.Spec.Restore.PrepareData.Image).
@@ -610,6 +683,10 @@ func (r *RestoreManager) BuildPostReadyActionJobs(reqCtx intctrlutil.RequestCtx,
 	if err != nil {
 		return nil, err
 	}
+	encryptionConfig, err := r.prepareEncryptionConfig(reqCtx, cli, backupSet)
+	if err != nil {
+		return nil, err
+	}
 	actionSpec := backupSet.ActionSet.Spec.Restore.PostReady[step]
 	getTargetPodList := func(labelSelector metav1.LabelSelector, msgKey string) (*corev1.PodList, error) {
 		targetPodList, err := utils.GetPodListByLabelSelector(reqCtx, cli, &labelSelector)
@@ -626,7 +703,8 @@ func (r *RestoreManager) BuildPostReadyActionJobs(reqCtx intctrlutil.RequestCtx,
 		jobName := fmt.Sprintf("restore-post-ready-%s-%s-%d-%d", r.Restore.UID[:8], backupSet.Backup.Name, step, index)
 		return cutJobName(jobName)
 	}
-	jobBuilder := newRestoreJobBuilder(r.Restore, backupSet, backupRepo, dpv1alpha1.PostReady)
+	jobBuilder := newRestoreJobBuilder(r.Restore, backupSet, backupRepo, dpv1alpha1.PostReady).
+		setEncryptionConfig(encryptionConfig)
 	buildJobsForJobAction := func() ([]*batchv1.Job, error) {
 		jobAction := r.Restore.Spec.ReadyConfig.JobAction
 		if jobAction == nil {
@@ -809,6 +887,14 @@ func (r *RestoreManager) CheckJobsDone(
 			BackupName: backupSet.Backup.Name,
 		}
 		done, _, errMsg := utils.IsJobFinished(fetchedJobs[i])
+		if done && errMsg == "" {
+			// the restore script may ignore the failure of datasafed in a pipeline.
+			mismatchMsg, err := r.getChecksumMismatchMessage(fetchedJobs[i])
+			if err != nil {
+				return false, false, err
+			}
+			errMsg = mismatchMsg
+		}
 		switch {
 		case errMsg != "":
 			existFailedJob = true
@@ -876,6 +962,27 @@ func (r *RestoreManager) CheckIfRestoreContainerTerminated(job *batchv1.Job) (no
 	return normalTerminated, nil
 }
 
+// getChecksumMismatchMessage gets the message of the checksum mismatch written to the
+// termination log of the `restore` container, which means the restored data is corrupted.
+func (r *RestoreManager) getChecksumMismatchMessage(job *batchv1.Job) (string, error) {
+	podList, err := utils.GetAssociatedPodsOfJob(context.Background(), r.Client, job.Namespace, job.Name)
+	if err != nil {
+		return "", err
+	}
+	for _, pod := range podList.Items {
+		for _, containerStatus := range pod.Status.ContainerStatuses {
+			if containerStatus.Name != Restore || containerStatus.State.Terminated == nil {
+				continue
+			}
+			message := strings.TrimSpace(containerStatus.State.Terminated.Message)
+			if strings.HasPrefix(message, utils.ChecksumMismatchMessage) {
+				return message, nil
+			}
+		}
+	}
+	return "", nil
+}
+
 // StopManagerContainerByJob stops the `restore manager` containers by the job.
 func (r *RestoreManager) StopManagerContainerByJob(job *batchv1.Job) error {
 	podList, err := utils.GetAssociatedPodsOfJob(context.Background(), r.Client, job.Namespace, job.Name)
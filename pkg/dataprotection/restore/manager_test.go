@@ -20,18 +20,23 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package restore
 
 import (
+	"context"
 	"fmt"
 	"strconv"
+	"testing"
 	"time"
 
+	"github.com/go-logr/logr"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
@@ -440,3 +445,75 @@ var _ = Describe("RestoreManager Test", func() {
 	})
 
 })
+
+func TestSelectBackupRepoName(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = dpv1alpha1.AddToScheme(scheme)
+	newRepo := func(name string, phase dpv1alpha1.BackupRepoPhase) *dpv1alpha1.BackupRepo {
+		return &dpv1alpha1.BackupRepo{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status:     dpv1alpha1.BackupRepoStatus{Phase: phase},
+		}
+	}
+	backup := &dpv1alpha1.Backup{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "backup"},
+		Status: dpv1alpha1.BackupStatus{
+			BackupRepoName: "primary",
+			Replicas: []dpv1alpha1.BackupReplicaStatus{
+				{BackupRepoName: "running", Phase: dpv1alpha1.BackupReplicaPhaseRunning},
+				{BackupRepoName: "secondary", Phase: dpv1alpha1.BackupReplicaPhaseCompleted},
+			},
+		},
+	}
+	reqCtx := intctrlutil.RequestCtx{Ctx: context.Background(), Log: logr.Discard()}
+	for _, tc := range []struct {
+		name          string
+		repos         []client.Object
+		specifiedRepo string
+		expected      string
+		expectErr     bool
+	}{
+		{
+			name:     "primary repo is ready",
+			repos:    []client.Object{newRepo("primary", dpv1alpha1.BackupRepoReady), newRepo("secondary", dpv1alpha1.BackupRepoReady)},
+			expected: "primary",
+		},
+		{
+			name:     "primary repo is not ready",
+			repos:    []client.Object{newRepo("primary", dpv1alpha1.BackupRepoFailed), newRepo("running", dpv1alpha1.BackupRepoReady), newRepo("secondary", dpv1alpha1.BackupRepoReady)},
+			expected: "secondary",
+		},
+		{
+			name:     "no ready copy",
+			repos:    []client.Object{newRepo("primary", dpv1alpha1.BackupRepoFailed), newRepo("secondary", dpv1alpha1.BackupRepoFailed)},
+			expected: "primary",
+		},
+		{
+			name:          "specified repo with available copy",
+			repos:         []client.Object{newRepo("primary", dpv1alpha1.BackupRepoReady)},
+			specifiedRepo: "secondary",
+			expected:      "secondary",
+		},
+		{
+			name:          "specified repo without available copy",
+			specifiedRepo: "running",
+			expectErr:     true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tc.repos...).Build()
+			restore := &dpv1alpha1.Restore{}
+			restore.Spec.Backup.BackupRepoName = tc.specifiedRepo
+			repoName, err := SelectBackupRepoName(reqCtx, cli, restore, backup)
+			if tc.expectErr {
+				if !intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal) {
+					t.Errorf("expect a fatal error, got %v", err)
+				}
+				return
+			}
+			if err != nil || repoName != tc.expected {
+				t.Errorf("expect repo %s, got %s %v", tc.expected, repoName, err)
+			}
+		})
+	}
+}
@@ -36,6 +36,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
 	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
@@ -506,3 +507,49 @@ func getComponentNameFromObj(obj client.Object) string {
 	}
 	return obj.GetLabels()[constant.KBAppComponentLabelKey]
 }
+
+// NormalizeSchedulingPolicy normalizes the scheduling policy copied from the backed up cluster,
+// so that the label selectors select the pods of the restored cluster.
+func NormalizeSchedulingPolicy(clusterName string, schedulePolicy *appsv1.SchedulingPolicy) {
+	if schedulePolicy == nil {
+		return
+	}
+	updateLabelSelector := func(selector *metav1.LabelSelector) {
+		if selector == nil {
+			return
+		}
+		if _, ok := selector.MatchLabels[constant.AppInstanceLabelKey]; ok {
+			selector.MatchLabels[constant.AppInstanceLabelKey] = clusterName
+		}
+		for i := range selector.MatchExpressions {
+			matchExpression := &selector.MatchExpressions[i]
+			if matchExpression.Key == constant.AppInstanceLabelKey {
+				matchExpression.Values = []string{clusterName}
+			}
+		}
+	}
+	for i := range schedulePolicy.TopologySpreadConstraints {
+		updateLabelSelector(schedulePolicy.TopologySpreadConstraints[i].LabelSelector)
+	}
+	if schedulePolicy.Affinity == nil {
+		return
+	}
+	updatePodAffinityTerm := func(pats []corev1.PodAffinityTerm, wpats []corev1.WeightedPodAffinityTerm) {
+		for i := range pats {
+			podAffinityTerm := &pats[i]
+			updateLabelSelector(podAffinityTerm.LabelSelector)
+		}
+		for i := range wpats {
+			wpat := &wpats[i]
+			updateLabelSelector(wpat.PodAffinityTerm.LabelSelector)
+		}
+	}
+	if schedulePolicy.Affinity.PodAntiAffinity != nil {
+		updatePodAffinityTerm(schedulePolicy.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution,
+			schedulePolicy.Affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution)
+	}
+	if schedulePolicy.Affinity.PodAffinity != nil {
+		updatePodAffinityTerm(schedulePolicy.Affinity.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution,
+			schedulePolicy.Affinity.PodAffinity.PreferredDuringSchedulingIgnoredDuringExecution)
+	}
+}
@@ -0,0 +1,478 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package restore
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils/boolptr"
+)
+
+const (
+	verificationClusterPrefix  = "verify-"
+	verificationCheckJobPrefix = "verify-check-"
+	verificationCheckContainer = "check"
+	defaultVerificationTimeout = time.Hour
+	verificationCheckInterval  = 30 * time.Second
+	verificationRetryInterval  = 10 * time.Minute
+)
+
+// Verifier periodically verifies that the latest backup of the backup schedule can be
+// restored. The backup is restored into a throwaway cluster which contains only the backed
+// up component, in the same way as a cluster is restored from the backup. Then the check
+// action runs against the restored component, and the result is recorded in the backup status.
+type Verifier struct {
+	intctrlutil.RequestCtx
+	Client               client.Client
+	Scheme               *k8sruntime.Scheme
+	BackupSchedule       *dpv1alpha1.BackupSchedule
+	WorkerServiceAccount string
+}
+
+// Verify drives the verification of the backup schedule, and returns the duration after
+// which the verification should be checked again, zero means no need to check again.
+func (v *Verifier) Verify() (time.Duration, error) {
+	policy := v.BackupSchedule.Spec.Verification
+	if policy == nil || !boolptr.IsSetToTrue(policy.Enabled) {
+		return 0, nil
+	}
+	cluster, err := v.getVerificationCluster()
+	if err != nil {
+		return 0, err
+	}
+	if cluster != nil {
+		if !cluster.DeletionTimestamp.IsZero() {
+			// wait for the previous verification to be cleaned up
+			return verificationCheckInterval, nil
+		}
+		return v.checkVerification(cluster)
+	}
+
+	if after := v.nextVerificationAfter(); after > 0 {
+		return after, nil
+	}
+	backup, err := v.getLatestBackup()
+	if err != nil {
+		return 0, err
+	}
+	if backup == nil {
+		v.Log.V(1).Info("no completed backup to verify", "backupMethod", policy.BackupMethod)
+		return verificationRetryInterval, nil
+	}
+	return verificationCheckInterval, v.startVerification(backup)
+}
+
+func (v *Verifier) verificationLabels() map[string]string {
+	return map[string]string{
+		constant.AppManagedByLabelKey:      dptypes.AppName,
+		dptypes.BackupScheduleLabelKey:     v.BackupSchedule.Name,
+		dptypes.BackupVerificationLabelKey: "true",
+	}
+}
+
+func (v *Verifier) getVerificationCluster() (*appsv1.Cluster, error) {
+	clusterList := &appsv1.ClusterList{}
+	if err := v.Client.List(v.Ctx, clusterList, client.InNamespace(v.BackupSchedule.Namespace),
+		client.MatchingLabels(v.verificationLabels())); err != nil {
+		return nil, err
+	}
+	if len(clusterList.Items) == 0 {
+		return nil, nil
+	}
+	return &clusterList.Items[0], nil
+}
+
+func (v *Verifier) nextVerificationAfter() time.Duration {
+	status := v.BackupSchedule.Status.Verification
+	if status == nil || status.LastVerificationTime == nil {
+		return 0
+	}
+	return v.BackupSchedule.Spec.Verification.Interval.Duration - time.Since(status.LastVerificationTime.Time)
+}
+
+// getLatestBackup gets the latest completed backup of the backup method to verify.
+func (v *Verifier) getLatestBackup() (*dpv1alpha1.Backup, error) {
+	backupList := &dpv1alpha1.BackupList{}
+	if err := v.Client.List(v.Ctx, backupList, client.InNamespace(v.BackupSchedule.Namespace),
+		client.MatchingLabels{dptypes.BackupPolicyLabelKey: v.BackupSchedule.Spec.BackupPolicyName}); err != nil {
+		return nil, err
+	}
+	var backups []dpv1alpha1.Backup
+	for _, backup := range backupList.Items {
+		if backup.Spec.BackupMethod != v.BackupSchedule.Spec.Verification.BackupMethod ||
+			backup.Status.Phase != dpv1alpha1.BackupPhaseCompleted || !backup.DeletionTimestamp.IsZero() {
+			continue
+		}
+		backups = append(backups, backup)
+	}
+	if len(backups) == 0 {
+		return nil, nil
+	}
+	sort.Slice(backups, func(i, j int) bool {
+		return utils.CompareWithBackupStopTime(backups[j], backups[i])
+	})
+	return &backups[0], nil
+}
+
+// startVerification creates the throwaway cluster to restore the backup into.
+func (v *Verifier) startVerification(backup *dpv1alpha1.Backup) error {
+	cluster, err := v.buildCluster(backup)
+	if err != nil {
+		v.Recorder.Event(backup, corev1.EventTypeWarning, "VerifyBackupFailed", err.Error())
+		return v.recordResult(backup, &dpv1alpha1.BackupVerificationStatus{
+			Phase:               dpv1alpha1.BackupVerificationFailed,
+			StartTimestamp:      &metav1.Time{Time: time.Now()},
+			CompletionTimestamp: &metav1.Time{Time: time.Now()},
+			Message:             err.Error(),
+		})
+	}
+	if err = v.Client.Create(v.Ctx, cluster); err != nil {
+		return err
+	}
+	v.Recorder.Event(backup, corev1.EventTypeNormal, "VerifyingBackup",
+		fmt.Sprintf("start to verify the backup by restoring it into cluster %s", cluster.Name))
+	return v.recordResult(backup, &dpv1alpha1.BackupVerificationStatus{
+		Phase:          dpv1alpha1.BackupVerificationRunning,
+		ClusterName:    cluster.Name,
+		StartTimestamp: &metav1.Time{Time: time.Now()},
+	})
+}
+
+// buildCluster builds the throwaway cluster from the cluster snapshot of the backup, which
+// contains only the backed up component, and restores the component from the backup.
+func (v *Verifier) buildCluster(backup *dpv1alpha1.Backup) (*appsv1.Cluster, error) {
+	clusterString, ok := backup.Annotations[constant.ClusterSnapshotAnnotationKey]
+	if !ok {
+		return nil, fmt.Errorf("backup %s has no cluster snapshot to restore", backup.Name)
+	}
+	source := &appsv1.Cluster{}
+	if err := json.Unmarshal([]byte(clusterString), source); err != nil {
+		return nil, err
+	}
+	restoreAnnotation, err := GetRestoreFromBackupAnnotation(backup,
+		string(dpv1alpha1.VolumeClaimRestorePolicyParallel), "", nil, false, nil)
+	if err != nil {
+		return nil, err
+	}
+	compName := getComponentNameFromObj(backup)
+	cluster := &appsv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      v.verificationClusterName(backup),
+			Namespace: backup.Namespace,
+			Labels:    v.verificationLabels(),
+			Annotations: map[string]string{
+				constant.RestoreFromBackupAnnotationKey: restoreAnnotation,
+			},
+		},
+		Spec: source.Spec,
+	}
+	cluster.Labels[dptypes.BackupNameLabelKey] = backup.Name
+
+	// keep the backed up component only, the cluster topology is kept if the component
+	// definition is resolved from it, and the other components of the topology are created.
+	resolvedByTopology := false
+	var compSpecs []appsv1.ClusterComponentSpec
+	for _, spec := range cluster.Spec.ComponentSpecs {
+		if spec.Name != compName {
+			continue
+		}
+		spec.OfflineInstances = nil
+		NormalizeSchedulingPolicy(cluster.Name, spec.SchedulingPolicy)
+		resolvedByTopology = resolvedByTopology || spec.ComponentDef == ""
+		compSpecs = append(compSpecs, spec)
+	}
+	var shardings []appsv1.ClusterSharding
+	for _, sharding := range cluster.Spec.Shardings {
+		if sharding.Name != compName {
+			continue
+		}
+		for i := range sharding.Template.SystemAccounts {
+			sharding.Template.SystemAccounts[i].SecretRef = nil
+		}
+		NormalizeSchedulingPolicy(cluster.Name, sharding.Template.SchedulingPolicy)
+		resolvedByTopology = resolvedByTopology || (sharding.ShardingDef == "" && sharding.Template.ComponentDef == "")
+		shardings = append(shardings, sharding)
+	}
+	if len(compSpecs) == 0 && len(shardings) == 0 {
+		return nil, fmt.Errorf("component %s of backup %s is not found in the cluster snapshot", compName, backup.Name)
+	}
+	cluster.Spec.ComponentSpecs = compSpecs
+	cluster.Spec.Shardings = shardings
+	if !resolvedByTopology {
+		cluster.Spec.ClusterDef = ""
+		cluster.Spec.Topology = ""
+	}
+	cluster.Spec.TerminationPolicy = appsv1.WipeOut
+	cluster.Spec.Services = nil
+	cluster.Spec.Backup = nil
+	cluster.Spec.MaintenanceWindow = nil
+	NormalizeSchedulingPolicy(cluster.Name, cluster.Spec.SchedulingPolicy)
+	return cluster, nil
+}
+
+func (v *Verifier) verificationClusterName(backup *dpv1alpha1.Backup) string {
+	// keep the cluster name short, as the names of the workloads are prefixed with it.
+	if len(backup.UID) >= 8 {
+		return verificationClusterPrefix + string(backup.UID[:8])
+	}
+	return cutJobName(verificationClusterPrefix + backup.Name)
+}
+
+// checkVerification checks the restore of the throwaway cluster, runs the check action after
+// the cluster is running, and records the result when the verification is finished.
+func (v *Verifier) checkVerification(cluster *appsv1.Cluster) (time.Duration, error) {
+	backup := &dpv1alpha1.Backup{}
+	backupKey := client.ObjectKey{Namespace: cluster.Namespace, Name: cluster.Labels[dptypes.BackupNameLabelKey]}
+	if err := v.Client.Get(v.Ctx, backupKey, backup); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return 0, err
+		}
+		// the backup is deleted, clean up the verification.
+		return verificationCheckInterval, v.cleanup(cluster)
+	}
+
+	finish := func(phase dpv1alpha1.BackupVerificationPhase, message string) (time.Duration, error) {
+		eventType := corev1.EventTypeNormal
+		if phase == dpv1alpha1.BackupVerificationFailed {
+			eventType = corev1.EventTypeWarning
+		}
+		v.Recorder.Event(backup, eventType, "VerifiedBackup", message)
+		if err := v.recordResult(backup, &dpv1alpha1.BackupVerificationStatus{
+			Phase:               phase,
+			ClusterName:         cluster.Name,
+			StartTimestamp:      &cluster.CreationTimestamp,
+			CompletionTimestamp: &metav1.Time{Time: time.Now()},
+			Message:             message,
+		}); err != nil {
+			return 0, err
+		}
+		if err := v.cleanup(cluster); err != nil {
+			return 0, err
+		}
+		return v.nextVerificationAfter(), nil
+	}
+
+	timeout := defaultVerificationTimeout
+	if v.BackupSchedule.Spec.Verification.Timeout != nil {
+		timeout = v.BackupSchedule.Spec.Verification.Timeout.Duration
+	}
+	if time.Since(cluster.CreationTimestamp.Time) > timeout {
+		return finish(dpv1alpha1.BackupVerificationFailed, fmt.Sprintf("the verification is not finished within %s", timeout))
+	}
+
+	failedMsg, err := v.getRestoreFailedMessage(cluster)
+	if err != nil {
+		return 0, err
+	}
+	if failedMsg != "" {
+		return finish(dpv1alpha1.BackupVerificationFailed, fmt.Sprintf("failed to restore the backup: %s", failedMsg))
+	}
+	// the restore annotation is removed after the component is restored.
+	if cluster.Status.Phase != appsv1.RunningClusterPhase ||
+		cluster.Annotations[constant.RestoreFromBackupAnnotationKey] != "" {
+		return verificationCheckInterval, nil
+	}
+	job, err := v.ensureCheckJob(cluster, backup)
+	if err != nil || job == nil {
+		return verificationCheckInterval, err
+	}
+	_, finishedType, msg := utils.IsJobFinished(job)
+	switch finishedType {
+	case batchv1.JobComplete:
+		return finish(dpv1alpha1.BackupVerificationPassed, "the backup is restored and checked successfully")
+	case batchv1.JobFailed:
+		return finish(dpv1alpha1.BackupVerificationFailed, fmt.Sprintf("the check action failed: %s", msg))
+	}
+	return verificationCheckInterval, nil
+}
+
+// getRestoreFailedMessage gets the message of the failed restore of the throwaway cluster.
+func (v *Verifier) getRestoreFailedMessage(cluster *appsv1.Cluster) (string, error) {
+	restoreList := &dpv1alpha1.RestoreList{}
+	if err := v.Client.List(v.Ctx, restoreList, client.InNamespace(cluster.Namespace),
+		client.MatchingLabels{constant.AppInstanceLabelKey: cluster.Name}); err != nil {
+		return "", err
+	}
+	for _, restore := range restoreList.Items {
+		if restore.Status.Phase != dpv1alpha1.RestorePhaseFailed {
+			continue
+		}
+		var messages []string
+		for _, cond := range restore.Status.Conditions {
+			if cond.Status == metav1.ConditionFalse && cond.Message != "" {
+				messages = append(messages, cond.Message)
+			}
+		}
+		return fmt.Sprintf("restore %s failed: %s", restore.Name, strings.Join(messages, "; ")), nil
+	}
+	return "", nil
+}
+
+// ensureCheckJob creates the job to run the check action against the restored component,
+// it returns nil if there is no available pod of the restored component.
+func (v *Verifier) ensureCheckJob(cluster *appsv1.Cluster, backup *dpv1alpha1.Backup) (*batchv1.Job, error) {
+	jobKey := client.ObjectKey{Namespace: cluster.Namespace, Name: cutJobName(verificationCheckJobPrefix + cluster.Name)}
+	job := &batchv1.Job{}
+	exists, err := intctrlutil.CheckResourceExists(v.Ctx, v.Client, jobKey, job)
+	if err != nil || exists {
+		return job, err
+	}
+
+	pod, err := v.getRestoredPod(cluster, backup)
+	if err != nil || pod == nil {
+		return nil, err
+	}
+	target := backup.Status.Target
+	if target == nil && len(backup.Status.Targets) > 0 {
+		target = &backup.Status.Targets[0]
+	}
+	var (
+		credential    *dpv1alpha1.ConnectionCredential
+		containerPort *dpv1alpha1.ContainerPort
+	)
+	if target != nil {
+		containerPort = target.ContainerPort
+		if target.ConnectionCredential != nil {
+			// the accounts of the restored cluster are restored from the backup, whose
+			// secrets are named by the restored cluster.
+			credential = target.ConnectionCredential.DeepCopy()
+			sourcePrefix := backup.Labels[constant.AppInstanceLabelKey] + "-"
+			if suffix, ok := strings.CutPrefix(credential.SecretName, sourcePrefix); ok {
+				credential.SecretName = cluster.Name + "-" + suffix
+			}
+		}
+	}
+	connectionEnv, err := utils.BuildEnvByTarget(pod, credential, containerPort)
+	if err != nil {
+		return nil, err
+	}
+
+	checkAction := v.BackupSchedule.Spec.Verification.CheckAction
+	env := []corev1.EnvVar{
+		{Name: dptypes.DPBackupName, Value: backup.Name},
+		{Name: constant.KBEnvClusterName, Value: cluster.Name},
+		{Name: constant.KBEnvCompName, Value: pod.Labels[constant.KBAppComponentLabelKey]},
+		{Name: constant.KBEnvNamespace, Value: cluster.Namespace},
+	}
+	env = append(env, connectionEnv...)
+	container := corev1.Container{
+		Name:            verificationCheckContainer,
+		Image:           checkAction.Image,
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Command:         checkAction.Command,
+		Env:             utils.MergeEnv(env, checkAction.Env),
+	}
+	intctrlutil.InjectZeroResourcesLimitsIfEmpty(&container)
+	podSpec := corev1.PodSpec{
+		Containers:         []corev1.Container{container},
+		RestartPolicy:      corev1.RestartPolicyNever,
+		ServiceAccountName: v.WorkerServiceAccount,
+	}
+	if err = utils.AddTolerations(&podSpec); err != nil {
+		return nil, err
+	}
+	job = &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: jobKey.Namespace,
+			Name:      jobKey.Name,
+			Labels:    v.verificationLabels(),
+		},
+		Spec: batchv1.JobSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: v.verificationLabels()},
+				Spec:       podSpec,
+			},
+			BackoffLimit: &dptypes.DefaultBackOffLimit,
+		},
+	}
+	if err = utils.SetControllerReference(cluster, job, v.Scheme); err != nil {
+		return nil, err
+	}
+	return job, client.IgnoreAlreadyExists(v.Client.Create(v.Ctx, job))
+}
+
+// getRestoredPod gets a running pod of the restored component.
+func (v *Verifier) getRestoredPod(cluster *appsv1.Cluster, backup *dpv1alpha1.Backup) (*corev1.Pod, error) {
+	labels := client.MatchingLabels{constant.AppInstanceLabelKey: cluster.Name}
+	if shardingName, ok := backup.Labels[constant.KBAppShardingNameLabelKey]; ok {
+		labels[constant.KBAppShardingNameLabelKey] = shardingName
+	} else {
+		labels[constant.KBAppComponentLabelKey] = backup.Labels[constant.KBAppComponentLabelKey]
+	}
+	podList := &corev1.PodList{}
+	if err := v.Client.List(v.Ctx, podList, client.InNamespace(cluster.Namespace), labels); err != nil {
+		return nil, err
+	}
+	sort.Slice(podList.Items, func(i, j int) bool {
+		return podList.Items[i].Name < podList.Items[j].Name
+	})
+	for i, pod := range podList.Items {
+		if pod.Status.Phase == corev1.PodRunning && pod.DeletionTimestamp.IsZero() {
+			return &podList.Items[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// recordResult records the verification result in the backup and the backup schedule.
+func (v *Verifier) recordResult(backup *dpv1alpha1.Backup, result *dpv1alpha1.BackupVerificationStatus) error {
+	backupPatch := client.MergeFrom(backup.DeepCopy())
+	backup.Status.Verification = result
+	if err := v.Client.Status().Patch(v.Ctx, backup, backupPatch); err != nil {
+		return err
+	}
+	schedulePatch := client.MergeFrom(v.BackupSchedule.DeepCopy())
+	if v.BackupSchedule.Status.Verification == nil {
+		v.BackupSchedule.Status.Verification = &dpv1alpha1.ScheduleVerificationStatus{}
+	}
+	v.BackupSchedule.Status.Verification.BackupName = backup.Name
+	v.BackupSchedule.Status.Verification.Phase = result.Phase
+	v.BackupSchedule.Status.Verification.LastVerificationTime = result.StartTimestamp
+	return v.Client.Status().Patch(v.Ctx, v.BackupSchedule, schedulePatch)
+}
+
+// cleanup deletes the check job and the throwaway cluster, the volumes of which are
+// deleted with it by the WipeOut termination policy.
+func (v *Verifier) cleanup(cluster *appsv1.Cluster) error {
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Namespace: cluster.Namespace, Name: cutJobName(verificationCheckJobPrefix + cluster.Name)},
+	}
+	if err := intctrlutil.BackgroundDeleteObject(v.Client, v.Ctx, job); err != nil {
+		return err
+	}
+	return intctrlutil.BackgroundDeleteObject(v.Client, v.Ctx, cluster)
+}
@@ -0,0 +1,365 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package restore
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils/boolptr"
+)
+
+func newVerificationScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	_ = dpv1alpha1.AddToScheme(scheme)
+	return scheme
+}
+
+func newVerificationSchedule() *dpv1alpha1.BackupSchedule {
+	return &dpv1alpha1.BackupSchedule{
+		ObjectMeta: metav1.ObjectMeta{Name: "schedule", Namespace: "default"},
+		Spec: dpv1alpha1.BackupScheduleSpec{
+			BackupPolicyName: "policy",
+			Verification: &dpv1alpha1.BackupVerificationPolicy{
+				Enabled:      boolptr.True(),
+				BackupMethod: "xtrabackup",
+				Interval:     metav1.Duration{Duration: 24 * time.Hour},
+				CheckAction: dpv1alpha1.VerificationCheckAction{
+					Image:   "mysql",
+					Command: []string{"sh", "-c", "mysql -e 'select 1'"},
+					Env:     []corev1.EnvVar{{Name: "DATABASE", Value: "test"}},
+				},
+			},
+		},
+	}
+}
+
+func newVerificationBackup(t *testing.T) *dpv1alpha1.Backup {
+	snapshot := &appsv1.Cluster{
+		Spec: appsv1.ClusterSpec{
+			ClusterDef: "apecloud-mysql",
+			Topology:   "mysql-proxy",
+			ComponentSpecs: []appsv1.ClusterComponentSpec{
+				{Name: "mysql", ComponentDef: "mysql-8.0", Replicas: 3, OfflineInstances: []string{"mysql-mysql-1"}},
+				{Name: "proxy", ComponentDef: "proxy", Replicas: 2},
+			},
+			Services: []appsv1.ClusterService{{Service: appsv1.Service{Name: "lb"}}},
+			Backup:   &appsv1.ClusterBackup{Enabled: boolptr.True()},
+			SchedulingPolicy: &appsv1.SchedulingPolicy{
+				Affinity: &corev1.Affinity{PodAntiAffinity: &corev1.PodAntiAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{{
+						LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{constant.AppInstanceLabelKey: "mysql"}},
+					}},
+				}},
+			},
+			TerminationPolicy: appsv1.Delete,
+		},
+	}
+	data, err := json.Marshal(snapshot)
+	require.NoError(t, err)
+	return &dpv1alpha1.Backup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "backup",
+			Namespace: "default",
+			UID:       "0123456789abcdef",
+			Labels: map[string]string{
+				dptypes.BackupPolicyLabelKey:    "policy",
+				constant.AppInstanceLabelKey:    "mysql",
+				constant.KBAppComponentLabelKey: "mysql",
+			},
+			Annotations: map[string]string{constant.ClusterSnapshotAnnotationKey: string(data)},
+		},
+		Spec: dpv1alpha1.BackupSpec{BackupMethod: "xtrabackup", BackupPolicyName: "policy"},
+		Status: dpv1alpha1.BackupStatus{
+			Phase: dpv1alpha1.BackupPhaseCompleted,
+			Target: &dpv1alpha1.BackupStatusTarget{
+				BackupTarget: dpv1alpha1.BackupTarget{
+					ConnectionCredential: &dpv1alpha1.ConnectionCredential{
+						SecretName:  "mysql-mysql-account-root",
+						UsernameKey: "username",
+						PasswordKey: "password",
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestVerifierBuildCluster(t *testing.T) {
+	verifier := &Verifier{BackupSchedule: newVerificationSchedule()}
+	backup := newVerificationBackup(t)
+
+	cluster, err := verifier.buildCluster(backup)
+	require.NoError(t, err)
+	assert.Equal(t, "verify-01234567", cluster.Name)
+	assert.Equal(t, "schedule", cluster.Labels[dptypes.BackupScheduleLabelKey])
+	assert.Equal(t, "backup", cluster.Labels[dptypes.BackupNameLabelKey])
+	restoreInfo := map[string]map[string]string{}
+	require.NoError(t, json.Unmarshal([]byte(cluster.Annotations[constant.RestoreFromBackupAnnotationKey]), &restoreInfo))
+	assert.Equal(t, "backup", restoreInfo["mysql"][constant.BackupNameKeyForRestore])
+	require.Len(t, cluster.Spec.ComponentSpecs, 1, "only the backed up component is restored")
+	assert.Equal(t, "mysql", cluster.Spec.ComponentSpecs[0].Name)
+	assert.Empty(t, cluster.Spec.ComponentSpecs[0].OfflineInstances)
+	assert.Empty(t, cluster.Spec.ClusterDef, "the topology is not needed as the component definition is specified")
+	assert.Empty(t, cluster.Spec.Topology)
+	assert.Empty(t, cluster.Spec.Services)
+	assert.Nil(t, cluster.Spec.Backup)
+	assert.Equal(t, appsv1.WipeOut, cluster.Spec.TerminationPolicy)
+	assert.Equal(t, cluster.Name, cluster.Spec.SchedulingPolicy.Affinity.PodAntiAffinity.
+		RequiredDuringSchedulingIgnoredDuringExecution[0].LabelSelector.MatchLabels[constant.AppInstanceLabelKey])
+
+	backup.Labels[constant.KBAppComponentLabelKey] = "other"
+	_, err = verifier.buildCluster(backup)
+	assert.ErrorContains(t, err, "component other of backup backup is not found")
+
+	delete(backup.Annotations, constant.ClusterSnapshotAnnotationKey)
+	_, err = verifier.buildCluster(backup)
+	assert.ErrorContains(t, err, "has no cluster snapshot")
+}
+
+func TestVerifierVerify(t *testing.T) {
+	ctx := context.Background()
+	newVerifier := func(cli client.Client, schedule *dpv1alpha1.BackupSchedule) *Verifier {
+		return &Verifier{
+			RequestCtx:           intctrlutil.RequestCtx{Ctx: ctx, Log: logr.Discard(), Recorder: record.NewFakeRecorder(10)},
+			Client:               cli,
+			Scheme:               cli.Scheme(),
+			BackupSchedule:       schedule,
+			WorkerServiceAccount: "worker",
+		}
+	}
+	setup := func(t *testing.T) (client.Client, *Verifier, *appsv1.Cluster) {
+		schedule := newVerificationSchedule()
+		backup := newVerificationBackup(t)
+		cli := fake.NewClientBuilder().WithScheme(newVerificationScheme()).
+			WithObjects(schedule, backup).
+			WithStatusSubresource(&dpv1alpha1.Backup{}, &dpv1alpha1.BackupSchedule{}).Build()
+		verifier := newVerifier(cli, schedule)
+
+		after, err := verifier.Verify()
+		require.NoError(t, err)
+		assert.Equal(t, verificationCheckInterval, after)
+		cluster := &appsv1.Cluster{}
+		require.NoError(t, cli.Get(ctx, client.ObjectKey{Namespace: "default", Name: "verify-01234567"}, cluster))
+		require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(backup), backup))
+		require.NotNil(t, backup.Status.Verification)
+		assert.Equal(t, dpv1alpha1.BackupVerificationRunning, backup.Status.Verification.Phase)
+		assert.Equal(t, cluster.Name, backup.Status.Verification.ClusterName)
+
+		// the fake client does not set the creation timestamp.
+		cluster.CreationTimestamp = metav1.Now()
+		require.NoError(t, cli.Update(ctx, cluster))
+		return cli, verifier, cluster
+	}
+	getBackup := func(t *testing.T, cli client.Client) *dpv1alpha1.Backup {
+		backup := &dpv1alpha1.Backup{}
+		require.NoError(t, cli.Get(ctx, client.ObjectKey{Namespace: "default", Name: "backup"}, backup))
+		return backup
+	}
+	assertCleanedUp := func(t *testing.T, cli client.Client, cluster *appsv1.Cluster) {
+		err := cli.Get(ctx, client.ObjectKeyFromObject(cluster), &appsv1.Cluster{})
+		assert.True(t, apierrors.IsNotFound(err), "the throwaway cluster should be deleted")
+	}
+
+	t.Run("check the restored component", func(t *testing.T) {
+		cli, verifier, cluster := setup(t)
+
+		// wait for the cluster to be restored
+		cluster.Status.Phase = appsv1.RunningClusterPhase
+		require.NoError(t, cli.Update(ctx, cluster))
+		_, err := verifier.Verify()
+		require.NoError(t, err)
+		jobKey := client.ObjectKey{Namespace: "default", Name: verificationCheckJobPrefix + cluster.Name}
+		assert.True(t, apierrors.IsNotFound(cli.Get(ctx, jobKey, &batchv1.Job{})),
+			"the check action runs after the component is restored")
+
+		delete(cluster.Annotations, constant.RestoreFromBackupAnnotationKey)
+		require.NoError(t, cli.Update(ctx, cluster))
+		_, err = verifier.Verify()
+		require.NoError(t, err)
+		assert.True(t, apierrors.IsNotFound(cli.Get(ctx, jobKey, &batchv1.Job{})),
+			"the check action runs after the pod is running")
+
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      cluster.Name + "-mysql-0",
+				Labels: map[string]string{
+					constant.AppInstanceLabelKey:    cluster.Name,
+					constant.KBAppComponentLabelKey: "mysql",
+				},
+			},
+			Spec: corev1.PodSpec{Subdomain: cluster.Name + "-mysql-headless", Containers: []corev1.Container{{
+				Name:  "mysql",
+				Ports: []corev1.ContainerPort{{Name: "mysql", ContainerPort: 3306}},
+			}}},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+		require.NoError(t, cli.Create(ctx, pod))
+		_, err = verifier.Verify()
+		require.NoError(t, err)
+		job := &batchv1.Job{}
+		require.NoError(t, cli.Get(ctx, jobKey, job))
+		container := job.Spec.Template.Spec.Containers[0]
+		assert.Equal(t, "mysql", container.Image)
+		envs := map[string]corev1.EnvVar{}
+		for _, env := range container.Env {
+			envs[env.Name] = env
+		}
+		assert.Equal(t, cluster.Name, envs[constant.KBEnvClusterName].Value)
+		assert.Equal(t, "mysql", envs[constant.KBEnvCompName].Value)
+		assert.Equal(t, "test", envs["DATABASE"].Value)
+		assert.Contains(t, envs[dptypes.DPDBHost].Value, pod.Name)
+		assert.Equal(t, cluster.Name+"-mysql-account-root", envs[dptypes.DPDBPassword].ValueFrom.SecretKeyRef.Name,
+			"the secret of the restored cluster is used")
+
+		// complete the check action
+		job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+		require.NoError(t, cli.Status().Update(ctx, job))
+		after, err := verifier.Verify()
+		require.NoError(t, err)
+		assert.InDelta(t, 24*time.Hour, after, float64(time.Minute))
+		backup := getBackup(t, cli)
+		assert.Equal(t, dpv1alpha1.BackupVerificationPassed, backup.Status.Verification.Phase)
+		assert.NotNil(t, backup.Status.Verification.CompletionTimestamp)
+		assert.Equal(t, dpv1alpha1.BackupVerificationPassed, verifier.BackupSchedule.Status.Verification.Phase)
+		assertCleanedUp(t, cli, cluster)
+
+		// the next verification is deferred by the interval
+		after, err = verifier.Verify()
+		require.NoError(t, err)
+		assert.InDelta(t, 24*time.Hour, after, float64(time.Minute))
+	})
+
+	t.Run("restore failed", func(t *testing.T) {
+		cli, verifier, cluster := setup(t)
+		restore := &dpv1alpha1.Restore{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      cluster.Name + "-mysql-preparedata",
+				Labels:    map[string]string{constant.AppInstanceLabelKey: cluster.Name},
+			},
+			Status: dpv1alpha1.RestoreStatus{
+				Phase: dpv1alpha1.RestorePhaseFailed,
+				Conditions: []metav1.Condition{{
+					Type: "PrepareData", Status: metav1.ConditionFalse, Reason: ReasonFailed,
+					Message: "checksum mismatch of data.xbstream",
+				}},
+			},
+		}
+		require.NoError(t, cli.Create(ctx, restore))
+		_, err := verifier.Verify()
+		require.NoError(t, err)
+		backup := getBackup(t, cli)
+		assert.Equal(t, dpv1alpha1.BackupVerificationFailed, backup.Status.Verification.Phase)
+		assert.Contains(t, backup.Status.Verification.Message, "checksum mismatch of data.xbstream")
+		assertCleanedUp(t, cli, cluster)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		cli, verifier, cluster := setup(t)
+		verifier.BackupSchedule.Spec.Verification.Timeout = &metav1.Duration{Duration: time.Minute}
+		cluster.CreationTimestamp = metav1.NewTime(time.Now().Add(-2 * time.Minute))
+		require.NoError(t, cli.Update(ctx, cluster))
+		_, err := verifier.Verify()
+		require.NoError(t, err)
+		backup := getBackup(t, cli)
+		assert.Equal(t, dpv1alpha1.BackupVerificationFailed, backup.Status.Verification.Phase)
+		assert.Contains(t, backup.Status.Verification.Message, "not finished within 1m0s")
+		assertCleanedUp(t, cli, cluster)
+	})
+
+	t.Run("the backup is deleted", func(t *testing.T) {
+		cli, verifier, cluster := setup(t)
+		require.NoError(t, cli.Delete(ctx, getBackup(t, cli)))
+		_, err := verifier.Verify()
+		require.NoError(t, err)
+		assertCleanedUp(t, cli, cluster)
+	})
+}
+
+func TestCheckJobsDoneChecksumMismatch(t *testing.T) {
+	newPod := func(jobName, message string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      jobName + "-pod",
+				Labels:    map[string]string{"job-name": jobName},
+			},
+			Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+				Name:  Restore,
+				State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Message: message}},
+			}}},
+		}
+	}
+	newJob := func(name string) *batchv1.Job {
+		return &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+			Status: batchv1.JobStatus{Conditions: []batchv1.JobCondition{
+				{Type: batchv1.JobComplete, Status: corev1.ConditionTrue},
+			}},
+		}
+	}
+	cli := fake.NewClientBuilder().WithScheme(newVerificationScheme()).WithObjects(
+		newPod("intact", "restored"),
+		newPod("corrupted", "checksum mismatch of data.xbstream, expected 1234, got 5678\n"),
+	).Build()
+	restore := &dpv1alpha1.Restore{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "restore"}}
+	mgr := NewRestoreManager(restore, record.NewFakeRecorder(10), cli.Scheme(), cli)
+	backupSet := BackupActionSet{Backup: &dpv1alpha1.Backup{ObjectMeta: metav1.ObjectMeta{Name: "backup"}}}
+
+	allFinished, existFailed, err := mgr.CheckJobsDone(dpv1alpha1.PrepareData, "restore", backupSet,
+		[]*batchv1.Job{newJob("intact")})
+	require.NoError(t, err)
+	assert.True(t, allFinished)
+	assert.False(t, existFailed)
+
+	allFinished, existFailed, err = mgr.CheckJobsDone(dpv1alpha1.PrepareData, "restore", backupSet,
+		[]*batchv1.Job{newJob("intact"), newJob("corrupted")})
+	require.NoError(t, err)
+	assert.True(t, allFinished)
+	assert.True(t, existFailed, "the restore with corrupted data is failed")
+	actions := restore.Status.Actions.PrepareData
+	require.Len(t, actions, 2)
+	assert.Equal(t, dpv1alpha1.RestoreActionFailed, actions[1].Status)
+	assert.Contains(t, actions[1].Message, "checksum mismatch of data.xbstream")
+}
@@ -46,6 +46,8 @@ const (
 const (
 	// DataProtectionFinalizerName is the name of our custom finalizer
 	DataProtectionFinalizerName = "dataprotection.kubeblocks.io/finalizer"
+	// ReplicationFinalizerName is the finalizer to delete the copies of the backup in the secondary backup repos.
+	ReplicationFinalizerName = "dataprotection.kubeblocks.io/replication-finalizer"
 )
 
 // annotation keys
@@ -66,6 +68,12 @@ const (
 	LastAppliedConfigsAnnotationKey = "dataprotection.kubeblocks.io/last-applied-configurations"
 	// SkipReconciliationAnnotationKey specifies whether to skip reconciliation.
 	SkipReconciliationAnnotationKey = "dataprotection.kubeblocks.io/skip-reconciliation"
+	// ImportedFromRepoAnnotationKey specifies the backup repo from which the backup is imported.
+	ImportedFromRepoAnnotationKey = "dataprotection.kubeblocks.io/imported-from-repo"
+	// ManifestTimestampAnnotationKey specifies the time when the backup manifest is written into the backup repo.
+	ManifestTimestampAnnotationKey = "dataprotection.kubeblocks.io/manifest-timestamp"
+	// ReplicatedTimeRangeEndAnnotationKey specifies the end of the time range of the backup data copied by the replication job.
+	ReplicatedTimeRangeEndAnnotationKey = "dataprotection.kubeblocks.io/replicated-time-range-end"
 )
 
 // label keys
@@ -88,6 +96,12 @@ const (
 	AutoBackupLabelKey = "dataprotection.kubeblocks.io/autobackup"
 	// BackupTargetPodLabelKey specifies the backup target pod label key.
 	BackupTargetPodLabelKey = "dataprotection.kubeblocks.io/target-pod-name"
+	// BackupVerificationLabelKey marks the resources created to verify the backup.
+	BackupVerificationLabelKey = "dataprotection.kubeblocks.io/backup-verification"
+	// BackupReplicationLabelKey specifies the name of the backup copied by the replication job.
+	BackupReplicationLabelKey = "dataprotection.kubeblocks.io/replication-of"
+	// BackupReplicaRepoLabelKey specifies the backup repo which the replication job copies the backup to.
+	BackupReplicaRepoLabelKey = "dataprotection.kubeblocks.io/replica-repo"
 )
 
 // env names
@@ -138,6 +152,12 @@ const (
 	DPBackupStopTime = "DP_BACKUP_STOP_TIME" // backup stop time
 	// DPDatasafedBinPath the path containing the datasafed binary
 	DPDatasafedBinPath = "DP_DATASAFED_BIN_PATH"
+	// DPChecksumMode specifies whether datasafed records or verifies the checksums of the transferred files
+	DPChecksumMode = "DP_CHECKSUM_MODE"
+	// DPChecksumFile the file which retains the checksums recorded by datasafed
+	DPChecksumFile = "DP_CHECKSUM_FILE"
+	// DPBackupChecksum the checksum of the backup, which is the digest of the checksum file
+	DPBackupChecksum = "DP_BACKUP_CHECKSUM"
 
 	// NOTE: do not add 'DP_' prefix to the value of the following constants, they are the datasafed built-in environment.
 
@@ -49,7 +49,7 @@ func InjectDatasafed(podSpec *corev1.PodSpec, repo *dpv1alpha1.BackupRepo, repoV
 }
 
 func injectEncryptionEnvs(podSpec *corev1.PodSpec, encryptionConfig *dpv1alpha1.EncryptionConfig) {
-	if encryptionConfig == nil {
+	if encryptionConfig == nil || encryptionConfig.PassPhraseSecretKeyRef == nil {
 		return
 	}
 	envs := []corev1.EnvVar{
@@ -0,0 +1,189 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package utils
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+const (
+	// ChecksumModeRecord makes datasafed record the checksums of the pushed files.
+	ChecksumModeRecord = "record"
+	// ChecksumModeVerify makes datasafed verify the checksums of the pulled files.
+	ChecksumModeVerify = "verify"
+
+	// ChecksumMismatchMessage is the message written to the termination log of the
+	// container when a pulled file does not match its checksum.
+	ChecksumMismatchMessage = "checksum mismatch"
+)
+
+// buildDatasafedChecksumWrapper builds the script which wraps datasafed to compute the
+// checksums of the files in the upload and download streams. In the record mode, the
+// checksums of the pushed files are appended to the file of $DP_CHECKSUM_FILE. In the
+// verify mode, the pulled files are verified against the checksum file in the backend
+// base path, which is also verified against $DP_BACKUP_CHECKSUM if it is the backup path.
+// The files without checksums are transferred as is.
+func buildDatasafedChecksumWrapper(checksumFileName string) string {
+	return fmt.Sprintf(`#!/bin/sh
+datasafed="$(dirname "$0")/datasafed.bin"
+checksumFile="/%[1]s"
+mode="${%[3]s:-}"
+for tool in sha256sum mkfifo tee awk; do
+	command -v "${tool}" >/dev/null 2>&1 || mode=""
+done
+cmd="$1"
+src=""
+dst=""
+for arg in "$@"; do
+	src="${dst}"
+	dst="${arg}"
+done
+
+fail() {
+	echo "$1" >&2
+	if [ -w /dev/termination-log ]; then
+		echo "$1" >> /dev/termination-log
+	fi
+	exit 1
+}
+
+if [ "${mode}" = "%[5]s" ] && [ "${cmd}" = "push" ] && [ "${dst}" != "-" ] && [ -n "${%[4]s:-}" ]; then
+	root="${DP_BACKUP_BASE_PATH:-}"
+	base="${DATASAFED_BACKEND_BASE_PATH:-}"
+	file="${base%%/}/${dst#/}"
+	case "${file}" in
+	"${root%%/}"/*) key="${file#"${root%%/}/"}" ;;
+	*) exec "${datasafed}" "$@" ;;
+	esac
+	fifo="$(mktemp -u)"
+	if [ "${src}" = "-" ]; then
+		mkfifo "${fifo}"
+		sha256sum < "${fifo}" > "${fifo}.sum" &
+		tee "${fifo}" | "${datasafed}" "$@"
+		rc=$?
+		wait
+	elif [ -f "${src}" ]; then
+		"${datasafed}" "$@"
+		rc=$?
+		sha256sum < "${src}" > "${fifo}.sum"
+	else
+		exec "${datasafed}" "$@"
+	fi
+	if [ "${rc}" -eq 0 ]; then
+		echo "$(awk '{print $1}' "${fifo}.sum")  ${key}" >> "${%[4]s}"
+	fi
+	rm -f "${fifo}" "${fifo}.sum"
+	exit "${rc}"
+fi
+
+if [ "${mode}" = "%[6]s" ] && [ "${cmd}" = "pull" ] && [ "${src}" != "-" ]; then
+	sums="$(mktemp)"
+	"${datasafed}" pull "${checksumFile}" - > "${sums}" 2>/dev/null || true
+	root="${DP_BACKUP_BASE_PATH:-}"
+	base="${DATASAFED_BACKEND_BASE_PATH:-}"
+	if [ -s "${sums}" ] && [ -n "${%[7]s:-}" ] && [ "${base%%/}" = "${root%%/}" ]; then
+		actual="sha256:$(sha256sum < "${sums}" | awk '{print $1}')"
+		if [ "${actual}" != "${%[7]s}" ]; then
+			rm -f "${sums}"
+			fail "%[2]s of ${checksumFile}, expected ${%[7]s}, got ${actual}"
+		fi
+	fi
+	expected="$(awk -v f="${src#/}" 'substr($0, 67) == f { sum = substr($0, 1, 64) } END { print sum }' "${sums}")"
+	rm -f "${sums}"
+	if [ -z "${expected}" ]; then
+		exec "${datasafed}" "$@"
+	fi
+	fifo="$(mktemp -u)"
+	if [ "${dst}" = "-" ]; then
+		mkfifo "${fifo}"
+		sha256sum < "${fifo}" > "${fifo}.sum" &
+		{ "${datasafed}" "$@" || echo $? > "${fifo}.rc"; } | tee "${fifo}"
+		wait
+	else
+		"${datasafed}" "$@" || echo $? > "${fifo}.rc"
+		if [ -f "${dst}" ]; then
+			sha256sum < "${dst}" > "${fifo}.sum"
+		fi
+	fi
+	rc=0
+	if [ -f "${fifo}.rc" ]; then
+		rc="$(cat "${fifo}.rc")"
+	fi
+	actual="$(awk '{print $1}' "${fifo}.sum" 2>/dev/null)"
+	rm -f "${fifo}" "${fifo}.sum" "${fifo}.rc"
+	if [ "${rc}" -ne 0 ]; then
+		exit "${rc}"
+	fi
+	if [ -n "${actual}" ] && [ "${actual}" != "${expected}" ]; then
+		fail "%[2]s of ${src}, expected ${expected}, got ${actual}"
+	fi
+	exit 0
+fi
+
+exec "${datasafed}" "$@"
+`, checksumFileName, ChecksumMismatchMessage, dptypes.DPChecksumMode, dptypes.DPChecksumFile,
+		ChecksumModeRecord, ChecksumModeVerify, dptypes.DPBackupChecksum)
+}
+
+// InjectDatasafedChecksum wraps the datasafed binary injected into the pod to record or
+// verify the checksums of the files it transfers, and the mode is specified by the env
+// of DP_CHECKSUM_MODE in the envs. It must be called after the datasafed is injected.
+func InjectDatasafedChecksum(podSpec *corev1.PodSpec, checksumFileName string, envs ...corev1.EnvVar) {
+	var binVolumeMount *corev1.VolumeMount
+	for _, container := range podSpec.Containers {
+		for i := range container.VolumeMounts {
+			if container.VolumeMounts[i].MountPath == datasafedBinMountPath {
+				binVolumeMount = &container.VolumeMounts[i]
+			}
+		}
+	}
+	if binVolumeMount == nil {
+		return
+	}
+	// replace the datasafed binary with the wrapper script, the binary is kept in
+	// the same directory and called by the script.
+	installScript := fmt.Sprintf(`
+set -e
+cd "%s"
+if [ ! -f datasafed.bin ]; then
+	mv datasafed datasafed.bin
+fi
+cat > datasafed <<'WRAPPER'
+%sWRAPPER
+chmod +x datasafed
+`, datasafedBinMountPath, buildDatasafedChecksumWrapper(checksumFileName))
+	initContainer := corev1.Container{
+		Name:            "dp-wrap-datasafed",
+		Image:           viper.GetString(constant.KBToolsImage),
+		ImagePullPolicy: corev1.PullPolicy(viper.GetString(constant.KBImagePullPolicy)),
+		Command:         []string{"/bin/sh", "-c", installScript},
+		VolumeMounts:    []corev1.VolumeMount{*binVolumeMount},
+	}
+	intctrlutil.InjectZeroResourcesLimitsIfEmpty(&initContainer)
+	podSpec.InitContainers = append(podSpec.InitContainers, initContainer)
+	injectElements(podSpec, nil, nil, envs)
+}
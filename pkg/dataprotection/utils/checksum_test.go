@@ -0,0 +1,177 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package utils
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+)
+
+const (
+	testChecksumFileName = "kubeblocks-checksums.sha256"
+
+	// fakeDatasafed stores the files in the directory of $FAKE_REPO.
+	fakeDatasafed = `#!/bin/sh
+cmd="$1"
+shift
+while [ $# -gt 2 ]; do
+	shift
+done
+base="${FAKE_REPO}/${DATASAFED_BACKEND_BASE_PATH#/}"
+case "${cmd}" in
+push)
+	mkdir -p "$(dirname "${base}/${2#/}")"
+	if [ "$1" = "-" ]; then cat > "${base}/${2#/}"; else cp "$1" "${base}/${2#/}"; fi
+	;;
+pull)
+	[ -f "${base}/${1#/}" ] || exit 2
+	if [ "$2" = "-" ]; then cat "${base}/${1#/}"; else cp "${base}/${1#/}" "$2"; fi
+	;;
+esac
+`
+)
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestDatasafedChecksumWrapper(t *testing.T) {
+	for _, tool := range []string{"sh", "sha256sum", "mkfifo", "tee", "awk"} {
+		if _, err := exec.LookPath(tool); err != nil {
+			t.Skipf("%s is not found", tool)
+		}
+	}
+	dir := t.TempDir()
+	binDir := filepath.Join(dir, "bin")
+	repoDir := filepath.Join(dir, "repo")
+	require.NoError(t, os.MkdirAll(binDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(binDir, "datasafed.bin"), []byte(fakeDatasafed), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(binDir, "datasafed"),
+		[]byte(buildDatasafedChecksumWrapper(testChecksumFileName)), 0755))
+	checksumFile := filepath.Join(dir, "checksums")
+
+	run := func(stdin []byte, env []string, args ...string) (string, string, error) {
+		cmd := exec.Command(filepath.Join(binDir, "datasafed"), args...)
+		cmd.Env = append([]string{
+			"PATH=" + os.Getenv("PATH"),
+			"FAKE_REPO=" + repoDir,
+			"DP_BACKUP_BASE_PATH=/backup",
+			"DATASAFED_BACKEND_BASE_PATH=/backup",
+		}, env...)
+		cmd.Stdin = bytes.NewReader(stdin)
+		var stdout, stderr bytes.Buffer
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+		err := cmd.Run()
+		return stdout.String(), stderr.String(), err
+	}
+	recordEnv := []string{dptypes.DPChecksumMode + "=" + ChecksumModeRecord, dptypes.DPChecksumFile + "=" + checksumFile}
+
+	// record the checksums of the pushed stream and file
+	data := []byte("backup data\n")
+	_, _, err := run(data, recordEnv, "push", "-z", "zstd-fastest", "-", "data/backup.xbstream")
+	require.NoError(t, err)
+	localFile := filepath.Join(dir, "meta")
+	meta := []byte("meta\n")
+	require.NoError(t, os.WriteFile(localFile, meta, 0644))
+	_, _, err = run(nil, recordEnv, "push", localFile, "/meta")
+	require.NoError(t, err)
+	stored, err := os.ReadFile(filepath.Join(repoDir, "backup", "data", "backup.xbstream"))
+	require.NoError(t, err)
+	assert.Equal(t, data, stored, "the stream is pushed as is")
+	sums, err := os.ReadFile(checksumFile)
+	require.NoError(t, err)
+	assert.Equal(t, sha256Hex(data)+"  data/backup.xbstream\n"+sha256Hex(meta)+"  meta\n", string(sums))
+
+	// the checksums are not recorded out of the record mode
+	_, _, err = run(data, []string{dptypes.DPChecksumFile + "=" + checksumFile}, "push", "-", "other")
+	require.NoError(t, err)
+	unchanged, _ := os.ReadFile(checksumFile)
+	assert.Equal(t, sums, unchanged)
+
+	// upload the checksum file as the backup does
+	require.NoError(t, os.WriteFile(filepath.Join(repoDir, "backup", testChecksumFileName), sums, 0644))
+	checksum := "sha256:" + sha256Hex(sums)
+	verifyEnv := []string{dptypes.DPChecksumMode + "=" + ChecksumModeVerify, dptypes.DPBackupChecksum + "=" + checksum}
+
+	out, _, err := run(nil, verifyEnv, "pull", "-d", "zstd-fastest", "data/backup.xbstream", "-")
+	require.NoError(t, err)
+	assert.Equal(t, string(data), out, "the stream is pulled only once and passed through")
+	pulled := filepath.Join(dir, "pulled")
+	_, _, err = run(nil, verifyEnv, "pull", "/meta", pulled)
+	require.NoError(t, err)
+	out, _, err = run(nil, verifyEnv, "pull", "other", "-")
+	require.NoError(t, err, "the file without checksum is not verified")
+	assert.Equal(t, string(data), out)
+
+	// the checksum file does not match the checksum of the backup
+	_, stderr, err := run(nil, []string{dptypes.DPChecksumMode + "=" + ChecksumModeVerify,
+		dptypes.DPBackupChecksum + "=sha256:0000"}, "pull", "meta", "-")
+	assert.Error(t, err)
+	assert.Contains(t, stderr, ChecksumMismatchMessage+" of /"+testChecksumFileName)
+
+	// the corrupted file
+	require.NoError(t, os.WriteFile(filepath.Join(repoDir, "backup", "data", "backup.xbstream"), []byte("corrupted\n"), 0644))
+	_, stderr, err = run(nil, verifyEnv, "pull", "data/backup.xbstream", "-")
+	assert.Error(t, err)
+	assert.True(t, strings.HasPrefix(stderr, ChecksumMismatchMessage+" of data/backup.xbstream"), stderr)
+
+	// the failure of datasafed is returned
+	require.NoError(t, os.Remove(filepath.Join(repoDir, "backup", "meta")))
+	_, _, err = run(nil, verifyEnv, "pull", "meta", "-")
+	var exitErr *exec.ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 2, exitErr.ExitCode())
+}
+
+func TestInjectDatasafedChecksum(t *testing.T) {
+	podSpec := &corev1.PodSpec{Containers: []corev1.Container{{Name: "backup"}}}
+	InjectDatasafedChecksum(podSpec, testChecksumFileName,
+		corev1.EnvVar{Name: dptypes.DPChecksumMode, Value: ChecksumModeRecord})
+	assert.Empty(t, podSpec.InitContainers, "the pod without datasafed is not changed")
+	assert.Empty(t, podSpec.Containers[0].Env)
+
+	InjectDatasafed(podSpec, &dpv1alpha1.BackupRepo{
+		Spec:   dpv1alpha1.BackupRepoSpec{AccessMethod: dpv1alpha1.AccessMethodTool},
+		Status: dpv1alpha1.BackupRepoStatus{ToolConfigSecretName: "config"},
+	}, "/backupdata", nil, "")
+	InjectDatasafedChecksum(podSpec, testChecksumFileName,
+		corev1.EnvVar{Name: dptypes.DPChecksumMode, Value: ChecksumModeRecord})
+	require.Len(t, podSpec.InitContainers, 2)
+	wrapper := podSpec.InitContainers[1]
+	assert.Equal(t, "dp-wrap-datasafed", wrapper.Name)
+	assert.Equal(t, datasafedBinMountPath, wrapper.VolumeMounts[0].MountPath)
+	assert.Contains(t, wrapper.Command[2], "mv datasafed datasafed.bin")
+	assert.Contains(t, podSpec.Containers[0].Env, corev1.EnvVar{Name: dptypes.DPChecksumMode, Value: ChecksumModeRecord})
+}
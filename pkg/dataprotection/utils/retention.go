@@ -0,0 +1,153 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package utils
+
+import (
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+)
+
+// SelectRetainedBackups returns the names of the completed backups which are kept by the retention.
+// The backups should belong to the same backup method.
+func SelectRetainedBackups(retention *dpv1alpha1.BackupRetention, backups []*dpv1alpha1.Backup) sets.Set[string] {
+	retained := sets.New[string]()
+	if retention == nil {
+		return retained
+	}
+	completedBackups := make([]*dpv1alpha1.Backup, 0, len(backups))
+	for i := range backups {
+		if backups[i].Status.Phase == dpv1alpha1.BackupPhaseCompleted && !backups[i].GetEndTime().IsZero() {
+			completedBackups = append(completedBackups, backups[i])
+		}
+	}
+	// sort by stop time in descending order
+	sort.Slice(completedBackups, func(i, j int) bool {
+		i, j = j, i
+		return CompareWithBackupStopTime(*completedBackups[i], *completedBackups[j])
+	})
+
+	keepLatestOfBuckets := func(keep *int32, bucketKey func(*dpv1alpha1.Backup) string) {
+		if keep == nil || *keep <= 0 {
+			return
+		}
+		buckets := sets.New[string]()
+		for _, b := range completedBackups {
+			key := bucketKey(b)
+			if buckets.Has(key) {
+				continue
+			}
+			if int32(buckets.Len()) >= *keep {
+				return
+			}
+			buckets.Insert(key)
+			retained.Insert(b.Name)
+		}
+	}
+
+	switch retention.Type {
+	case dpv1alpha1.BackupRetentionTypeKeepLast:
+		keepLatestOfBuckets(retention.KeepLast, func(b *dpv1alpha1.Backup) string {
+			return b.Name
+		})
+	case dpv1alpha1.BackupRetentionTypeGFS:
+		keepLatestOfBuckets(retention.KeepDaily, func(b *dpv1alpha1.Backup) string {
+			return b.GetEndTime().UTC().Format("2006-01-02")
+		})
+		keepLatestOfBuckets(retention.KeepWeekly, func(b *dpv1alpha1.Backup) string {
+			year, week := b.GetEndTime().UTC().ISOWeek()
+			return fmt.Sprintf("%d-W%02d", year, week)
+		})
+		keepLatestOfBuckets(retention.KeepMonthly, func(b *dpv1alpha1.Backup) string {
+			return b.GetEndTime().UTC().Format("2006-01")
+		})
+	}
+	return retained
+}
+
+// GetDependedBackups returns the names of the backups which the retained backups depend on,
+// the dependencies are looked up from the candidates.
+//   - an incremental backup depends on its parent backups and its base full backup.
+//   - a continuous backup depends on the earliest full or incremental backup whose stop time is
+//     not before its start time, which is the earliest base backup for the point-in-time recovery.
+func GetDependedBackups(retained []*dpv1alpha1.Backup, candidates []*dpv1alpha1.Backup) sets.Set[string] {
+	candidateMap := map[string]*dpv1alpha1.Backup{}
+	for i := range candidates {
+		candidateMap[candidates[i].Name] = candidates[i]
+	}
+	depended := sets.New[string]()
+	var addBackupChain func(name string)
+	addBackupChain = func(name string) {
+		if len(name) == 0 || depended.Has(name) {
+			return
+		}
+		depended.Insert(name)
+		if b, ok := candidateMap[name]; ok {
+			addBackupChain(getParentBackupName(b))
+			addBackupChain(b.Status.BaseBackupName)
+		}
+	}
+
+	for _, b := range retained {
+		switch b.Labels[dptypes.BackupTypeLabelKey] {
+		case string(dpv1alpha1.BackupTypeIncremental):
+			addBackupChain(getParentBackupName(b))
+			addBackupChain(b.Status.BaseBackupName)
+		case string(dpv1alpha1.BackupTypeContinuous):
+			if base := getEarliestBaseBackupForContinuous(b, candidates); base != nil {
+				addBackupChain(base.Name)
+			}
+		}
+	}
+	return depended
+}
+
+func getParentBackupName(backup *dpv1alpha1.Backup) string {
+	if len(backup.Status.ParentBackupName) != 0 {
+		return backup.Status.ParentBackupName
+	}
+	return backup.Spec.ParentBackupName
+}
+
+func getEarliestBaseBackupForContinuous(continuousBackup *dpv1alpha1.Backup, candidates []*dpv1alpha1.Backup) *dpv1alpha1.Backup {
+	startTime := continuousBackup.GetStartTime()
+	if startTime.IsZero() {
+		return nil
+	}
+	var earliest *dpv1alpha1.Backup
+	for _, b := range candidates {
+		backupType := b.Labels[dptypes.BackupTypeLabelKey]
+		if backupType != string(dpv1alpha1.BackupTypeFull) && backupType != string(dpv1alpha1.BackupTypeIncremental) {
+			continue
+		}
+		stopTime := b.GetEndTime()
+		if b.Status.Phase != dpv1alpha1.BackupPhaseCompleted || stopTime.IsZero() || stopTime.Before(startTime) {
+			continue
+		}
+		if earliest == nil || CompareWithBackupStopTime(*b, *earliest) {
+			earliest = b
+		}
+	}
+	return earliest
+}
@@ -0,0 +1,98 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+)
+
+func newRetentionTestBackup(name string, backupType dpv1alpha1.BackupType, start, stop time.Time) *dpv1alpha1.Backup {
+	return &dpv1alpha1.Backup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{dptypes.BackupTypeLabelKey: string(backupType)},
+		},
+		Status: dpv1alpha1.BackupStatus{
+			Phase: dpv1alpha1.BackupPhaseCompleted,
+			TimeRange: &dpv1alpha1.BackupTimeRange{
+				Start: &metav1.Time{Time: start},
+				End:   &metav1.Time{Time: stop},
+			},
+		},
+	}
+}
+
+func TestSelectRetainedBackups(t *testing.T) {
+	int32Ptr := func(i int32) *int32 { return &i }
+	// daily backups from 2024-01-01 to 2024-03-31
+	var backups []*dpv1alpha1.Backup
+	begin := time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC)
+	for d := begin; d.Before(time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)); d = d.AddDate(0, 0, 1) {
+		backups = append(backups, newRetentionTestBackup(d.Format("full-20060102"), dpv1alpha1.BackupTypeFull, d, d.Add(time.Minute)))
+	}
+	failed := newRetentionTestBackup("failed", dpv1alpha1.BackupTypeFull, begin, time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC))
+	failed.Status.Phase = dpv1alpha1.BackupPhaseFailed
+	backups = append(backups, failed)
+
+	retained := SelectRetainedBackups(&dpv1alpha1.BackupRetention{
+		Type:     dpv1alpha1.BackupRetentionTypeKeepLast,
+		KeepLast: int32Ptr(3),
+	}, backups)
+	assert.Equal(t, sets.New("full-20240331", "full-20240330", "full-20240329"), retained)
+
+	retained = SelectRetainedBackups(&dpv1alpha1.BackupRetention{
+		Type:        dpv1alpha1.BackupRetentionTypeGFS,
+		KeepDaily:   int32Ptr(2),
+		KeepWeekly:  int32Ptr(2),
+		KeepMonthly: int32Ptr(3),
+	}, backups)
+	// 2024-03-31 is a Sunday, the previous week ends on 2024-03-24.
+	assert.Equal(t, sets.New("full-20240331", "full-20240330", "full-20240324", "full-20240229", "full-20240131"), retained)
+
+	assert.Empty(t, SelectRetainedBackups(nil, backups))
+}
+
+func TestGetDependedBackups(t *testing.T) {
+	now := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	full1 := newRetentionTestBackup("full-1", dpv1alpha1.BackupTypeFull, now.Add(-72*time.Hour), now.Add(-71*time.Hour))
+	full2 := newRetentionTestBackup("full-2", dpv1alpha1.BackupTypeFull, now.Add(-48*time.Hour), now.Add(-47*time.Hour))
+	full3 := newRetentionTestBackup("full-3", dpv1alpha1.BackupTypeFull, now.Add(-24*time.Hour), now.Add(-23*time.Hour))
+	inc1 := newRetentionTestBackup("inc-1", dpv1alpha1.BackupTypeIncremental, now.Add(-70*time.Hour), now.Add(-69*time.Hour))
+	inc1.Status.ParentBackupName = full1.Name
+	inc1.Status.BaseBackupName = full1.Name
+	inc2 := newRetentionTestBackup("inc-2", dpv1alpha1.BackupTypeIncremental, now.Add(-68*time.Hour), now.Add(-67*time.Hour))
+	inc2.Status.ParentBackupName = inc1.Name
+	inc2.Status.BaseBackupName = full1.Name
+	continuous := newRetentionTestBackup("continuous", dpv1alpha1.BackupTypeContinuous, now.Add(-50*time.Hour), now)
+	continuous.Status.Phase = dpv1alpha1.BackupPhaseRunning
+	candidates := []*dpv1alpha1.Backup{full1, full2, full3, inc1, inc2, continuous}
+
+	assert.Equal(t, sets.New(full1.Name, inc1.Name), GetDependedBackups([]*dpv1alpha1.Backup{inc2}, candidates))
+	assert.Equal(t, sets.New(full2.Name), GetDependedBackups([]*dpv1alpha1.Backup{continuous}, candidates))
+	assert.Empty(t, GetDependedBackups([]*dpv1alpha1.Backup{full3}, candidates))
+}
@@ -26,6 +26,9 @@ import (
 type Action struct {
 	Name           string       `json:"name"`
 	Exec           *ExecAction  `json:"exec,omitempty"`
+	HTTP           *HTTPAction  `json:"http,omitempty"`
+	GRPC           *GRPCAction  `json:"grpc,omitempty"`
+	FileSystem     *FSAction    `json:"fs,omitempty"`
 	TimeoutSeconds int32        `json:"timeoutSeconds,omitempty"`
 	RetryPolicy    *RetryPolicy `json:"retryPolicy,omitempty"`
 }
@@ -35,6 +38,41 @@ type ExecAction struct {
 	Args     []string `json:"args,omitempty"`
 }
 
+type HTTPAction struct {
+	Port                int32        `json:"port"`
+	Host                string       `json:"host,omitempty"`
+	Scheme              string       `json:"scheme,omitempty"`
+	Method              string       `json:"method,omitempty"`
+	Path                string       `json:"path,omitempty"`
+	Headers             []HTTPHeader `json:"headers,omitempty"`
+	Body                string       `json:"body,omitempty"`                // template of the request body
+	ExpectedStatusCodes []int32      `json:"expectedStatusCodes,omitempty"` // default to 200
+}
+
+type HTTPHeader struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type GRPCAction struct {
+	Port    int32  `json:"port"`
+	Host    string `json:"host,omitempty"`
+	Service string `json:"service"`           // fully-qualified service name
+	Method  string `json:"method"`            // method name
+	Request string `json:"request,omitempty"` // template of the request message in JSON format
+}
+
+// FSAction is a built-in action which reports the usage of the file systems mounted into the kbagent container.
+type FSAction struct {
+	Paths map[string]string `json:"paths"` // the mount paths of the file systems, keyed by the volume names
+}
+
+// FSUsage is the usage of a file system, the output of the FSAction is a map of it keyed by the volume names.
+type FSUsage struct {
+	UsedBytes     int64 `json:"usedBytes"`
+	CapacityBytes int64 `json:"capacityBytes"`
+}
+
 type RetryPolicy struct {
 	MaxRetries    int           `json:"maxRetries,omitempty"`
 	RetryInterval time.Duration `json:"retryInterval,omitempty"`
@@ -62,6 +100,43 @@ const (
 	ProbeEventSourceComponent     = "kbagent"
 )
 
+const (
+	// EventChannelPath is the path of the controller-side receiver that kbagent pushes the events to.
+	EventChannelPath = "/v1.0/events"
+	// EventChannelTokenAudience is the audience of the projected service account token that kbagent authenticates with.
+	EventChannelTokenAudience = "kubeblocks.io/kbagent-event-channel"
+	// EventChannelTokenPath is the path of the projected service account token in the kbagent containers.
+	EventChannelTokenPath = "/var/run/secrets/kubeblocks.io/kbagent/token"
+	// EventChannelCAKey is the key of the CA certificate in the config map projected to the kbagent containers.
+	EventChannelCAKey = "ca.crt"
+	// EventChannelCAPath is the path of the CA certificate that kbagent verifies the event receiver with.
+	EventChannelCAPath = "/var/run/secrets/kubeblocks.io/kbagent/ca.crt"
+	// EventChannelAnnotationKey marks the K8s Events built from the events received through the event channel,
+	// they are not persisted and should not be updated.
+	EventChannelAnnotationKey = "kubeblocks.io/event-channel"
+)
+
+// Event is an event pushed by kbagent to the controller through the event channel,
+// the reason and message are the same as the ones of the K8s Event.
+type Event struct {
+	Namespace string    `json:"namespace"`
+	PodName   string    `json:"podName"`
+	PodUID    string    `json:"podUID"`
+	NodeName  string    `json:"nodeName,omitempty"`
+	Reason    string    `json:"reason"`
+	Message   string    `json:"message"` // the ProbeEvent or TaskEvent in JSON format
+	Timestamp time.Time `json:"timestamp"`
+}
+
+type EventBatch struct {
+	Events []Event `json:"events"`
+}
+
+type EventBatchResponse struct {
+	Failed []int  `json:"failed,omitempty"` // the indexes of the events failed to handle
+	Error  string `json:"error,omitempty"`
+}
+
 type Probe struct {
 	Instance            string `json:"instance"`
 	Action              string `json:"action"`
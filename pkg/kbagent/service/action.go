@@ -117,30 +117,60 @@ func (s *actionService) handleRequest(ctx context.Context, req *proto.ActionRequ
 		return nil, errors.Wrapf(proto.ErrNotDefined, "%s is not defined", req.Action)
 	}
 	action := s.actions[req.Action]
-	if action.Exec == nil {
-		return nil, errors.Wrap(proto.ErrNotImplemented, "only exec action is supported")
+	if action.Exec == nil && action.HTTP == nil && action.GRPC == nil && action.FileSystem == nil {
+		return nil, errors.Wrap(proto.ErrNotImplemented, "only exec, http, grpc and fs actions are supported")
 	}
 	// HACK: pre-check for the reconfigure action
 	if err := checkReconfigure(ctx, req); err != nil {
 		return nil, err
 	}
-	return s.handleExecAction(ctx, req, action)
+	switch {
+	case action.HTTP != nil:
+		return s.handleHTTPAction(ctx, req, action)
+	case action.GRPC != nil:
+		return s.handleGRPCAction(ctx, req, action)
+	case action.FileSystem != nil:
+		// the fs action returns immediately, it is always run in blocking mode
+		return runFSAction(action.FileSystem)
+	default:
+		return s.handleExecAction(ctx, req, action)
+	}
 }
 
 func (s *actionService) handleExecAction(ctx context.Context, req *proto.ActionRequest, action *proto.Action) ([]byte, error) {
 	if req.NonBlocking == nil || !*req.NonBlocking {
 		return runCommand(ctx, action.Exec, req.Parameters, req.TimeoutSeconds)
 	}
-	return s.handleExecActionNonBlocking(ctx, req, action)
+	return s.handleActionNonBlocking(req, func() (chan *commandResult, error) {
+		return runCommandNonBlocking(ctx, action.Exec, req.Parameters, req.TimeoutSeconds)
+	})
+}
+
+func (s *actionService) handleHTTPAction(ctx context.Context, req *proto.ActionRequest, action *proto.Action) ([]byte, error) {
+	if req.NonBlocking == nil || !*req.NonBlocking {
+		return runHTTPRequest(ctx, action.HTTP, req.Parameters, req.TimeoutSeconds)
+	}
+	return s.handleActionNonBlocking(req, func() (chan *commandResult, error) {
+		return runHTTPRequestNonBlocking(ctx, action.HTTP, req.Parameters, req.TimeoutSeconds)
+	})
+}
+
+func (s *actionService) handleGRPCAction(ctx context.Context, req *proto.ActionRequest, action *proto.Action) ([]byte, error) {
+	if req.NonBlocking == nil || !*req.NonBlocking {
+		return runGRPCCall(ctx, action.GRPC, req.Parameters, req.TimeoutSeconds)
+	}
+	return s.handleActionNonBlocking(req, func() (chan *commandResult, error) {
+		return runGRPCCallNonBlocking(ctx, action.GRPC, req.Parameters, req.TimeoutSeconds)
+	})
 }
 
-func (s *actionService) handleExecActionNonBlocking(ctx context.Context, req *proto.ActionRequest, action *proto.Action) ([]byte, error) {
+func (s *actionService) handleActionNonBlocking(req *proto.ActionRequest, run func() (chan *commandResult, error)) ([]byte, error) {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
 	running, ok := s.runningActions[req.Action]
 	if !ok {
-		resultChan, err := runCommandNonBlocking(ctx, action.Exec, req.Parameters, req.TimeoutSeconds)
+		resultChan, err := run()
 		if err != nil {
 			return nil, err
 		}
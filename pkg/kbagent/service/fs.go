@@ -0,0 +1,54 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package service
+
+import (
+	"encoding/json"
+	"syscall"
+
+	"github.com/pkg/errors"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+// runFSAction reports the usage of the file systems, it is calculated in the same way as the kubelet volume stats.
+func runFSAction(action *proto.FSAction) ([]byte, error) {
+	usages := make(map[string]proto.FSUsage, len(action.Paths))
+	for name, path := range action.Paths {
+		usage, err := fsUsage(path)
+		if err != nil {
+			return nil, errors.Wrapf(proto.ErrFailed, "failed to get the usage of volume %s: %v", name, err)
+		}
+		usages[name] = usage
+	}
+	return json.Marshal(usages)
+}
+
+func fsUsage(path string) (proto.FSUsage, error) {
+	stat := &syscall.Statfs_t{}
+	if err := syscall.Statfs(path, stat); err != nil {
+		return proto.FSUsage{}, err
+	}
+	blockSize := int64(stat.Bsize)
+	return proto.FSUsage{
+		UsedBytes:     int64(stat.Blocks-stat.Bfree) * blockSize,
+		CapacityBytes: int64(stat.Blocks) * blockSize,
+	}, nil
+}
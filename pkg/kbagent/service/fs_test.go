@@ -0,0 +1,67 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package service
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+var _ = Describe("fs", func() {
+	Context("runFSAction", func() {
+		It("ok", func() {
+			output, err := runFSAction(&proto.FSAction{Paths: map[string]string{"data": GinkgoT().TempDir()}})
+			Expect(err).Should(BeNil())
+
+			usages := map[string]proto.FSUsage{}
+			Expect(json.Unmarshal(output, &usages)).Should(Succeed())
+			Expect(usages).Should(HaveKey("data"))
+			Expect(usages["data"].CapacityBytes).Should(BeNumerically(">", 0))
+			Expect(usages["data"].UsedBytes).Should(BeNumerically("<=", usages["data"].CapacityBytes))
+		})
+
+		It("not exist", func() {
+			_, err := runFSAction(&proto.FSAction{Paths: map[string]string{"data": "/not/exist"}})
+			Expect(err).ShouldNot(BeNil())
+			Expect(errors.Is(err, proto.ErrFailed)).Should(BeTrue())
+		})
+
+		It("handle request", func() {
+			service, err := newActionService(logr.Discard(), []proto.Action{
+				{
+					Name:       "volumeUsage",
+					FileSystem: &proto.FSAction{Paths: map[string]string{"data": GinkgoT().TempDir()}},
+				},
+			})
+			Expect(err).Should(BeNil())
+
+			output, err := service.handleRequest(ctx, &proto.ActionRequest{Action: "volumeUsage"})
+			Expect(err).Should(BeNil())
+			Expect(string(output)).Should(ContainSubstring(`"data"`))
+		})
+	})
+})
@@ -0,0 +1,200 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	protov2 "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+func runGRPCCall(ctx context.Context, action *proto.GRPCAction, parameters map[string]string, timeout *int32) ([]byte, error) {
+	if action.Port <= 0 {
+		return nil, errors.Wrapf(proto.ErrBadRequest, "invalid port: %d", action.Port)
+	}
+	if len(action.Service) == 0 || len(action.Method) == 0 {
+		return nil, errors.Wrap(proto.ErrBadRequest, "the service and method of grpc action are required")
+	}
+	request, err := renderTemplate("request", action.Request, templateData(parameters))
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := withActionTimeout(ctx, timeout)
+	defer cancel()
+
+	conn, err := grpc.NewClient(actionHostPort(action.Host, action.Port), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, errors.Wrapf(proto.ErrBadRequest, "failed to create grpc client: %v", err)
+	}
+	defer conn.Close()
+
+	method, err := resolveGRPCMethod(ctx, conn, action.Service, action.Method)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	if method.IsStreamingClient() || method.IsStreamingServer() {
+		return nil, errors.Wrapf(proto.ErrNotImplemented, "streaming method %s is not supported", method.FullName())
+	}
+
+	in := dynamicpb.NewMessage(method.Input())
+	if len(request) > 0 {
+		if err = protojson.Unmarshal([]byte(request), in); err != nil {
+			return nil, errors.Wrapf(proto.ErrBadRequest, "invalid grpc request: %v", err)
+		}
+	}
+	out := dynamicpb.NewMessage(method.Output())
+	if err = conn.Invoke(ctx, fmt.Sprintf("/%s/%s", action.Service, action.Method), in, out); err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return protojson.Marshal(out)
+}
+
+func runGRPCCallNonBlocking(ctx context.Context, action *proto.GRPCAction, parameters map[string]string, timeout *int32) (chan *commandResult, error) {
+	return runNonBlocking(func() ([]byte, error) {
+		return runGRPCCall(ctx, action, parameters, timeout)
+	}), nil
+}
+
+// resolveGRPCMethod resolves the method descriptor through the server reflection service.
+func resolveGRPCMethod(ctx context.Context, conn *grpc.ClientConn, service, method string) (protoreflect.MethodDescriptor, error) {
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = stream.CloseSend() }()
+
+	fdps := map[string]*descriptorpb.FileDescriptorProto{}
+	request := func(req *reflectionpb.ServerReflectionRequest) error {
+		if err := stream.Send(req); err != nil {
+			return err
+		}
+		rsp, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		if errRsp := rsp.GetErrorResponse(); errRsp != nil {
+			return status.Error(codes.Code(errRsp.ErrorCode), errRsp.ErrorMessage)
+		}
+		for _, b := range rsp.GetFileDescriptorResponse().GetFileDescriptorProto() {
+			fdp := &descriptorpb.FileDescriptorProto{}
+			if err := protov2.Unmarshal(b, fdp); err != nil {
+				return err
+			}
+			fdps[fdp.GetName()] = fdp
+		}
+		return nil
+	}
+
+	err = request(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: service},
+	})
+	if err != nil {
+		return nil, err
+	}
+	// the server may omit the dependencies which have been sent before, fetch the missing ones explicitly
+	for {
+		var missing []string
+		for _, fdp := range fdps {
+			for _, dep := range fdp.GetDependency() {
+				if _, ok := fdps[dep]; !ok {
+					missing = append(missing, dep)
+				}
+			}
+		}
+		if len(missing) == 0 {
+			break
+		}
+		for _, dep := range missing {
+			if _, ok := fdps[dep]; ok {
+				continue
+			}
+			err = request(&reflectionpb.ServerReflectionRequest{
+				MessageRequest: &reflectionpb.ServerReflectionRequest_FileByFilename{FileByFilename: dep},
+			})
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := fdps[dep]; !ok {
+				return nil, fmt.Errorf("file descriptor %s is not found", dep)
+			}
+		}
+	}
+
+	fds := &descriptorpb.FileDescriptorSet{}
+	for _, fdp := range fdps {
+		fds.File = append(fds.File, fdp)
+	}
+	files, err := protodesc.NewFiles(fds)
+	if err != nil {
+		return nil, err
+	}
+	desc, err := files.FindDescriptorByName(protoreflect.FullName(service))
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "service %s is not found", service)
+	}
+	sd, ok := desc.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "%s is not a service", service)
+	}
+	md := sd.Methods().ByName(protoreflect.Name(method))
+	if md == nil {
+		return nil, status.Errorf(codes.NotFound, "method %s is not found in service %s", method, service)
+	}
+	return md, nil
+}
+
+func grpcError(ctx context.Context, err error) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return proto.ErrTimedOut
+	}
+	if errors.Is(err, io.EOF) {
+		return errors.Wrap(proto.ErrFailed, "grpc stream closed unexpectedly")
+	}
+	s, ok := status.FromError(err)
+	if !ok {
+		return errors.Wrapf(proto.ErrFailed, "grpc call error: %v", err)
+	}
+	switch s.Code() {
+	case codes.DeadlineExceeded:
+		return proto.ErrTimedOut
+	case codes.InvalidArgument:
+		return errors.Wrapf(proto.ErrBadRequest, "grpc code: %s, message: %s", s.Code(), s.Message())
+	case codes.Unimplemented, codes.NotFound:
+		return errors.Wrapf(proto.ErrNotImplemented, "grpc code: %s, message: %s", s.Code(), s.Message())
+	default:
+		return errors.Wrapf(proto.ErrFailed, "grpc code: %s, message: %s", s.Code(), s.Message())
+	}
+}
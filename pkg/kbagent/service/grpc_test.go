@@ -0,0 +1,119 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package service
+
+import (
+	"net"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+var _ = Describe("grpc", func() {
+	var (
+		listener net.Listener
+		server   *grpc.Server
+	)
+
+	newAction := func(service, method string) *proto.GRPCAction {
+		addr := listener.Addr().(*net.TCPAddr)
+		return &proto.GRPCAction{
+			Host:    addr.IP.String(),
+			Port:    int32(addr.Port),
+			Service: service,
+			Method:  method,
+		}
+	}
+
+	BeforeEach(func() {
+		var err error
+		listener, err = net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).Should(BeNil())
+
+		server = grpc.NewServer()
+		healthServer := health.NewServer()
+		healthServer.SetServingStatus("ready", healthpb.HealthCheckResponse_SERVING)
+		healthServer.SetServingStatus("not-ready", healthpb.HealthCheckResponse_NOT_SERVING)
+		healthpb.RegisterHealthServer(server, healthServer)
+		reflection.Register(server)
+		go func() {
+			_ = server.Serve(listener)
+		}()
+	})
+
+	AfterEach(func() {
+		server.Stop()
+	})
+
+	Context("runGRPCCall", func() {
+		It("simple", func() {
+			action := newAction("grpc.health.v1.Health", "Check")
+			output, err := runGRPCCall(ctx, action, nil, nil)
+			Expect(err).Should(BeNil())
+			Expect(string(output)).Should(MatchJSON(`{"status": "SERVING"}`))
+		})
+
+		It("request template", func() {
+			action := newAction("grpc.health.v1.Health", "Check")
+			action.Request = `{"service": "{{ .SERVICE }}"}`
+			output, err := runGRPCCall(ctx, action, map[string]string{"SERVICE": "not-ready"}, nil)
+			Expect(err).Should(BeNil())
+			Expect(string(output)).Should(MatchJSON(`{"status": "NOT_SERVING"}`))
+		})
+
+		It("invalid request", func() {
+			action := newAction("grpc.health.v1.Health", "Check")
+			action.Request = `{"unknown": "field"}`
+			_, err := runGRPCCall(ctx, action, nil, nil)
+			Expect(err).ShouldNot(BeNil())
+			Expect(errors.Is(err, proto.ErrBadRequest)).Should(BeTrue())
+		})
+
+		It("error status", func() {
+			action := newAction("grpc.health.v1.Health", "Check")
+			action.Request = `{"service": "unknown"}`
+			_, err := runGRPCCall(ctx, action, nil, nil)
+			Expect(err).ShouldNot(BeNil())
+			Expect(errors.Is(err, proto.ErrNotImplemented)).Should(BeTrue())
+		})
+
+		It("method not found", func() {
+			action := newAction("grpc.health.v1.Health", "Unknown")
+			_, err := runGRPCCall(ctx, action, nil, nil)
+			Expect(err).ShouldNot(BeNil())
+			Expect(errors.Is(err, proto.ErrNotImplemented)).Should(BeTrue())
+		})
+
+		It("streaming method", func() {
+			action := newAction("grpc.health.v1.Health", "Watch")
+			_, err := runGRPCCall(ctx, action, nil, nil)
+			Expect(err).ShouldNot(BeNil())
+			Expect(errors.Is(err, proto.ErrNotImplemented)).Should(BeTrue())
+		})
+	})
+})
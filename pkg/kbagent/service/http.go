@@ -0,0 +1,192 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+	"github.com/apecloud/kubeblocks/pkg/kbagent/util"
+)
+
+const (
+	defaultActionHost = "127.0.0.1"
+)
+
+func runHTTPRequest(ctx context.Context, action *proto.HTTPAction, parameters map[string]string, timeout *int32) ([]byte, error) {
+	ctx, cancel := withActionTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := buildHTTPRequest(ctx, action, parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	rsp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, proto.ErrTimedOut
+		}
+		return nil, errors.Wrapf(proto.ErrFailed, "failed to send http request: %v", err)
+	}
+	defer rsp.Body.Close()
+
+	body, err := io.ReadAll(rsp.Body)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, proto.ErrTimedOut
+		}
+		return nil, errors.Wrapf(proto.ErrFailed, "failed to read http response: %v", err)
+	}
+
+	expected := action.ExpectedStatusCodes
+	if len(expected) == 0 {
+		expected = []int32{http.StatusOK}
+	}
+	if !slices.Contains(expected, int32(rsp.StatusCode)) {
+		errMsg := fmt.Sprintf("status code: %d", rsp.StatusCode)
+		if len(body) > 0 {
+			errMsg += fmt.Sprintf(", body: %s", string(body))
+		}
+		return nil, errors.Wrap(proto.ErrFailed, errMsg)
+	}
+	return body, nil
+}
+
+func runHTTPRequestNonBlocking(ctx context.Context, action *proto.HTTPAction, parameters map[string]string, timeout *int32) (chan *commandResult, error) {
+	return runNonBlocking(func() ([]byte, error) {
+		return runHTTPRequest(ctx, action, parameters, timeout)
+	}), nil
+}
+
+func buildHTTPRequest(ctx context.Context, action *proto.HTTPAction, parameters map[string]string) (*http.Request, error) {
+	if action.Port <= 0 {
+		return nil, errors.Wrapf(proto.ErrBadRequest, "invalid port: %d", action.Port)
+	}
+	data := templateData(parameters)
+
+	path, err := renderTemplate("path", action.Path, data)
+	if err != nil {
+		return nil, err
+	}
+	body, err := renderTemplate("body", action.Body, data)
+	if err != nil {
+		return nil, err
+	}
+
+	scheme := "http"
+	if len(action.Scheme) > 0 {
+		scheme = strings.ToLower(action.Scheme)
+	}
+	method := http.MethodGet
+	if len(action.Method) > 0 {
+		method = strings.ToUpper(action.Method)
+	}
+	if len(path) > 0 && !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	url := fmt.Sprintf("%s://%s%s", scheme, actionHostPort(action.Host, action.Port), path)
+
+	var bodyReader io.Reader
+	if len(body) > 0 {
+		bodyReader = bytes.NewBufferString(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
+	if err != nil {
+		return nil, errors.Wrapf(proto.ErrBadRequest, "invalid http request: %v", err)
+	}
+	for _, header := range action.Headers {
+		value, err := renderTemplate("header", header.Value, data)
+		if err != nil {
+			return nil, err
+		}
+		if strings.EqualFold(header.Name, "host") {
+			req.Host = value
+		} else {
+			req.Header.Add(header.Name, value)
+		}
+	}
+	return req, nil
+}
+
+func actionHostPort(host string, port int32) string {
+	if len(host) == 0 {
+		host = defaultActionHost
+	}
+	return net.JoinHostPort(host, strconv.Itoa(int(port)))
+}
+
+// templateData returns the data to render the templates of an action,
+// the action-specific parameters take precedence over the environment variables.
+func templateData(parameters map[string]string) map[string]string {
+	data := util.EnvL2M(os.Environ())
+	for k, v := range parameters {
+		data[k] = v
+	}
+	return data
+}
+
+func renderTemplate(name, text string, data map[string]string) (string, error) {
+	if !strings.Contains(text, "{{") {
+		return text, nil
+	}
+	tpl, err := template.New(name).Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return "", errors.Wrapf(proto.ErrBadRequest, "invalid %s template: %v", name, err)
+	}
+	buf := &bytes.Buffer{}
+	if err = tpl.Execute(buf, data); err != nil {
+		return "", errors.Wrapf(proto.ErrBadRequest, "failed to render %s template: %v", name, err)
+	}
+	return buf.String(), nil
+}
+
+func withActionTimeout(ctx context.Context, timeout *int32) (context.Context, context.CancelFunc) {
+	if timeout != nil && *timeout > 0 {
+		return context.WithTimeout(ctx, time.Duration(*timeout)*time.Second)
+	}
+	return context.WithCancel(ctx)
+}
+
+func runNonBlocking(f func() ([]byte, error)) chan *commandResult {
+	resultChan := make(chan *commandResult, 1)
+	go func() {
+		out, err := f()
+		resultChan <- &commandResult{
+			err:    err,
+			stdout: bytes.NewBuffer(out),
+			stderr: bytes.NewBuffer(nil),
+		}
+	}()
+	return resultChan
+}
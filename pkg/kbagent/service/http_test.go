@@ -0,0 +1,175 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package service
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/pkg/errors"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+var _ = Describe("http", func() {
+	var (
+		server *httptest.Server
+	)
+
+	newAction := func() *proto.HTTPAction {
+		host, port, err := net.SplitHostPort(server.Listener.Addr().String())
+		Expect(err).Should(BeNil())
+		p, err := strconv.Atoi(port)
+		Expect(err).Should(BeNil())
+		return &proto.HTTPAction{
+			Host: host,
+			Port: int32(p),
+		}
+	}
+
+	BeforeEach(func() {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/echo", func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			_, _ = w.Write([]byte(r.Method + ":" + r.Header.Get("X-Param") + ":" + string(body)))
+		})
+		mux.HandleFunc("/path/", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(r.URL.Path))
+		})
+		mux.HandleFunc("/created", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+		})
+		mux.HandleFunc("/fail", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte("internal error"))
+		})
+		mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(10 * time.Second):
+			}
+		})
+		server = httptest.NewServer(mux)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Context("runHTTPRequest", func() {
+		It("simple", func() {
+			action := newAction()
+			action.Path = "/echo"
+			output, err := runHTTPRequest(ctx, action, nil, nil)
+			Expect(err).Should(BeNil())
+			Expect(string(output)).Should(Equal("GET::"))
+		})
+
+		It("templates", func() {
+			action := newAction()
+			action.Method = "post"
+			action.Path = "/echo"
+			action.Headers = []proto.HTTPHeader{
+				{
+					Name:  "X-Param",
+					Value: "{{ .HEADER }}",
+				},
+			}
+			action.Body = `{"candidate": "{{ .CANDIDATE }}"}`
+			parameters := map[string]string{
+				"HEADER":    "header",
+				"CANDIDATE": "pod-1",
+			}
+			output, err := runHTTPRequest(ctx, action, parameters, nil)
+			Expect(err).Should(BeNil())
+			Expect(string(output)).Should(Equal(`POST:header:{"candidate": "pod-1"}`))
+		})
+
+		It("path template", func() {
+			action := newAction()
+			action.Path = "path/{{ .NAME }}"
+			output, err := runHTTPRequest(ctx, action, map[string]string{"NAME": "name"}, nil)
+			Expect(err).Should(BeNil())
+			Expect(string(output)).Should(Equal("/path/name"))
+		})
+
+		It("bad template", func() {
+			action := newAction()
+			action.Path = "/echo"
+			action.Body = "{{ .NAME"
+			_, err := runHTTPRequest(ctx, action, nil, nil)
+			Expect(err).ShouldNot(BeNil())
+			Expect(errors.Is(err, proto.ErrBadRequest)).Should(BeTrue())
+		})
+
+		It("expected status codes", func() {
+			action := newAction()
+			action.Path = "/created"
+			_, err := runHTTPRequest(ctx, action, nil, nil)
+			Expect(err).ShouldNot(BeNil())
+			Expect(errors.Is(err, proto.ErrFailed)).Should(BeTrue())
+
+			action.ExpectedStatusCodes = []int32{http.StatusOK, http.StatusCreated}
+			_, err = runHTTPRequest(ctx, action, nil, nil)
+			Expect(err).Should(BeNil())
+		})
+
+		It("fail", func() {
+			action := newAction()
+			action.Path = "/fail"
+			_, err := runHTTPRequest(ctx, action, nil, nil)
+			Expect(err).ShouldNot(BeNil())
+			Expect(errors.Is(err, proto.ErrFailed)).Should(BeTrue())
+			Expect(err.Error()).Should(ContainSubstring("internal error"))
+		})
+
+		It("timeout", func() {
+			action := newAction()
+			action.Path = "/slow"
+			timeout := int32(1)
+			_, err := runHTTPRequest(ctx, action, nil, &timeout)
+			Expect(err).ShouldNot(BeNil())
+			Expect(errors.Is(err, proto.ErrTimedOut)).Should(BeTrue())
+		})
+	})
+
+	Context("runHTTPRequestNonBlocking", func() {
+		It("ok", func() {
+			action := newAction()
+			action.Path = "/echo"
+			resultChan, err := runHTTPRequestNonBlocking(ctx, action, nil, nil)
+			Expect(err).Should(BeNil())
+			Eventually(func() *commandResult {
+				result := gather(resultChan)
+				if result == nil {
+					return nil
+				}
+				return *result
+			}).ShouldNot(BeNil())
+		})
+	})
+})
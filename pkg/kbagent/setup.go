@@ -23,11 +23,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
 
 	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
 	"github.com/apecloud/kubeblocks/pkg/kbagent/server"
@@ -50,8 +52,57 @@ const (
 	probeEnvName     = "KB_AGENT_PROBE"
 	streamingEnvName = "KB_AGENT_STREAMING"
 	taskEnvName      = "KB_AGENT_TASK"
+
+	eventTokenVolumeName        = "kbagent-event-token"
+	eventTokenExpirationSeconds = int64(3600)
 )
 
+// BuildEnv4EventChannel builds the env vars to push the events to the controller through the event channel.
+func BuildEnv4EventChannel(endpoint string) []corev1.EnvVar {
+	return util.EventChannelEnvVars(endpoint)
+}
+
+// BuildVolume4EventChannel builds the projected volume of the service account token which kbagent authenticates with,
+// and the CA certificate which kbagent verifies the event receiver with. The token is bound to the pod and dedicated to
+// the event channel, and the CA certificate is projected from the config map, kbagent reloads it once it is updated.
+func BuildVolume4EventChannel(caConfigMapName string) (corev1.Volume, corev1.VolumeMount) {
+	volume := corev1.Volume{
+		Name: eventTokenVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{
+					{
+						ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+							Audience:          proto.EventChannelTokenAudience,
+							ExpirationSeconds: ptr.To(eventTokenExpirationSeconds),
+							Path:              filepath.Base(proto.EventChannelTokenPath),
+						},
+					},
+					{
+						ConfigMap: &corev1.ConfigMapProjection{
+							LocalObjectReference: corev1.LocalObjectReference{Name: caConfigMapName},
+							Items: []corev1.KeyToPath{
+								{
+									Key:  proto.EventChannelCAKey,
+									Path: filepath.Base(proto.EventChannelCAPath),
+								},
+							},
+							// the pod is not blocked by the config map, the events fall back to the K8s Events until it is created
+							Optional: ptr.To(true),
+						},
+					},
+				},
+			},
+		},
+	}
+	mount := corev1.VolumeMount{
+		Name:      eventTokenVolumeName,
+		MountPath: filepath.Dir(proto.EventChannelTokenPath),
+		ReadOnly:  true,
+	}
+	return volume, mount
+}
+
 func BuildEnv4Server(actions []proto.Action, probes []proto.Probe, streaming []string) ([]corev1.EnvVar, error) {
 	da, dp, err := serializeActionNProbe(actions, probes)
 	if err != nil {
@@ -31,6 +31,8 @@ const (
 	kbEnvPodName   = "KB_AGENT_POD_NAME"
 	kbEnvPodUID    = "KB_AGENT_POD_UID"
 	kbEnvNodeName  = "KB_AGENT_NODE_NAME"
+
+	kbEnvEventEndpoint = "KB_AGENT_EVENT_ENDPOINT"
 )
 
 func EnvM2L(m map[string]string) []string {
@@ -96,6 +98,20 @@ func DefaultEnvVars() []corev1.EnvVar {
 	}
 }
 
+// EventChannelEnvVars returns the env vars to push the events to the controller through the event channel,
+// the endpoint should be served over TLS.
+func EventChannelEnvVars(endpoint string) []corev1.EnvVar {
+	if len(endpoint) == 0 {
+		return nil
+	}
+	return []corev1.EnvVar{
+		{
+			Name:  kbEnvEventEndpoint,
+			Value: endpoint,
+		},
+	}
+}
+
 func namespace() string {
 	return os.Getenv(kbEnvNamespace)
 }
@@ -115,3 +131,7 @@ func podUID() string {
 func nodeName() string {
 	return os.Getenv(kbEnvNodeName)
 }
+
+func eventEndpoint() string {
+	return os.Getenv(kbEnvEventEndpoint)
+}
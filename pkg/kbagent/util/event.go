@@ -43,6 +43,14 @@ const (
 )
 
 func SendEventWithMessage(logger *logr.Logger, reason string, message string, sync bool) error {
+	// push the event to the controller directly if the event channel is enabled.
+	if c := getEventChannel(); c != nil {
+		if sync {
+			return c.send(logger, reason, message)
+		}
+		c.enqueue(logger, reason, message)
+		return nil
+	}
 	send := func() error {
 		err := createOrUpdateEvent(reason, message)
 		if logger != nil && err != nil {
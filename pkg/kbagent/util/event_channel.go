@@ -0,0 +1,259 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package util
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+const (
+	eventChannelBatchSize     = 64
+	eventChannelQueueSize     = 1024
+	eventChannelFlushInterval = time.Second
+	eventChannelTimeout       = 10 * time.Second
+)
+
+var (
+	channelOnce sync.Once
+	channel     *eventChannel
+)
+
+// eventChannel pushes the events to the controller in batches, and falls back to K8s Events if the push fails.
+type eventChannel struct {
+	endpoint string
+	caFile   string
+	queue    chan pendingEvent
+
+	mu     sync.Mutex
+	caCert []byte
+	client *http.Client
+}
+
+type pendingEvent struct {
+	event  proto.Event
+	logger *logr.Logger
+}
+
+// getEventChannel returns the event channel, or nil if the event channel is not enabled.
+func getEventChannel() *eventChannel {
+	channelOnce.Do(func() {
+		var err error
+		channel, err = newEventChannel(eventEndpoint(), proto.EventChannelCAPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to create the event channel, fallback to the K8s Events: %s\n", err.Error())
+		}
+		if channel != nil {
+			go channel.run()
+		}
+	})
+	return channel
+}
+
+// newEventChannel creates the event channel, the events are only pushed over TLS since they carry the token.
+func newEventChannel(endpoint, caFile string) (*eventChannel, error) {
+	if len(endpoint) == 0 {
+		return nil, nil
+	}
+	if !strings.HasPrefix(endpoint, "https://") {
+		return nil, fmt.Errorf("the event endpoint should be served over https: %s", endpoint)
+	}
+	return &eventChannel{
+		endpoint: strings.TrimSuffix(endpoint, "/") + proto.EventChannelPath,
+		caFile:   caFile,
+		queue:    make(chan pendingEvent, eventChannelQueueSize),
+	}, nil
+}
+
+// httpClient returns the client to push the events with. The CA certificate is projected from a config map
+// which is updated in place when the CA is renewed, so the client is rebuilt once the CA certificate changes.
+func (c *eventChannel) httpClient() (*http.Client, error) {
+	caCert, err := os.ReadFile(c.caFile)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.client != nil && bytes.Equal(caCert, c.caCert) {
+		return c.client, nil
+	}
+	rootCAs := x509.NewCertPool()
+	if !rootCAs.AppendCertsFromPEM(caCert) {
+		return nil, fmt.Errorf("invalid CA certificate of the event endpoint")
+	}
+	if c.client != nil {
+		c.client.CloseIdleConnections()
+	}
+	c.caCert = caCert
+	c.client = &http.Client{
+		Timeout: eventChannelTimeout,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				MinVersion: tls.VersionTLS12,
+				RootCAs:    rootCAs,
+			},
+		},
+	}
+	return c.client, nil
+}
+
+func newChannelEvent(reason, message string) proto.Event {
+	return proto.Event{
+		Namespace: namespace(),
+		PodName:   podName(),
+		PodUID:    podUID(),
+		NodeName:  nodeName(),
+		Reason:    reason,
+		Message:   message,
+		Timestamp: time.Now(),
+	}
+}
+
+// send pushes the event synchronously, and falls back to the K8s Event if it fails.
+func (c *eventChannel) send(logger *logr.Logger, reason, message string) error {
+	failed, err := c.push([]proto.Event{newChannelEvent(reason, message)})
+	if err == nil && len(failed) == 0 {
+		return nil
+	}
+	if logger != nil && err != nil {
+		logger.Error(err, "failed to push event, fallback to the K8s Event", "reason", reason)
+	}
+	return createOrUpdateEvent(reason, message)
+}
+
+// enqueue pushes the event asynchronously, and falls back to the K8s Event if the queue is full.
+func (c *eventChannel) enqueue(logger *logr.Logger, reason, message string) {
+	select {
+	case c.queue <- pendingEvent{event: newChannelEvent(reason, message), logger: logger}:
+	default:
+		go c.fallback(pendingEvent{event: newChannelEvent(reason, message), logger: logger})
+	}
+}
+
+func (c *eventChannel) run() {
+	ticker := time.NewTicker(eventChannelFlushInterval)
+	defer ticker.Stop()
+	batch := make([]pendingEvent, 0, eventChannelBatchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		c.flush(batch)
+		batch = make([]pendingEvent, 0, eventChannelBatchSize)
+	}
+	for {
+		select {
+		case e := <-c.queue:
+			batch = append(batch, e)
+			if len(batch) >= eventChannelBatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+func (c *eventChannel) flush(batch []pendingEvent) {
+	events := make([]proto.Event, 0, len(batch))
+	for _, e := range batch {
+		events = append(events, e.event)
+	}
+	failed, err := c.push(events)
+	if err != nil {
+		if batch[0].logger != nil {
+			batch[0].logger.Error(err, "failed to push events, fallback to the K8s Events", "count", len(batch))
+		}
+		for _, e := range batch {
+			go c.fallback(e)
+		}
+		return
+	}
+	for _, i := range failed {
+		if i >= 0 && i < len(batch) {
+			go c.fallback(batch[i])
+		}
+	}
+}
+
+func (c *eventChannel) fallback(e pendingEvent) {
+	err := createOrUpdateEvent(e.event.Reason, e.event.Message)
+	if e.logger != nil && err != nil {
+		e.logger.Error(err, "failed to send event",
+			"reason", e.event.Reason,
+			"message", e.event.Message)
+	}
+}
+
+// push posts the events to the controller, and returns the indexes of the events failed to handle.
+func (c *eventChannel) push(events []proto.Event) ([]int, error) {
+	body, err := json.Marshal(proto.EventBatch{Events: events})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(http.MethodPost, c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	// the projected token is rotated by the kubelet, read it every time.
+	token, err := os.ReadFile(proto.EventChannelTokenPath)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+
+	cli, err := c.httpClient()
+	if err != nil {
+		return nil, err
+	}
+	rsp, err := cli.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer rsp.Body.Close()
+	data, err := io.ReadAll(rsp.Body)
+	if err != nil {
+		return nil, err
+	}
+	batchRsp := &proto.EventBatchResponse{}
+	if len(data) > 0 {
+		if err = json.Unmarshal(data, batchRsp); err != nil {
+			return nil, fmt.Errorf("unexpected response with status %d: %s", rsp.StatusCode, string(data))
+		}
+	}
+	if rsp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to push events with status %d: %s", rsp.StatusCode, batchRsp.Error)
+	}
+	return batchRsp.Failed, nil
+}
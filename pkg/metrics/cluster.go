@@ -0,0 +1,124 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package metrics
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+const collectTimeout = 10 * time.Second
+
+var (
+	clusterPhases = []appsv1.ClusterPhase{
+		appsv1.CreatingClusterPhase,
+		appsv1.RunningClusterPhase,
+		appsv1.UpdatingClusterPhase,
+		appsv1.StoppingClusterPhase,
+		appsv1.StoppedClusterPhase,
+		appsv1.DeletingClusterPhase,
+		appsv1.FailedClusterPhase,
+		appsv1.AbnormalClusterPhase,
+	}
+
+	componentPhases = []appsv1.ComponentPhase{
+		appsv1.CreatingComponentPhase,
+		appsv1.StartingComponentPhase,
+		appsv1.RunningComponentPhase,
+		appsv1.UpdatingComponentPhase,
+		appsv1.StoppingComponentPhase,
+		appsv1.StoppedComponentPhase,
+		appsv1.DeletingComponentPhase,
+		appsv1.FailedComponentPhase,
+	}
+
+	clusterPhaseDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "cluster", "status_phase"),
+		"The current phase of the cluster, the value is 1 for the current phase and 0 for the others.",
+		[]string{"namespace", "cluster", "phase"}, nil)
+
+	componentPhaseDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "component", "status_phase"),
+		"The current phase of the component, the value is 1 for the current phase and 0 for the others.",
+		[]string{"namespace", "cluster", "component", "phase"}, nil)
+)
+
+// clusterCollector collects the phases of clusters and components from the cache at scrape time.
+type clusterCollector struct {
+	reader client.Reader
+}
+
+var _ prometheus.Collector = &clusterCollector{}
+
+// NewClusterCollector creates a collector that exports the phases of clusters and components.
+func NewClusterCollector(reader client.Reader) prometheus.Collector {
+	return &clusterCollector{reader: reader}
+}
+
+func (c *clusterCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- clusterPhaseDesc
+	ch <- componentPhaseDesc
+}
+
+func (c *clusterCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+
+	clusters := &appsv1.ClusterList{}
+	if err := c.reader.List(ctx, clusters); err != nil {
+		logf.Log.WithName("metrics").Error(err, "failed to list clusters")
+	} else {
+		for _, cluster := range clusters.Items {
+			for _, phase := range clusterPhases {
+				ch <- prometheus.MustNewConstMetric(clusterPhaseDesc, prometheus.GaugeValue,
+					boolValue(cluster.Status.Phase == phase), cluster.Namespace, cluster.Name, string(phase))
+			}
+		}
+	}
+
+	comps := &appsv1.ComponentList{}
+	if err := c.reader.List(ctx, comps); err != nil {
+		logf.Log.WithName("metrics").Error(err, "failed to list components")
+	} else {
+		for _, comp := range comps.Items {
+			clusterName := comp.Labels[constant.AppInstanceLabelKey]
+			compName, _ := strings.CutPrefix(comp.Name, clusterName+"-")
+			for _, phase := range componentPhases {
+				ch <- prometheus.MustNewConstMetric(componentPhaseDesc, prometheus.GaugeValue,
+					boolValue(comp.Status.Phase == phase), comp.Namespace, clusterName, compName, string(phase))
+			}
+		}
+	}
+}
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,186 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package metrics
+
+import (
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/api/resource"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
+)
+
+const namespace = "kubeblocks"
+
+const (
+	resultSuccess = "success"
+	resultFailure = "failure"
+)
+
+var (
+	opsRequestTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "opsrequest",
+		Name:      "total",
+		Help:      "The number of completed OpsRequests, partitioned by the type and the final phase.",
+	}, []string{"type", "phase"})
+
+	opsRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "opsrequest",
+		Name:      "duration_seconds",
+		Help:      "The duration of completed OpsRequests from start to completion, partitioned by the type and the final phase.",
+		Buckets:   prometheus.ExponentialBuckets(1, 2, 16),
+	}, []string{"type", "phase"})
+
+	backupTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "backup",
+		Name:      "total",
+		Help:      "The number of completed and failed backups, partitioned by the BackupPolicy, the backup method and the final phase.",
+	}, []string{"namespace", "backup_policy", "backup_method", "phase"})
+
+	backupDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "backup",
+		Name:      "duration_seconds",
+		Help:      "The duration of completed backups, partitioned by the BackupPolicy and the backup method.",
+		Buckets:   prometheus.ExponentialBuckets(10, 2, 14),
+	}, []string{"namespace", "backup_policy", "backup_method"})
+
+	backupSize = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "backup",
+		Name:      "last_size_bytes",
+		Help:      "The total size of the last completed backup, partitioned by the BackupPolicy and the backup method.",
+	}, []string{"namespace", "backup_policy", "backup_method"})
+
+	restoreTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "restore",
+		Name:      "total",
+		Help:      "The number of completed and failed restores, partitioned by the final phase.",
+	}, []string{"namespace", "phase"})
+
+	restoreDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "restore",
+		Name:      "duration_seconds",
+		Help:      "The duration of completed and failed restores, partitioned by the final phase.",
+		Buckets:   prometheus.ExponentialBuckets(10, 2, 14),
+	}, []string{"namespace", "phase"})
+
+	lifecycleActionDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "lifecycle_action",
+		Name:      "duration_seconds",
+		Help:      "The latency of lifecycle actions called by the controller, partitioned by the action name and the result.",
+		Buckets:   prometheus.ExponentialBuckets(0.01, 2, 14),
+	}, []string{"action", "result"})
+)
+
+// RegisterManagerMetrics registers the metrics of the KubeBlocks manager to the controller-runtime registry,
+// they are exported under the metrics endpoint of the manager.
+func RegisterManagerMetrics(collectors ...prometheus.Collector) {
+	ctrlmetrics.Registry.MustRegister(opsRequestTotal, opsRequestDuration, lifecycleActionDuration)
+	ctrlmetrics.Registry.MustRegister(collectors...)
+}
+
+// RegisterDataProtectionMetrics registers the metrics of the data protection to the controller-runtime registry.
+func RegisterDataProtectionMetrics() {
+	ctrlmetrics.Registry.MustRegister(backupTotal, backupDuration, backupSize, restoreTotal, restoreDuration)
+}
+
+// ObserveOpsRequest records a completed OpsRequest.
+func ObserveOpsRequest(ops *opsv1alpha1.OpsRequest) {
+	if ops == nil || !ops.IsComplete() {
+		return
+	}
+	opsType, phase := string(ops.Spec.Type), string(ops.Status.Phase)
+	opsRequestTotal.WithLabelValues(opsType, phase).Inc()
+	if !ops.Status.StartTimestamp.IsZero() && !ops.Status.CompletionTimestamp.IsZero() {
+		duration := ops.Status.CompletionTimestamp.Sub(ops.Status.StartTimestamp.Time)
+		opsRequestDuration.WithLabelValues(opsType, phase).Observe(duration.Seconds())
+	}
+}
+
+// ObserveBackup records a completed or failed backup.
+func ObserveBackup(backup *dpv1alpha1.Backup) {
+	if backup == nil {
+		return
+	}
+	phase := backup.Status.Phase
+	if phase != dpv1alpha1.BackupPhaseCompleted && phase != dpv1alpha1.BackupPhaseFailed {
+		return
+	}
+	labels := []string{backup.Namespace, backup.Spec.BackupPolicyName, backup.Spec.BackupMethod}
+	backupTotal.WithLabelValues(append(labels, string(phase))...).Inc()
+	if phase != dpv1alpha1.BackupPhaseCompleted {
+		return
+	}
+	if backup.Status.Duration != nil {
+		backupDuration.WithLabelValues(labels...).Observe(backup.Status.Duration.Seconds())
+	}
+	if size, ok := parseSize(backup.Status.TotalSize); ok {
+		backupSize.WithLabelValues(labels...).Set(size)
+	}
+}
+
+// ObserveRestore records a completed or failed restore.
+func ObserveRestore(restore *dpv1alpha1.Restore) {
+	if restore == nil {
+		return
+	}
+	phase := restore.Status.Phase
+	if phase != dpv1alpha1.RestorePhaseCompleted && phase != dpv1alpha1.RestorePhaseFailed {
+		return
+	}
+	restoreTotal.WithLabelValues(restore.Namespace, string(phase)).Inc()
+	if restore.Status.StartTimestamp != nil && restore.Status.CompletionTimestamp != nil {
+		duration := restore.Status.CompletionTimestamp.Sub(restore.Status.StartTimestamp.Time)
+		restoreDuration.WithLabelValues(restore.Namespace, string(phase)).Observe(duration.Seconds())
+	}
+}
+
+// ObserveLifecycleAction records the latency of a lifecycle action.
+func ObserveLifecycleAction(action string, duration time.Duration, err error) {
+	result := resultSuccess
+	if err != nil {
+		result = resultFailure
+	}
+	lifecycleActionDuration.WithLabelValues(action, result).Observe(duration.Seconds())
+}
+
+// parseSize parses the size in the form of quantity, e.g. 1Gi, or bytes.
+func parseSize(size string) (float64, bool) {
+	size = strings.TrimSpace(size)
+	if len(size) == 0 {
+		return 0, false
+	}
+	q, err := resource.ParseQuantity(size)
+	if err != nil {
+		return 0, false
+	}
+	return q.AsApproximateFloat64(), true
+}
@@ -0,0 +1,118 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+func TestClusterCollector(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := appsv1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	cluster := &appsv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "mysql"},
+		Status:     appsv1.ClusterStatus{Phase: appsv1.RunningClusterPhase},
+	}
+	comp := &appsv1.Component{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "mysql-server",
+			Labels:    map[string]string{constant.AppInstanceLabelKey: "mysql"},
+		},
+		Status: appsv1.ComponentStatus{Phase: appsv1.UpdatingComponentPhase},
+	}
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cluster, comp).Build()
+
+	expected := `
+# HELP kubeblocks_component_status_phase The current phase of the component, the value is 1 for the current phase and 0 for the others.
+# TYPE kubeblocks_component_status_phase gauge
+kubeblocks_component_status_phase{cluster="mysql",component="server",namespace="default",phase="Creating"} 0
+kubeblocks_component_status_phase{cluster="mysql",component="server",namespace="default",phase="Deleting"} 0
+kubeblocks_component_status_phase{cluster="mysql",component="server",namespace="default",phase="Failed"} 0
+kubeblocks_component_status_phase{cluster="mysql",component="server",namespace="default",phase="Running"} 0
+kubeblocks_component_status_phase{cluster="mysql",component="server",namespace="default",phase="Starting"} 0
+kubeblocks_component_status_phase{cluster="mysql",component="server",namespace="default",phase="Stopped"} 0
+kubeblocks_component_status_phase{cluster="mysql",component="server",namespace="default",phase="Stopping"} 0
+kubeblocks_component_status_phase{cluster="mysql",component="server",namespace="default",phase="Updating"} 1
+`
+	collector := NewClusterCollector(cli)
+	if err := testutil.CollectAndCompare(collector, strings.NewReader(expected), "kubeblocks_component_status_phase"); err != nil {
+		t.Error(err)
+	}
+	if n := testutil.CollectAndCount(collector, "kubeblocks_cluster_status_phase"); n != len(clusterPhases) {
+		t.Errorf("expected %d cluster phase series, got %d", len(clusterPhases), n)
+	}
+}
+
+func TestObserveOpsRequest(t *testing.T) {
+	now := time.Now()
+	ops := &opsv1alpha1.OpsRequest{
+		Spec: opsv1alpha1.OpsRequestSpec{Type: opsv1alpha1.RestartType},
+		Status: opsv1alpha1.OpsRequestStatus{
+			Phase:               opsv1alpha1.OpsSucceedPhase,
+			StartTimestamp:      metav1.NewTime(now.Add(-time.Minute)),
+			CompletionTimestamp: metav1.NewTime(now),
+		},
+	}
+	ObserveOpsRequest(ops)
+	if v := testutil.ToFloat64(opsRequestTotal.WithLabelValues("Restart", "Succeed")); v != 1 {
+		t.Errorf("expected 1 succeed restart ops, got %v", v)
+	}
+
+	// the running OpsRequest is ignored.
+	ops.Status.Phase = opsv1alpha1.OpsRunningPhase
+	ObserveOpsRequest(ops)
+	if n := testutil.CollectAndCount(opsRequestTotal); n != 1 {
+		t.Errorf("expected 1 series, got %d", n)
+	}
+}
+
+func TestObserveBackup(t *testing.T) {
+	backup := &dpv1alpha1.Backup{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "backup"},
+		Spec:       dpv1alpha1.BackupSpec{BackupPolicyName: "policy", BackupMethod: "xtrabackup"},
+		Status: dpv1alpha1.BackupStatus{
+			Phase:     dpv1alpha1.BackupPhaseCompleted,
+			TotalSize: "1Ki",
+			Duration:  &metav1.Duration{Duration: time.Minute},
+		},
+	}
+	ObserveBackup(backup)
+	if v := testutil.ToFloat64(backupSize.WithLabelValues("default", "policy", "xtrabackup")); v != 1024 {
+		t.Errorf("expected backup size 1024, got %v", v)
+	}
+	if v := testutil.ToFloat64(backupTotal.WithLabelValues("default", "policy", "xtrabackup", "Completed")); v != 1 {
+		t.Errorf("expected 1 completed backup, got %v", v)
+	}
+}
@@ -44,10 +44,12 @@ var _ OpsHandler = BackupOpsHandler{}
 
 func init() {
 	// ToClusterPhase is not defined, because 'backup' does not affect the cluster phase.
+	backupHandler := BackupOpsHandler{}
 	backupBehaviour := OpsBehaviour{
 		FromClusterPhases: []appsv1.ClusterPhase{appsv1.RunningClusterPhase,
 			appsv1.UpdatingClusterPhase, appsv1.AbnormalClusterPhase},
-		OpsHandler: BackupOpsHandler{},
+		OpsHandler: backupHandler,
+		CancelFunc: backupHandler.Cancel,
 	}
 
 	opsMgr := GetOpsManager()
@@ -87,6 +89,13 @@ func (b BackupOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli cli
 		return opsv1alpha1.OpsFailedPhase, 0, err
 	}
 
+	if opsRequest.Status.Phase == opsv1alpha1.OpsCancellingPhase {
+		// wait for the backup in progress to be deleted.
+		if len(backups.Items) == 0 {
+			return opsv1alpha1.OpsSucceedPhase, 0, nil
+		}
+		return opsv1alpha1.OpsRunningPhase, time.Second, nil
+	}
 	if len(backups.Items) == 0 {
 		return opsv1alpha1.OpsFailedPhase, 0, fmt.Errorf("backup not found")
 	}
@@ -105,6 +114,25 @@ func (b BackupOpsHandler) SaveLastConfiguration(reqCtx intctrlutil.RequestCtx, c
 	return nil
 }
 
+// Cancel deletes the backup in progress.
+func (b BackupOpsHandler) Cancel(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	backups := &dpv1alpha1.BackupList{}
+	if err := cli.List(reqCtx.Ctx, backups, client.InNamespace(opsRes.Cluster.Namespace),
+		client.MatchingLabels(getBackupLabels(opsRes.Cluster.Name, opsRes.OpsRequest.Name))); err != nil {
+		return err
+	}
+	for i := range backups.Items {
+		backup := &backups.Items[i]
+		if backup.Status.Phase == dpv1alpha1.BackupPhaseCompleted {
+			return intctrlutil.NewErrorf(intctrlutil.ErrorIgnoreCancel, `the backup "%s" has been completed`, backup.Name)
+		}
+		if err := cli.Delete(reqCtx.Ctx, backup); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func buildBackup(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRequest *opsv1alpha1.OpsRequest, cluster *appsv1.Cluster) (*dpv1alpha1.Backup, error) {
 	var err error
 
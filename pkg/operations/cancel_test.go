@@ -0,0 +1,134 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+func TestExposeCancel(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+
+	internalService := appsv1.ClusterService{
+		Service: appsv1.Service{
+			Name: "mysql-vpc",
+			Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP},
+		},
+		ComponentSelector: "mysql",
+	}
+	otherService := appsv1.ClusterService{
+		Service:           appsv1.Service{Name: "proxy-vpc"},
+		ComponentSelector: "proxy",
+	}
+	cluster := &appsv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "mycluster"},
+		Spec: appsv1.ClusterSpec{
+			ComponentSpecs: []appsv1.ClusterComponentSpec{{Name: "mysql"}, {Name: "proxy"}},
+			Services:       []appsv1.ClusterService{internalService, otherService},
+		},
+	}
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cluster).Build()
+	reqCtx := intctrlutil.RequestCtx{Ctx: context.Background(), Log: logr.Discard()}
+	opsRes := &OpsResource{
+		Cluster: cluster,
+		OpsRequest: &opsv1alpha1.OpsRequest{
+			Spec: opsv1alpha1.OpsRequestSpec{
+				ClusterName: cluster.Name,
+				Type:        opsv1alpha1.ExposeType,
+				SpecificOpsRequest: opsv1alpha1.SpecificOpsRequest{
+					ExposeList: []opsv1alpha1.Expose{
+						{
+							ComponentName: "mysql",
+							Switch:        opsv1alpha1.EnableExposeSwitch,
+							Services: []opsv1alpha1.OpsService{
+								{Name: "vpc", ServiceType: corev1.ServiceTypeLoadBalancer},
+								{Name: "internet", ServiceType: corev1.ServiceTypeLoadBalancer},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	handler := ExposeOpsHandler{}
+	if err := handler.SaveLastConfiguration(reqCtx, cli, opsRes); err != nil {
+		t.Fatal(err)
+	}
+	lastServices := opsRes.OpsRequest.Status.LastConfiguration.Services
+	if len(lastServices) != 1 || lastServices[0].Name != internalService.Name {
+		t.Fatalf("expect only the existing service changed by the expose to be recorded, got %v", lastServices)
+	}
+
+	// mock the expose action which updates the existing service and adds a new one
+	cluster.Spec.Services[0].Spec.Type = corev1.ServiceTypeLoadBalancer
+	cluster.Spec.Services = append(cluster.Spec.Services, appsv1.ClusterService{
+		Service:           appsv1.Service{Name: "mysql-internet"},
+		ComponentSelector: "mysql",
+	})
+
+	if err := handler.Cancel(reqCtx, cli, opsRes); err != nil {
+		t.Fatal(err)
+	}
+	restored := &appsv1.Cluster{}
+	if err := cli.Get(reqCtx.Ctx, client.ObjectKeyFromObject(cluster), restored); err != nil {
+		t.Fatal(err)
+	}
+	if len(restored.Spec.Services) != 2 {
+		t.Fatalf("expect the new service to be removed, got %v", restored.Spec.Services)
+	}
+	if svc := findClusterService(restored.Spec.Services, "mysql", "mysql-vpc"); svc == nil || svc.Spec.Type != corev1.ServiceTypeClusterIP {
+		t.Errorf("expect the existing service to be restored, got %v", svc)
+	}
+	if findClusterService(restored.Spec.Services, "proxy", "proxy-vpc") == nil {
+		t.Error("expect the services not changed by the expose to be kept")
+	}
+
+	exposes := handler.restoredExposes(opsRes.OpsRequest)
+	if len(exposes) != 2 ||
+		exposes[0].Switch != opsv1alpha1.EnableExposeSwitch || len(exposes[0].Services) != 1 || exposes[0].Services[0].Name != "vpc" ||
+		exposes[1].Switch != opsv1alpha1.DisableExposeSwitch || len(exposes[1].Services) != 1 || exposes[1].Services[0].Name != "internet" {
+		t.Errorf("expect to wait for the recorded service to exist and the new one to be removed, got %v", exposes)
+	}
+}
+
+func TestCancelNotSupported(t *testing.T) {
+	// the restarted pods and the expanded PVCs can not be rolled back.
+	for _, opsType := range []opsv1alpha1.OpsType{opsv1alpha1.RestartType, opsv1alpha1.VolumeExpansionType} {
+		if GetOpsManager().OpsMap[opsType].CancelFunc != nil {
+			t.Errorf("expect %s not to be cancelable once it's running", opsType)
+		}
+	}
+}
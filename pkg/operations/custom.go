@@ -45,8 +45,12 @@ type CustomOpsHandler struct{}
 var _ OpsHandler = CustomOpsHandler{}
 
 func init() {
+	customHandler := CustomOpsHandler{}
 	customBehaviour := OpsBehaviour{
-		OpsHandler: CustomOpsHandler{},
+		// the workflow of the opsDefinition is performed by the ReconcileAction.
+		ChangedByReconcileAction: true,
+		OpsHandler:               customHandler,
+		CancelFunc:               customHandler.Cancel,
 	}
 
 	opsMgr := GetOpsManager()
@@ -126,6 +130,12 @@ func (c CustomOpsHandler) SaveLastConfiguration(reqCtx intctrlutil.RequestCtx, c
 	return nil
 }
 
+// Cancel the custom opsRequest does not change the Cluster, so there is nothing to revert.
+// the pending actions will be skipped and the running actions will be waited for completion in the workflow.
+func (c CustomOpsHandler) Cancel(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	return nil
+}
+
 func (c CustomOpsHandler) listComponents(reqCtx intctrlutil.RequestCtx,
 	cli client.Client,
 	cluster *appsv1.Cluster,
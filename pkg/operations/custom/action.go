@@ -21,6 +21,7 @@ package custom
 
 import (
 	"fmt"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -31,6 +32,12 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
 )
 
+const (
+	// maxActionOutputLength is the max length of the action output recorded in the OpsRequest status.
+	maxActionOutputLength = 1024
+	redactedOutput        = "******"
+)
+
 type OpsAction interface {
 	// Execute executes the action.
 	Execute(actionCtx ActionContext) (*ActionStatus, error)
@@ -142,7 +149,13 @@ func (actionCtx ActionContext) checkPodTaskStatus(
 		switch pod.Status.Phase {
 		case corev1.PodSucceeded:
 			completed = true
+			if task.Output, err = actionCtx.getPodOutput(pod); err != nil {
+				return false, false, err
+			}
 		case corev1.PodFailed:
+			if task.Output, err = actionCtx.getPodOutput(pod); err != nil {
+				return false, false, err
+			}
 			if task.Retries < backOffLimit {
 				task.Retries += 1
 				return false, false, createPod()
@@ -154,3 +167,65 @@ func (actionCtx ActionContext) checkPodTaskStatus(
 	}
 	return completed, existFailure, nil
 }
+
+// getPodOutput returns the termination message of the first container as the output of the pod.
+// The values of the secrets referenced by the env of the container are redacted, as the output is recorded
+// in the OpsRequest status, and the output is truncated to the last maxActionOutputLength bytes.
+func (actionCtx ActionContext) getPodOutput(pod *corev1.Pod) (string, error) {
+	if len(pod.Spec.Containers) == 0 {
+		return "", nil
+	}
+	var output string
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == pod.Spec.Containers[0].Name && status.State.Terminated != nil {
+			output = status.State.Terminated.Message
+		}
+	}
+	if output == "" {
+		return "", nil
+	}
+	getSecret := func(name string) (*corev1.Secret, error) {
+		secret := &corev1.Secret{}
+		if err := actionCtx.Client.Get(actionCtx.ReqCtx.Ctx, client.ObjectKey{Namespace: pod.Namespace, Name: name}, secret); err != nil {
+			return nil, client.IgnoreNotFound(err)
+		}
+		return secret, nil
+	}
+	redact := func(value []byte) {
+		if len(value) > 0 {
+			output = strings.ReplaceAll(output, string(value), redactedOutput)
+		}
+	}
+	container := pod.Spec.Containers[0]
+	for _, env := range container.Env {
+		if env.ValueFrom == nil || env.ValueFrom.SecretKeyRef == nil {
+			continue
+		}
+		secret, err := getSecret(env.ValueFrom.SecretKeyRef.Name)
+		if err != nil {
+			return "", err
+		}
+		if secret != nil {
+			redact(secret.Data[env.ValueFrom.SecretKeyRef.Key])
+		}
+	}
+	for _, envFrom := range container.EnvFrom {
+		if envFrom.SecretRef == nil {
+			continue
+		}
+		secret, err := getSecret(envFrom.SecretRef.Name)
+		if err != nil {
+			return "", err
+		}
+		if secret != nil {
+			for _, value := range secret.Data {
+				redact(value)
+			}
+		}
+	}
+	output = strings.TrimSpace(output)
+	if len(output) > maxActionOutputLength {
+		output = output[len(output)-maxActionOutputLength:]
+	}
+	return output, nil
+}
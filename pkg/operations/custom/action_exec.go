@@ -30,6 +30,11 @@ import (
 	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
+// execOutputScript runs kubectl with the given args, streams the stdout to the container log while it runs,
+// and writes the tail of stdout to the termination log after it exits.
+const execOutputScript = `{ kubectl "$@"; echo $? > /tmp/rc; } | tee /tmp/output; ` +
+	`tail -c 1024 /tmp/output > /dev/termination-log; exit "$(cat /tmp/rc)"`
+
 type ExecAction struct {
 	OpsRequest     *opsv1alpha1.OpsRequest
 	Cluster        *appsv1.Cluster
@@ -156,8 +161,9 @@ func (e *ExecAction) buildExecPodSpec(actionCtx ActionContext,
 		Name:            actionCtx.Action.Name,
 		Image:           viper.GetString(constant.KBToolsImage),
 		ImagePullPolicy: corev1.PullPolicy(viper.GetString(constant.KBImagePullPolicy)),
-		Command:         []string{"kubectl"},
-		Env:             env,
+		// capture the stdout of the command as the output of the action.
+		Command: []string{"sh", "-c", execOutputScript, "kubectl"},
+		Env:     env,
 		Args: append([]string{
 			"-n",
 			targetPod.Namespace,
@@ -0,0 +1,127 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package custom
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+func TestGetPodOutput(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "account", Namespace: "default"},
+		Data:       map[string][]byte{"password": []byte("p@ssw0rd"), "token": []byte("t0ken")},
+	}
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+	actionCtx := ActionContext{
+		ReqCtx: intctrlutil.RequestCtx{Ctx: context.Background(), Log: logr.Discard()},
+		Client: cli,
+	}
+	newPod := func(message string, env []corev1.EnvVar, envFrom []corev1.EnvFromSource) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "action", Namespace: "default"},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "main", Env: env, EnvFrom: envFrom}},
+			},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name:  "main",
+					State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Message: message}},
+				}},
+			},
+		}
+	}
+	secretKeyRef := func(name, key string) *corev1.EnvVarSource {
+		return &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: name}, Key: key}}
+	}
+
+	// the values of the referenced secrets are redacted.
+	output, err := actionCtx.getPodOutput(newPod("user: root, password: p@ssw0rd\n", []corev1.EnvVar{
+		{Name: "PASSWORD", ValueFrom: secretKeyRef("account", "password")},
+		{Name: "MISSING", ValueFrom: secretKeyRef("missing", "password")},
+	}, nil))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if output != "user: root, password: "+redactedOutput {
+		t.Errorf("expect the password redacted, got %s", output)
+	}
+	output, err = actionCtx.getPodOutput(newPod("t0ken p@ssw0rd", nil, []corev1.EnvFromSource{
+		{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "account"}}},
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if output != redactedOutput+" "+redactedOutput {
+		t.Errorf("expect all the values of the secret redacted, got %s", output)
+	}
+
+	// the output is truncated to the tail.
+	output, err = actionCtx.getPodOutput(newPod(strings.Repeat("a", maxActionOutputLength)+"end", nil, nil))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(output) != maxActionOutputLength || !strings.HasSuffix(output, "end") {
+		t.Errorf("expect the output truncated to the last %d bytes, got %d bytes", maxActionOutputLength, len(output))
+	}
+}
+
+func TestExecOutputScript(t *testing.T) {
+	dir := t.TempDir()
+	kubectl := "#!/bin/sh\necho \"$@\"\nexit 3\n"
+	if err := os.WriteFile(filepath.Join(dir, "kubectl"), []byte(kubectl), 0755); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	terminationLog := filepath.Join(dir, "termination-log")
+	script := strings.ReplaceAll(execOutputScript, "/dev/termination-log", terminationLog)
+	cmd := exec.Command("sh", "-c", script, "kubectl", "exec", "pod", "--", "echo")
+	cmd.Env = append(os.Environ(), "PATH="+dir+":"+os.Getenv("PATH"))
+	stdout, err := cmd.Output()
+	exitErr, ok := err.(*exec.ExitError)
+	if !ok || exitErr.ExitCode() != 3 {
+		t.Errorf("expect the exit code of kubectl, got %v", err)
+	}
+	if string(stdout) != "exec pod -- echo\n" {
+		t.Errorf("expect the stdout of kubectl, got %s", stdout)
+	}
+	message, err := os.ReadFile(terminationLog)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(message) != "exec pod -- echo\n" {
+		t.Errorf("expect the stdout written to the termination log, got %s", message)
+	}
+}
@@ -45,6 +45,7 @@ const (
 
 	KBEnvOpsName             = "KB_OPS_NAME"
 	KbEnvOpsNamespace        = "KB_OPS_NAMESPACE"
+	KBEnvOpsOutputPrefix     = "KB_OPS_OUTPUT_"
 	kbEnvCompHeadlessSVCName = "KB_COMP_HEADLESS_SVC_NAME"
 	kbEnvCompSVCName         = "KB_COMP_SVC_NAME"
 	kbEnvCompSVCPortPrefix   = "KB_COMP_SVC_PORT_"
@@ -291,6 +292,16 @@ func buildActionPodEnv(reqCtx intctrlutil.RequestCtx,
 		env = append(env, envVars...)
 	}
 
+	// inject the outputs of the completed actions
+	if compStatus, ok := ops.Status.Components[compCustomItem.ComponentName]; ok {
+		outputs := GetActionOutputs(compStatus.ProgressDetails)
+		for _, action := range opsDef.Spec.Actions {
+			if output, ok := outputs[action.Name]; ok {
+				env = append(env, corev1.EnvVar{Name: BuildOutputEnvName(action.Name), Value: output})
+			}
+		}
+	}
+
 	// inject params env
 	for i := range compCustomItem.Parameters {
 		param := compCustomItem.Parameters[i]
@@ -312,6 +323,31 @@ func buildActionPodEnv(reqCtx intctrlutil.RequestCtx,
 	return env, nil
 }
 
+// GetActionOutputs returns the outputs of the completed actions, keyed by the action name.
+// the outputs of multiple tasks of an action are joined by newlines.
+func GetActionOutputs(progressDetails []opsv1alpha1.ProgressStatusDetail) map[string]string {
+	outputs := map[string]string{}
+	for _, detail := range progressDetails {
+		if detail.ActionName == "" || (detail.Status != opsv1alpha1.SucceedProgressStatus &&
+			detail.Status != opsv1alpha1.FailedProgressStatus) {
+			continue
+		}
+		var taskOutputs []string
+		for _, task := range detail.ActionTasks {
+			if task.Output != "" {
+				taskOutputs = append(taskOutputs, task.Output)
+			}
+		}
+		outputs[detail.ActionName] = strings.Join(taskOutputs, "\n")
+	}
+	return outputs
+}
+
+// BuildOutputEnvName builds the env name of the action output.
+func BuildOutputEnvName(actionName string) string {
+	return KBEnvOpsOutputPrefix + strings.ToUpper(strings.ReplaceAll(actionName, "-", "_"))
+}
+
 func buildActionPodName(opsRequest *opsv1alpha1.OpsRequest,
 	compName,
 	actionName string,
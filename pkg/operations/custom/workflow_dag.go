@@ -0,0 +1,156 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package custom
+
+import (
+	"fmt"
+	"slices"
+	"text/template"
+
+	"github.com/Masterminds/sprig/v3"
+
+	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
+)
+
+// NewWhenTemplate parses the 'when' expression of the action.
+func NewWhenTemplate(action opsv1alpha1.OpsAction) (*template.Template, error) {
+	return template.New("when").Funcs(sprig.TxtFuncMap()).Parse(action.When)
+}
+
+// BuildActionDependencies returns the dependencies of each action.
+// if none of the actions specifies the dependencies, each action depends on its previous action
+// except the compensation actions, so the actions are executed sequentially.
+func BuildActionDependencies(actions []opsv1alpha1.OpsAction) map[string][]string {
+	dependencies := map[string][]string{}
+	isDAG := slices.ContainsFunc(actions, func(action opsv1alpha1.OpsAction) bool {
+		return len(action.DependsOn) > 0
+	})
+	if isDAG {
+		for _, action := range actions {
+			dependencies[action.Name] = action.DependsOn
+		}
+		return dependencies
+	}
+	compensations := BuildCompensationReferences(actions)
+	var previous string
+	for _, action := range actions {
+		if _, ok := compensations[action.Name]; ok {
+			continue
+		}
+		if previous != "" {
+			dependencies[action.Name] = []string{previous}
+		}
+		previous = action.Name
+	}
+	return dependencies
+}
+
+// BuildCompensationReferences returns the names of the actions that reference each compensation action.
+func BuildCompensationReferences(actions []opsv1alpha1.OpsAction) map[string][]string {
+	references := map[string][]string{}
+	for _, action := range actions {
+		for _, name := range action.Compensations {
+			references[name] = append(references[name], action.Name)
+		}
+	}
+	return references
+}
+
+// SortActions validates the references between the actions and returns the indexes of the actions in topological order.
+// a compensation action is always ordered after the actions that reference it.
+func SortActions(actions []opsv1alpha1.OpsAction) ([]int, error) {
+	indexes := map[string]int{}
+	for i, action := range actions {
+		if _, ok := indexes[action.Name]; ok {
+			return nil, fmt.Errorf(`the action "%s" is duplicated`, action.Name)
+		}
+		indexes[action.Name] = i
+	}
+	edges := make([][]int, len(actions))
+	inDegrees := make([]int, len(actions))
+	addEdge := func(from, to string, field string) error {
+		fromIndex, ok := indexes[from]
+		if !ok {
+			return fmt.Errorf(`the action "%s" referenced in %s of the action "%s" is not found`, from, field, to)
+		}
+		if from == to {
+			return fmt.Errorf(`the action "%s" can not reference itself in %s`, to, field)
+		}
+		edges[fromIndex] = append(edges[fromIndex], indexes[to])
+		inDegrees[indexes[to]] += 1
+		return nil
+	}
+	dependencies := BuildActionDependencies(actions)
+	for _, action := range actions {
+		for _, dependency := range dependencies[action.Name] {
+			if err := addEdge(dependency, action.Name, "dependsOn"); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, action := range actions {
+		for _, name := range action.Compensations {
+			if _, ok := indexes[name]; !ok {
+				return nil, fmt.Errorf(`the compensation action "%s" of the action "%s" is not found`, name, action.Name)
+			}
+			if err := addEdge(action.Name, name, "compensations"); err != nil {
+				return nil, err
+			}
+		}
+	}
+	// sort the actions by Kahn's algorithm.
+	var queue, sorted []int
+	for i := range actions {
+		if inDegrees[i] == 0 {
+			queue = append(queue, i)
+		}
+	}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		sorted = append(sorted, current)
+		for _, next := range edges[current] {
+			inDegrees[next] -= 1
+			if inDegrees[next] == 0 {
+				queue = append(queue, next)
+			}
+		}
+	}
+	if len(sorted) != len(actions) {
+		return nil, fmt.Errorf("there is a cycle in the dependencies of the actions")
+	}
+	return sorted, nil
+}
+
+// ValidateActions validates the dependencies, compensations and 'when' expressions of the actions.
+func ValidateActions(actions []opsv1alpha1.OpsAction) error {
+	if _, err := SortActions(actions); err != nil {
+		return err
+	}
+	for _, action := range actions {
+		if action.When == "" {
+			continue
+		}
+		if _, err := NewWhenTemplate(action); err != nil {
+			return fmt.Errorf(`invalid 'when' expression of the action "%s": %s`, action.Name, err.Error())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package custom
+
+import (
+	"reflect"
+	"testing"
+
+	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
+)
+
+func TestSortActions(t *testing.T) {
+	names := func(actions []opsv1alpha1.OpsAction, indexes []int) []string {
+		var res []string
+		for _, i := range indexes {
+			res = append(res, actions[i].Name)
+		}
+		return res
+	}
+
+	// sequential actions, the compensation action is ordered after the action that references it.
+	actions := []opsv1alpha1.OpsAction{
+		{Name: "rollback"},
+		{Name: "prepare", Compensations: []string{"rollback"}},
+		{Name: "apply"},
+	}
+	sorted, err := SortActions(actions)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := names(actions, sorted); !reflect.DeepEqual(got, []string{"prepare", "apply", "rollback"}) {
+		t.Errorf("unexpected order: %v", got)
+	}
+	if deps := BuildActionDependencies(actions); !reflect.DeepEqual(deps["apply"], []string{"prepare"}) || len(deps["rollback"]) != 0 {
+		t.Errorf("unexpected dependencies: %v", deps)
+	}
+
+	// DAG actions.
+	actions = []opsv1alpha1.OpsAction{
+		{Name: "c", DependsOn: []string{"a", "b"}},
+		{Name: "a"},
+		{Name: "b"},
+	}
+	sorted, err = SortActions(actions)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := names(actions, sorted); !reflect.DeepEqual(got, []string{"a", "b", "c"}) {
+		t.Errorf("unexpected order: %v", got)
+	}
+
+	// invalid actions.
+	for _, invalid := range [][]opsv1alpha1.OpsAction{
+		{{Name: "a", DependsOn: []string{"b"}}, {Name: "b", DependsOn: []string{"a"}}},
+		{{Name: "a", DependsOn: []string{"not-found"}}},
+		{{Name: "a", Compensations: []string{"a"}}},
+		{{Name: "a"}, {Name: "a"}},
+	} {
+		if _, err = SortActions(invalid); err == nil {
+			t.Errorf("expected error for actions: %v", invalid)
+		}
+	}
+}
+
+func TestValidateActions(t *testing.T) {
+	if err := ValidateActions([]opsv1alpha1.OpsAction{{Name: "a", When: `{{ eq (index .outputs "b") "ok" }}`}}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := ValidateActions([]opsv1alpha1.OpsAction{{Name: "a", When: `{{ eq .outputs`}}); err == nil {
+		t.Error("expected error for invalid when expression")
+	}
+}
@@ -102,6 +102,31 @@ func (w *WorkloadAction) checkJobStatus(actionCtx ActionContext, task *opsv1alph
 				existFailure = true
 			}
 		}
+		if completed {
+			if task.Output, err = w.getJobOutput(actionCtx, job); err != nil {
+				return false, false, err
+			}
+		}
 	}
 	return completed, existFailure, nil
 }
+
+// getJobOutput returns the output of the latest pod of the job.
+func (w *WorkloadAction) getJobOutput(actionCtx ActionContext, job *batchv1.Job) (string, error) {
+	podList := &corev1.PodList{}
+	if err := actionCtx.Client.List(actionCtx.ReqCtx.Ctx, podList, client.InNamespace(job.Namespace),
+		client.MatchingLabels{batchv1.JobNameLabel: job.Name}); err != nil {
+		return "", err
+	}
+	var latestPod *corev1.Pod
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if latestPod == nil || latestPod.CreationTimestamp.Before(&pod.CreationTimestamp) {
+			latestPod = pod
+		}
+	}
+	if latestPod == nil {
+		return "", nil
+	}
+	return actionCtx.getPodOutput(latestPod)
+}
@@ -20,7 +20,11 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package operations
 
 import (
+	"encoding/json"
 	"fmt"
+	"slices"
+	"strconv"
+	"strings"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -54,14 +58,18 @@ func NewWorkflowContext(
 }
 
 // Run actions execution layer.
+// the actions are executed as a DAG built from the dependencies and compensations of the actions,
+// the actions whose dependencies are completed will be executed in parallel.
 func (w *WorkflowContext) Run(compCustomSpec *opsv1alpha1.CustomOpsComponent) (*WorkflowStatus, error) {
 	var (
 		err            error
-		actionStatus   *custom.ActionStatus
 		compStatus     = w.OpsRes.OpsRequest.Status.Components[compCustomSpec.ComponentName]
 		workflowStatus = &WorkflowStatus{}
 		actions        = w.OpsRes.OpsDef.Spec.Actions
 		compSpec       = getComponentSpecOrShardingTemplate(w.OpsRes.Cluster, compCustomSpec.ComponentName)
+		dependencies   = custom.BuildActionDependencies(actions)
+		compensations  = custom.BuildCompensationReferences(actions)
+		sortedIndexes  []int
 	)
 	defer func() {
 		if intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal) {
@@ -72,14 +80,11 @@ func (w *WorkflowContext) Run(compCustomSpec *opsv1alpha1.CustomOpsComponent) (*
 		}
 		w.OpsRes.OpsRequest.Status.Components[compCustomSpec.ComponentName] = compStatus
 	}()
-	setSucceedWorkflowStatus := func(actionIndex int) {
-		workflowStatus.CompletedCount += 1
-		if actionIndex == len(actions)-1 {
-			workflowStatus.IsCompleted = true
-		}
+	if sortedIndexes, err = custom.SortActions(actions); err != nil {
+		err = intctrlutil.NewFatalError(err.Error())
+		return nil, err
 	}
-steps:
-	for i := range actions {
+	for _, i := range sortedIndexes {
 		actionProgress := findActionProgress(compStatus.ProgressDetails, actions[i].Name)
 		if actionProgress == nil {
 			err = intctrlutil.NewFatalError("can not find the action progress for action " + actions[i].Name)
@@ -87,64 +92,198 @@ steps:
 		}
 		switch actionProgress.Status {
 		case opsv1alpha1.PendingProgressStatus:
-			// execute action and set status progress
-			progressDetail := *actionProgress
-			ac := w.getAction(actions[i], compCustomSpec, compSpec, progressDetail)
-			if ac == nil {
-				err = intctrlutil.NewFatalError("the action type is not implement for action " + actions[i].Name)
-				return nil, err
+			if w.OpsRes.OpsRequest.Status.Phase == opsv1alpha1.OpsCancellingPhase {
+				// the remaining actions will not be executed after the opsRequest is canceled.
+				continue
 			}
-			actionStatus, err = ac.Execute(custom.ActionContext{ReqCtx: w.reqCtx, Client: w.Cli, Action: &actions[i]})
-			if err != nil {
+			if err = w.startAction(compCustomSpec, compSpec, &compStatus, actions[i],
+				dependencies[actions[i].Name], compensations[actions[i].Name]); err != nil {
 				return nil, err
 			}
-			progressDetail.ActionTasks = actionStatus.ActionTasks
-			progressDetail.SetStatusAndMessage(opsv1alpha1.ProcessingProgressStatus,
-				fmt.Sprintf(`Start to processing action "%s" of the component %s`, actions[i].Name, compCustomSpec.ComponentName))
-			setComponentStatusProgressDetail(w.reqCtx.Recorder, w.OpsRes.OpsRequest, &compStatus.ProgressDetails, progressDetail)
-			break steps
 		case opsv1alpha1.ProcessingProgressStatus:
-			// check action status and set status progress
-			progressDetail := *actionProgress
-			ac := w.getAction(actions[i], compCustomSpec, compSpec, progressDetail)
-			if ac == nil {
-				err = intctrlutil.NewFatalError("the action type is not implement for action " + actions[i].Name)
+			if err = w.checkAction(compCustomSpec, compSpec, &compStatus, actions[i], *actionProgress); err != nil {
 				return nil, err
 			}
-			actionStatus, err = ac.CheckStatus(custom.ActionContext{ReqCtx: w.reqCtx, Client: w.Cli, Action: &actions[i]})
-			if err != nil {
-				return nil, err
-			}
-			progressDetail.ActionTasks = actionStatus.ActionTasks
-			if actionStatus.IsCompleted {
-				if actionStatus.ExistFailure {
-					progressDetail.Status = opsv1alpha1.FailedProgressStatus
-				} else {
-					progressDetail.Status = opsv1alpha1.SucceedProgressStatus
-				}
-				progressDetail.Message = fmt.Sprintf(`the action "%s" of the component "%s" is %s`,
-					actions[i].Name, compCustomSpec.ComponentName, progressDetail.Status)
-			}
-			setComponentStatusProgressDetail(w.reqCtx.Recorder, w.OpsRes.OpsRequest, &compStatus.ProgressDetails, progressDetail)
-			break steps
+		}
+	}
+	// summarize the workflow status after all the runnable actions have been handled.
+	processingCount := 0
+	for _, action := range actions {
+		switch findActionProgress(compStatus.ProgressDetails, action.Name).Status {
+		case opsv1alpha1.ProcessingProgressStatus:
+			processingCount += 1
 		case opsv1alpha1.FailedProgressStatus:
-			if actions[i].FailurePolicy == opsv1alpha1.FailurePolicyFail {
+			workflowStatus.CompletedCount += 1
+			if action.FailurePolicy == opsv1alpha1.FailurePolicyFail {
 				workflowStatus.ExistFailure = true
-				workflowStatus.CompletedCount += 1
-				workflowStatus.IsCompleted = true
-				break steps
-			} else {
-				// if the action is final action and ignore Failure, mark workflow to succeed
-				setSucceedWorkflowStatus(i)
 			}
-		case opsv1alpha1.SucceedProgressStatus:
-			// if the action is final action, mark workflow to succeed
-			setSucceedWorkflowStatus(i)
+		case opsv1alpha1.SucceedProgressStatus, opsv1alpha1.SkippedProgressStatus:
+			workflowStatus.CompletedCount += 1
 		}
 	}
+	// the pending actions can not be executed anymore if no action is processing.
+	workflowStatus.IsCompleted = processingCount == 0
 	return workflowStatus, nil
 }
 
+// startAction executes the action if its dependencies are completed, or skips it if it should not be executed.
+func (w *WorkflowContext) startAction(compCustomSpec *opsv1alpha1.CustomOpsComponent,
+	compSpec *appsv1.ClusterComponentSpec,
+	compStatus *opsv1alpha1.OpsRequestComponentStatus,
+	action opsv1alpha1.OpsAction,
+	dependencies []string,
+	compensatedActions []string) error {
+	var (
+		progressDetail = *findActionProgress(compStatus.ProgressDetails, action.Name)
+		actions        = w.OpsRes.OpsDef.Spec.Actions
+	)
+	setProgressDetail := func(status opsv1alpha1.ProgressStatus, message string) {
+		progressDetail.SetStatusAndMessage(status, message)
+		setComponentStatusProgressDetail(w.reqCtx.Recorder, w.OpsRes.OpsRequest, &compStatus.ProgressDetails, progressDetail)
+	}
+	isCompleted := func(names []string, failed func(opsv1alpha1.OpsAction) bool) (bool, bool) {
+		var existFailure bool
+		for _, name := range names {
+			detail := findActionProgress(compStatus.ProgressDetails, name)
+			if detail == nil || !isCompletedProgressStatus(detail.Status) {
+				return false, false
+			}
+			if detail.Status == opsv1alpha1.FailedProgressStatus &&
+				failed(actions[slices.IndexFunc(actions, func(a opsv1alpha1.OpsAction) bool { return a.Name == name })]) {
+				existFailure = true
+			}
+		}
+		return true, existFailure
+	}
+	completed, existFailure := isCompleted(dependencies, func(a opsv1alpha1.OpsAction) bool {
+		return a.FailurePolicy == opsv1alpha1.FailurePolicyFail
+	})
+	if !completed {
+		return nil
+	}
+	if len(compensatedActions) > 0 {
+		// the compensation action is executed only when one of the actions that reference it fails.
+		completed, compensated := isCompleted(compensatedActions, func(a opsv1alpha1.OpsAction) bool { return true })
+		if !completed {
+			return nil
+		}
+		if !compensated {
+			setProgressDetail(opsv1alpha1.SkippedProgressStatus,
+				fmt.Sprintf(`Skip the compensation action "%s" of the component %s as no action failed`, action.Name, compCustomSpec.ComponentName))
+			return nil
+		}
+	} else if existFailure || w.existFailure(compStatus) {
+		// stop executing new actions if the workflow has failed.
+		return nil
+	}
+	if action.When != "" {
+		passed, err := w.evaluateWhen(compCustomSpec, compSpec, compStatus, action)
+		if err != nil {
+			setProgressDetail(opsv1alpha1.FailedProgressStatus,
+				fmt.Sprintf(`Failed to evaluate the "when" expression of the action "%s": %s`, action.Name, err.Error()))
+			return nil
+		}
+		if !passed {
+			setProgressDetail(opsv1alpha1.SkippedProgressStatus,
+				fmt.Sprintf(`Skip the action "%s" of the component %s as the "when" expression is false`, action.Name, compCustomSpec.ComponentName))
+			return nil
+		}
+	}
+	ac := w.getAction(action, compCustomSpec, compSpec, progressDetail)
+	if ac == nil {
+		return intctrlutil.NewFatalError("the action type is not implement for action " + action.Name)
+	}
+	// sync the progress details to the OpsRequest, the outputs of the completed actions are read from it.
+	w.OpsRes.OpsRequest.Status.Components[compCustomSpec.ComponentName] = *compStatus
+	actionStatus, err := ac.Execute(custom.ActionContext{ReqCtx: w.reqCtx, Client: w.Cli, Action: &action})
+	if err != nil {
+		return err
+	}
+	progressDetail.ActionTasks = actionStatus.ActionTasks
+	setProgressDetail(opsv1alpha1.ProcessingProgressStatus,
+		fmt.Sprintf(`Start to processing action "%s" of the component %s`, action.Name, compCustomSpec.ComponentName))
+	return nil
+}
+
+// checkAction checks the status of the processing action.
+func (w *WorkflowContext) checkAction(compCustomSpec *opsv1alpha1.CustomOpsComponent,
+	compSpec *appsv1.ClusterComponentSpec,
+	compStatus *opsv1alpha1.OpsRequestComponentStatus,
+	action opsv1alpha1.OpsAction,
+	progressDetail opsv1alpha1.ProgressStatusDetail) error {
+	ac := w.getAction(action, compCustomSpec, compSpec, progressDetail)
+	if ac == nil {
+		return intctrlutil.NewFatalError("the action type is not implement for action " + action.Name)
+	}
+	actionStatus, err := ac.CheckStatus(custom.ActionContext{ReqCtx: w.reqCtx, Client: w.Cli, Action: &action})
+	if err != nil {
+		return err
+	}
+	progressDetail.ActionTasks = actionStatus.ActionTasks
+	if actionStatus.IsCompleted {
+		if actionStatus.ExistFailure {
+			progressDetail.Status = opsv1alpha1.FailedProgressStatus
+		} else {
+			progressDetail.Status = opsv1alpha1.SucceedProgressStatus
+		}
+		progressDetail.Message = fmt.Sprintf(`the action "%s" of the component "%s" is %s`,
+			action.Name, compCustomSpec.ComponentName, progressDetail.Status)
+	}
+	setComponentStatusProgressDetail(w.reqCtx.Recorder, w.OpsRes.OpsRequest, &compStatus.ProgressDetails, progressDetail)
+	return nil
+}
+
+// existFailure checks if any action with the "Fail" failure policy has failed.
+func (w *WorkflowContext) existFailure(compStatus *opsv1alpha1.OpsRequestComponentStatus) bool {
+	for _, action := range w.OpsRes.OpsDef.Spec.Actions {
+		detail := findActionProgress(compStatus.ProgressDetails, action.Name)
+		if detail != nil && detail.Status == opsv1alpha1.FailedProgressStatus &&
+			action.FailurePolicy == opsv1alpha1.FailurePolicyFail {
+			return true
+		}
+	}
+	return false
+}
+
+// evaluateWhen renders the "when" expression of the action and parses the result as a boolean.
+func (w *WorkflowContext) evaluateWhen(compCustomSpec *opsv1alpha1.CustomOpsComponent,
+	compSpec *appsv1.ClusterComponentSpec,
+	compStatus *opsv1alpha1.OpsRequestComponentStatus,
+	action opsv1alpha1.OpsAction) (bool, error) {
+	params, err := covertParametersToMap(w.reqCtx.Ctx, w.Cli, compCustomSpec.Parameters, w.OpsRes.OpsRequest.Namespace)
+	if err != nil {
+		return false, err
+	}
+	// covert the built-in objects with the json tag
+	b, err := json.Marshal(map[string]interface{}{
+		"cluster":    w.OpsRes.Cluster,
+		"component":  compSpec,
+		"parameters": params,
+		"outputs":    custom.GetActionOutputs(compStatus.ProgressDetails),
+	})
+	if err != nil {
+		return false, err
+	}
+	data := map[string]interface{}{}
+	if err = json.Unmarshal(b, &data); err != nil {
+		return false, err
+	}
+	tmpl, err := custom.NewWhenTemplate(action)
+	if err != nil {
+		return false, err
+	}
+	var buf strings.Builder
+	if err = tmpl.Execute(&buf, data); err != nil {
+		return false, err
+	}
+	result := strings.TrimSpace(buf.String())
+	passed, err := strconv.ParseBool(result)
+	if err != nil {
+		return false, fmt.Errorf(`the "when" expression must render to a boolean, but got "%s"`, result)
+	}
+	return passed, nil
+}
+
 func (w *WorkflowContext) getAction(action opsv1alpha1.OpsAction,
 	compCustomItem *opsv1alpha1.CustomOpsComponent,
 	compSpec *appsv1.ClusterComponentSpec,
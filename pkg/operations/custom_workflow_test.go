@@ -0,0 +1,302 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+const workflowTestCompName = "comp"
+
+func newWorkflowTestContext(actions []opsv1alpha1.OpsAction) (client.Client, *WorkflowContext, *opsv1alpha1.CustomOpsComponent) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	_ = opsv1alpha1.AddToScheme(scheme)
+
+	cluster := &appsv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: appsv1.ClusterSpec{
+			ComponentSpecs: []appsv1.ClusterComponentSpec{{Name: workflowTestCompName, Replicas: 1}},
+		},
+	}
+	for i := range actions {
+		if actions[i].Workload == nil {
+			actions[i].Workload = &opsv1alpha1.OpsWorkloadAction{
+				Type: opsv1alpha1.JobWorkload,
+				PodSpec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "main", Image: "busybox"}},
+				},
+			}
+		}
+		if actions[i].FailurePolicy == "" {
+			actions[i].FailurePolicy = opsv1alpha1.FailurePolicyFail
+		}
+	}
+	opsDef := &opsv1alpha1.OpsDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-opsdef"},
+		Spec:       opsv1alpha1.OpsDefinitionSpec{Actions: actions},
+	}
+	compCustomSpec := &opsv1alpha1.CustomOpsComponent{
+		ComponentOps: opsv1alpha1.ComponentOps{ComponentName: workflowTestCompName},
+	}
+	compStatus := opsv1alpha1.OpsRequestComponentStatus{}
+	for _, action := range actions {
+		compStatus.ProgressDetails = append(compStatus.ProgressDetails, opsv1alpha1.ProgressStatusDetail{
+			Status:     opsv1alpha1.PendingProgressStatus,
+			ActionName: action.Name,
+		})
+	}
+	ops := &opsv1alpha1.OpsRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-ops", Namespace: "default", UID: "12345678-1234-1234-1234-123456789012"},
+		Spec: opsv1alpha1.OpsRequestSpec{
+			ClusterName: cluster.Name,
+			Type:        opsv1alpha1.CustomType,
+			SpecificOpsRequest: opsv1alpha1.SpecificOpsRequest{
+				CustomOps: &opsv1alpha1.CustomOps{
+					OpsDefinitionName:   opsDef.Name,
+					CustomOpsComponents: []opsv1alpha1.CustomOpsComponent{*compCustomSpec},
+				},
+			},
+		},
+		Status: opsv1alpha1.OpsRequestStatus{
+			Phase:      opsv1alpha1.OpsRunningPhase,
+			Components: map[string]opsv1alpha1.OpsRequestComponentStatus{workflowTestCompName: compStatus},
+		},
+	}
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cluster, opsDef, ops).Build()
+	recorder := record.NewFakeRecorder(100)
+	reqCtx := intctrlutil.RequestCtx{Ctx: context.Background(), Log: logr.Discard(), Recorder: recorder}
+	opsRes := &OpsResource{Cluster: cluster, OpsDef: opsDef, OpsRequest: ops, Recorder: recorder}
+	return cli, NewWorkflowContext(reqCtx, cli, opsRes), compCustomSpec
+}
+
+func getWorkflowTestProgress(t *testing.T, w *WorkflowContext, actionName string) opsv1alpha1.ProgressStatusDetail {
+	detail := findActionProgress(w.OpsRes.OpsRequest.Status.Components[workflowTestCompName].ProgressDetails, actionName)
+	if detail == nil {
+		t.Fatalf("the progress of the action %s is not found", actionName)
+	}
+	return *detail
+}
+
+// completeWorkflowTestJob completes the job of the action, with the termination message as the output.
+func completeWorkflowTestJob(t *testing.T, cli client.Client, w *WorkflowContext, actionName string,
+	conditionType batchv1.JobConditionType, output string) {
+	detail := getWorkflowTestProgress(t, w, actionName)
+	if len(detail.ActionTasks) != 1 {
+		t.Fatalf("expect one task of the action %s, got %d", actionName, len(detail.ActionTasks))
+	}
+	ctx := context.Background()
+	job := &batchv1.Job{}
+	jobName := strings.TrimPrefix(detail.ActionTasks[0].ObjectKey, "Job/")
+	if err := cli.Get(ctx, client.ObjectKey{Namespace: "default", Name: jobName}, job); err != nil {
+		t.Fatalf("failed to get the job of the action %s: %v", actionName, err)
+	}
+	job.Status.Conditions = append(job.Status.Conditions, batchv1.JobCondition{Type: conditionType, Status: corev1.ConditionTrue})
+	if err := cli.Status().Update(ctx, job); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jobName + "-pod",
+			Namespace: "default",
+			Labels:    map[string]string{batchv1.JobNameLabel: jobName},
+		},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "main", Image: "busybox"}}},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:  "main",
+				State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Message: output}},
+			}},
+		},
+	}
+	status := pod.Status
+	if err := cli.Create(ctx, pod); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	pod.Status = status
+	if err := cli.Status().Update(ctx, pod); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestCustomWorkflowRun(t *testing.T) {
+	cli, w, compCustomSpec := newWorkflowTestContext([]opsv1alpha1.OpsAction{
+		{Name: "check"},
+		{Name: "apply", DependsOn: []string{"check"}, When: `{{ eq (index .outputs "check") "primary" }}`,
+			Compensations: []string{"rollback"}},
+		{Name: "demote", DependsOn: []string{"check"}, When: `{{ eq (index .outputs "check") "secondary" }}`},
+		{Name: "rollback"},
+	})
+	expectStatus := func(expected map[string]opsv1alpha1.ProgressStatus) {
+		for name, status := range expected {
+			if detail := getWorkflowTestProgress(t, w, name); detail.Status != status {
+				t.Errorf("expect the action %s %s, got %s: %s", name, status, detail.Status, detail.Message)
+			}
+		}
+	}
+	run := func() *WorkflowStatus {
+		workflowStatus, err := w.Run(compCustomSpec)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return workflowStatus
+	}
+
+	// the dependents wait for the dependency to complete.
+	workflowStatus := run()
+	expectStatus(map[string]opsv1alpha1.ProgressStatus{
+		"check":    opsv1alpha1.ProcessingProgressStatus,
+		"apply":    opsv1alpha1.PendingProgressStatus,
+		"demote":   opsv1alpha1.PendingProgressStatus,
+		"rollback": opsv1alpha1.PendingProgressStatus,
+	})
+	if workflowStatus.IsCompleted {
+		t.Error("expect the workflow not completed")
+	}
+
+	// the "when" expressions are evaluated with the output of the dependency.
+	completeWorkflowTestJob(t, cli, w, "check", batchv1.JobComplete, "primary")
+	run()
+	expectStatus(map[string]opsv1alpha1.ProgressStatus{
+		"check":    opsv1alpha1.SucceedProgressStatus,
+		"apply":    opsv1alpha1.ProcessingProgressStatus,
+		"demote":   opsv1alpha1.SkippedProgressStatus,
+		"rollback": opsv1alpha1.PendingProgressStatus,
+	})
+	if detail := getWorkflowTestProgress(t, w, "check"); detail.ActionTasks[0].Output != "primary" {
+		t.Errorf("expect the output of the check action recorded, got %s", detail.ActionTasks[0].Output)
+	}
+
+	// the compensation action runs after the action fails.
+	completeWorkflowTestJob(t, cli, w, "apply", batchv1.JobFailed, "")
+	workflowStatus = run()
+	expectStatus(map[string]opsv1alpha1.ProgressStatus{
+		"apply":    opsv1alpha1.FailedProgressStatus,
+		"rollback": opsv1alpha1.ProcessingProgressStatus,
+	})
+	if workflowStatus.IsCompleted || !workflowStatus.ExistFailure {
+		t.Errorf("expect the workflow failed but not completed, got %+v", workflowStatus)
+	}
+
+	completeWorkflowTestJob(t, cli, w, "rollback", batchv1.JobComplete, "")
+	workflowStatus = run()
+	expectStatus(map[string]opsv1alpha1.ProgressStatus{
+		"rollback": opsv1alpha1.SucceedProgressStatus,
+	})
+	if !workflowStatus.IsCompleted || !workflowStatus.ExistFailure || workflowStatus.CompletedCount != 4 {
+		t.Errorf("expect the workflow completed with failure, got %+v", workflowStatus)
+	}
+}
+
+func TestCustomWorkflowSkipCompensation(t *testing.T) {
+	cli, w, compCustomSpec := newWorkflowTestContext([]opsv1alpha1.OpsAction{
+		{Name: "apply", Compensations: []string{"rollback"}},
+		{Name: "rollback"},
+	})
+	if _, err := w.Run(compCustomSpec); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	completeWorkflowTestJob(t, cli, w, "apply", batchv1.JobComplete, "")
+	workflowStatus, err := w.Run(compCustomSpec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if detail := getWorkflowTestProgress(t, w, "rollback"); detail.Status != opsv1alpha1.SkippedProgressStatus {
+		t.Errorf("expect the compensation action skipped, got %s", detail.Status)
+	}
+	if !workflowStatus.IsCompleted || workflowStatus.ExistFailure {
+		t.Errorf("expect the workflow completed without failure, got %+v", workflowStatus)
+	}
+}
+
+func TestCustomWorkflowStopOnFailure(t *testing.T) {
+	cli, w, compCustomSpec := newWorkflowTestContext([]opsv1alpha1.OpsAction{
+		{Name: "first"},
+		{Name: "second"},
+	})
+	if _, err := w.Run(compCustomSpec); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	completeWorkflowTestJob(t, cli, w, "first", batchv1.JobFailed, "")
+	workflowStatus, err := w.Run(compCustomSpec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if detail := getWorkflowTestProgress(t, w, "second"); detail.Status != opsv1alpha1.PendingProgressStatus {
+		t.Errorf("expect the action after the failed one not executed, got %s", detail.Status)
+	}
+	if !workflowStatus.IsCompleted || !workflowStatus.ExistFailure {
+		t.Errorf("expect the workflow completed with failure, got %+v", workflowStatus)
+	}
+}
+
+func TestCustomWorkflowEvaluateWhen(t *testing.T) {
+	_, w, compCustomSpec := newWorkflowTestContext([]opsv1alpha1.OpsAction{{Name: "apply"}})
+	compStatus := w.OpsRes.OpsRequest.Status.Components[workflowTestCompName]
+	compSpec := &w.OpsRes.Cluster.Spec.ComponentSpecs[0]
+	for _, c := range []struct {
+		when     string
+		expected bool
+		hasError bool
+	}{
+		{`{{ eq .component.name "comp" }}`, true, false},
+		{`{{ eq .component.name "other" }}`, false, false},
+		{" False ", false, false},
+		{"TRUE", true, false},
+		{"no", false, true},
+		{"", false, true},
+	} {
+		action := opsv1alpha1.OpsAction{Name: "apply", When: c.when}
+		passed, err := w.evaluateWhen(compCustomSpec, compSpec, &compStatus, action)
+		if (err != nil) != c.hasError || passed != c.expected {
+			t.Errorf("expect %v and error %v for the when expression %q, got %v %v", c.expected, c.hasError, c.when, passed, err)
+		}
+	}
+
+	// the action fails if the "when" expression does not render to a boolean.
+	_, w, compCustomSpec = newWorkflowTestContext([]opsv1alpha1.OpsAction{{Name: "apply", When: "yes"}})
+	workflowStatus, err := w.Run(compCustomSpec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if detail := getWorkflowTestProgress(t, w, "apply"); detail.Status != opsv1alpha1.FailedProgressStatus {
+		t.Errorf("expect the action failed, got %s", detail.Status)
+	}
+	if !workflowStatus.IsCompleted || !workflowStatus.ExistFailure {
+		t.Errorf("expect the workflow completed with failure, got %+v", workflowStatus)
+	}
+}
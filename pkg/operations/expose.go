@@ -22,12 +22,12 @@ package operations
 import (
 	"fmt"
 	"reflect"
+	"slices"
 	"strings"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
@@ -44,9 +44,11 @@ var _ OpsHandler = ExposeOpsHandler{}
 
 func init() {
 	// ToClusterPhase is not defined, because 'expose' does not affect the cluster status.
+	exposeHandler := ExposeOpsHandler{}
 	exposeBehavior := OpsBehaviour{
-		OpsHandler:  ExposeOpsHandler{},
+		OpsHandler:  exposeHandler,
 		QueueBySelf: true,
+		CancelFunc:  exposeHandler.Cancel,
 	}
 
 	opsMgr := GetOpsManager()
@@ -54,9 +56,61 @@ func init() {
 }
 
 func (e ExposeOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
-	var (
-		exposeMap = opsRes.OpsRequest.Spec.ToExposeListToMap()
-	)
+	return e.exposeServices(reqCtx, cli, opsRes, opsRes.OpsRequest.Spec.ToExposeListToMap())
+}
+
+// Cancel restores the cluster services changed by the exposes to the ones recorded in the last configuration.
+func (e ExposeOpsHandler) Cancel(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	cluster := opsRes.Cluster
+	lastServices := opsRes.OpsRequest.Status.LastConfiguration.Services
+	for _, expose := range opsRes.OpsRequest.Spec.ExposeList {
+		for _, exposeService := range expose.Services {
+			svcName := generateServiceName(expose.ComponentName, exposeService.Name)
+			cluster.Spec.Services = slices.DeleteFunc(cluster.Spec.Services, func(svc appsv1.ClusterService) bool {
+				return svc.Name == svcName && svc.ComponentSelector == expose.ComponentName
+			})
+			if lastService := findClusterService(lastServices, expose.ComponentName, svcName); lastService != nil {
+				cluster.Spec.Services = append(cluster.Spec.Services, *lastService)
+			}
+		}
+	}
+	reqCtx.Log.Info("cluster service to be restored", "clusterService", cluster.Spec.Services)
+	return cli.Update(reqCtx.Ctx, cluster)
+}
+
+// restoredExposes returns the exposes to check when cancelling, the services recorded in the last configuration
+// are expected to exist and the others are expected to be removed.
+func (e ExposeOpsHandler) restoredExposes(opsRequest *opsv1alpha1.OpsRequest) []opsv1alpha1.Expose {
+	var exposes []opsv1alpha1.Expose
+	for _, expose := range opsRequest.Spec.ExposeList {
+		enabled := opsv1alpha1.Expose{ComponentName: expose.ComponentName, Switch: opsv1alpha1.EnableExposeSwitch}
+		disabled := opsv1alpha1.Expose{ComponentName: expose.ComponentName, Switch: opsv1alpha1.DisableExposeSwitch}
+		for _, exposeService := range expose.Services {
+			svcName := generateServiceName(expose.ComponentName, exposeService.Name)
+			if findClusterService(opsRequest.Status.LastConfiguration.Services, expose.ComponentName, svcName) != nil {
+				enabled.Services = append(enabled.Services, exposeService)
+			} else {
+				disabled.Services = append(disabled.Services, exposeService)
+			}
+		}
+		exposes = append(exposes, enabled, disabled)
+	}
+	return exposes
+}
+
+func findClusterService(services []appsv1.ClusterService, clusterCompSpecName, svcName string) *appsv1.ClusterService {
+	for i, svc := range services {
+		if svc.Name == svcName && svc.ComponentSelector == clusterCompSpecName {
+			return &services[i]
+		}
+	}
+	return nil
+}
+
+func (e ExposeOpsHandler) exposeServices(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	exposeMap map[string]opsv1alpha1.Expose) error {
 	reqCtx.Log.Info("cluster service before action", "clusterService", opsRes.Cluster.Spec.Services)
 	compMap := make(map[string]appsv1.ClusterComponentSpec)
 	for _, comp := range opsRes.Cluster.Spec.ComponentSpecs {
@@ -117,7 +171,11 @@ func (e ExposeOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli cli
 		actualProgressCount int
 		expectProgressCount int
 	)
-	for _, v := range opsRequest.Spec.ExposeList {
+	exposes := opsRequest.Spec.ExposeList
+	if opsRequest.Status.Phase == opsv1alpha1.OpsCancellingPhase {
+		exposes = e.restoredExposes(opsRequest)
+	}
+	for _, v := range exposes {
 		actualCount, expectCount, err := e.handleComponentServices(reqCtx, cli, opsResource, v)
 		if err != nil {
 			return "", 0, err
@@ -228,22 +286,16 @@ func (e ExposeOpsHandler) ActionStartedCondition(reqCtx intctrlutil.RequestCtx,
 }
 
 func (e ExposeOpsHandler) SaveLastConfiguration(reqCtx intctrlutil.RequestCtx, cli client.Client, opsResource *OpsResource) error {
-	compOpsSet := map[string]sets.Empty{}
-	for _, v := range opsResource.OpsRequest.Spec.ExposeList {
-		if v.ComponentName != "" {
-			compOpsSet[v.ComponentName] = sets.Empty{}
-		}
-	}
-	lastComponentInfo := map[string]opsv1alpha1.LastComponentConfiguration{}
-	for _, v := range opsResource.Cluster.Spec.ComponentSpecs {
-		if _, ok := compOpsSet[v.Name]; !ok {
-			continue
-		}
-		lastComponentInfo[v.Name] = opsv1alpha1.LastComponentConfiguration{
-			Services: v.Services,
+	var lastServices []appsv1.ClusterService
+	for _, expose := range opsResource.OpsRequest.Spec.ExposeList {
+		for _, exposeService := range expose.Services {
+			svcName := generateServiceName(expose.ComponentName, exposeService.Name)
+			if svc := findClusterService(opsResource.Cluster.Spec.Services, expose.ComponentName, svcName); svc != nil {
+				lastServices = append(lastServices, *svc.DeepCopy())
+			}
 		}
 	}
-	opsResource.OpsRequest.Status.LastConfiguration.Components = lastComponentInfo
+	opsResource.OpsRequest.Status.LastConfiguration.Services = lastServices
 	return nil
 }
 
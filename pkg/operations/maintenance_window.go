@@ -0,0 +1,221 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+// defaultMaintenanceWindowBypassDuration is the duration to bypass the maintenance window if the timeoutSeconds is not specified.
+const defaultMaintenanceWindowBypassDuration = time.Hour
+
+// checkMaintenanceWindowBeforeRunning checks whether the disruptive opsRequest can be executed in the maintenance window.
+// It returns the condition to record if the opsRequest can be executed, or a WaitForMaintenanceWindowErr if it should wait.
+func checkMaintenanceWindowBeforeRunning(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	opsBehaviour OpsBehaviour) (*metav1.Condition, error) {
+	cluster := opsRes.Cluster
+	opsRequest := opsRes.OpsRequest
+	if cluster == nil || cluster.Spec.MaintenanceWindow == nil {
+		return nil, nil
+	}
+	disruptive, err := isDisruptive(reqCtx, cli, opsRes, opsBehaviour)
+	if err != nil || !disruptive {
+		return nil, err
+	}
+	if opsRequest.Spec.BypassMaintenanceWindow {
+		if err = bypassMaintenanceWindow(reqCtx, cli, opsRes); err != nil {
+			return nil, err
+		}
+		return opsv1alpha1.NewInMaintenanceWindowCondition(opsRequest), nil
+	}
+	open, openTime, err := intctrlutil.CheckMaintenanceWindow(cluster.Spec.MaintenanceWindow, time.Now())
+	if err != nil {
+		return nil, intctrlutil.NewFatalError(err.Error())
+	}
+	if open {
+		return opsv1alpha1.NewInMaintenanceWindowCondition(opsRequest), nil
+	}
+	if !hasMaintenanceWindowCondition(opsRequest, opsv1alpha1.ReasonWaitForMaintenanceWindow) {
+		if err = PatchOpsStatus(reqCtx.Ctx, cli, opsRes, opsRequest.Status.Phase,
+			opsv1alpha1.NewWaitForMaintenanceWindowCondition(opsRequest, openTime)); err != nil {
+			return nil, err
+		}
+	}
+	return nil, &WaitForMaintenanceWindowErr{clusterName: cluster.Name, openTime: openTime}
+}
+
+// syncMaintenanceWindowCondition updates the maintenance window condition of the running disruptive opsRequest,
+// and returns the duration to requeue when the window opens if it's paused.
+func syncMaintenanceWindowCondition(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	opsBehaviour OpsBehaviour) (time.Duration, error) {
+	cluster := opsRes.Cluster
+	opsRequest := opsRes.OpsRequest
+	if cluster == nil || cluster.Spec.MaintenanceWindow == nil || opsRequest.Spec.BypassMaintenanceWindow {
+		return 0, nil
+	}
+	disruptive, err := isDisruptive(reqCtx, cli, opsRes, opsBehaviour)
+	if err != nil || !disruptive {
+		return 0, err
+	}
+	open, openTime, err := intctrlutil.CheckMaintenanceWindow(cluster.Spec.MaintenanceWindow, time.Now())
+	if err != nil {
+		return 0, nil
+	}
+	if open {
+		if hasMaintenanceWindowCondition(opsRequest, opsv1alpha1.ReasonPausedByMaintenanceWindow) {
+			return 0, PatchOpsStatus(reqCtx.Ctx, cli, opsRes, opsRequest.Status.Phase,
+				opsv1alpha1.NewInMaintenanceWindowCondition(opsRequest))
+		}
+		return 0, nil
+	}
+	// the instances are not updated until the window opens, so the opsRequest is paused.
+	if !hasMaintenanceWindowCondition(opsRequest, opsv1alpha1.ReasonPausedByMaintenanceWindow) {
+		if err = PatchOpsStatus(reqCtx.Ctx, cli, opsRes, opsRequest.Status.Phase,
+			opsv1alpha1.NewPausedByMaintenanceWindowCondition(opsRequest, openTime)); err != nil {
+			return 0, err
+		}
+	}
+	return time.Until(openTime), nil
+}
+
+// isDisruptive checks whether the opsRequest disrupts the instances.
+func isDisruptive(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource, opsBehaviour OpsBehaviour) (bool, error) {
+	if opsBehaviour.DisruptiveFunc != nil {
+		return opsBehaviour.DisruptiveFunc(reqCtx, cli, opsRes)
+	}
+	return opsBehaviour.Disruptive, nil
+}
+
+// bypassMaintenanceWindow records the opsRequest in the cluster annotation to allow the instances of the components
+// it operates on to be updated outside the maintenance window, until the opsRequest completes or times out.
+func bypassMaintenanceWindow(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	cluster := opsRes.Cluster
+	opsRequest := opsRes.OpsRequest
+	now := time.Now()
+	for _, bypass := range intctrlutil.GetMaintenanceWindowBypasses(cluster.Annotations) {
+		if bypass.OpsRequest == opsRequest.Name && now.Before(bypass.Until) {
+			return nil
+		}
+	}
+	bypassDuration := defaultMaintenanceWindowBypassDuration
+	if timeoutSeconds := opsRequest.Spec.TimeoutSeconds; timeoutSeconds != nil && *timeoutSeconds > 0 {
+		bypassDuration = time.Duration(*timeoutSeconds) * time.Second
+	}
+	bypasses := append(activeMaintenanceWindowBypasses(cluster, now, opsRequest.Name), intctrlutil.MaintenanceWindowBypass{
+		OpsRequest: opsRequest.Name,
+		Components: getOpsTargetComponentNames(opsRequest),
+		Until:      now.Add(bypassDuration).UTC().Truncate(time.Second),
+	})
+	return patchMaintenanceWindowBypasses(reqCtx, cli, cluster, bypasses)
+}
+
+// removeMaintenanceWindowBypass removes the opsRequest from the bypasses of the cluster once it is completed.
+func removeMaintenanceWindowBypass(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	cluster := opsRes.Cluster
+	if cluster == nil || !opsRes.OpsRequest.Spec.BypassMaintenanceWindow {
+		return nil
+	}
+	existing := intctrlutil.GetMaintenanceWindowBypasses(cluster.Annotations)
+	bypasses := activeMaintenanceWindowBypasses(cluster, time.Now(), opsRes.OpsRequest.Name)
+	if len(bypasses) == len(existing) {
+		return nil
+	}
+	return patchMaintenanceWindowBypasses(reqCtx, cli, cluster, bypasses)
+}
+
+// activeMaintenanceWindowBypasses returns the unexpired bypasses of the cluster, excluding the specified opsRequest.
+func activeMaintenanceWindowBypasses(cluster *appsv1.Cluster, now time.Time, excludedOpsName string) []intctrlutil.MaintenanceWindowBypass {
+	var bypasses []intctrlutil.MaintenanceWindowBypass
+	for _, bypass := range intctrlutil.GetMaintenanceWindowBypasses(cluster.Annotations) {
+		if bypass.OpsRequest != excludedOpsName && now.Before(bypass.Until) {
+			bypasses = append(bypasses, bypass)
+		}
+	}
+	return bypasses
+}
+
+func patchMaintenanceWindowBypasses(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	cluster *appsv1.Cluster,
+	bypasses []intctrlutil.MaintenanceWindowBypass) error {
+	patch := client.MergeFrom(cluster.DeepCopy())
+	if cluster.Annotations == nil {
+		cluster.Annotations = map[string]string{}
+	}
+	intctrlutil.SetMaintenanceWindowBypasses(cluster.Annotations, bypasses)
+	return cli.Patch(reqCtx.Ctx, cluster, patch)
+}
+
+// getOpsTargetComponentNames returns the names of the components or shardings that the opsRequest operates on.
+func getOpsTargetComponentNames(opsRequest *opsv1alpha1.OpsRequest) []string {
+	var compNames []string
+	addCompName := func(compName string) {
+		if len(compName) > 0 && !slices.Contains(compNames, compName) {
+			compNames = append(compNames, compName)
+		}
+	}
+	spec := opsRequest.Spec
+	for _, v := range spec.RestartList {
+		addCompName(v.ComponentName)
+	}
+	for _, v := range spec.VerticalScalingList {
+		addCompName(v.ComponentName)
+	}
+	for _, v := range spec.Reconfigures {
+		addCompName(v.ComponentName)
+	}
+	for _, v := range spec.SwitchoverList {
+		if len(v.ComponentObjectName) > 0 {
+			addCompName(strings.TrimPrefix(v.ComponentObjectName, spec.GetClusterName()+"-"))
+		} else {
+			addCompName(v.ComponentName)
+		}
+	}
+	if spec.Upgrade != nil {
+		for _, v := range spec.Upgrade.Components {
+			addCompName(v.ComponentName)
+		}
+	}
+	if spec.CustomOps != nil {
+		for _, v := range spec.CustomOps.CustomOpsComponents {
+			addCompName(v.ComponentName)
+		}
+	}
+	return compNames
+}
+
+func hasMaintenanceWindowCondition(opsRequest *opsv1alpha1.OpsRequest, reason string) bool {
+	condition := meta.FindStatusCondition(opsRequest.Status.Conditions, opsv1alpha1.ConditionTypeMaintenanceWindow)
+	return condition != nil && condition.Reason == reason
+}
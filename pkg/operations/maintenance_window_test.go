@@ -0,0 +1,273 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
+	parametersv1alpha1 "github.com/apecloud/kubeblocks/apis/parameters/v1alpha1"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+func newMaintenanceWindowTestResource(objs ...client.Object) (intctrlutil.RequestCtx, client.Client, *OpsResource) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	_ = opsv1alpha1.AddToScheme(scheme)
+	_ = parametersv1alpha1.AddToScheme(scheme)
+
+	cluster := &appsv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "mycluster"},
+		Spec: appsv1.ClusterSpec{
+			ComponentSpecs: []appsv1.ClusterComponentSpec{
+				{Name: "mysql", ComponentDef: "mysql-8.0"},
+				{Name: "proxy", ComponentDef: "proxy"},
+			},
+			// the window opens 12 hours later
+			MaintenanceWindow: &appsv1.MaintenanceWindow{
+				Schedule: fmt.Sprintf("0 %d * * *", (time.Now().UTC().Hour()+12)%24),
+				Duration: metav1.Duration{Duration: time.Hour},
+			},
+		},
+	}
+	opsRequest := &opsv1alpha1.OpsRequest{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "restart"},
+		Spec: opsv1alpha1.OpsRequestSpec{
+			ClusterName: cluster.Name,
+			Type:        opsv1alpha1.RestartType,
+			SpecificOpsRequest: opsv1alpha1.SpecificOpsRequest{
+				RestartList: []opsv1alpha1.ComponentOps{{ComponentName: "mysql"}},
+			},
+		},
+		Status: opsv1alpha1.OpsRequestStatus{Phase: opsv1alpha1.OpsPendingPhase},
+	}
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(append(objs, cluster, opsRequest)...).
+		WithStatusSubresource(&opsv1alpha1.OpsRequest{}).
+		Build()
+	reqCtx := intctrlutil.RequestCtx{Ctx: context.Background(), Log: logr.Discard()}
+	opsRes := &OpsResource{
+		OpsRequest: opsRequest,
+		Cluster:    cluster,
+		Recorder:   record.NewFakeRecorder(10),
+	}
+	return reqCtx, cli, opsRes
+}
+
+func TestCheckMaintenanceWindowBeforeRunning(t *testing.T) {
+	disruptive := OpsBehaviour{Disruptive: true}
+
+	reqCtx, cli, opsRes := newMaintenanceWindowTestResource()
+	if condition, err := checkMaintenanceWindowBeforeRunning(reqCtx, cli, opsRes, OpsBehaviour{}); err != nil || condition != nil {
+		t.Fatalf("the non-disruptive opsRequest should not be deferred, got %v %v", condition, err)
+	}
+
+	_, err := checkMaintenanceWindowBeforeRunning(reqCtx, cli, opsRes, disruptive)
+	if _, ok := err.(*WaitForMaintenanceWindowErr); !ok {
+		t.Fatalf("expect the opsRequest to wait for the maintenance window, got %v", err)
+	}
+	if !hasMaintenanceWindowCondition(opsRes.OpsRequest, opsv1alpha1.ReasonWaitForMaintenanceWindow) {
+		t.Error("expect the WaitForMaintenanceWindow condition")
+	}
+
+	// the bypass of another opsRequest does not take effect
+	opsRes.Cluster.Annotations = map[string]string{}
+	intctrlutil.SetMaintenanceWindowBypasses(opsRes.Cluster.Annotations, []intctrlutil.MaintenanceWindowBypass{
+		{OpsRequest: "upgrade", Components: []string{"mysql"}, Until: time.Now().Add(time.Hour)},
+	})
+	if _, err = checkMaintenanceWindowBeforeRunning(reqCtx, cli, opsRes, disruptive); err == nil {
+		t.Fatal("the opsRequest should not be bypassed by another one")
+	}
+}
+
+func TestBypassMaintenanceWindow(t *testing.T) {
+	reqCtx, cli, opsRes := newMaintenanceWindowTestResource()
+	opsRes.Cluster.Annotations = map[string]string{}
+	intctrlutil.SetMaintenanceWindowBypasses(opsRes.Cluster.Annotations, []intctrlutil.MaintenanceWindowBypass{
+		{OpsRequest: "upgrade", Components: []string{"proxy"}, Until: time.Now().Add(time.Hour)},
+		{OpsRequest: "expired", Components: []string{"proxy"}, Until: time.Now().Add(-time.Hour)},
+	})
+	opsRes.OpsRequest.Spec.BypassMaintenanceWindow = true
+	opsRes.OpsRequest.Spec.TimeoutSeconds = ptr.To(int32(600))
+
+	condition, err := checkMaintenanceWindowBeforeRunning(reqCtx, cli, opsRes, OpsBehaviour{Disruptive: true})
+	if err != nil || condition == nil || condition.Reason != opsv1alpha1.ReasonMaintenanceWindowBypassed {
+		t.Fatalf("expect the opsRequest to bypass the maintenance window, got %v %v", condition, err)
+	}
+	cluster := &appsv1.Cluster{}
+	if err = cli.Get(reqCtx.Ctx, client.ObjectKeyFromObject(opsRes.Cluster), cluster); err != nil {
+		t.Fatal(err)
+	}
+	bypasses := intctrlutil.GetMaintenanceWindowBypasses(cluster.Annotations)
+	if len(bypasses) != 2 || bypasses[0].OpsRequest != "upgrade" || bypasses[1].OpsRequest != "restart" {
+		t.Fatalf("expect the expired bypass to be pruned and the opsRequest to be recorded, got %v", bypasses)
+	}
+	if len(bypasses[1].Components) != 1 || bypasses[1].Components[0] != "mysql" {
+		t.Errorf("expect the bypass to be scoped to the components of the opsRequest, got %v", bypasses[1].Components)
+	}
+	if until := time.Until(bypasses[1].Until); until > 10*time.Minute || until < 9*time.Minute {
+		t.Errorf("expect the bypass to expire when the opsRequest times out, got %v", bypasses[1].Until)
+	}
+	if compBypasses, _ := intctrlutil.ComponentMaintenanceWindowBypasses(cluster.Annotations, "mysql"); len(compBypasses) != 1 {
+		t.Errorf("expect the component to inherit the bypass of the opsRequest only, got %v", compBypasses)
+	}
+
+	if err = removeMaintenanceWindowBypass(reqCtx, cli, opsRes); err != nil {
+		t.Fatal(err)
+	}
+	if err = cli.Get(reqCtx.Ctx, client.ObjectKeyFromObject(opsRes.Cluster), cluster); err != nil {
+		t.Fatal(err)
+	}
+	bypasses = intctrlutil.GetMaintenanceWindowBypasses(cluster.Annotations)
+	if len(bypasses) != 1 || bypasses[0].OpsRequest != "upgrade" {
+		t.Errorf("expect the bypass to be removed once the opsRequest completes, got %v", bypasses)
+	}
+}
+
+type maintenanceWindowTestHandler struct {
+	reconciled int
+}
+
+func (h *maintenanceWindowTestHandler) Action(intctrlutil.RequestCtx, client.Client, *OpsResource) error {
+	return nil
+}
+
+func (h *maintenanceWindowTestHandler) ReconcileAction(intctrlutil.RequestCtx, client.Client, *OpsResource) (opsv1alpha1.OpsPhase, time.Duration, error) {
+	h.reconciled++
+	return opsv1alpha1.OpsRunningPhase, time.Second, nil
+}
+
+func (h *maintenanceWindowTestHandler) ActionStartedCondition(intctrlutil.RequestCtx, client.Client, *OpsResource) (*metav1.Condition, error) {
+	return nil, nil
+}
+
+func (h *maintenanceWindowTestHandler) SaveLastConfiguration(intctrlutil.RequestCtx, client.Client, *OpsResource) error {
+	return nil
+}
+
+func TestReconcilePausedOutsideMaintenanceWindow(t *testing.T) {
+	reqCtx, cli, opsRes := newMaintenanceWindowTestResource()
+	opsRes.OpsRequest.Status.Phase = opsv1alpha1.OpsRunningPhase
+	handler := &maintenanceWindowTestHandler{}
+	opsMgr := &OpsManager{OpsMap: map[opsv1alpha1.OpsType]OpsBehaviour{
+		opsv1alpha1.RestartType: {Disruptive: true, PausedOutsideMaintenanceWindow: true, OpsHandler: handler},
+	}}
+
+	requeueAfter, err := opsMgr.Reconcile(reqCtx, cli, opsRes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if handler.reconciled != 0 {
+		t.Error("the ReconcileAction should not be performed outside the maintenance window")
+	}
+	if requeueAfter < 11*time.Hour {
+		t.Errorf("expect to requeue when the window opens, got %v", requeueAfter)
+	}
+	condition := meta.FindStatusCondition(opsRes.OpsRequest.Status.Conditions, opsv1alpha1.ConditionTypeMaintenanceWindow)
+	if condition == nil || condition.Reason != opsv1alpha1.ReasonPausedByMaintenanceWindow {
+		t.Errorf("expect the PausedByMaintenanceWindow condition, got %v", condition)
+	}
+
+	opsRes.OpsRequest.Spec.BypassMaintenanceWindow = true
+	if _, err = opsMgr.Reconcile(reqCtx, cli, opsRes); err != nil {
+		t.Fatal(err)
+	}
+	if handler.reconciled != 1 {
+		t.Error("the ReconcileAction should be performed if the opsRequest bypasses the maintenance window")
+	}
+}
+
+func TestReconfigureIsDisruptive(t *testing.T) {
+	cmpd := &appsv1.ComponentDefinition{ObjectMeta: metav1.ObjectMeta{Name: "mysql-8.0"}}
+	pcr := &parametersv1alpha1.ParamConfigRenderer{
+		ObjectMeta: metav1.ObjectMeta{Name: "mysql-8.0-pcr"},
+		Spec: parametersv1alpha1.ParamConfigRendererSpec{
+			ComponentDef:   cmpd.Name,
+			ParametersDefs: []string{"mysql-8.0-pd"},
+		},
+		Status: parametersv1alpha1.ParamConfigRendererStatus{Phase: parametersv1alpha1.PDAvailablePhase},
+	}
+	pd := &parametersv1alpha1.ParametersDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "mysql-8.0-pd"},
+		Spec: parametersv1alpha1.ParametersDefinitionSpec{
+			ReloadAction: &parametersv1alpha1.ReloadAction{
+				ShellTrigger: &parametersv1alpha1.ShellTrigger{Command: []string{"reload.sh"}},
+			},
+			StaticParameters:    []string{"innodb_buffer_pool_instances"},
+			DynamicParameters:   []string{"max_connections"},
+			ImmutableParameters: []string{"server_id"},
+		},
+		Status: parametersv1alpha1.ParametersDefinitionStatus{Phase: parametersv1alpha1.PDAvailablePhase},
+	}
+	reqCtx, cli, opsRes := newMaintenanceWindowTestResource(cmpd, pcr, pd)
+	opsRes.OpsRequest.Spec.Type = opsv1alpha1.ReconfiguringType
+	opsRes.OpsRequest.Spec.RestartList = nil
+
+	reconfigure := func(compName string, keys ...string) bool {
+		var params []opsv1alpha1.ParameterPair
+		for _, key := range keys {
+			params = append(params, opsv1alpha1.ParameterPair{Key: key})
+		}
+		opsRes.OpsRequest.Spec.Reconfigures = []opsv1alpha1.Reconfigure{
+			{ComponentOps: opsv1alpha1.ComponentOps{ComponentName: compName}, Parameters: params},
+		}
+		disruptive, err := (&reconfigureAction{}).isDisruptive(reqCtx, cli, opsRes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return disruptive
+	}
+	if reconfigure("mysql", "max_connections") {
+		t.Error("the dynamic parameters should be reloaded without disruption")
+	}
+	if !reconfigure("mysql", "max_connections", "innodb_buffer_pool_instances") {
+		t.Error("the static parameters should be disruptive")
+	}
+	if !reconfigure("mysql", "server_id") {
+		t.Error("the immutable parameters should be disruptive")
+	}
+	if !reconfigure("mysql", "unknown") {
+		t.Error("the unknown parameters should be disruptive")
+	}
+
+	pd.Spec.ReloadAction = nil
+	if err := cli.Update(reqCtx.Ctx, pd); err != nil {
+		t.Fatal(err)
+	}
+	if !reconfigure("mysql", "max_connections") {
+		t.Error("the dynamic parameters should be disruptive if they can't be reloaded")
+	}
+}
@@ -20,6 +20,7 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package operations
 
 import (
+	"fmt"
 	"slices"
 	"strings"
 	"sync"
@@ -81,6 +82,9 @@ func (opsMgr *OpsManager) Do(reqCtx intctrlutil.RequestCtx, cli client.Client, o
 			if _, ok := err.(*WaitForClusterPhaseErr); ok {
 				return intctrlutil.ResultToP(intctrlutil.RequeueAfter(time.Second, reqCtx.Log, "wait cluster to a right phase"))
 			}
+			if windowErr, ok := err.(*WaitForMaintenanceWindowErr); ok {
+				return intctrlutil.ResultToP(intctrlutil.RequeueAfter(time.Until(windowErr.openTime), reqCtx.Log, windowErr.Error()))
+			}
 			return nil, err
 		}
 		return intctrlutil.ResultToP(intctrlutil.Reconciled())
@@ -102,6 +106,42 @@ func (opsMgr *OpsManager) Do(reqCtx intctrlutil.RequestCtx, cli client.Client, o
 	return nil, nil
 }
 
+// DryRun performs the Action of the OpsRequest in simulation, the cluster and other objects are mutated through the client,
+// which should capture the changes rather than writing them to the API server.
+// The cluster phase, the queue and the maintenance window are not checked in dry-run,
+// and the operations whose changes are made by the ReconcileAction are not supported.
+func (opsMgr *OpsManager) DryRun(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	var (
+		opsRequest = opsRes.OpsRequest
+		err        error
+	)
+	opsBehaviour, ok := opsMgr.OpsMap[opsRequest.Spec.Type]
+	if !ok || opsBehaviour.OpsHandler == nil {
+		return intctrlutil.NewFatalError(fmt.Sprintf("spec.type %s is not supported by operator", opsRequest.Spec.Type))
+	}
+	if opsBehaviour.ChangedByReconcileAction {
+		return intctrlutil.NewFatalError(fmt.Sprintf("spec.type %s is not supported in dry-run", opsRequest.Spec.Type))
+	}
+	if err = opsRequest.ValidateOps(reqCtx.Ctx, cli, opsRes.Cluster); err != nil {
+		return err
+	}
+	if err = opsBehaviour.OpsHandler.SaveLastConfiguration(reqCtx, cli, opsRes); err != nil {
+		return err
+	}
+	condition, err := opsBehaviour.OpsHandler.ActionStartedCondition(reqCtx, cli, opsRes)
+	if err != nil {
+		return err
+	}
+	if condition != nil {
+		opsRequest.SetStatusCondition(*condition)
+	}
+	if opsRequest.Status.StartTimestamp.IsZero() {
+		opsRequest.Status.StartTimestamp = metav1.Now()
+	}
+	opsRequest.Status.Phase = opsv1alpha1.OpsCreatingPhase
+	return opsBehaviour.OpsHandler.Action(reqCtx, cli, opsRes)
+}
+
 func (opsMgr *OpsManager) doPreConditionAndTransPhaseToCreating(reqCtx intctrlutil.RequestCtx,
 	cli client.Client,
 	opsRes *OpsResource,
@@ -141,11 +181,19 @@ func (opsMgr *OpsManager) doPreConditionAndTransPhaseToCreating(reqCtx intctrlut
 			return intctrlutil.NewFatalError(err.Error())
 		}
 	}
+	// the disruptive operations are deferred to the maintenance window of the cluster
+	windowCondition, err := checkMaintenanceWindowBeforeRunning(reqCtx, cli, opsRes, opsBehaviour)
+	if err != nil {
+		return err
+	}
 	opsDeepCopy := opsRes.OpsRequest.DeepCopy()
 	// save last configuration into status.lastConfiguration
 	if err = opsBehaviour.OpsHandler.SaveLastConfiguration(reqCtx, cli, opsRes); err != nil {
 		return err
 	}
+	if windowCondition != nil {
+		opsRes.OpsRequest.SetStatusCondition(*windowCondition)
+	}
 	return patchOpsRequestToCreating(reqCtx, cli, opsRes, opsDeepCopy, opsBehaviour.OpsHandler)
 }
 
@@ -174,6 +222,13 @@ func (opsMgr *OpsManager) Reconcile(reqCtx intctrlutil.RequestCtx, cli client.Cl
 			return requeueAfter, err
 		}
 	}
+	windowRequeueAfter, err := syncMaintenanceWindowCondition(reqCtx, cli, opsRes, opsBehaviour)
+	if err != nil {
+		return requeueAfter, err
+	}
+	if windowRequeueAfter > 0 && opsBehaviour.PausedOutsideMaintenanceWindow {
+		return opsMgr.checkAndHandleOpsTimeout(reqCtx, cli, opsRes, windowRequeueAfter)
+	}
 	if opsRequestPhase, requeueAfter, err = opsBehaviour.OpsHandler.ReconcileAction(reqCtx, cli, opsRes); err != nil &&
 		!isOpsRequestFailedPhase(opsRequestPhase) {
 		if intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal) {
@@ -190,6 +245,9 @@ func (opsMgr *OpsManager) Reconcile(reqCtx intctrlutil.RequestCtx, cli client.Cl
 		return 0, opsMgr.handleOpsCompleted(reqCtx, cli, opsRes, opsRequestPhase,
 			opsv1alpha1.NewCancelFailedCondition(opsRequest, err), opsv1alpha1.NewFailedCondition(opsRequest, err))
 	default:
+		if windowRequeueAfter > 0 && (requeueAfter == 0 || windowRequeueAfter < requeueAfter) {
+			requeueAfter = windowRequeueAfter
+		}
 		return opsMgr.checkAndHandleOpsTimeout(reqCtx, cli, opsRes, requeueAfter)
 	}
 }
@@ -203,6 +261,9 @@ func (opsMgr *OpsManager) handleOpsCompleted(reqCtx intctrlutil.RequestCtx,
 	if err := updateHAConfigIfNecessary(reqCtx, cli, opsRes.OpsRequest, "true"); err != nil {
 		return err
 	}
+	if err := removeMaintenanceWindowBypass(reqCtx, cli, opsRes); err != nil {
+		return err
+	}
 	if opsRes.OpsRequest.Status.Phase == opsv1alpha1.OpsCancellingPhase {
 		return PatchOpsStatus(reqCtx.Ctx, cli, opsRes, opsv1alpha1.OpsCancelledPhase, cancelledCondition)
 	}
@@ -0,0 +1,50 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+func TestDryRunNotSupported(t *testing.T) {
+	reqCtx := intctrlutil.RequestCtx{Ctx: context.Background(), Log: logr.Discard()}
+	cluster := &appsv1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "mycluster"}}
+	for _, opsType := range []opsv1alpha1.OpsType{opsv1alpha1.SwitchoverType, opsv1alpha1.RebuildInstanceType, opsv1alpha1.CustomType} {
+		opsRes := &OpsResource{
+			Cluster: cluster,
+			OpsRequest: &opsv1alpha1.OpsRequest{
+				Spec: opsv1alpha1.OpsRequestSpec{ClusterName: cluster.Name, Type: opsType},
+			},
+		}
+		// the client is not touched as the operation is rejected at first
+		err := GetOpsManager().DryRun(reqCtx, nil, opsRes)
+		if !intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal) {
+			t.Errorf("expect %s to be rejected in dry-run, got %v", opsType, err)
+		}
+	}
+}
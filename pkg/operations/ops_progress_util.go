@@ -48,10 +48,10 @@ func getProgressObjectKey(kind, name string) string {
 	return fmt.Sprintf("%s/%s", kind, name)
 }
 
-// isCompletedProgressStatus checks the progress detail with final state, either Failed or Succeed.
+// isCompletedProgressStatus checks the progress detail with final state, either Failed, Succeed or Skipped.
 func isCompletedProgressStatus(status opsv1alpha1.ProgressStatus) bool {
 	return slices.Contains([]opsv1alpha1.ProgressStatus{opsv1alpha1.SucceedProgressStatus,
-		opsv1alpha1.FailedProgressStatus}, status)
+		opsv1alpha1.FailedProgressStatus, opsv1alpha1.SkippedProgressStatus}, status)
 }
 
 // setComponentStatusProgressDetail sets the corresponding progressDetail in progressDetails to newProgressDetail.
@@ -114,6 +114,50 @@ func findActionProgress(progressDetails []opsv1alpha1.ProgressStatusDetail, acti
 	return nil
 }
 
+// getTouchedInstances gets the instances that have been changed by the OpsRequest,
+// which are resolved from the progress details that are no longer pending.
+func getTouchedInstances(compStatus opsv1alpha1.OpsRequestComponentStatus) []string {
+	instanceSet := sets.New(compStatus.TouchedInstances...)
+	for _, v := range compStatus.ProgressDetails {
+		if v.Status == opsv1alpha1.PendingProgressStatus {
+			continue
+		}
+		for _, task := range v.ActionTasks {
+			if task.TargetPodName != "" {
+				instanceSet.Insert(task.TargetPodName)
+			}
+		}
+		kind, name, found := strings.Cut(v.ObjectKey, "/")
+		if !found {
+			continue
+		}
+		switch kind {
+		case constant.PodKind:
+			instanceSet.Insert(name)
+		case pvcProgressObjectKind:
+			// the pvc name is "<vctName>-<podName>" and the group of the progress detail is the vctName.
+			instanceSet.Insert(strings.TrimPrefix(name, v.Group+"-"))
+		}
+	}
+	return sets.List(instanceSet)
+}
+
+// updateTouchedInstances updates the touched instances of the components when the OpsRequest is entering the
+// final phase or being canceled. the instances touched before the cancellation have been recorded when
+// entering the "Cancelling" phase, so they will not be updated again.
+func updateTouchedInstances(opsRequest *opsv1alpha1.OpsRequest, phase opsv1alpha1.OpsPhase) {
+	if opsRequest.Status.Phase == opsv1alpha1.OpsCancellingPhase {
+		return
+	}
+	if phase != opsv1alpha1.OpsCancellingPhase && !opsRequest.IsComplete(phase) {
+		return
+	}
+	for name, compStatus := range opsRequest.Status.Components {
+		compStatus.TouchedInstances = getTouchedInstances(compStatus)
+		opsRequest.Status.Components[name] = compStatus
+	}
+}
+
 // getProgressDetailEventType gets the event type with progressDetail status.
 func getProgressDetailEventType(status opsv1alpha1.ProgressStatus) string {
 	if status == opsv1alpha1.FailedProgressStatus {
@@ -131,6 +175,8 @@ func getProgressDetailEventReason(status opsv1alpha1.ProgressStatus) string {
 		return "Processing"
 	case opsv1alpha1.FailedProgressStatus:
 		return "Failed"
+	case opsv1alpha1.SkippedProgressStatus:
+		return "Skipped"
 	}
 	return ""
 }
@@ -212,6 +212,37 @@ var _ = Describe("Ops ProgressDetails", func() {
 			Expect(getProgressDetailStatus(opsRes, defaultCompName, targetPod)).Should(Equal(opsv1alpha1.SucceedProgressStatus))
 			Expect(opsRes.OpsRequest.Status.Progress).Should(Equal("1/1"))
 		})
+
+		It("Test touched instances of the Ops", func() {
+			compStatus := opsv1alpha1.OpsRequestComponentStatus{
+				TouchedInstances: []string{"pod-3"},
+				ProgressDetails: []opsv1alpha1.ProgressStatusDetail{
+					{ObjectKey: getProgressObjectKey(constant.PodKind, "pod-0"), Status: opsv1alpha1.SucceedProgressStatus},
+					{ObjectKey: getProgressObjectKey(constant.PodKind, "pod-1"), Status: opsv1alpha1.PendingProgressStatus},
+					{ObjectKey: getPVCProgressObjectKey("data-pod-2"), Group: "data", Status: opsv1alpha1.ProcessingProgressStatus},
+					{ActionName: "action", Status: opsv1alpha1.FailedProgressStatus, ActionTasks: []opsv1alpha1.ActionTask{
+						{ObjectKey: "job", TargetPodName: "pod-0"},
+						{ObjectKey: "job", TargetPodName: "pod-4"},
+					}},
+				},
+			}
+			Expect(getTouchedInstances(compStatus)).Should(Equal([]string{"pod-0", "pod-2", "pod-3", "pod-4"}))
+
+			By("expect the touched instances are recorded when the ops is canceling and not updated later")
+			opsRequest := &opsv1alpha1.OpsRequest{}
+			opsRequest.Status.Phase = opsv1alpha1.OpsRunningPhase
+			opsRequest.Status.Components = map[string]opsv1alpha1.OpsRequestComponentStatus{defaultCompName: compStatus}
+			updateTouchedInstances(opsRequest, opsv1alpha1.OpsCancellingPhase)
+			Expect(opsRequest.Status.Components[defaultCompName].TouchedInstances).Should(HaveLen(4))
+
+			opsRequest.Status.Phase = opsv1alpha1.OpsCancellingPhase
+			compStatus = opsRequest.Status.Components[defaultCompName]
+			compStatus.ProgressDetails = append(compStatus.ProgressDetails, opsv1alpha1.ProgressStatusDetail{
+				ObjectKey: getProgressObjectKey(constant.PodKind, "pod-5"), Status: opsv1alpha1.SucceedProgressStatus})
+			opsRequest.Status.Components[defaultCompName] = compStatus
+			updateTouchedInstances(opsRequest, opsv1alpha1.OpsCancelledPhase)
+			Expect(opsRequest.Status.Components[defaultCompName].TouchedInstances).ShouldNot(ContainElement("pod-5"))
+		})
 	})
 })
 
@@ -34,6 +34,7 @@ import (
 	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/metrics"
 	opsutil "github.com/apecloud/kubeblocks/pkg/operations/util"
 )
 
@@ -49,6 +50,17 @@ func (e *WaitForClusterPhaseErr) Error() string {
 	return fmt.Sprintf("wait for cluster %s to reach phase %v, current status is :%s", e.clusterName, e.expectedPhase, e.currentPhase)
 }
 
+var _ error = &WaitForMaintenanceWindowErr{}
+
+type WaitForMaintenanceWindowErr struct {
+	clusterName string
+	openTime    time.Time
+}
+
+func (e *WaitForMaintenanceWindowErr) Error() string {
+	return fmt.Sprintf("wait for the maintenance window of cluster %s to open at %s", e.clusterName, e.openTime.Format(time.RFC3339))
+}
+
 type handleStatusProgressWithComponent func(reqCtx intctrlutil.RequestCtx,
 	cli client.Client,
 	opsRes *OpsResource,
@@ -86,6 +98,7 @@ func PatchOpsStatusWithOpsDeepCopy(ctx context.Context,
 		}
 		opsRes.Recorder.Event(opsRequest, eventType, v.Reason, v.Message)
 	}
+	updateTouchedInstances(opsRequest, phase)
 	opsRequest.Status.Phase = phase
 	if opsRequest.IsComplete(phase) {
 		opsRequest.Status.CompletionTimestamp = metav1.Time{Time: time.Now()}
@@ -97,7 +110,13 @@ func PatchOpsStatusWithOpsDeepCopy(ctx context.Context,
 	if phase == opsv1alpha1.OpsCreatingPhase && opsRequest.Status.StartTimestamp.IsZero() {
 		opsRequest.Status.StartTimestamp = metav1.Time{Time: time.Now()}
 	}
-	return cli.Status().Patch(ctx, opsRequest, patch)
+	if err := cli.Status().Patch(ctx, opsRequest, patch); err != nil {
+		return err
+	}
+	if opsRequest.IsComplete(phase) && !opsRequestDeepCopy.IsComplete() {
+		metrics.ObserveOpsRequest(opsRequest)
+	}
+	return nil
 }
 
 // PatchOpsStatus patches OpsRequest.status
@@ -279,6 +298,7 @@ func abortEarlierOpsRequestWithSameKind(reqCtx intctrlutil.RequestCtx,
 			if err = cli.Status().Patch(reqCtx.Ctx, earlierOps, patch); err != nil {
 				return err
 			}
+			metrics.ObserveOpsRequest(earlierOps)
 			opsRes.Recorder.Event(earlierOps, corev1.EventTypeNormal, abortedCondition.Type, abortedCondition.Message)
 			index, _ := GetOpsRecorderFromSlice(opsRequestSlice, earlierOps.Name)
 			if index != -1 {
@@ -31,6 +31,7 @@ import (
 
 	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/metrics"
 	opsutil "github.com/apecloud/kubeblocks/pkg/operations/util"
 )
 
@@ -77,6 +78,8 @@ func DequeueOpsRequestInClusterAnnotation(ctx context.Context, cli client.Client
 			})
 			if err = cli.Status().Patch(ctx, ops, patch); err != nil && apierrors.IsNotFound(err) {
 				return err
+			} else if err == nil {
+				metrics.ObserveOpsRequest(ops)
 			}
 		}
 		// 2. cleanup opsRequest queue
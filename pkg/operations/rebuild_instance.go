@@ -61,11 +61,14 @@ type rebuildInstanceOpsHandler struct{}
 var _ OpsHandler = rebuildInstanceOpsHandler{}
 
 func init() {
+	rebuildHandler := rebuildInstanceOpsHandler{}
 	rebuildInstanceBehaviour := OpsBehaviour{
-		FromClusterPhases: []appsv1.ClusterPhase{appsv1.AbnormalClusterPhase, appsv1.FailedClusterPhase, appsv1.UpdatingClusterPhase},
-		ToClusterPhase:    appsv1.UpdatingClusterPhase,
-		QueueByCluster:    true,
-		OpsHandler:        rebuildInstanceOpsHandler{},
+		FromClusterPhases:        []appsv1.ClusterPhase{appsv1.AbnormalClusterPhase, appsv1.FailedClusterPhase, appsv1.UpdatingClusterPhase},
+		ToClusterPhase:           appsv1.UpdatingClusterPhase,
+		QueueByCluster:           true,
+		ChangedByReconcileAction: true,
+		OpsHandler:               rebuildHandler,
+		CancelFunc:               rebuildHandler.Cancel,
 	}
 	opsMgr := GetOpsManager()
 	opsMgr.RegisterOps(opsv1alpha1.RebuildInstanceType, rebuildInstanceBehaviour)
@@ -142,6 +145,11 @@ func (r rebuildInstanceOpsHandler) validateRebuildInstanceWithHScale(reqCtx intc
 		"may you can rebuild instances in place with backup by set 'inPlace' to 'true'.")
 }
 
+// Cancel the instances being rebuilt can not be interrupted safely, which may lose the data of the instances.
+func (r rebuildInstanceOpsHandler) Cancel(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	return intctrlutil.NewErrorf(intctrlutil.ErrorIgnoreCancel, "does not support cancellation of rebuilding instances.")
+}
+
 func (r rebuildInstanceOpsHandler) SaveLastConfiguration(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
 	compOpsHelper := newComponentOpsHelper(opsRes.OpsRequest.Spec.RebuildFrom)
 	getLastComponentInfo := func(compSpec appsv1.ClusterComponentSpec, comOps ComponentOpsInterface) opsv1alpha1.LastComponentConfiguration {
@@ -21,14 +21,18 @@ package operations
 
 import (
 	"fmt"
+	"slices"
 	"time"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
 	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
 	parametersv1alpha1 "github.com/apecloud/kubeblocks/apis/parameters/v1alpha1"
+	cfgcm "github.com/apecloud/kubeblocks/pkg/configuration/config_manager"
+	cfgcore "github.com/apecloud/kubeblocks/pkg/configuration/core"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/builder"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
@@ -46,23 +50,118 @@ func init() {
 		// TODO: add cluster reconcile Reconfiguring phase.
 		ToClusterPhase: appsv1.UpdatingClusterPhase,
 		QueueByCluster: true,
+		DisruptiveFunc: reAction.isDisruptive,
 		OpsHandler:     &reAction,
+		CancelFunc:     reAction.Cancel,
 	}
 	opsManager.RegisterOps(opsv1alpha1.ReconfiguringType, reconfigureBehaviour)
 }
 
 var noRequeueAfter time.Duration = 0
 
+// isDisruptive checks whether the reconfiguring restarts the instances, which is not the case
+// if all the parameters are dynamic and can be reloaded.
+func (r *reconfigureAction) isDisruptive(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (bool, error) {
+	for _, reconfigure := range opsRes.OpsRequest.Spec.Reconfigures {
+		compSpec := getComponentSpecOrShardingTemplate(opsRes.Cluster, reconfigure.ComponentName)
+		if compSpec == nil || len(compSpec.ComponentDef) == 0 {
+			return true, nil
+		}
+		cmpd := &appsv1.ComponentDefinition{}
+		if err := cli.Get(reqCtx.Ctx, client.ObjectKey{Name: compSpec.ComponentDef}, cmpd); err != nil {
+			return false, err
+		}
+		_, paramsDefs, err := intctrlutil.ResolveCmpdParametersDefs(reqCtx.Ctx, cli, cmpd)
+		if err != nil {
+			return false, err
+		}
+		for _, param := range reconfigure.Parameters {
+			if !isDynamicReloadParameter(param.Key, paramsDefs) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// isDynamicReloadParameter checks whether the parameter is dynamic and can be reloaded without restarting.
+func isDynamicReloadParameter(paramName string, paramsDefs []*parametersv1alpha1.ParametersDefinition) bool {
+	dynamic := false
+	for _, paramsDef := range paramsDefs {
+		spec := &paramsDef.Spec
+		if slices.Contains(spec.StaticParameters, paramName) || slices.Contains(spec.ImmutableParameters, paramName) {
+			return false
+		}
+		if cfgcm.IsSupportReload(spec.ReloadAction) && cfgcore.IsDynamicParameter(paramName, spec) {
+			dynamic = true
+		}
+	}
+	return dynamic
+}
+
 // ActionStartedCondition the started condition when handle the reconfiguring request.
 func (r *reconfigureAction) ActionStartedCondition(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (*metav1.Condition, error) {
 	return opsv1alpha1.NewReconfigureCondition(opsRes.OpsRequest), nil
 }
 
+// SaveLastConfiguration records the current values of the parameters to be updated, which will be re-applied when canceling.
 func (r *reconfigureAction) SaveLastConfiguration(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	lastConfiguration := &opsRes.OpsRequest.Status.LastConfiguration
+	lastConfiguration.Components = map[string]opsv1alpha1.LastComponentConfiguration{}
+	for _, reconfigure := range opsRes.OpsRequest.Spec.Reconfigures {
+		if len(reconfigure.Parameters) == 0 {
+			continue
+		}
+		compParameter := &parametersv1alpha1.ComponentParameter{}
+		compParameterKey := client.ObjectKey{
+			Namespace: opsRes.Cluster.Namespace,
+			Name:      cfgcore.GenerateComponentConfigurationName(opsRes.Cluster.Name, reconfigure.ComponentName),
+		}
+		if err := cli.Get(reqCtx.Ctx, compParameterKey, compParameter); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+		lastParameters := make([]opsv1alpha1.ParameterPair, 0, len(reconfigure.Parameters))
+		for _, param := range reconfigure.Parameters {
+			lastParameters = append(lastParameters, opsv1alpha1.ParameterPair{
+				Key:   param.Key,
+				Value: r.getParameterValue(compParameter, param.Key),
+			})
+		}
+		lastConfiguration.Components[reconfigure.ComponentName] = opsv1alpha1.LastComponentConfiguration{
+			Parameters: lastParameters,
+		}
+	}
+	return nil
+}
+
+// Cancel deletes the Parameter of the opsRequest to stop further updates,
+// and re-applies the last values of the parameters with a rollback Parameter.
+func (r *reconfigureAction) Cancel(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	parameter := &parametersv1alpha1.Parameter{}
+	if err := cli.Get(reqCtx.Ctx, client.ObjectKeyFromObject(opsRes.OpsRequest), parameter); err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	} else if err = cli.Delete(reqCtx.Ctx, parameter); client.IgnoreNotFound(err) != nil {
+		return err
+	}
+	rollbackParameter := buildReconfigureRollbackParameter(opsRes.OpsRequest)
+	if rollbackParameter == nil {
+		return nil
+	}
+	if err := intctrlutil.SetControllerReference(opsRes.OpsRequest, rollbackParameter); err != nil {
+		return err
+	}
+	if err := cli.Create(reqCtx.Ctx, rollbackParameter); err != nil && !apierrors.IsAlreadyExists(err) {
+		return err
+	}
 	return nil
 }
 
 func (r *reconfigureAction) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli client.Client, resource *OpsResource) (opsv1alpha1.OpsPhase, time.Duration, error) {
+	if resource.OpsRequest.Status.Phase == opsv1alpha1.OpsCancellingPhase {
+		return r.reconcileRollback(reqCtx, cli, resource)
+	}
 
 	var parameters = parametersv1alpha1.Parameter{}
 	if err := cli.Get(reqCtx.Ctx, client.ObjectKeyFromObject(resource.OpsRequest), &parameters); err != nil {
@@ -81,6 +180,39 @@ func (r *reconfigureAction) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli c
 	return syncReconfigureForOps(reqCtx, cli, resource, opsDeepCopy, opsv1alpha1.OpsFailedPhase)
 }
 
+// reconcileRollback checks the rollback Parameter, the phase will be transformed to "Cancelled" by the OpsManager
+// once the rollback Parameter is finished.
+func (r *reconfigureAction) reconcileRollback(reqCtx intctrlutil.RequestCtx, cli client.Client, resource *OpsResource) (opsv1alpha1.OpsPhase, time.Duration, error) {
+	var parameters = parametersv1alpha1.Parameter{}
+	rollbackKey := client.ObjectKey{Namespace: resource.OpsRequest.Namespace, Name: getReconfigureRollbackParameterName(resource.OpsRequest)}
+	if err := cli.Get(reqCtx.Ctx, rollbackKey, &parameters); err != nil {
+		if apierrors.IsNotFound(err) {
+			// no parameters need to be rolled back.
+			return opsv1alpha1.OpsSucceedPhase, noRequeueAfter, nil
+		}
+		return "", noRequeueAfter, err
+	}
+	switch {
+	case !intctrlutil.IsParameterFinished(parameters.Status.Phase):
+		return opsv1alpha1.OpsRunningPhase, noRequeueAfter, nil
+	case parameters.Status.Phase == parametersv1alpha1.CFinishedPhase:
+		return opsv1alpha1.OpsSucceedPhase, noRequeueAfter, nil
+	default:
+		return opsv1alpha1.OpsFailedPhase, noRequeueAfter, nil
+	}
+}
+
+func (r *reconfigureAction) getParameterValue(compParameter *parametersv1alpha1.ComponentParameter, key string) *string {
+	for _, item := range compParameter.Spec.ConfigItemDetails {
+		for _, fileParams := range item.ConfigFileParams {
+			if value, ok := fileParams.Parameters[key]; ok {
+				return value
+			}
+		}
+	}
+	return nil
+}
+
 func syncReconfigureForOps(reqCtx intctrlutil.RequestCtx, cli client.Client, resource *OpsResource, opsDeepCopy *opsv1alpha1.OpsRequest, phase opsv1alpha1.OpsPhase) (opsv1alpha1.OpsPhase, time.Duration, error) {
 	if err := PatchOpsStatusWithOpsDeepCopy(reqCtx.Ctx, cli, resource, opsDeepCopy, phase); err != nil {
 		return "", noRequeueAfter, err
@@ -124,3 +256,29 @@ func buildReconfigureParameter(ops *opsv1alpha1.OpsRequest) *parametersv1alpha1.
 	}
 	return paramBuilder.GetObject()
 }
+
+func getReconfigureRollbackParameterName(ops *opsv1alpha1.OpsRequest) string {
+	return fmt.Sprintf("%s-rollback", ops.GetName())
+}
+
+// buildReconfigureRollbackParameter builds the Parameter with the last values of the parameters,
+// returns nil if there is no parameter to roll back.
+func buildReconfigureRollbackParameter(ops *opsv1alpha1.OpsRequest) *parametersv1alpha1.Parameter {
+	paramBuilder := builder.NewParameterBuilder(ops.Namespace, getReconfigureRollbackParameterName(ops)).
+		AddLabels(constant.AppInstanceLabelKey, ops.Spec.ClusterName).
+		AddLabels(constant.OpsRequestNameLabelKey, ops.Name).
+		ClusterRef(ops.Spec.ClusterName)
+	var rollback bool
+	for _, reconfigure := range ops.Spec.Reconfigures {
+		lastConfig, ok := ops.Status.LastConfiguration.Components[reconfigure.ComponentName]
+		if !ok || len(lastConfig.Parameters) == 0 {
+			continue
+		}
+		paramBuilder.SetComponentParameters(reconfigure.ComponentName, intctrlutil.TransformComponentParameters(lastConfig.Parameters))
+		rollback = true
+	}
+	if !rollback {
+		return nil
+	}
+	return paramBuilder.GetObject()
+}
@@ -47,9 +47,11 @@ var _ OpsHandler = RestoreOpsHandler{}
 func init() {
 	// register restore operation, it will create a new cluster
 	// so set IsClusterCreationEnabled to true
+	restoreHandler := RestoreOpsHandler{}
 	restoreBehaviour := OpsBehaviour{
-		OpsHandler:        RestoreOpsHandler{},
+		OpsHandler:        restoreHandler,
 		IsClusterCreation: true,
+		CancelFunc:        restoreHandler.Cancel,
 	}
 
 	opsMgr := GetOpsManager()
@@ -136,6 +138,13 @@ func (r RestoreOpsHandler) SaveLastConfiguration(reqCtx intctrlutil.RequestCtx,
 	return nil
 }
 
+// Cancel the restore opsRequest can not be canceled, as it is owned by the restored cluster,
+// deleting the restored cluster can be used to abort the restore.
+func (r RestoreOpsHandler) Cancel(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	return intctrlutil.NewErrorf(intctrlutil.ErrorIgnoreCancel,
+		`does not support cancellation of restore, you can delete the restored cluster "%s" instead`, opsRes.OpsRequest.Spec.GetClusterName())
+}
+
 func (r RestoreOpsHandler) restoreClusterFromBackup(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRequest *opsv1alpha1.OpsRequest) (*appsv1.Cluster, error) {
 	restoreSpec := opsRequest.Spec.GetRestore()
 	if restoreSpec == nil {
@@ -232,61 +241,16 @@ func (r RestoreOpsHandler) getClusterObjFromBackup(backup *dpv1alpha1.Backup, op
 		cluster.Spec.ComponentSpecs[i].OfflineInstances = nil
 	}
 	r.rebuildShardAccountSecrets(cluster)
-	r.normalizeSchedulePolicy(cluster, cluster.Spec.SchedulingPolicy)
+	restore.NormalizeSchedulingPolicy(cluster.Name, cluster.Spec.SchedulingPolicy)
 	for i := range cluster.Spec.ComponentSpecs {
-		r.normalizeSchedulePolicy(cluster, cluster.Spec.ComponentSpecs[i].SchedulingPolicy)
+		restore.NormalizeSchedulingPolicy(cluster.Name, cluster.Spec.ComponentSpecs[i].SchedulingPolicy)
 	}
 	for i := range cluster.Spec.Shardings {
-		r.normalizeSchedulePolicy(cluster, cluster.Spec.Shardings[i].Template.SchedulingPolicy)
+		restore.NormalizeSchedulingPolicy(cluster.Name, cluster.Spec.Shardings[i].Template.SchedulingPolicy)
 	}
 	return cluster, nil
 }
 
-// normalizeSchedulePolicy normalizes the schedule policy of the new cluster.
-func (r RestoreOpsHandler) normalizeSchedulePolicy(cluster *appsv1.Cluster, schedulePolicy *appsv1.SchedulingPolicy) {
-	if schedulePolicy == nil {
-		return
-	}
-	updateLabelSelector := func(selector *metav1.LabelSelector) {
-		if selector == nil {
-			return
-		}
-		if _, ok := selector.MatchLabels[constant.AppInstanceLabelKey]; ok {
-			selector.MatchLabels[constant.AppInstanceLabelKey] = cluster.Name
-		}
-		for i := range selector.MatchExpressions {
-			matchExpression := &selector.MatchExpressions[i]
-			if matchExpression.Key == constant.AppInstanceLabelKey {
-				matchExpression.Values = []string{cluster.Name}
-			}
-		}
-	}
-	for i := range schedulePolicy.TopologySpreadConstraints {
-		updateLabelSelector(schedulePolicy.TopologySpreadConstraints[i].LabelSelector)
-	}
-	if schedulePolicy.Affinity == nil {
-		return
-	}
-	updatePodAffinityTerm := func(pats []corev1.PodAffinityTerm, wpats []corev1.WeightedPodAffinityTerm) {
-		for i := range pats {
-			podAffinityTerm := &pats[i]
-			updateLabelSelector(podAffinityTerm.LabelSelector)
-		}
-		for i := range wpats {
-			wpat := &wpats[i]
-			updateLabelSelector(wpat.PodAffinityTerm.LabelSelector)
-		}
-	}
-	if schedulePolicy.Affinity.PodAntiAffinity != nil {
-		updatePodAffinityTerm(schedulePolicy.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution,
-			schedulePolicy.Affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution)
-	}
-	if schedulePolicy.Affinity.PodAffinity != nil {
-		updatePodAffinityTerm(schedulePolicy.Affinity.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution,
-			schedulePolicy.Affinity.PodAffinity.PreferredDuringSchedulingIgnoredDuringExecution)
-	}
-}
-
 func (r RestoreOpsHandler) rebuildShardAccountSecrets(cluster *appsv1.Cluster) {
 	if len(cluster.Spec.Shardings) == 0 {
 		return
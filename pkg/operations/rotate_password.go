@@ -0,0 +1,238 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+type rotatePasswordOpsHandler struct{}
+
+var _ OpsHandler = rotatePasswordOpsHandler{}
+
+func init() {
+	// ToClusterPhase is not defined, because 'rotatePassword' does not affect the cluster phase.
+	rotatePasswordHandler := rotatePasswordOpsHandler{}
+	rotatePasswordBehaviour := OpsBehaviour{
+		FromClusterPhases: []appsv1.ClusterPhase{appsv1.RunningClusterPhase,
+			appsv1.UpdatingClusterPhase, appsv1.AbnormalClusterPhase},
+		OpsHandler: rotatePasswordHandler,
+		CancelFunc: rotatePasswordHandler.Cancel,
+	}
+
+	opsMgr := GetOpsManager()
+	opsMgr.RegisterOps(opsv1alpha1.RotatePasswordType, rotatePasswordBehaviour)
+}
+
+// ActionStartedCondition the started condition when handling the rotatePassword request.
+func (r rotatePasswordOpsHandler) ActionStartedCondition(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (*metav1.Condition, error) {
+	return opsv1alpha1.NewPasswordRotatingCondition(opsRes.OpsRequest), nil
+}
+
+// Action requests the components to rotate the passwords of the system accounts,
+// the rotation is performed by the component controller.
+func (r rotatePasswordOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	if opsRes.OpsRequest.Status.StartTimestamp.IsZero() {
+		return fmt.Errorf("status.startTimestamp can not be null")
+	}
+	requestTime := opsRes.OpsRequest.Status.StartTimestamp.UTC().Format(time.RFC3339)
+	for _, rotate := range opsRes.OpsRequest.Spec.RotatePasswordList {
+		comps, err := r.listComponents(reqCtx, cli, opsRes, rotate.ComponentName)
+		if err != nil {
+			return err
+		}
+		for i := range comps {
+			if err = r.requestRotation(reqCtx, cli, &comps[i], rotate.Accounts, requestTime); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Cancel withdraws the rotation requests of the accounts whose rotations have not started yet,
+// the passwords that have been rotated can not be restored.
+func (r rotatePasswordOpsHandler) Cancel(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	startTime := opsRes.OpsRequest.Status.StartTimestamp.Truncate(time.Second)
+	requestTime := opsRes.OpsRequest.Status.StartTimestamp.UTC().Format(time.RFC3339)
+	withdrawn := false
+	for _, rotate := range opsRes.OpsRequest.Spec.RotatePasswordList {
+		comps, err := r.listComponents(reqCtx, cli, opsRes, rotate.ComponentName)
+		if err != nil {
+			return err
+		}
+		for i := range comps {
+			var accounts []string
+			for _, account := range rotate.Accounts {
+				if r.rotationSince(&comps[i], account, startTime) == nil {
+					accounts = append(accounts, account)
+				}
+			}
+			if len(accounts) == 0 {
+				continue
+			}
+			if err = r.withdrawRotation(reqCtx, cli, &comps[i], accounts, requestTime); err != nil {
+				return err
+			}
+			withdrawn = true
+		}
+	}
+	if !withdrawn {
+		return intctrlutil.NewErrorf(intctrlutil.ErrorIgnoreCancel, "the passwords have been rotated")
+	}
+	return nil
+}
+
+// ReconcileAction checks the password rotations of the components.
+// It returns OpsSucceedPhase when the passwords of all the accounts have been rotated and the old ones have been revoked,
+// and OpsFailedPhase if any rotation is failed.
+func (r rotatePasswordOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (opsv1alpha1.OpsPhase, time.Duration, error) {
+	if opsRes.OpsRequest.Status.Phase == opsv1alpha1.OpsCancellingPhase {
+		// the rotations not started have been withdrawn, and the started ones can not be canceled.
+		return opsv1alpha1.OpsSucceedPhase, 0, nil
+	}
+	startTime := opsRes.OpsRequest.Status.StartTimestamp.Truncate(time.Second)
+	completed := true
+	for _, rotate := range opsRes.OpsRequest.Spec.RotatePasswordList {
+		comps, err := r.listComponents(reqCtx, cli, opsRes, rotate.ComponentName)
+		if err != nil {
+			return opsv1alpha1.OpsRunningPhase, 0, err
+		}
+		if len(comps) == 0 {
+			return opsv1alpha1.OpsFailedPhase, 0, fmt.Errorf("component %s not found", rotate.ComponentName)
+		}
+		for _, comp := range comps {
+			for _, account := range rotate.Accounts {
+				rotation := r.rotationSince(&comp, account, startTime)
+				switch {
+				case rotation == nil:
+					completed = false
+				case rotation.Phase == appsv1.FailedPasswordRotationPhase:
+					return opsv1alpha1.OpsFailedPhase, 0, intctrlutil.NewFatalError(
+						fmt.Sprintf("failed to rotate the password of account %s in component %s: %s", account, comp.Name, rotation.Message))
+				case rotation.Phase != appsv1.CompletedPasswordRotationPhase:
+					// the old password is still valid until it is revoked
+					completed = false
+				}
+			}
+		}
+	}
+	if completed {
+		return opsv1alpha1.OpsSucceedPhase, 0, nil
+	}
+	return opsv1alpha1.OpsRunningPhase, 5 * time.Second, nil
+}
+
+// SaveLastConfiguration records last configuration to the OpsRequest.status.lastConfiguration
+func (r rotatePasswordOpsHandler) SaveLastConfiguration(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	return nil
+}
+
+// listComponents lists the components of the component or sharding.
+func (r rotatePasswordOpsHandler) listComponents(reqCtx intctrlutil.RequestCtx, cli client.Client,
+	opsRes *OpsResource, compName string) ([]appsv1.Component, error) {
+	compList := &appsv1.ComponentList{}
+	if err := cli.List(reqCtx.Ctx, compList, client.InNamespace(opsRes.Cluster.Namespace),
+		client.MatchingLabels{constant.AppInstanceLabelKey: opsRes.Cluster.Name}); err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(compList.Items, func(comp appsv1.Component) bool {
+		return comp.Name != component.FullName(opsRes.Cluster.Name, compName) &&
+			comp.Labels[constant.KBAppShardingNameLabelKey] != compName
+	}), nil
+}
+
+func (r rotatePasswordOpsHandler) requestRotation(reqCtx intctrlutil.RequestCtx, cli client.Client,
+	comp *appsv1.Component, accounts []string, requestTime string) error {
+	requests := map[string]string{}
+	if value := comp.Annotations[constant.SystemAccountRotationAnnotationKey]; len(value) > 0 {
+		if err := json.Unmarshal([]byte(value), &requests); err != nil {
+			return err
+		}
+	}
+	for _, account := range accounts {
+		requests[account] = requestTime
+	}
+	value, err := json.Marshal(requests)
+	if err != nil {
+		return err
+	}
+	if comp.Annotations[constant.SystemAccountRotationAnnotationKey] == string(value) {
+		return nil
+	}
+	patch := client.MergeFrom(comp.DeepCopy())
+	if comp.Annotations == nil {
+		comp.Annotations = map[string]string{}
+	}
+	comp.Annotations[constant.SystemAccountRotationAnnotationKey] = string(value)
+	return cli.Patch(reqCtx.Ctx, comp, patch)
+}
+
+// withdrawRotation removes the rotation requests of the accounts made by the opsRequest.
+func (r rotatePasswordOpsHandler) withdrawRotation(reqCtx intctrlutil.RequestCtx, cli client.Client,
+	comp *appsv1.Component, accounts []string, requestTime string) error {
+	value := comp.Annotations[constant.SystemAccountRotationAnnotationKey]
+	if len(value) == 0 {
+		return nil
+	}
+	requests := map[string]string{}
+	if err := json.Unmarshal([]byte(value), &requests); err != nil {
+		return err
+	}
+	for _, account := range accounts {
+		// the account may be requested by another opsRequest later
+		if requests[account] == requestTime {
+			delete(requests, account)
+		}
+	}
+	newValue, err := json.Marshal(requests)
+	if err != nil {
+		return err
+	}
+	if value == string(newValue) {
+		return nil
+	}
+	patch := client.MergeFrom(comp.DeepCopy())
+	comp.Annotations[constant.SystemAccountRotationAnnotationKey] = string(newValue)
+	return cli.Patch(reqCtx.Ctx, comp, patch)
+}
+
+// rotationSince returns the latest rotation of the account which started after the start time.
+func (r rotatePasswordOpsHandler) rotationSince(comp *appsv1.Component, account string, startTime time.Time) *appsv1.PasswordRotationStatus {
+	rotations := comp.Status.PasswordRotations
+	for i := len(rotations) - 1; i >= 0; i-- {
+		if rotations[i].Account == account && !rotations[i].StartTime.Time.Before(startTime) {
+			return &rotations[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+func newRotatePasswordTestResource(startTime time.Time) (client.Client, *appsv1.Component, *OpsResource) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+
+	cluster := &appsv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "mycluster"},
+	}
+	comp := &appsv1.Component{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "mycluster-mysql",
+			Labels:    map[string]string{constant.AppInstanceLabelKey: cluster.Name},
+		},
+	}
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cluster, comp).WithStatusSubresource(comp).Build()
+	opsRes := &OpsResource{
+		Cluster: cluster,
+		OpsRequest: &opsv1alpha1.OpsRequest{
+			Spec: opsv1alpha1.OpsRequestSpec{
+				ClusterName: cluster.Name,
+				Type:        opsv1alpha1.RotatePasswordType,
+				SpecificOpsRequest: opsv1alpha1.SpecificOpsRequest{
+					RotatePasswordList: []opsv1alpha1.RotatePassword{
+						{ComponentOps: opsv1alpha1.ComponentOps{ComponentName: "mysql"}, Accounts: []string{"root", "admin"}},
+					},
+				},
+			},
+			Status: opsv1alpha1.OpsRequestStatus{
+				Phase:          opsv1alpha1.OpsRunningPhase,
+				StartTimestamp: metav1.NewTime(startTime),
+			},
+		},
+	}
+	return cli, comp, opsRes
+}
+
+func rotationRequests(t *testing.T, cli client.Client, comp *appsv1.Component) map[string]string {
+	if err := cli.Get(context.Background(), client.ObjectKeyFromObject(comp), comp); err != nil {
+		t.Fatal(err)
+	}
+	requests := map[string]string{}
+	if value := comp.Annotations[constant.SystemAccountRotationAnnotationKey]; len(value) > 0 {
+		if err := json.Unmarshal([]byte(value), &requests); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return requests
+}
+
+func TestRotatePasswordAction(t *testing.T) {
+	startTime := time.Now().Truncate(time.Second)
+	cli, comp, opsRes := newRotatePasswordTestResource(startTime)
+	reqCtx := intctrlutil.RequestCtx{Ctx: context.Background(), Log: logr.Discard()}
+	handler := rotatePasswordOpsHandler{}
+
+	if err := handler.Action(reqCtx, cli, opsRes); err != nil {
+		t.Fatal(err)
+	}
+	requests := rotationRequests(t, cli, comp)
+	requestTime := startTime.UTC().Format(time.RFC3339)
+	if len(requests) != 2 || requests["root"] != requestTime || requests["admin"] != requestTime {
+		t.Fatalf("expect the rotations of the accounts to be requested, got %v", requests)
+	}
+
+	phase, _, err := handler.ReconcileAction(reqCtx, cli, opsRes)
+	if err != nil || phase != opsv1alpha1.OpsRunningPhase {
+		t.Errorf("expect to wait for the rotations, got %s %v", phase, err)
+	}
+
+	// the rotations before the opsRequest are ignored
+	comp.Status.PasswordRotations = []appsv1.PasswordRotationStatus{
+		{Account: "root", Phase: appsv1.CompletedPasswordRotationPhase, StartTime: metav1.NewTime(startTime.Add(-time.Hour))},
+		{Account: "admin", Phase: appsv1.RotatingPasswordRotationPhase, StartTime: metav1.NewTime(startTime.Add(time.Second))},
+	}
+	if err = cli.Status().Update(reqCtx.Ctx, comp); err != nil {
+		t.Fatal(err)
+	}
+	phase, _, err = handler.ReconcileAction(reqCtx, cli, opsRes)
+	if err != nil || phase != opsv1alpha1.OpsRunningPhase {
+		t.Errorf("expect to wait for the rotation of root, got %s %v", phase, err)
+	}
+
+	comp.Status.PasswordRotations = append(comp.Status.PasswordRotations, appsv1.PasswordRotationStatus{
+		Account: "root", Phase: appsv1.RotatingPasswordRotationPhase, StartTime: metav1.NewTime(startTime.Add(time.Second)),
+	})
+	if err = cli.Status().Update(reqCtx.Ctx, comp); err != nil {
+		t.Fatal(err)
+	}
+	phase, _, err = handler.ReconcileAction(reqCtx, cli, opsRes)
+	if err != nil || phase != opsv1alpha1.OpsRunningPhase {
+		t.Errorf("expect to wait for the old passwords to be revoked, got %s %v", phase, err)
+	}
+
+	comp.Status.PasswordRotations[1].Phase = appsv1.CompletedPasswordRotationPhase
+	comp.Status.PasswordRotations[2].Phase = appsv1.CompletedPasswordRotationPhase
+	if err = cli.Status().Update(reqCtx.Ctx, comp); err != nil {
+		t.Fatal(err)
+	}
+	phase, _, err = handler.ReconcileAction(reqCtx, cli, opsRes)
+	if err != nil || phase != opsv1alpha1.OpsSucceedPhase {
+		t.Errorf("expect the opsRequest to succeed, got %s %v", phase, err)
+	}
+
+	comp.Status.PasswordRotations[2].Phase = appsv1.FailedPasswordRotationPhase
+	if err = cli.Status().Update(reqCtx.Ctx, comp); err != nil {
+		t.Fatal(err)
+	}
+	phase, _, err = handler.ReconcileAction(reqCtx, cli, opsRes)
+	if err == nil || phase != opsv1alpha1.OpsFailedPhase {
+		t.Errorf("expect the opsRequest to fail, got %s %v", phase, err)
+	}
+}
+
+func TestRotatePasswordCancel(t *testing.T) {
+	startTime := time.Now().Truncate(time.Second)
+	cli, comp, opsRes := newRotatePasswordTestResource(startTime)
+	reqCtx := intctrlutil.RequestCtx{Ctx: context.Background(), Log: logr.Discard()}
+	handler := rotatePasswordOpsHandler{}
+
+	if err := handler.Action(reqCtx, cli, opsRes); err != nil {
+		t.Fatal(err)
+	}
+	if err := cli.Get(reqCtx.Ctx, client.ObjectKeyFromObject(comp), comp); err != nil {
+		t.Fatal(err)
+	}
+	// the rotation of root has started
+	comp.Status.PasswordRotations = []appsv1.PasswordRotationStatus{
+		{Account: "root", Phase: appsv1.RotatingPasswordRotationPhase, StartTime: metav1.NewTime(startTime.Add(time.Second))},
+	}
+	if err := cli.Status().Update(reqCtx.Ctx, comp); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := handler.Cancel(reqCtx, cli, opsRes); err != nil {
+		t.Fatal(err)
+	}
+	requests := rotationRequests(t, cli, comp)
+	if _, ok := requests["admin"]; ok || len(requests) != 1 {
+		t.Errorf("expect the rotation of admin to be withdrawn only, got %v", requests)
+	}
+
+	opsRes.OpsRequest.Status.Phase = opsv1alpha1.OpsCancellingPhase
+	phase, _, err := handler.ReconcileAction(reqCtx, cli, opsRes)
+	if err != nil || phase != opsv1alpha1.OpsSucceedPhase {
+		t.Errorf("expect the cancellation to complete, got %s %v", phase, err)
+	}
+
+	// all the rotations have started
+	comp.Status.PasswordRotations = append(comp.Status.PasswordRotations, appsv1.PasswordRotationStatus{
+		Account: "admin", Phase: appsv1.RotatingPasswordRotationPhase, StartTime: metav1.NewTime(startTime.Add(time.Second)),
+	})
+	if err = cli.Status().Update(reqCtx.Ctx, comp); err != nil {
+		t.Fatal(err)
+	}
+	err = handler.Cancel(reqCtx, cli, opsRes)
+	if !intctrlutil.IsTargetError(err, intctrlutil.ErrorIgnoreCancel) {
+		t.Errorf("expect the cancellation to be ignored, got %v", err)
+	}
+}
@@ -27,7 +27,6 @@ import (
 
 	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
 	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
-	intctrlcomp "github.com/apecloud/kubeblocks/pkg/controller/component"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 )
 
@@ -36,12 +35,14 @@ type StartOpsHandler struct{}
 var _ OpsHandler = StartOpsHandler{}
 
 func init() {
+	startHandler := StartOpsHandler{}
 	startBehaviour := OpsBehaviour{
 		FromClusterPhases: append(appsv1.GetClusterUpRunningPhases(), appsv1.UpdatingClusterPhase,
 			appsv1.StoppedClusterPhase, appsv1.StoppingClusterPhase),
 		ToClusterPhase: appsv1.UpdatingClusterPhase,
 		QueueByCluster: true,
-		OpsHandler:     StartOpsHandler{},
+		OpsHandler:     startHandler,
+		CancelFunc:     startHandler.Cancel,
 	}
 
 	opsMgr := GetOpsManager()
@@ -96,10 +97,7 @@ func (start StartOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli
 		opsRes *OpsResource,
 		pgRes *progressResource,
 		compStatus *opsv1alpha1.OpsRequestComponentStatus) (int32, int32, error) {
-		var err error
-		pgRes.createdPodSet, err = intctrlcomp.GenerateAllPodNamesToSet(pgRes.clusterComponent.Replicas, pgRes.clusterComponent.Instances,
-			pgRes.clusterComponent.OfflineInstances, opsRes.Cluster.Name, pgRes.fullComponentName)
-		if err != nil {
+		if err := setPodSetForStopOrStart(opsRes, pgRes, false); err != nil {
 			return 0, 0, err
 		}
 		return handleComponentProgressForScalingReplicas(reqCtx, cli, opsRes, pgRes, compStatus)
@@ -110,5 +108,11 @@ func (start StartOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli
 
 // SaveLastConfiguration records last configuration to the OpsRequest.status.lastConfiguration
 func (start StartOpsHandler) SaveLastConfiguration(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	saveLastStopConfigurations(opsRes, opsRes.OpsRequest.Spec.StartList)
 	return nil
 }
+
+// Cancel reverts the `stop` of the components, the started pods will be deleted.
+func (start StartOpsHandler) Cancel(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	return cancelStopOrStart(reqCtx, cli, opsRes)
+}
@@ -38,11 +38,13 @@ type StopOpsHandler struct{}
 var _ OpsHandler = StopOpsHandler{}
 
 func init() {
+	stopHandler := StopOpsHandler{}
 	stopBehaviour := OpsBehaviour{
 		FromClusterPhases: append(appsv1.GetClusterUpRunningPhases(), appsv1.UpdatingClusterPhase),
 		ToClusterPhase:    appsv1.StoppingClusterPhase,
 		QueueByCluster:    true,
-		OpsHandler:        StopOpsHandler{},
+		OpsHandler:        stopHandler,
+		CancelFunc:        stopHandler.Cancel,
 	}
 
 	opsMgr := GetOpsManager()
@@ -116,10 +118,7 @@ func (stop StopOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli cl
 		opsRes *OpsResource,
 		pgRes *progressResource,
 		compStatus *opsv1alpha1.OpsRequestComponentStatus) (int32, int32, error) {
-		var err error
-		pgRes.deletedPodSet, err = intctrlcomp.GenerateAllPodNamesToSet(pgRes.clusterComponent.Replicas, pgRes.clusterComponent.Instances,
-			pgRes.clusterComponent.OfflineInstances, opsRes.Cluster.Name, pgRes.fullComponentName)
-		if err != nil {
+		if err := setPodSetForStopOrStart(opsRes, pgRes, true); err != nil {
 			return 0, 0, err
 		}
 		expectProgressCount, completedCount, err := handleComponentProgressForScalingReplicas(reqCtx, cli, opsRes, pgRes, compStatus)
@@ -134,5 +133,59 @@ func (stop StopOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli cl
 
 // SaveLastConfiguration records last configuration to the OpsRequest.status.lastConfiguration
 func (stop StopOpsHandler) SaveLastConfiguration(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	saveLastStopConfigurations(opsRes, opsRes.OpsRequest.Spec.StopList)
+	return nil
+}
+
+// Cancel reverts the `stop` of the components, the stopped pods will be recreated.
+func (stop StopOpsHandler) Cancel(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	return cancelStopOrStart(reqCtx, cli, opsRes)
+}
+
+// saveLastStopConfigurations records the `stop` of the components to be stopped or started.
+// all the components are involved if the compOpsList is empty.
+func saveLastStopConfigurations(opsRes *OpsResource, compOpsList []opsv1alpha1.ComponentOps) {
+	compOpsHelper := newComponentOpsHelper(compOpsList)
+	lastConfiguration := &opsRes.OpsRequest.Status.LastConfiguration
+	lastConfiguration.Components = map[string]opsv1alpha1.LastComponentConfiguration{}
+	setLastStop := func(compSpec appsv1.ClusterComponentSpec, clusterCompName string) {
+		if _, ok := compOpsHelper.componentOpsSet[clusterCompName]; len(compOpsList) > 0 && !ok {
+			return
+		}
+		lastConfiguration.Components[clusterCompName] = opsv1alpha1.LastComponentConfiguration{Stop: compSpec.Stop}
+	}
+	for _, v := range opsRes.Cluster.Spec.ComponentSpecs {
+		setLastStop(v, v.Name)
+	}
+	for _, v := range opsRes.Cluster.Spec.Shardings {
+		setLastStop(v.Template, v.Name)
+	}
+}
+
+// cancelStopOrStart reverts the `stop` of the components to the last configuration.
+func cancelStopOrStart(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	var compOpsHelper componentOpsHelper
+	return compOpsHelper.cancelComponentOps(reqCtx.Ctx, cli, opsRes, func(lastConfig *opsv1alpha1.LastComponentConfiguration, comp *appsv1.ClusterComponentSpec) {
+		comp.Stop = lastConfig.Stop
+	})
+}
+
+// setPodSetForStopOrStart sets the pods to be deleted or created for the Stop/Start opsRequest.
+// when canceling, the pods will be deleted if the component was stopped before the opsRequest, otherwise created.
+func setPodSetForStopOrStart(opsRes *OpsResource, pgRes *progressResource, stop bool) error {
+	podSet, err := intctrlcomp.GenerateAllPodNamesToSet(pgRes.clusterComponent.Replicas, pgRes.clusterComponent.Instances,
+		pgRes.clusterComponent.OfflineInstances, opsRes.Cluster.Name, pgRes.fullComponentName)
+	if err != nil {
+		return err
+	}
+	if opsRes.OpsRequest.Status.Phase == opsv1alpha1.OpsCancellingPhase {
+		lastConfig := opsRes.OpsRequest.Status.LastConfiguration.Components[pgRes.compOps.GetComponentName()]
+		stop = lastConfig.Stop != nil && *lastConfig.Stop
+	}
+	if stop {
+		pgRes.deletedPodSet = podSet
+	} else {
+		pgRes.createdPodSet = podSet
+	}
 	return nil
 }
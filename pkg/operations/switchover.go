@@ -52,11 +52,17 @@ type switchoverOpsHandler struct{}
 var _ OpsHandler = switchoverOpsHandler{}
 
 func init() {
+	switchoverHandler := switchoverOpsHandler{}
 	switchoverBehaviour := OpsBehaviour{
 		FromClusterPhases: appsv1.GetClusterUpRunningPhases(),
 		ToClusterPhase:    appsv1.UpdatingClusterPhase,
 		QueueByCluster:    true,
-		OpsHandler:        switchoverOpsHandler{},
+		Disruptive:        true,
+		// the switchover is performed by the ReconcileAction directly.
+		PausedOutsideMaintenanceWindow: true,
+		ChangedByReconcileAction:       true,
+		OpsHandler:                     switchoverHandler,
+		CancelFunc:                     switchoverHandler.Cancel,
 	}
 
 	opsMgr := GetOpsManager()
@@ -100,6 +106,12 @@ func (r switchoverOpsHandler) SaveLastConfiguration(reqCtx intctrlutil.RequestCt
 	return nil
 }
 
+// Cancel the switchover which has been performed can not be reverted,
+// the pending switchovers will be skipped and the running ones will be waited for completion.
+func (r switchoverOpsHandler) Cancel(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	return nil
+}
+
 // switchoverPreCheck checks whether the component need switchover.
 func switchoverPreCheck(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource, switchoverList []opsv1alpha1.Switchover) error {
 	var (
@@ -237,6 +249,11 @@ func handleSwitchover(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *
 	}
 	switch progressDetail.Status {
 	case opsv1alpha1.PendingProgressStatus:
+		if opsRequest.Status.Phase == opsv1alpha1.OpsCancellingPhase {
+			// skip the pending switchover after the opsRequest is canceled.
+			*completedCount++
+			return nil
+		}
 		// do switchover
 		if err = doSwitchover(reqCtx.Ctx, cli, synthesizedComp, switchover); err != nil {
 			progressDetail.Status = opsv1alpha1.FailedProgressStatus
@@ -244,6 +261,7 @@ func handleSwitchover(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *
 		} else {
 			progressDetail.Message = "doing switchover"
 			progressDetail.Status = opsv1alpha1.ProcessingProgressStatus
+			setSwitchoverTouchedInstances(opsRequest, switchover)
 		}
 		progressDetail.StartTime = metav1.Now()
 	case opsv1alpha1.ProcessingProgressStatus:
@@ -305,12 +323,25 @@ func setComponentSwitchoverProgressDetails(recorder record.EventRecorder,
 	phase appsv1.ComponentPhase,
 	processDetail opsv1alpha1.ProgressStatusDetail,
 	componentName string) {
-	componentProcessDetails := opsRequest.Status.Components[componentName].ProgressDetails
+	compStatus := opsRequest.Status.Components[componentName]
+	componentProcessDetails := compStatus.ProgressDetails
 	setComponentStatusProgressDetail(recorder, opsRequest, &componentProcessDetails, processDetail)
 	opsRequest.Status.Components[componentName] = opsv1alpha1.OpsRequestComponentStatus{
-		Phase:           phase,
-		ProgressDetails: componentProcessDetails,
+		Phase:            phase,
+		ProgressDetails:  componentProcessDetails,
+		TouchedInstances: compStatus.TouchedInstances,
+	}
+}
+
+// setSwitchoverTouchedInstances records the instance and the candidate of the performed switchover as touched instances.
+func setSwitchoverTouchedInstances(opsRequest *opsv1alpha1.OpsRequest, switchover *opsv1alpha1.Switchover) {
+	compName := switchover.GetComponentName()
+	compStatus := opsRequest.Status.Components[compName]
+	compStatus.TouchedInstances = append(compStatus.TouchedInstances, switchover.InstanceName)
+	if switchover.CandidateName != "" {
+		compStatus.TouchedInstances = append(compStatus.TouchedInstances, switchover.CandidateName)
 	}
+	opsRequest.Status.Components[compName] = compStatus
 }
 
 func getClusterCompSpec(cluster *appsv1.Cluster, clusterCompName string) (*appsv1.ClusterComponentSpec, error) {
@@ -69,6 +69,22 @@ type OpsBehaviour struct {
 	// QueueWithSelf indicates that the operation is queued for execution within opsType scope.
 	QueueBySelf bool
 
+	// Disruptive indicates that the operation disrupts the instances,
+	// it's deferred to the maintenance window of the cluster if one is specified.
+	Disruptive bool
+
+	// DisruptiveFunc decides whether the operation disrupts the instances according to the opsRequest,
+	// it takes precedence over Disruptive if specified.
+	DisruptiveFunc func(reqCtx intctrlutil.RequestCtx, cli client.Client, opsResource *OpsResource) (bool, error)
+
+	// PausedOutsideMaintenanceWindow indicates that the ReconcileAction disrupts the instances itself rather than
+	// through the workloads, so it's not performed outside the maintenance window.
+	PausedOutsideMaintenanceWindow bool
+
+	// ChangedByReconcileAction indicates that the changes are made by the ReconcileAction rather than the Action,
+	// the operation can not be simulated in dry-run.
+	ChangedByReconcileAction bool
+
 	OpsHandler OpsHandler
 }
 
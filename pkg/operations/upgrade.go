@@ -39,12 +39,15 @@ type upgradeOpsHandler struct{}
 var _ OpsHandler = upgradeOpsHandler{}
 
 func init() {
+	upgradeHandler := upgradeOpsHandler{}
 	upgradeBehaviour := OpsBehaviour{
 		// if cluster is Abnormal or Failed, new opsRequest may can repair it.
 		FromClusterPhases: appsv1.GetClusterUpRunningPhases(),
 		ToClusterPhase:    appsv1.UpdatingClusterPhase,
 		QueueByCluster:    true,
-		OpsHandler:        upgradeOpsHandler{},
+		Disruptive:        true,
+		OpsHandler:        upgradeHandler,
+		CancelFunc:        upgradeHandler.Cancel,
 	}
 
 	opsMgr := GetOpsManager()
@@ -134,6 +137,16 @@ func (u upgradeOpsHandler) SaveLastConfiguration(reqCtx intctrlutil.RequestCtx,
 	return nil
 }
 
+// Cancel reverts the componentDefinition and serviceVersion of the components.
+// the pods that have been upgraded will be rolled back to the images of the last configuration.
+func (u upgradeOpsHandler) Cancel(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	compOpsHelper := newComponentOpsHelper(opsRes.OpsRequest.Spec.Upgrade.Components)
+	return compOpsHelper.cancelComponentOps(reqCtx.Ctx, cli, opsRes, func(lastConfig *opsv1alpha1.LastComponentConfiguration, comp *appsv1.ClusterComponentSpec) {
+		comp.ComponentDef = lastConfig.ComponentDefinitionName
+		comp.ServiceVersion = lastConfig.ServiceVersion
+	})
+}
+
 // getComponentDefMapWithUpdatedImages gets the desired componentDefinition map
 // that is updated with the corresponding images of the ComponentDefinition and service version.
 func (u upgradeOpsHandler) getComponentDefMapWithUpdatedImages(reqCtx intctrlutil.RequestCtx,
@@ -45,6 +45,7 @@ func init() {
 		ToClusterPhase:    appsv1.UpdatingClusterPhase,
 		OpsHandler:        vsHandler,
 		QueueByCluster:    true,
+		Disruptive:        true,
 		CancelFunc:        vsHandler.Cancel,
 	}
 
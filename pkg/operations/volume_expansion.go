@@ -348,6 +348,8 @@ func getComponentVCTKey(compoName, vctName string) string {
 	return fmt.Sprintf("%s.%s", compoName, vctName)
 }
 
+const pvcProgressObjectKind = "PVC"
+
 func getPVCProgressObjectKey(pvcName string) string {
-	return fmt.Sprintf("PVC/%s", pvcName)
+	return getProgressObjectKey(pvcProgressObjectKind, pvcName)
 }
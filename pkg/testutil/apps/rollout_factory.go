@@ -41,6 +41,21 @@ func (factory *MockRolloutFactory) SetClusterName(clusterName string) *MockRollo
 	return factory
 }
 
+func (factory *MockRolloutFactory) SetPaused(paused bool) *MockRolloutFactory {
+	factory.Get().Spec.Paused = ptr.To(paused)
+	return factory
+}
+
+func (factory *MockRolloutFactory) SetAbort(abort bool) *MockRolloutFactory {
+	factory.Get().Spec.Abort = ptr.To(abort)
+	return factory
+}
+
+func (factory *MockRolloutFactory) SetAutoRollback(autoRollback bool) *MockRolloutFactory {
+	factory.Get().Spec.AutoRollback = ptr.To(autoRollback)
+	return factory
+}
+
 func (factory *MockRolloutFactory) AddComponent(compName string) *MockRolloutFactory {
 	comp := appsv1alpha1.RolloutComponent{
 		Name: compName,
@@ -101,3 +116,49 @@ func (factory *MockRolloutFactory) SetReplicas(replicas int32) *MockRolloutFacto
 		comp.Replicas = ptr.To(intstr.FromInt32(replicas))
 	})
 }
+
+func (factory *MockRolloutFactory) SetWave(wave int32) *MockRolloutFactory {
+	return factory.updateLastComponent(func(comp *appsv1alpha1.RolloutComponent) {
+		comp.Wave = wave
+	})
+}
+
+func (factory *MockRolloutFactory) AddSharding(shardingName string) *MockRolloutFactory {
+	sharding := appsv1alpha1.RolloutSharding{
+		Name: shardingName,
+		Strategy: appsv1alpha1.RolloutStrategy{
+			Inplace: &appsv1alpha1.RolloutStrategyInplace{},
+		},
+	}
+	factory.Get().Spec.Shardings = append(factory.Get().Spec.Shardings, sharding)
+	return factory
+}
+
+type updateRollShardingFn func(*appsv1alpha1.RolloutSharding)
+
+func (factory *MockRolloutFactory) updateLastSharding(update updateRollShardingFn) *MockRolloutFactory {
+	shardings := factory.Get().Spec.Shardings
+	if len(shardings) > 0 {
+		update(&shardings[len(shardings)-1])
+	}
+	factory.Get().Spec.Shardings = shardings
+	return factory
+}
+
+func (factory *MockRolloutFactory) SetShardingServiceVersion(serviceVersion string) *MockRolloutFactory {
+	return factory.updateLastSharding(func(sharding *appsv1alpha1.RolloutSharding) {
+		sharding.ServiceVersion = ptr.To(serviceVersion)
+	})
+}
+
+func (factory *MockRolloutFactory) SetShardingMaxUnavailable(maxUnavailable intstr.IntOrString) *MockRolloutFactory {
+	return factory.updateLastSharding(func(sharding *appsv1alpha1.RolloutSharding) {
+		sharding.MaxUnavailable = ptr.To(maxUnavailable)
+	})
+}
+
+func (factory *MockRolloutFactory) SetShardingWave(wave int32) *MockRolloutFactory {
+	return factory.updateLastSharding(func(sharding *appsv1alpha1.RolloutSharding) {
+		sharding.Wave = wave
+	})
+}
@@ -0,0 +1,193 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+const (
+	tracerName  = "github.com/apecloud/kubeblocks"
+	serviceName = "kubeblocks"
+)
+
+// attribute keys of the spans
+const (
+	ClusterKey         = attribute.Key("kubeblocks.cluster")
+	ComponentKey       = attribute.Key("kubeblocks.component")
+	OpsRequestKey      = attribute.Key("kubeblocks.opsrequest")
+	OpsRequestTypeKey  = attribute.Key("kubeblocks.opsrequest.type")
+	ObjectKindKey      = attribute.Key("kubeblocks.object.kind")
+	ObjectNameKey      = attribute.Key("kubeblocks.object.name")
+	TransformerKey     = attribute.Key("kubeblocks.transformer")
+	LifecycleActionKey = attribute.Key("kubeblocks.lifecycle.action")
+	PodKey             = attribute.Key("kubeblocks.pod")
+	ChangeTypeKey      = attribute.Key("kubeblocks.change.type")
+)
+
+// Setup installs a global TracerProvider which exports the spans by the exporter,
+// and returns a function to flush the pending spans and stop exporting.
+// Spans are not recorded until Setup is called.
+func Setup(exporter sdktrace.SpanExporter, sampleRatio float64) func(context.Context) error {
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(sampleRatio))),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(serviceName))),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown
+}
+
+// NewOTLPExporter creates an exporter which sends the spans to an OTLP collector by gRPC.
+func NewOTLPExporter(ctx context.Context, endpoint string, insecure bool) (sdktrace.SpanExporter, error) {
+	opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(endpoint)}
+	if insecure {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	}
+	return otlptracegrpc.New(ctx, opts...)
+}
+
+func tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
+
+// Start starts a span as a child of the span in the context if there is one.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End records the error if any, and ends the span.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// AddEvent adds an event to the span in the context.
+func AddEvent(ctx context.Context, name string, attrs ...attribute.KeyValue) {
+	trace.SpanFromContext(ctx).AddEvent(name, trace.WithAttributes(attrs...))
+}
+
+// SpanEvent is an event happened during a span which is recorded afterward.
+type SpanEvent struct {
+	Name       string
+	Timestamp  time.Time
+	Attributes []attribute.KeyValue
+}
+
+// RecordSpan records a span which has already completed, with the start and end time known afterward.
+func RecordSpan(ctx context.Context, name string, start, end time.Time, attrs []attribute.KeyValue, events []SpanEvent) {
+	_, span := tracer().Start(ctx, name, trace.WithTimestamp(start), trace.WithAttributes(attrs...))
+	for _, event := range events {
+		span.AddEvent(event.Name, trace.WithTimestamp(event.Timestamp), trace.WithAttributes(event.Attributes...))
+	}
+	span.End(trace.WithTimestamp(end))
+}
+
+// SetObjectAttributes sets the attributes of the object to the span in the context.
+func SetObjectAttributes(ctx context.Context, obj client.Object) {
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() || obj == nil || reflect.ValueOf(obj).IsNil() {
+		return
+	}
+	span.SetAttributes(ObjectAttributes(obj)...)
+}
+
+// ObjectAttributes returns the cluster, component and ops attributes of the object.
+func ObjectAttributes(obj client.Object) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		semconv.K8SNamespaceName(obj.GetNamespace()),
+		ObjectNameKey.String(obj.GetName()),
+	}
+	labels := obj.GetLabels()
+	clusterName := labels[constant.AppInstanceLabelKey]
+	switch o := obj.(type) {
+	case *appsv1.Cluster:
+		attrs = append(attrs, ObjectKindKey.String(appsv1.ClusterKind))
+		clusterName = o.Name
+	case *appsv1.Component:
+		attrs = append(attrs, ObjectKindKey.String(appsv1.ComponentKind))
+	case *opsv1alpha1.OpsRequest:
+		attrs = append(attrs, ObjectKindKey.String(constant.OpsRequestKind),
+			OpsRequestKey.String(o.Name),
+			OpsRequestTypeKey.String(string(o.Spec.Type)))
+		clusterName = o.Spec.GetClusterName()
+	default:
+		if ops, ok := labels[constant.OpsRequestNameLabelKey]; ok {
+			attrs = append(attrs, OpsRequestKey.String(ops))
+		}
+	}
+	if len(clusterName) > 0 {
+		attrs = append(attrs, ClusterKey.String(clusterName))
+	}
+	if comp, ok := labels[constant.KBAppComponentLabelKey]; ok {
+		attrs = append(attrs, ComponentKey.String(comp))
+	}
+	return attrs
+}
+
+// WrapReconciler traces each reconciliation of the reconciler as a span,
+// the reconciler can enrich the span with the attributes of the reconciled object by SetObjectAttributes.
+func WrapReconciler(kind string, r reconcile.Reconciler) reconcile.Reconciler {
+	return reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+		ctx, span := Start(ctx, fmt.Sprintf("Reconcile %s", kind),
+			semconv.K8SNamespaceName(req.Namespace),
+			ObjectKindKey.String(kind),
+			ObjectNameKey.String(req.Name))
+		res, err := r.Reconcile(ctx, req)
+		End(span, err)
+		return res, err
+	})
+}
+
+// StartTransformer starts a span for the execution of the transformer.
+func StartTransformer(ctx context.Context, transformer any) (context.Context, trace.Span) {
+	name := reflect.TypeOf(transformer).String()
+	return Start(ctx, fmt.Sprintf("Transform %s", name), TransformerKey.String(name))
+}
+
+// StartLifecycleAction starts a span for the lifecycle action of the component.
+func StartLifecycleAction(ctx context.Context, namespace, clusterName, compName, action string) (context.Context, trace.Span) {
+	return Start(ctx, fmt.Sprintf("LifecycleAction %s", action),
+		semconv.K8SNamespaceName(namespace),
+		ClusterKey.String(clusterName),
+		ComponentKey.String(compName),
+		LifecycleActionKey.String(action))
+}
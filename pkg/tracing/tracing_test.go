@@ -0,0 +1,190 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+// collector is an in-process exporter which keeps the exported spans.
+type collector struct {
+	mu    sync.Mutex
+	spans []sdktrace.ReadOnlySpan
+}
+
+func (c *collector) ExportSpans(_ context.Context, spans []sdktrace.ReadOnlySpan) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.spans = append(c.spans, spans...)
+	return nil
+}
+
+func (c *collector) Shutdown(context.Context) error {
+	return nil
+}
+
+func (c *collector) find(name string) sdktrace.ReadOnlySpan {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, span := range c.spans {
+		if span.Name() == name {
+			return span
+		}
+	}
+	return nil
+}
+
+func setupCollector(t *testing.T) (*collector, func()) {
+	c := &collector{}
+	shutdown := Setup(c, 1)
+	return c, func() {
+		if err := shutdown(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func attributeValue(span sdktrace.ReadOnlySpan, key attribute.Key) string {
+	for _, attr := range span.Attributes() {
+		if attr.Key == key {
+			return attr.Value.Emit()
+		}
+	}
+	return ""
+}
+
+type testTransformer struct{}
+
+func TestReconcileSpans(t *testing.T) {
+	c, flush := setupCollector(t)
+
+	cluster := &appsv1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "mysql"}}
+	r := WrapReconciler(appsv1.ClusterKind, reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+		SetObjectAttributes(ctx, cluster)
+		tctx, span := StartTransformer(ctx, &testTransformer{})
+		End(span, nil)
+		_, span = StartLifecycleAction(tctx, "default", "mysql", "server", "switchover")
+		End(span, errors.New("action failed"))
+		return reconcile.Result{}, nil
+	}))
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "mysql"}}
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatal(err)
+	}
+	flush()
+
+	reconcileSpan := c.find("Reconcile Cluster")
+	if reconcileSpan == nil {
+		t.Fatal("reconcile span not found")
+	}
+	if v := attributeValue(reconcileSpan, ClusterKey); v != "mysql" {
+		t.Errorf("unexpected cluster attribute: %s", v)
+	}
+	transformerSpan := c.find("Transform *tracing.testTransformer")
+	if transformerSpan == nil {
+		t.Fatal("transformer span not found")
+	}
+	if transformerSpan.Parent().SpanID() != reconcileSpan.SpanContext().SpanID() {
+		t.Error("transformer span should be a child of the reconcile span")
+	}
+	actionSpan := c.find("LifecycleAction switchover")
+	if actionSpan == nil {
+		t.Fatal("lifecycle action span not found")
+	}
+	if actionSpan.Status().Code != codes.Error {
+		t.Errorf("unexpected status of the failed action: %v", actionSpan.Status())
+	}
+	if v := attributeValue(actionSpan, ComponentKey); v != "server" {
+		t.Errorf("unexpected component attribute: %s", v)
+	}
+}
+
+func TestRecordSpan(t *testing.T) {
+	c, flush := setupCollector(t)
+
+	start := time.Now().Add(-time.Minute)
+	end := start.Add(30 * time.Second)
+	RecordSpan(context.Background(), "ReconciliationCycle Cluster", start, end,
+		[]attribute.KeyValue{ClusterKey.String("mysql")},
+		[]SpanEvent{{Name: "Pod created", Timestamp: start.Add(time.Second)}})
+	flush()
+
+	span := c.find("ReconciliationCycle Cluster")
+	if span == nil {
+		t.Fatal("span not found")
+	}
+	if !span.StartTime().Equal(start) || !span.EndTime().Equal(end) {
+		t.Errorf("unexpected span time: %v - %v", span.StartTime(), span.EndTime())
+	}
+	if len(span.Events()) != 1 || span.Events()[0].Name != "Pod created" {
+		t.Errorf("unexpected span events: %v", span.Events())
+	}
+}
+
+func TestObjectAttributes(t *testing.T) {
+	ops := &opsv1alpha1.OpsRequest{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "restart"},
+		Spec: opsv1alpha1.OpsRequestSpec{
+			ClusterName: "mysql",
+			Type:        opsv1alpha1.RestartType,
+		},
+	}
+	comp := &appsv1.Component{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "mysql-server",
+			Labels: map[string]string{
+				constant.AppInstanceLabelKey:    "mysql",
+				constant.KBAppComponentLabelKey: "server",
+			},
+		},
+	}
+	for _, tc := range []struct {
+		attrs    []attribute.KeyValue
+		expected map[attribute.Key]string
+	}{
+		{ObjectAttributes(ops), map[attribute.Key]string{
+			ClusterKey: "mysql", OpsRequestKey: "restart", OpsRequestTypeKey: "Restart", ObjectKindKey: constant.OpsRequestKind}},
+		{ObjectAttributes(comp), map[attribute.Key]string{
+			ClusterKey: "mysql", ComponentKey: "server", ObjectKindKey: appsv1.ComponentKind}},
+	} {
+		set := attribute.NewSet(tc.attrs...)
+		for k, v := range tc.expected {
+			if actual, ok := set.Value(k); !ok || actual.Emit() != v {
+				t.Errorf("unexpected attribute %s: %s", k, actual.Emit())
+			}
+		}
+	}
+}